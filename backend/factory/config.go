@@ -31,14 +31,20 @@ type Info struct {
 }
 
 type Configuration struct {
-	Mongodb                 *Mongodb  `yaml:"mongodb"`
-	WebuiTLS                *TLS      `yaml:"webui-tls"`
-	NfConfigTLS             *TLS      `yaml:"nfconfig-tls"`
-	RocEnd                  *RocEndpt `yaml:"managedByConfigPod,omitempty"` // fetch config during bootup
-	SdfComp                 bool      `yaml:"spec-compliant-sdf"`
-	EnableAuthentication    bool      `yaml:"enableAuthentication,omitempty"`
-	SendPebbleNotifications bool      `yaml:"send-pebble-notifications,omitempty"`
-	CfgPort                 int       `yaml:"cfgport,omitempty"`
+	Mongodb                 *Mongodb      `yaml:"mongodb"`
+	WebuiTLS                *TLS          `yaml:"webui-tls"`
+	NfConfigTLS             *TLS          `yaml:"nfconfig-tls"`
+	NfConfig                *NfConfig     `yaml:"nfconfig,omitempty"`
+	RocEnd                  *RocEndpt     `yaml:"managedByConfigPod,omitempty"` // fetch config during bootup
+	SdfComp                 bool          `yaml:"spec-compliant-sdf"`
+	EnableAuthentication    bool          `yaml:"enableAuthentication,omitempty"`
+	SendPebbleNotifications bool          `yaml:"send-pebble-notifications,omitempty"`
+	CfgPort                 int           `yaml:"cfgport,omitempty"`
+	GnbDiscovery            *GnbDiscovery `yaml:"gnb-discovery,omitempty"`
+	NfConfigGrpc            *NfConfigGrpc `yaml:"nfconfig-grpc,omitempty"`
+	NfConfigMtls            *NfConfigMtls `yaml:"nfconfig-mtls,omitempty"`
+	// subscriptions to the NF configuration changes are refused without it
+	NfConfigSubscriptions *NfConfigSubscriptions `yaml:"nfconfig-subscriptions,omitempty"`
 }
 
 type TLS struct {
@@ -59,3 +65,57 @@ type RocEndpt struct {
 	SyncUrl string `yaml:"syncUrl,omitempty"`
 	Enabled bool   `yaml:"enabled,omitempty"`
 }
+
+// GnbDiscovery periodically compares the gNBs connected to the AMFs with the
+// inventory and optionally imports the unknown ones. The AMF OAM API only
+// serves the registered UE contexts, so the path listing the connected gNBs
+// must be set to enable the discovery.
+type GnbDiscovery struct {
+	Enabled         bool   `yaml:"enabled,omitempty"`
+	AmfPath         string `yaml:"amf-path,omitempty"`
+	IntervalSeconds int    `yaml:"interval-seconds,omitempty"`
+	AutoImport      bool   `yaml:"auto-import,omitempty"`
+}
+
+// NfConfig configures the listener of the NF configuration service. Unset
+// fields keep the defaults: all interfaces, port 5001, no timeouts and the
+// net/http maximum header size. HTTP version 1 only serves HTTP/1.1, while
+// version 2 adds HTTP/2 over TLS (h2) or cleartext (h2c, prior knowledge).
+// Without a version, HTTP/2 is only served over TLS. The instance ID
+// identifies this webconsole among the replicas sharing the database, the
+// hostname by default.
+type NfConfig struct {
+	BindAddress            string `yaml:"bind-address,omitempty"`
+	Port                   int    `yaml:"port,omitempty"`
+	HttpVersion            int    `yaml:"http-version,omitempty"`
+	ReadTimeoutSeconds     int    `yaml:"read-timeout-seconds,omitempty"`
+	WriteTimeoutSeconds    int    `yaml:"write-timeout-seconds,omitempty"`
+	MaxHeaderBytes         int    `yaml:"max-header-bytes,omitempty"`
+	ShutdownTimeoutSeconds int    `yaml:"shutdown-timeout-seconds,omitempty"`
+	InstanceId             string `yaml:"instance-id,omitempty"`
+}
+
+// NfConfigGrpc serves the NF configuration over gRPC alongside the REST API.
+// It uses the nfconfig-tls certificate when set.
+type NfConfigGrpc struct {
+	Enabled bool `yaml:"enabled,omitempty"`
+	Port    int  `yaml:"port,omitempty"`
+}
+
+// NfConfigSubscriptions restricts the callback URIs of the subscriptions to
+// the NF configuration changes. A callback URI must use one of the schemes,
+// https when unset, and one of the hosts: a hostname, a domain prefixed with
+// '*.', an IP address or an IP network in CIDR notation.
+type NfConfigSubscriptions struct {
+	CallbackSchemes []string `yaml:"callback-schemes,omitempty"`
+	CallbackHosts   []string `yaml:"callback-hosts,omitempty"`
+}
+
+// NfConfigMtls requires the NFs to present a certificate signed by the client
+// CA on the NF configuration service. The NF type, read from the certificate
+// OU or DNS SAN, selects the endpoints the NF may read. The allow-list maps an
+// NF type to resources and replaces the default entry of that type.
+type NfConfigMtls struct {
+	ClientCA          string              `yaml:"client-ca,omitempty"`
+	EndpointAllowList map[string][]string `yaml:"endpoint-allow-list,omitempty"`
+}
@@ -15,6 +15,7 @@ package factory
 import (
 	"fmt"
 	"os"
+	"strings"
 
 	openapiLogger "github.com/omec-project/openapi/v2/logger"
 	utilLogger "github.com/omec-project/util/logger"
@@ -50,6 +51,17 @@ func InitConfigFactory(f string) error {
 			return fmt.Errorf("[NFConfig Configuration] TLS Key and PEM must be set")
 		}
 	}
+	if err := validateNfConfig(WebUIConfig.Configuration.NfConfig); err != nil {
+		return err
+	}
+	if WebUIConfig.Configuration.NfConfigMtls != nil {
+		if WebUIConfig.Configuration.NfConfigTLS == nil {
+			return fmt.Errorf("[NFConfig Configuration] mTLS requires the NFConfig TLS Key and PEM")
+		}
+		if WebUIConfig.Configuration.NfConfigMtls.ClientCA == "" {
+			return fmt.Errorf("[NFConfig Configuration] mTLS client CA must be set")
+		}
+	}
 	if WebUIConfig.Configuration.Mongodb.AuthUrl == "" {
 		authUrl := WebUIConfig.Configuration.Mongodb.Url
 		WebUIConfig.Configuration.Mongodb.AuthUrl = authUrl
@@ -71,6 +83,38 @@ func InitConfigFactory(f string) error {
 		}
 	}
 
+	if discoveryConfig := WebUIConfig.Configuration.GnbDiscovery; discoveryConfig != nil {
+		if discoveryConfig.IntervalSeconds < 0 {
+			return fmt.Errorf("[Configuration] gNB discovery interval must not be negative")
+		}
+		if discoveryConfig.Enabled && !strings.HasPrefix(discoveryConfig.AmfPath, "/") {
+			return fmt.Errorf("[Configuration] if gNB discovery is enabled, the AMF path listing the connected gNBs must be set")
+		}
+	}
+
+	if grpcConfig := WebUIConfig.Configuration.NfConfigGrpc; grpcConfig != nil && (grpcConfig.Port < 0 || grpcConfig.Port > 65535) {
+		return fmt.Errorf("[NFConfig Configuration] gRPC port must be within the range [0, 65535]")
+	}
+
+	return nil
+}
+
+func validateNfConfig(nfConfig *NfConfig) error {
+	if nfConfig == nil {
+		return nil
+	}
+	if nfConfig.Port < 0 || nfConfig.Port > 65535 {
+		return fmt.Errorf("[NFConfig Configuration] port must be within the range [0, 65535]")
+	}
+	if nfConfig.HttpVersion != 0 && nfConfig.HttpVersion != 1 && nfConfig.HttpVersion != 2 {
+		return fmt.Errorf("[NFConfig Configuration] HTTP version must be 1 or 2")
+	}
+	if nfConfig.ReadTimeoutSeconds < 0 || nfConfig.WriteTimeoutSeconds < 0 || nfConfig.ShutdownTimeoutSeconds < 0 {
+		return fmt.Errorf("[NFConfig Configuration] timeouts must not be negative")
+	}
+	if nfConfig.MaxHeaderBytes < 0 {
+		return fmt.Errorf("[NFConfig Configuration] maximum header size must not be negative")
+	}
 	return nil
 }
 
@@ -9,8 +9,10 @@ package metrics
 
 import (
 	"net/http"
+	"time"
 
 	"github.com/omec-project/webconsole/backend/logger"
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 )
 
@@ -21,3 +23,84 @@ func InitMetrics() {
 		logger.InitLog.Errorf("could not open metrics port: %v", err)
 	}
 }
+
+// NfConfigStats captures the versions of the NF configuration, the versions
+// served to each NF configuration client and the configuration issues
+type NfConfigStats struct {
+	resourceVersion *prometheus.GaugeVec
+	clientVersion   *prometheus.GaugeVec
+	clientLastSeen  *prometheus.GaugeVec
+	clientRequests  *prometheus.CounterVec
+	diagnostics     prometheus.Gauge
+}
+
+var nfConfigStats *NfConfigStats
+
+func init() {
+	nfConfigStats = initNfConfigStats()
+	if err := nfConfigStats.register(); err != nil {
+		logger.InitLog.Errorf("failed to register NF configuration metrics: %v", err)
+	}
+}
+
+func initNfConfigStats() *NfConfigStats {
+	return &NfConfigStats{
+		resourceVersion: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "nfconfig_resource_version",
+			Help: "Current version of each NF configuration endpoint",
+		}, []string{"endpoint"}),
+		clientVersion: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "nfconfig_client_served_version",
+			Help: "Last version of an NF configuration endpoint served to a client",
+		}, []string{"client", "endpoint"}),
+		clientLastSeen: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "nfconfig_client_last_seen_timestamp_seconds",
+			Help: "Time of the last request of a client to an NF configuration endpoint",
+		}, []string{"client", "endpoint"}),
+		clientRequests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "nfconfig_client_requests_total",
+			Help: "Requests of a client to an NF configuration endpoint",
+		}, []string{"client", "endpoint"}),
+		diagnostics: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "nfconfig_diagnostics",
+			Help: "Issues of the stored configuration ignored or worked around by the last NF configuration sync",
+		}),
+	}
+}
+
+func (s *NfConfigStats) register() error {
+	for _, collector := range []prometheus.Collector{s.resourceVersion, s.clientVersion, s.clientLastSeen, s.clientRequests, s.diagnostics} {
+		if err := prometheus.Register(collector); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SetNfConfigResourceVersion records the current version of an NF
+// configuration endpoint
+func SetNfConfigResourceVersion(endpoint string, version uint64) {
+	nfConfigStats.resourceVersion.WithLabelValues(endpoint).Set(float64(version))
+}
+
+// RecordNfConfigClientRequest records the version of an NF configuration
+// endpoint served to a client
+func RecordNfConfigClientRequest(client string, endpoint string, version uint64, seen time.Time) {
+	nfConfigStats.clientVersion.WithLabelValues(client, endpoint).Set(float64(version))
+	nfConfigStats.clientLastSeen.WithLabelValues(client, endpoint).Set(float64(seen.Unix()))
+	nfConfigStats.clientRequests.WithLabelValues(client, endpoint).Inc()
+}
+
+// DeleteNfConfigClient removes the metrics of a client
+func DeleteNfConfigClient(client string) {
+	labels := prometheus.Labels{"client": client}
+	nfConfigStats.clientVersion.DeletePartialMatch(labels)
+	nfConfigStats.clientLastSeen.DeletePartialMatch(labels)
+	nfConfigStats.clientRequests.DeletePartialMatch(labels)
+}
+
+// SetNfConfigDiagnostics records the number of issues found by the last NF
+// configuration sync
+func SetNfConfigDiagnostics(count int) {
+	nfConfigStats.diagnostics.Set(float64(count))
+}
@@ -0,0 +1,251 @@
+// SPDX-FileCopyrightText: 2026 Canonical Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package nfconfig
+
+import (
+	"context"
+	"errors"
+	"maps"
+	"os"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/omec-project/webconsole/backend/logger"
+	"github.com/omec-project/webconsole/configmodels"
+	"github.com/omec-project/webconsole/dbadapter"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+const resumeTokenDataColl = "webconsoleData.snapshots.nfConfigResumeTokenData"
+
+var (
+	// changeStreamRetryInterval is the time before reopening a failed change
+	// stream
+	changeStreamRetryInterval = 5 * time.Second
+	// changeStreamFallbackInterval is the period of the full reloads when the
+	// database does not support change streams
+	changeStreamFallbackInterval = time.Minute
+)
+
+// syncScope selects the collections reloaded by a sync, and so the views
+// rebuilt from them
+type syncScope uint32
+
+const (
+	// slices: PLMN, PLMN-SNSSAI, access and mobility, session management
+	// and policy control
+	sliceScope syncScope = 1 << iota
+	// device groups: session management, policy control and IMSI QoS
+	deviceGroupScope
+	fullScope = sliceScope | deviceGroupScope
+)
+
+func (s syncScope) String() string {
+	switch s {
+	case sliceScope:
+		return "slices"
+	case deviceGroupScope:
+		return "device groups"
+	case fullScope:
+		return "full"
+	}
+	return "none"
+}
+
+// changeStreamScopes maps the watched collections to the scope of the sync
+// their changes trigger. The catalogs and the UPF inventory the slices and
+// device groups refer to trigger a full sync.
+var changeStreamScopes = map[string]syncScope{
+	sliceDataColl:                     sliceScope,
+	devGroupDataColl:                  deviceGroupScope,
+	configmodels.ApplicationDataColl:  fullScope,
+	configmodels.TrafficClassDataColl: fullScope,
+	configmodels.QosProfileDataColl:   fullScope,
+	configmodels.DnnDataColl:          fullScope,
+	configmodels.UpfDataColl:          fullScope,
+}
+
+// resumeTokenTracker keeps the resume token of the last change stream event.
+// The token is stored once a sync includes the changes up to it rather than on
+// every event, so that a restart replays the changes not synced yet.
+type resumeTokenTracker struct {
+	mutex  sync.Mutex
+	id     string
+	latest string
+	stored string
+}
+
+func newResumeTokenTracker(tokenId string) *resumeTokenTracker {
+	token := loadResumeToken(tokenId)
+	return &resumeTokenTracker{id: tokenId, latest: token, stored: token}
+}
+
+func (t *resumeTokenTracker) seen(token string) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	t.latest = token
+}
+
+// last returns the token of the last seen change
+func (t *resumeTokenTracker) last() string {
+	if t == nil {
+		return ""
+	}
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	return t.latest
+}
+
+// synced stores the token once the changes up to it are synced
+func (t *resumeTokenTracker) synced(token string) {
+	if t == nil || token == "" {
+		return
+	}
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	if token == t.stored {
+		return
+	}
+	if saveResumeToken(t.id, token) {
+		t.stored = token
+	}
+}
+
+// reset restarts the stream from now, when the stored token cannot resume it
+func (t *resumeTokenTracker) reset() {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	t.latest = ""
+	t.stored = ""
+	deleteResumeToken(t.id)
+}
+
+// notifyChange requests a sync of the scope from the sync worker
+func (n *NFConfigServer) notifyChange(scope syncScope) {
+	n.requestSync(scope)
+	select {
+	case n.changeChan <- struct{}{}:
+	default:
+		// a sync is already triggered and takes the scope
+	}
+}
+
+// startChangeStream syncs the changes of the collections the configuration is
+// built from, made by any writer. Without a change stream client, the configuration is only
+// reloaded on the writes of this webconsole.
+func (n *NFConfigServer) startChangeStream(ctx context.Context) {
+	streamer, ok := dbadapter.CommonDBClient.(dbadapter.ChangeStreamer)
+	if !ok {
+		logger.NfConfigLog.Infoln("Database client does not support change streams. The NF configuration is reloaded on WebUI writes only")
+		return
+	}
+	n.workers.Go(func() { n.watchChanges(ctx, streamer) })
+}
+
+// watchChanges follows the change stream until ctx ends. After a failure, it
+// reloads the whole configuration and reopens the stream after the last seen
+// change. When the database does not support change streams, it reloads the
+// whole configuration periodically instead.
+func (n *NFConfigServer) watchChanges(ctx context.Context, streamer dbadapter.ChangeStreamer) {
+	collections := slices.Sorted(maps.Keys(changeStreamScopes))
+	resumeTokens := newResumeTokenTracker(n.resumeTokenId())
+	n.resumeTokens.Store(resumeTokens)
+	for {
+		logger.NfConfigLog.Infof("Watching the changes of %v", collections)
+		n.changeStreamOpen.Store(true)
+		err := streamer.WatchCollections(ctx, collections, resumeTokens.last(), func(event dbadapter.ChangeEvent) {
+			scope, watched := changeStreamScopes[event.Collection]
+			if !watched {
+				return
+			}
+			logger.NfConfigLog.Debugf("Change stream event %s on %s", event.OperationType, event.Collection)
+			n.notifyChange(scope)
+			// seen after requesting the sync, so that a sync taking the token
+			// also takes the change
+			if event.ResumeToken != "" {
+				resumeTokens.seen(event.ResumeToken)
+			}
+		})
+		n.changeStreamOpen.Store(false)
+		if ctx.Err() != nil {
+			return
+		}
+		// changes may have been missed while the stream was down
+		n.notifyChange(fullScope)
+		if errors.Is(err, dbadapter.ErrChangeStreamUnsupported) {
+			logger.NfConfigLog.Warnf("Change streams are unavailable. The NF configuration is reloaded every %s: %v", changeStreamFallbackInterval, err)
+			n.reloadPeriodically(ctx)
+			return
+		}
+		if errors.Is(err, dbadapter.ErrChangeStreamNotResumable) {
+			resumeTokens.reset()
+		}
+		logger.NfConfigLog.Warnf("Change stream failed, reopening it in %s: %v", changeStreamRetryInterval, err)
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(changeStreamRetryInterval):
+		}
+	}
+}
+
+// reloadPeriodically reloads the whole configuration every
+// changeStreamFallbackInterval until ctx ends
+func (n *NFConfigServer) reloadPeriodically(ctx context.Context) {
+	ticker := time.NewTicker(changeStreamFallbackInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			n.notifyChange(fullScope)
+		}
+	}
+}
+
+// resumeTokenId identifies the resume token of this instance, so that the
+// replicas sharing the database each resume their own stream
+func (n *NFConfigServer) resumeTokenId() string {
+	instanceId := n.listenerConfig().InstanceId
+	if instanceId == "" {
+		hostname, err := os.Hostname()
+		if err != nil {
+			logger.NfConfigLog.Warnf("Failed to get the hostname identifying the change stream resume token: %v", err)
+			return "nfconfig"
+		}
+		instanceId = hostname
+	}
+	return "nfconfig-" + instanceId
+}
+
+// loadResumeToken returns the resume token stored by the last run, or an
+// empty token to start the stream now
+func loadResumeToken(tokenId string) string {
+	rawToken, err := dbadapter.CommonDBClient.RestfulAPIGetOne(resumeTokenDataColl, bson.M{"id": tokenId})
+	if err != nil {
+		logger.NfConfigLog.Warnf("Failed to load the change stream resume token: %v", err)
+		return ""
+	}
+	token, _ := rawToken["token"].(string)
+	return token
+}
+
+// saveResumeToken returns false when the token could not be stored
+func saveResumeToken(tokenId string, token string) bool {
+	if _, err := dbadapter.CommonDBClient.RestfulAPIPutOne(resumeTokenDataColl, bson.M{"id": tokenId}, map[string]any{"id": tokenId, "token": token}); err != nil {
+		logger.NfConfigLog.Warnf("Failed to store the change stream resume token: %v", err)
+		return false
+	}
+	return true
+}
+
+func deleteResumeToken(tokenId string) {
+	if err := dbadapter.CommonDBClient.RestfulAPIDeleteOne(resumeTokenDataColl, bson.M{"id": tokenId}); err != nil {
+		logger.NfConfigLog.Warnf("Failed to delete the change stream resume token: %v", err)
+	}
+}
@@ -0,0 +1,398 @@
+// SPDX-FileCopyrightText: 2026 Canonical Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+package nfconfig
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/omec-project/webconsole/backend/factory"
+	"github.com/omec-project/webconsole/configmodels"
+	"github.com/omec-project/webconsole/dbadapter"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+type mockChangeStream struct {
+	events []dbadapter.ChangeEvent
+	err    error
+}
+
+// changeStreamDBClient serves the documents of each collection and replays a
+// change stream per call to WatchCollections. A stream without error stays
+// open until ctx ends.
+type changeStreamDBClient struct {
+	dbadapter.DBInterface
+	mutex        sync.Mutex
+	collections  map[string][]map[string]any
+	storedToken  map[string]any
+	streams      []mockChangeStream
+	resumeTokens []string
+}
+
+func (m *changeStreamDBClient) RestfulAPIGetMany(collName string, filter bson.M) ([]map[string]any, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	return m.collections[collName], nil
+}
+
+func (m *changeStreamDBClient) RestfulAPIGetOne(collName string, filter bson.M) (map[string]any, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	return m.storedToken, nil
+}
+
+func (m *changeStreamDBClient) RestfulAPIPutOne(collName string, filter bson.M, putData map[string]any) (bool, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.storedToken = putData
+	return true, nil
+}
+
+func (m *changeStreamDBClient) RestfulAPIDeleteOne(collName string, filter bson.M) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.storedToken = nil
+	return nil
+}
+
+func (m *changeStreamDBClient) WatchCollections(ctx context.Context, collNames []string, resumeToken string, handle func(dbadapter.ChangeEvent)) error {
+	m.mutex.Lock()
+	m.resumeTokens = append(m.resumeTokens, resumeToken)
+	if len(m.streams) == 0 {
+		m.mutex.Unlock()
+		<-ctx.Done()
+		return ctx.Err()
+	}
+	stream := m.streams[0]
+	m.streams = m.streams[1:]
+	m.mutex.Unlock()
+	for _, event := range stream.events {
+		handle(event)
+	}
+	if stream.err == nil {
+		<-ctx.Done()
+		return ctx.Err()
+	}
+	return stream.err
+}
+
+func (m *changeStreamDBClient) setDocuments(t *testing.T, slices []configmodels.Slice, deviceGroups []configmodels.DeviceGroups) {
+	t.Helper()
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.collections = map[string][]map[string]any{}
+	for _, s := range slices {
+		m.collections[sliceDataColl] = append(m.collections[sliceDataColl], configmodels.ToBsonM(s))
+	}
+	for _, dg := range deviceGroups {
+		m.collections[devGroupDataColl] = append(m.collections[devGroupDataColl], configmodels.ToBsonM(dg))
+	}
+}
+
+func makeNamedDeviceGroup(name string, imsi string) configmodels.DeviceGroups {
+	_, dg := makeDeviceGroup(deviceGroupParams{
+		name:  name,
+		dnn:   "internet",
+		imsis: []string{imsi},
+		qos: &configmodels.DeviceGroupsIpDomainExpandedUeDnnQos{
+			DnnMbrUplink:   1000000,
+			DnnMbrDownlink: 2000000,
+			TrafficClass:   &configmodels.TrafficClassInfo{Qci: 9, Arp: 1},
+		},
+	})
+	dg.DeviceGroupName = name
+	return dg
+}
+
+func TestSyncInMemoryConfigScope(t *testing.T) {
+	mockDB := &changeStreamDBClient{}
+	originalDBClient := dbadapter.CommonDBClient
+	defer func() { dbadapter.CommonDBClient = originalDBClient }()
+	dbadapter.CommonDBClient = mockDB
+	n := &NFConfigServer{watcher: newConfigWatcher()}
+	mockDB.setDocuments(t,
+		[]configmodels.Slice{makeNetworkSlice("001", "01", "1", "010203", []int32{1})},
+		[]configmodels.DeviceGroups{makeNamedDeviceGroup("dg1", "001010000000001")})
+	if err := n.syncInMemoryConfigScope(sliceScope); err != nil {
+		t.Fatalf("failed to sync: %v", err)
+	}
+	if n.snapshot().imsiQos.size() != 1 {
+		t.Fatal("expected the first sync to load the device groups too")
+	}
+
+	mockDB.setDocuments(t,
+		[]configmodels.Slice{
+			makeNetworkSlice("001", "01", "1", "010203", []int32{1}),
+			makeNetworkSlice("002", "02", "1", "010203", []int32{1}),
+		},
+		[]configmodels.DeviceGroups{makeNamedDeviceGroup("dg2", "001010000000002")})
+	initial := n.snapshot()
+	if err := n.syncInMemoryConfigScope(sliceScope); err != nil {
+		t.Fatalf("failed to sync: %v", err)
+	}
+	afterSlices := n.snapshot()
+	if len(afterSlices.plmn) != 2 {
+		t.Errorf("expected the PLMNs of the new slices, got %+v", afterSlices.plmn)
+	}
+	if afterSlices.imsiQos != initial.imsiQos {
+		t.Error("expected the IMSI QoS to be kept on a change of the slices")
+	}
+
+	if err := n.syncInMemoryConfigScope(deviceGroupScope); err != nil {
+		t.Fatalf("failed to sync: %v", err)
+	}
+	afterDeviceGroups := n.snapshot()
+	if _, found := afterDeviceGroups.imsiQos.lookup("internet", "001010000000002"); !found {
+		t.Error("expected the IMSI QoS of the new device group")
+	}
+	if &afterDeviceGroups.plmn[0] != &afterSlices.plmn[0] {
+		t.Error("expected the PLMNs to be kept on a change of the device groups")
+	}
+	if afterDeviceGroups.resourceVersions[plmnResource] != afterSlices.resourceVersions[plmnResource] {
+		t.Error("expected the PLMN version to be unchanged")
+	}
+	if afterDeviceGroups.resourceVersions[imsiQosResource] == afterSlices.resourceVersions[imsiQosResource] {
+		t.Error("expected the IMSI QoS version to increase")
+	}
+}
+
+func TestSyncInMemoryConfigFunc_KeepsScopeOnFailure(t *testing.T) {
+	originalDBClient := dbadapter.CommonDBClient
+	defer func() { dbadapter.CommonDBClient = originalDBClient }()
+	dbadapter.CommonDBClient = &MockDBClient{err: fmt.Errorf("mock error")}
+	n := &NFConfigServer{}
+	n.requestSync(deviceGroupScope)
+	if err := syncInMemoryConfigFunc(n); err == nil {
+		t.Fatal("expected an error")
+	}
+	if scope := syncScope(n.pendingScope.Load()); scope != deviceGroupScope {
+		t.Errorf("expected the scope to be kept for the retry, got %s", scope)
+	}
+	n.pendingScope.Store(0)
+	if err := syncInMemoryConfigFunc(n); err != nil {
+		t.Errorf("expected nothing to sync, got %v", err)
+	}
+}
+
+func TestSyncInMemoryConfigFunc_StoresResumeTokenAfterSync(t *testing.T) {
+	mockDB := &changeStreamDBClient{}
+	originalDBClient := dbadapter.CommonDBClient
+	defer func() { dbadapter.CommonDBClient = originalDBClient }()
+	dbadapter.CommonDBClient = mockDB
+	n := &NFConfigServer{}
+	resumeTokens := newResumeTokenTracker("nfconfig-webui-0")
+	n.resumeTokens.Store(resumeTokens)
+
+	for _, token := range []string{"t1", "t2", "t3"} {
+		n.requestSync(sliceScope)
+		resumeTokens.seen(token)
+	}
+	if mockDB.storedToken != nil {
+		t.Fatalf("expected no resume token to be stored per change, got %+v", mockDB.storedToken)
+	}
+	if err := syncInMemoryConfigFunc(n); err != nil {
+		t.Fatalf("failed to sync: %v", err)
+	}
+	if mockDB.storedToken["token"] != "t3" {
+		t.Errorf("expected the token of the last synced change to be stored, got %+v", mockDB.storedToken)
+	}
+
+	dbadapter.CommonDBClient = &MockDBClient{err: fmt.Errorf("mock error")}
+	n.requestSync(sliceScope)
+	resumeTokens.seen("t4")
+	if err := syncInMemoryConfigFunc(n); err == nil {
+		t.Fatal("expected an error")
+	}
+	if mockDB.storedToken["token"] != "t3" {
+		t.Errorf("expected the token of a failed sync not to be stored, got %+v", mockDB.storedToken)
+	}
+}
+
+func TestWatchChanges(t *testing.T) {
+	originalRetryInterval := changeStreamRetryInterval
+	originalFallbackInterval := changeStreamFallbackInterval
+	defer func() {
+		changeStreamRetryInterval = originalRetryInterval
+		changeStreamFallbackInterval = originalFallbackInterval
+	}()
+	changeStreamRetryInterval = time.Millisecond
+	changeStreamFallbackInterval = 10 * time.Millisecond
+	mockDB := &changeStreamDBClient{
+		storedToken: map[string]any{"id": "nfconfig-webui-0", "token": "stored"},
+		streams: []mockChangeStream{
+			{
+				events: []dbadapter.ChangeEvent{
+					{Collection: sliceDataColl, OperationType: "update", ResumeToken: "t1"},
+					{Collection: "webconsoleData.snapshots.otherData", OperationType: "insert", ResumeToken: "t2"},
+				},
+				err: fmt.Errorf("connection reset"),
+			},
+			{err: fmt.Errorf("%w: history lost", dbadapter.ErrChangeStreamNotResumable)},
+			{
+				events: []dbadapter.ChangeEvent{{Collection: devGroupDataColl, OperationType: "delete", ResumeToken: "t3"}},
+				err:    fmt.Errorf("%w: standalone", dbadapter.ErrChangeStreamUnsupported),
+			},
+		},
+	}
+	originalDBClient := dbadapter.CommonDBClient
+	defer func() { dbadapter.CommonDBClient = originalDBClient }()
+	dbadapter.CommonDBClient = mockDB
+	n := &NFConfigServer{
+		config:     &factory.Configuration{NfConfig: &factory.NfConfig{InstanceId: "webui-0"}},
+		changeChan: make(chan struct{}, 1),
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		n.watchChanges(ctx, mockDB)
+		close(done)
+	}()
+	// the full reloads go on after the streams turn out to be unsupported
+	deadline := time.Now().Add(time.Second)
+	for {
+		mockDB.mutex.Lock()
+		opened := len(mockDB.resumeTokens)
+		mockDB.mutex.Unlock()
+		if opened == 3 || time.Now().After(deadline) {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+	for range 3 {
+		select {
+		case <-n.changeChan:
+		case <-time.After(time.Second):
+			t.Fatal("expected the configuration to be reloaded periodically")
+		}
+	}
+	if scope := syncScope(n.pendingScope.Load()); scope != fullScope {
+		t.Errorf("expected a full reload, got %s", scope)
+	}
+	if n.changeStreamOpen.Load() {
+		t.Error("expected the change stream to be closed")
+	}
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("expected the watch to stop with the context")
+	}
+
+	expectedTokens := []string{"stored", "t1", ""}
+	if fmt.Sprint(mockDB.resumeTokens) != fmt.Sprint(expectedTokens) {
+		t.Errorf("expected the streams to resume after %v, got %v", expectedTokens, mockDB.resumeTokens)
+	}
+	if mockDB.storedToken != nil {
+		t.Errorf("expected no resume token to be stored before a sync, got %+v", mockDB.storedToken)
+	}
+	if err := syncInMemoryConfigFunc(n); err != nil {
+		t.Fatalf("failed to sync: %v", err)
+	}
+	if mockDB.storedToken["token"] != "t3" || mockDB.storedToken["id"] != "nfconfig-webui-0" {
+		t.Errorf("expected the last resume token to be stored for the instance after the sync, got %+v", mockDB.storedToken)
+	}
+}
+
+func TestResumeTokenId(t *testing.T) {
+	hostname, err := os.Hostname()
+	if err != nil {
+		t.Skipf("no hostname: %v", err)
+	}
+	n := &NFConfigServer{}
+	if id := n.resumeTokenId(); id != "nfconfig-"+hostname {
+		t.Errorf("expected the resume token of the host, got %s", id)
+	}
+	n.config = &factory.Configuration{NfConfig: &factory.NfConfig{InstanceId: "webui-1"}}
+	if id := n.resumeTokenId(); id != "nfconfig-webui-1" {
+		t.Errorf("expected the resume token of the instance, got %s", id)
+	}
+}
+
+func TestStartSyncWorker_WebUIWriteWithChangeStream(t *testing.T) {
+	originalDBClient := dbadapter.CommonDBClient
+	defer func() { dbadapter.CommonDBClient = originalDBClient }()
+	// a failed sync would keep the scope of the write
+	dbadapter.CommonDBClient = &MockDBClient{err: fmt.Errorf("mock error")}
+	n := &NFConfigServer{changeChan: make(chan struct{}, 1)}
+	n.changeStreamOpen.Store(true)
+	ctx, cancel := context.WithCancel(context.Background())
+	syncChan := make(chan struct{})
+	n.startSyncWorker(ctx, syncChan)
+	syncChan <- struct{}{}
+	syncChan <- struct{}{}
+	cancel()
+	n.workers.Wait()
+	if scope := syncScope(n.pendingScope.Load()); scope != 0 {
+		t.Errorf("expected the change stream to sync the WebUI writes, got a %s sync", scope)
+	}
+}
+
+func TestWatchChanges_SyncsScopeOfChange(t *testing.T) {
+	mockDB := &changeStreamDBClient{
+		streams: []mockChangeStream{{events: []dbadapter.ChangeEvent{{Collection: devGroupDataColl, OperationType: "insert", ResumeToken: "t1"}}}},
+	}
+	originalDBClient := dbadapter.CommonDBClient
+	defer func() { dbadapter.CommonDBClient = originalDBClient }()
+	dbadapter.CommonDBClient = mockDB
+	n := &NFConfigServer{changeChan: make(chan struct{}, 1)}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go n.watchChanges(ctx, mockDB)
+
+	select {
+	case <-n.changeChan:
+	case <-time.After(time.Second):
+		t.Fatal("expected a sync to be triggered")
+	}
+	if scope := syncScope(n.pendingScope.Load()); scope != deviceGroupScope {
+		t.Errorf("expected a sync of the device groups, got %s", scope)
+	}
+}
+
+func TestWatchChanges_CatalogWrite(t *testing.T) {
+	mockDB := &changeStreamDBClient{}
+	originalDBClient := dbadapter.CommonDBClient
+	defer func() { dbadapter.CommonDBClient = originalDBClient }()
+	dbadapter.CommonDBClient = mockDB
+	slice := makeNetworkSlice("001", "01", "1", "010203", []int32{1})
+	slice.SiteDeviceGroup = []string{"dg1"}
+	mockDB.setDocuments(t, []configmodels.Slice{slice}, []configmodels.DeviceGroups{makeNamedDeviceGroup("dg1", "001010000000001")})
+	n := &NFConfigServer{watcher: newConfigWatcher(), changeChan: make(chan struct{}, 1)}
+	if err := n.syncInMemoryConfig(); err != nil {
+		t.Fatalf("failed to sync: %v", err)
+	}
+	initialETag := n.snapshot().resourceETags[sessionManagementResource]
+
+	// the WebUI writes the DNN while the stream is open
+	mockDB.mutex.Lock()
+	mockDB.collections[configmodels.DnnDataColl] = []map[string]any{configmodels.ToBsonM(configmodels.Dnn{Name: "internet", Mtu: 1400})}
+	mockDB.streams = []mockChangeStream{{events: []dbadapter.ChangeEvent{{Collection: configmodels.DnnDataColl, OperationType: "insert", ResumeToken: "t1"}}}}
+	mockDB.mutex.Unlock()
+	ctx, cancel := context.WithCancel(context.Background())
+	syncChan := make(chan struct{}, 1)
+	n.startSyncWorker(ctx, syncChan)
+	n.startChangeStream(ctx)
+	syncChan <- struct{}{}
+	defer func() {
+		cancel()
+		n.workers.Wait()
+	}()
+
+	deadline := time.Now().Add(2 * time.Second)
+	for n.snapshot().resourceETags[sessionManagementResource] == initialETag {
+		if time.Now().After(deadline) {
+			t.Fatal("expected the DNN write to change the session management configuration")
+		}
+		time.Sleep(time.Millisecond)
+	}
+	sessionManagement := n.snapshot().sessionManagement
+	if len(sessionManagement) != 1 || len(sessionManagement[0].IpDomain) != 1 || sessionManagement[0].IpDomain[0].Mtu != 1400 {
+		t.Errorf("expected the MTU of the DNN catalog, got %+v", sessionManagement)
+	}
+}
@@ -0,0 +1,214 @@
+// SPDX-FileCopyrightText: 2026 Canonical Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package nfconfig
+
+import (
+	"context"
+	"crypto/tls"
+	"net"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/omec-project/webconsole/backend/logger"
+	"github.com/omec-project/webconsole/backend/metrics"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+// clientsResource is the endpoint listing the clients. With mTLS, only the NF
+// types with clients in their endpoint allow-list may read it.
+const clientsResource configResource = "clients"
+
+// clientIdHeader holds the NF instance ID, a UUID, which identifies the NF
+// when it has no client certificate
+const clientIdHeader = "X-NF-Instance-Id"
+
+const (
+	certificateIdentity = "certificate"
+	headerIdentity      = "header"
+	addressIdentity     = "address"
+)
+
+var (
+	// clientRetention is how long a client stays listed after its last request
+	clientRetention = 24 * time.Hour
+	// clientSweepInterval is the period of the removal of the expired clients
+	clientSweepInterval = time.Minute
+	// maxClients bounds the listed clients, and so the series of their metrics
+	maxClients = 1000
+)
+
+// clientIdentity identifies an NF configuration client by its certificate CN,
+// the NF instance ID of its X-NF-Instance-Id header, or else its address
+type clientIdentity struct {
+	id      string
+	source  string
+	nfType  string
+	address string
+}
+
+func (n *NFConfigServer) identifyClient(state *tls.ConnectionState, header string, address string) clientIdentity {
+	identity := clientIdentity{id: address, source: addressIdentity, address: address}
+	if state != nil && len(state.VerifiedChains) > 0 && len(state.VerifiedChains[0]) > 0 {
+		cert := state.VerifiedChains[0][0]
+		if n.authorizer != nil {
+			identity.nfType, _ = n.authorizer.nfType(cert)
+		}
+		if cert.Subject.CommonName != "" {
+			identity.id, identity.source = cert.Subject.CommonName, certificateIdentity
+			return identity
+		}
+	}
+	// the header is not authenticated, only a valid NF instance ID is kept
+	if instanceId, err := uuid.Parse(strings.TrimSpace(header)); err == nil {
+		identity.id, identity.source = instanceId.String(), headerIdentity
+	}
+	return identity
+}
+
+func (n *NFConfigServer) httpClientIdentity(c *gin.Context) clientIdentity {
+	return n.identifyClient(c.Request.TLS, c.GetHeader(clientIdHeader), c.ClientIP())
+}
+
+func (n *NFConfigServer) grpcClientIdentity(ctx context.Context) clientIdentity {
+	var state *tls.ConnectionState
+	var address string
+	if p, ok := peer.FromContext(ctx); ok {
+		if tlsInfo, ok := p.AuthInfo.(credentials.TLSInfo); ok {
+			state = &tlsInfo.State
+		}
+		if p.Addr != nil {
+			address = p.Addr.String()
+			if host, _, err := net.SplitHostPort(address); err == nil {
+				address = host
+			}
+		}
+	}
+	var header string
+	if values := metadata.ValueFromIncomingContext(ctx, strings.ToLower(clientIdHeader)); len(values) > 0 {
+		header = values[0]
+	}
+	return n.identifyClient(state, header, address)
+}
+
+type endpointStatus struct {
+	Endpoint       configResource `json:"endpoint"`
+	ServedVersion  uint64         `json:"servedVersion"`
+	CurrentVersion uint64         `json:"currentVersion"`
+	UpToDate       bool           `json:"upToDate"`
+	LastSeen       time.Time      `json:"lastSeen"`
+	Requests       uint64         `json:"requests"`
+}
+
+type clientStatus struct {
+	Client         string           `json:"client"`
+	IdentitySource string           `json:"identitySource"`
+	NfType         string           `json:"nfType,omitempty"`
+	Address        string           `json:"address"`
+	LastSeen       time.Time        `json:"lastSeen"`
+	Endpoints      []endpointStatus `json:"endpoints"`
+}
+
+// clientRegistry records the versions served to each client, so that the
+// clients stuck on old versions stand out. It is kept in memory.
+type clientRegistry struct {
+	mutex   sync.Mutex
+	clients map[string]*clientStatus
+}
+
+func newClientRegistry() *clientRegistry {
+	return &clientRegistry{clients: make(map[string]*clientStatus)}
+}
+
+// record stores the version of the endpoint served to the client. New
+// clients are not recorded once maxClients are listed.
+func (r *clientRegistry) record(identity clientIdentity, endpoint configResource, version uint64) {
+	if r == nil {
+		return
+	}
+	now := timeNow()
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	client, exists := r.clients[identity.id]
+	if !exists {
+		if len(r.clients) >= maxClients {
+			logger.NfConfigLog.Debugf("Not recording NF configuration client %s, %d clients are listed", identity.id, maxClients)
+			return
+		}
+		client = &clientStatus{Client: identity.id, IdentitySource: identity.source}
+		r.clients[identity.id] = client
+	}
+	client.NfType = identity.nfType
+	client.Address = identity.address
+	client.LastSeen = now
+	i := slices.IndexFunc(client.Endpoints, func(e endpointStatus) bool { return e.Endpoint == endpoint })
+	if i < 0 {
+		client.Endpoints = append(client.Endpoints, endpointStatus{Endpoint: endpoint})
+		slices.SortFunc(client.Endpoints, func(a, b endpointStatus) int { return strings.Compare(string(a.Endpoint), string(b.Endpoint)) })
+		i = slices.IndexFunc(client.Endpoints, func(e endpointStatus) bool { return e.Endpoint == endpoint })
+	}
+	client.Endpoints[i].ServedVersion = version
+	client.Endpoints[i].LastSeen = now
+	client.Endpoints[i].Requests++
+	metrics.RecordNfConfigClientRequest(identity.id, string(endpoint), version, now)
+}
+
+// run drops the clients not seen within the retention every
+// clientSweepInterval until ctx ends
+func (r *clientRegistry) run(ctx context.Context) {
+	ticker := time.NewTicker(clientSweepInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			r.sweep()
+		}
+	}
+}
+
+func (r *clientRegistry) sweep() {
+	now := timeNow()
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	for id, client := range r.clients {
+		if now.Sub(client.LastSeen) > clientRetention {
+			delete(r.clients, id)
+			metrics.DeleteNfConfigClient(id)
+		}
+	}
+}
+
+// list returns the clients sorted by identity, compared to the snapshot
+func (r *clientRegistry) list(snapshot *inMemoryConfig) []clientStatus {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	clients := make([]clientStatus, 0, len(r.clients))
+	for _, client := range r.clients {
+		status := *client
+		status.Endpoints = slices.Clone(client.Endpoints)
+		for i := range status.Endpoints {
+			status.Endpoints[i].CurrentVersion = snapshot.resourceVersions[status.Endpoints[i].Endpoint]
+			status.Endpoints[i].UpToDate = status.Endpoints[i].ServedVersion == status.Endpoints[i].CurrentVersion
+		}
+		clients = append(clients, status)
+	}
+	slices.SortFunc(clients, func(a, b clientStatus) int { return strings.Compare(a.Client, b.Client) })
+	return clients
+}
+
+// recordResourceVersions exposes the versions of the snapshot as metrics
+func recordResourceVersions(snapshot *inMemoryConfig) {
+	for resource, version := range snapshot.resourceVersions {
+		metrics.SetNfConfigResourceVersion(string(resource), version)
+	}
+}
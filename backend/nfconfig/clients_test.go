@@ -0,0 +1,234 @@
+// SPDX-FileCopyrightText: 2026 Canonical Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+package nfconfig
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/omec-project/openapi/v2/nfConfigApi"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+func newClientsTestServer() *NFConfigServer {
+	n := &NFConfigServer{Router: gin.New(), watcher: newConfigWatcher(), clients: newClientRegistry()}
+	storeSnapshot(n, &inMemoryConfig{
+		plmn:              []nfConfigApi.PlmnId{{Mcc: "001", Mnc: "01"}},
+		sessionManagement: []nfConfigApi.SessionManagement{{SliceName: "slice1"}},
+	})
+	n.setupRoutes()
+	return n
+}
+
+func getClients(t *testing.T, n *NFConfigServer) []clientStatus {
+	t.Helper()
+	w := httptest.NewRecorder()
+	n.Router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/nfconfig/clients", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d", http.StatusOK, w.Code)
+	}
+	var clients []clientStatus
+	if err := json.Unmarshal(w.Body.Bytes(), &clients); err != nil {
+		t.Fatalf("failed to unmarshal clients: %v", err)
+	}
+	return clients
+}
+
+// gaugeValue returns the value of the gauge of the default registry with the
+// given client and endpoint labels
+func gaugeValue(t *testing.T, name string, client string, endpoint string) (float64, bool) {
+	t.Helper()
+	families, err := prometheus.DefaultGatherer.Gather()
+	if err != nil {
+		t.Fatalf("failed to gather metrics: %v", err)
+	}
+	for _, family := range families {
+		if family.GetName() != name {
+			continue
+		}
+		for _, metric := range family.GetMetric() {
+			labels := map[string]string{}
+			for _, label := range metric.GetLabel() {
+				labels[label.GetName()] = label.GetValue()
+			}
+			if labels["endpoint"] == endpoint && (client == "" || labels["client"] == client) {
+				return metric.GetGauge().GetValue(), true
+			}
+		}
+	}
+	return 0, false
+}
+
+func TestGetClients(t *testing.T) {
+	n := newClientsTestServer()
+	requests := []struct {
+		route       string
+		instanceId  string
+		remoteAddr  string
+		ifNoneMatch string
+	}{
+		{route: "/nfconfig/session-management", instanceId: "db9b7d5e-6c4f-4b1e-9d59-6f0c3a1e5b01", remoteAddr: "10.0.0.1:4000"},
+		{route: "/nfconfig/plmn", instanceId: "DB9B7D5E-6C4F-4B1E-9D59-6F0C3A1E5B01", remoteAddr: "10.0.0.1:4000"},
+		{route: "/nfconfig/plmn", remoteAddr: "10.0.0.2:4000", ifNoneMatch: `"1"`},
+		{route: "/nfconfig/plmn?sst=1", remoteAddr: "10.0.0.3:4000"},
+	}
+	for _, r := range requests {
+		req := httptest.NewRequest(http.MethodGet, r.route, nil)
+		req.RemoteAddr = r.remoteAddr
+		if r.instanceId != "" {
+			req.Header.Set(clientIdHeader, r.instanceId)
+		}
+		if r.ifNoneMatch != "" {
+			req.Header.Set("If-None-Match", r.ifNoneMatch)
+		}
+		n.Router.ServeHTTP(httptest.NewRecorder(), req)
+	}
+	storeSnapshot(n, &inMemoryConfig{
+		plmn:              []nfConfigApi.PlmnId{{Mcc: "001", Mnc: "01"}, {Mcc: "002", Mnc: "02"}},
+		sessionManagement: []nfConfigApi.SessionManagement{{SliceName: "slice1"}},
+	})
+
+	clients := getClients(t, n)
+	if len(clients) != 2 {
+		t.Fatalf("expected the two clients with valid requests, got %+v", clients)
+	}
+	if clients[0].Client != "10.0.0.2" || clients[0].IdentitySource != addressIdentity {
+		t.Errorf("expected the client identified by its address first, got %+v", clients[0])
+	}
+	smf := clients[1]
+	if smf.Client != "db9b7d5e-6c4f-4b1e-9d59-6f0c3a1e5b01" || smf.IdentitySource != headerIdentity || smf.Address != "10.0.0.1" || len(smf.Endpoints) != 2 {
+		t.Fatalf("expected the SMF identified by its header with two endpoints, got %+v", smf)
+	}
+	plmn, sessionManagement := smf.Endpoints[0], smf.Endpoints[1]
+	if plmn.Endpoint != plmnResource || plmn.ServedVersion != 1 || plmn.CurrentVersion != 2 || plmn.UpToDate {
+		t.Errorf("expected the PLMN served at version 1 to be outdated, got %+v", plmn)
+	}
+	if sessionManagement.Endpoint != sessionManagementResource || !sessionManagement.UpToDate || sessionManagement.Requests != 1 {
+		t.Errorf("expected the session management to be up to date, got %+v", sessionManagement)
+	}
+	if version, ok := gaugeValue(t, "nfconfig_client_served_version", "db9b7d5e-6c4f-4b1e-9d59-6f0c3a1e5b01", "plmn"); !ok || version != 1 {
+		t.Errorf("expected the served version metric to be 1, got %v (%v)", version, ok)
+	}
+}
+
+func TestClientRegistry_Retention(t *testing.T) {
+	originalTimeNow := timeNow
+	defer func() { timeNow = originalTimeNow }()
+	now := time.Now()
+	timeNow = func() time.Time { return now }
+	r := newClientRegistry()
+	r.record(clientIdentity{id: "amf-retention-1", source: headerIdentity}, accessMobilityResource, 1)
+	now = now.Add(clientRetention + time.Minute)
+	r.record(clientIdentity{id: "amf-retention-2", source: headerIdentity}, accessMobilityResource, 1)
+	r.sweep()
+
+	clients := r.list(emptyInMemoryConfig)
+	if len(clients) != 1 || clients[0].Client != "amf-retention-2" {
+		t.Errorf("expected only the recently seen client, got %+v", clients)
+	}
+	if _, ok := gaugeValue(t, "nfconfig_client_served_version", "amf-retention-1", "access-mobility"); ok {
+		t.Error("expected the metrics of the expired client to be deleted")
+	}
+}
+
+func TestClientRegistry_MaxClients(t *testing.T) {
+	originalMaxClients := maxClients
+	defer func() { maxClients = originalMaxClients }()
+	maxClients = 2
+	r := newClientRegistry()
+	for _, id := range []string{"10.0.1.1", "10.0.1.2", "10.0.1.3"} {
+		r.record(clientIdentity{id: id, source: addressIdentity}, plmnResource, 1)
+	}
+	r.record(clientIdentity{id: "10.0.1.1", source: addressIdentity}, sessionManagementResource, 1)
+
+	clients := r.list(emptyInMemoryConfig)
+	if len(clients) != 2 || clients[0].Client != "10.0.1.1" || len(clients[0].Endpoints) != 2 {
+		t.Errorf("expected the two first clients to be listed and updated, got %+v", clients)
+	}
+	if _, ok := gaugeValue(t, "nfconfig_client_served_version", "10.0.1.3", "plmn"); ok {
+		t.Error("expected no metrics for the client over the limit")
+	}
+}
+
+func TestIdentifyClient(t *testing.T) {
+	state := func(commonName string, ou string) *tls.ConnectionState {
+		cert := &x509.Certificate{Subject: pkix.Name{CommonName: commonName, OrganizationalUnit: []string{ou}}}
+		return &tls.ConnectionState{VerifiedChains: [][]*x509.Certificate{{cert}}}
+	}
+	tests := []struct {
+		name     string
+		state    *tls.ConnectionState
+		header   string
+		expected clientIdentity
+	}{
+		{
+			name:     "certificate",
+			state:    state("pcf-0", "pcf"),
+			header:   "ignored",
+			expected: clientIdentity{id: "pcf-0", source: certificateIdentity, nfType: "pcf", address: "10.0.0.1"},
+		},
+		{
+			name:     "certificate without CN",
+			state:    state("", "pcf"),
+			header:   "5d4c3b2a-1f0e-4d9c-8b7a-6f5e4d3c2b1a",
+			expected: clientIdentity{id: "5d4c3b2a-1f0e-4d9c-8b7a-6f5e4d3c2b1a", source: headerIdentity, nfType: "pcf", address: "10.0.0.1"},
+		},
+		{
+			name:     "header",
+			header:   " 8f2a1c3e-5b6d-4e7f-8a9b-0c1d2e3f4a5b ",
+			expected: clientIdentity{id: "8f2a1c3e-5b6d-4e7f-8a9b-0c1d2e3f4a5b", source: headerIdentity, address: "10.0.0.1"},
+		},
+		{
+			name:     "header without NF instance ID",
+			header:   "amf-0",
+			expected: clientIdentity{id: "10.0.0.1", source: addressIdentity, address: "10.0.0.1"},
+		},
+		{
+			name:     "address",
+			expected: clientIdentity{id: "10.0.0.1", source: addressIdentity, address: "10.0.0.1"},
+		},
+	}
+	n := &NFConfigServer{authorizer: newTestAuthorizer(t, nil)}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			identity := n.identifyClient(tc.state, tc.header, "10.0.0.1")
+			if identity != tc.expected {
+				t.Errorf("expected %+v, got %+v", tc.expected, identity)
+			}
+		})
+	}
+}
+
+func TestGetClients_MutualTLS(t *testing.T) {
+	n := newClientsTestServer()
+	n.authorizer = newTestAuthorizer(t, map[string][]string{"operator": {"clients"}})
+	n.Router = gin.New()
+	n.setupRoutes()
+	tests := []struct {
+		name         string
+		ou           string
+		expectedCode int
+	}{
+		{name: "allowed NF type", ou: "operator", expectedCode: http.StatusOK},
+		{name: "NF type without access", ou: "smf", expectedCode: http.StatusForbidden},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/nfconfig/clients", nil)
+			req.TLS = verifiedState(tc.ou)
+			w := httptest.NewRecorder()
+			n.Router.ServeHTTP(w, req)
+			if w.Code != tc.expectedCode {
+				t.Errorf("expected status %d, got %d", tc.expectedCode, w.Code)
+			}
+		})
+	}
+}
@@ -7,10 +7,12 @@ package nfconfig
 
 import (
 	"fmt"
+	"maps"
 	"slices"
 	"sort"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/omec-project/openapi/v2/nfConfigApi"
 	"github.com/omec-project/webconsole/backend/factory"
@@ -24,46 +26,147 @@ const (
 	udp int32 = 17
 )
 
+// sliceQosProperty is the additional property carrying the slice QoS in
+// the Session Management and Policy Control configurations
+const sliceQosProperty = "sliceQos"
+
+// pduSessionTypesProperty is the additional property carrying the allowed
+// PDU session types of the DNN in the IP domains
+const pduSessionTypesProperty = "pduSessionTypes"
+
+// dnsSecondaryProperty is the additional property carrying the secondary DNS
+// server of the DNN in the IP domains
+const dnsSecondaryProperty = "dnsSecondaryIpv4"
+
+// Additional properties carrying the UPF inventory details in the Session
+// Management configuration
+const (
+	upfN4AddressProperty    = "n4Address"
+	upfN3InterfacesProperty = "n3Interfaces"
+	upfDnnsProperty         = "dnns"
+	upfCapacityProperty     = "capacity"
+)
+
+type upfN3Interface struct {
+	Name    string `json:"name"`
+	Address string `json:"address"`
+}
+
+type upfDnn struct {
+	Dnn       string   `json:"dnn"`
+	UeIpPools []string `json:"ueIpPools"`
+}
+
+type upfCapacity struct {
+	MaxSessions       int32 `json:"maxSessions,omitempty"`
+	MaxThroughputMbps int64 `json:"maxThroughputMbps,omitempty"`
+}
+
 type accessAndMobilityKey struct {
 	plmn    configmodels.SliceSiteInfoPlmn
 	sliceId configmodels.SliceSliceId
 }
 
+// accessAndMobilityTacs holds the S-NSSAI of the slices of an
+// accessAndMobilityKey, parsed once, and the TACs of their gNBs
+type accessAndMobilityTacs struct {
+	snssai nfConfigApi.Snssai
+	tacs   map[string]struct{}
+}
+
 type imsiQosConfig struct {
-	imsis []string
-	dnn   string
-	qos   []nfConfigApi.ImsiQos
+	deviceGroup string
+	imsis       []string
+	dnn         string
+	qos         []nfConfigApi.ImsiQos
 }
 
+// inMemoryConfig is a snapshot of the NF configuration. A snapshot is never
+// modified once it is served: each sync builds a new one and swaps it in.
 type inMemoryConfig struct {
+	// version of the snapshot, increased by every sync
+	version uint64
+	// version of each resource, only increased when the resource changed.
+	// Versions are local to this webconsole, unlike the ETags.
+	resourceVersions map[configResource]uint64
+	// ETag of each resource, a hash of its content
+	resourceETags     map[configResource]string
 	plmn              []nfConfigApi.PlmnId
 	plmnSnssai        []nfConfigApi.PlmnSnssai
 	accessAndMobility []nfConfigApi.AccessAndMobility
 	sessionManagement []nfConfigApi.SessionManagement
 	policyControl     []nfConfigApi.PolicyControl
-	imsiQos           []imsiQosConfig
+	imsiQos           *imsiQosIndex
+	// issues found building the views, sorted
+	diagnostics diagnostics
+	// next time a scheduled application filtering rule becomes active or inactive
+	nextRuleBoundary time.Time
 }
 
-var defaultPccRule = nfConfigApi.NewPccRule(
-	"DefaultRule",
-	[]nfConfigApi.PccFlow{
-		{
-			Description: "permit out ip from any to assigned",
-			Direction:   nfConfigApi.DIRECTION_BIDIRECTIONAL,
-			Status:      nfConfigApi.STATUS_ENABLED,
-		},
-	},
-	*nfConfigApi.NewPccQos(
-		9,
-		*nfConfigApi.NewArp(
-			1,
-			nfConfigApi.PREEMPTCAP_MAY_PREEMPT,
-			nfConfigApi.PREEMPTVULN_PREEMPTABLE,
-		),
-	),
-	255,
+// configSources holds the resolved slices and device groups the views of a
+// snapshot are built from
+type configSources struct {
+	slices       []configmodels.Slice
+	deviceGroups map[string]configmodels.DeviceGroups
+	// issues found loading the slices and the device groups
+	sliceDiagnostics       diagnostics
+	deviceGroupDiagnostics diagnostics
+}
+
+var timeNow = time.Now
+
+const (
+	defaultPccRuleId         = "DefaultRule"
+	defaultPccRuleFiveQi     = 9
+	defaultPccRuleArp        = 1
+	defaultPccRulePrecedence = 255
 )
 
+// buildDefaultPccRule builds the PCC rule applied when the slice has no active
+// application filtering rule. Settings that are not configured in the slice
+// fall back to the defaults above.
+func buildDefaultPccRule(slice configmodels.Slice) nfConfigApi.PccRule {
+	trafficClass := configmodels.TrafficClassInfo{
+		Qci: defaultPccRuleFiveQi,
+		Arp: defaultPccRuleArp,
+	}
+	var precedence int32 = defaultPccRulePrecedence
+	if slice.DefaultPccRule != nil {
+		if slice.DefaultPccRule.TrafficClass != nil {
+			trafficClass = *slice.DefaultPccRule.TrafficClass
+		}
+		if slice.DefaultPccRule.Precedence != 0 {
+			precedence = slice.DefaultPccRule.Precedence
+		}
+	}
+	return *nfConfigApi.NewPccRule(
+		defaultPccRuleId,
+		[]nfConfigApi.PccFlow{
+			{
+				Description: "permit out ip from any to assigned",
+				Direction:   nfConfigApi.DIRECTION_BIDIRECTIONAL,
+				Status:      nfConfigApi.STATUS_ENABLED,
+			},
+		},
+		*nfConfigApi.NewPccQos(trafficClass.Qci, buildArp(trafficClass)),
+		precedence,
+	)
+}
+
+// buildArp converts a traffic class into an ARP. Unset preemption settings
+// default to MAY_PREEMPT and PREEMPTABLE.
+func buildArp(trafficClass configmodels.TrafficClassInfo) nfConfigApi.Arp {
+	preemptCap := nfConfigApi.PREEMPTCAP_MAY_PREEMPT
+	if trafficClass.PreemptCap != "" {
+		preemptCap = nfConfigApi.PreemptCap(trafficClass.PreemptCap)
+	}
+	preemptVuln := nfConfigApi.PREEMPTVULN_PREEMPTABLE
+	if trafficClass.PreemptVuln != "" {
+		preemptVuln = nfConfigApi.PreemptVuln(trafficClass.PreemptVuln)
+	}
+	return *nfConfigApi.NewArp(trafficClass.Arp, preemptCap, preemptVuln)
+}
+
 func (c *inMemoryConfig) syncPlmn(slices []configmodels.Slice) {
 	plmnSet := make(map[string]struct{})
 	newPlmnConfig := []nfConfigApi.PlmnId{}
@@ -88,19 +191,28 @@ func (c *inMemoryConfig) syncPlmn(slices []configmodels.Slice) {
 }
 
 func (c *inMemoryConfig) syncPlmnSnssai(slices []configmodels.Slice) {
-	plmnMap := make(map[configmodels.SliceSiteInfoPlmn]map[configmodels.SliceSliceId]struct{})
+	plmnMap := make(map[configmodels.SliceSiteInfoPlmn]map[configmodels.SliceSliceId]nfConfigApi.Snssai)
 	for _, s := range slices {
+		snssai, err := parseSnssaiFromSlice(s.SliceId)
+		if err != nil {
+			c.diagnostics.add(plmnSnssaiResource, sliceObject(s.SliceName), "slice-id.sst", invalidSstReason(s.SliceId))
+			continue
+		}
 		plmn := s.SiteInfo.Plmn
 		if plmnMap[plmn] == nil {
-			plmnMap[plmn] = map[configmodels.SliceSliceId]struct{}{}
+			plmnMap[plmn] = map[configmodels.SliceSliceId]nfConfigApi.Snssai{}
 		}
-		plmnMap[plmn][s.SliceId] = struct{}{}
+		plmnMap[plmn][s.SliceId] = snssai
 	}
 
 	c.plmnSnssai = convertPlmnMapToSortedList(plmnMap)
 	logger.NfConfigLog.Debugf("Updated PLMN S-NSSAI in-memory configuration. New configuration: %+v", c.plmnSnssai)
 }
 
+func invalidSstReason(sliceId configmodels.SliceSliceId) string {
+	return fmt.Sprintf("invalid SST '%s'. The slice is ignored", sliceId.Sst)
+}
+
 func parseSnssaiFromSlice(sliceId configmodels.SliceSliceId) (nfConfigApi.Snssai, error) {
 	val, err := strconv.ParseInt(sliceId.Sst, 10, 64)
 	if err != nil {
@@ -114,20 +226,12 @@ func parseSnssaiFromSlice(sliceId configmodels.SliceSliceId) (nfConfigApi.Snssai
 	return *snssai, nil
 }
 
-func convertPlmnMapToSortedList(plmnMap map[configmodels.SliceSiteInfoPlmn]map[configmodels.SliceSliceId]struct{}) []nfConfigApi.PlmnSnssai {
+func convertPlmnMapToSortedList(plmnMap map[configmodels.SliceSiteInfoPlmn]map[configmodels.SliceSliceId]nfConfigApi.Snssai) []nfConfigApi.PlmnSnssai {
 	newPlmnSnssaiConfig := []nfConfigApi.PlmnSnssai{}
 	for plmn, snssaiSet := range plmnMap {
 		snssaiList := make([]nfConfigApi.Snssai, 0, len(snssaiSet))
-		for snssai := range snssaiSet {
-			newSnssai, err := parseSnssaiFromSlice(snssai)
-			if err != nil {
-				logger.NfConfigLog.Warnf("Error parsing Snssai: %+v. Network slice `%+v` will be ignored", err, snssai)
-				continue
-			}
-			snssaiList = append(snssaiList, newSnssai)
-		}
-		if len(snssaiList) == 0 {
-			continue
+		for _, snssai := range snssaiSet {
+			snssaiList = append(snssaiList, snssai)
 		}
 		plmnId := nfConfigApi.NewPlmnId(plmn.Mcc, plmn.Mnc)
 		plmnSnssai := nfConfigApi.NewPlmnSnssai(*plmnId, snssaiList)
@@ -167,8 +271,13 @@ func sortPlmnSnssaiConfig(plmnSnssai []nfConfigApi.PlmnSnssai) {
 }
 
 func (c *inMemoryConfig) syncAccessAndMobility(networkSlices []configmodels.Slice) {
-	plmnSnssaiTacsMap := map[accessAndMobilityKey]map[string]struct{}{}
+	plmnSnssaiTacsMap := map[accessAndMobilityKey]*accessAndMobilityTacs{}
 	for _, s := range networkSlices {
+		snssai, err := parseSnssaiFromSlice(s.SliceId)
+		if err != nil {
+			c.diagnostics.add(accessMobilityResource, sliceObject(s.SliceName), "slice-id.sst", invalidSstReason(s.SliceId))
+			continue
+		}
 		accessAndMobilityTmp := accessAndMobilityKey{
 			plmn:    s.SiteInfo.Plmn,
 			sliceId: s.SliceId,
@@ -176,29 +285,24 @@ func (c *inMemoryConfig) syncAccessAndMobility(networkSlices []configmodels.Slic
 		if plmnSnssaiTacsMap[accessAndMobilityTmp] != nil {
 			logger.NfConfigLog.Warnf("Found duplicate Network slice `%+v` for PLMN `%+v`, merging TACs for Access and Mobility", s.SliceId, s.SiteInfo.Plmn)
 		} else {
-			plmnSnssaiTacsMap[accessAndMobilityTmp] = map[string]struct{}{}
+			plmnSnssaiTacsMap[accessAndMobilityTmp] = &accessAndMobilityTacs{snssai: snssai, tacs: map[string]struct{}{}}
 		}
 		for _, g := range s.SiteInfo.GNodeBs {
 			tac := strconv.Itoa(int(g.Tac))
-			plmnSnssaiTacsMap[accessAndMobilityTmp][tac] = struct{}{}
+			plmnSnssaiTacsMap[accessAndMobilityTmp].tacs[tac] = struct{}{}
 		}
 	}
 	c.accessAndMobility = convertPlmnSnssaiTacsMapToSortedList(plmnSnssaiTacsMap)
 	logger.NfConfigLog.Debugf("Updated Access and Mobility in-memory configuration. New configuration: %+v", c.accessAndMobility)
 }
 
-func convertPlmnSnssaiTacsMapToSortedList(plmnSnssaiMap map[accessAndMobilityKey]map[string]struct{}) []nfConfigApi.AccessAndMobility {
+func convertPlmnSnssaiTacsMapToSortedList(plmnSnssaiMap map[accessAndMobilityKey]*accessAndMobilityTacs) []nfConfigApi.AccessAndMobility {
 	newAccessAndMobilityConfig := []nfConfigApi.AccessAndMobility{}
-	for plmnSliceId, tacSet := range plmnSnssaiMap {
+	for plmnSliceId, entry := range plmnSnssaiMap {
 		plmnId := nfConfigApi.NewPlmnId(plmnSliceId.plmn.Mcc, plmnSliceId.plmn.Mnc)
-		parsedSnssai, err := parseSnssaiFromSlice(plmnSliceId.sliceId)
-		if err != nil {
-			logger.NfConfigLog.Warnf("Error in parsing SNSSAI: %v. Network slice `%+v` will be ignored", err, plmnSliceId.sliceId)
-			continue
-		}
-		accessAndMobility := nfConfigApi.NewAccessAndMobility(*plmnId, parsedSnssai)
-		tacList := make([]string, 0, len(tacSet))
-		for tac := range tacSet {
+		accessAndMobility := nfConfigApi.NewAccessAndMobility(*plmnId, entry.snssai)
+		tacList := make([]string, 0, len(entry.tacs))
+		for tac := range entry.tacs {
 			tacList = append(tacList, tac)
 		}
 		accessAndMobility.Tacs = tacList
@@ -229,11 +333,11 @@ func sortAccessAndMobilityConfig(accessAndMobility []nfConfigApi.AccessAndMobili
 	}
 }
 
-func (c *inMemoryConfig) syncSessionManagement(slices []configmodels.Slice, deviceGroupMap map[string]configmodels.DeviceGroups) {
+func (c *inMemoryConfig) syncSessionManagement(slices []configmodels.Slice, deviceGroupMap map[string]configmodels.DeviceGroups, dnns map[string]configmodels.Dnn, upfs map[string]configmodels.Upf) {
 	sessionConfigs := make([]nfConfigApi.SessionManagement, 0, len(slices))
 
 	for _, slice := range slices {
-		session, ok := buildSessionManagementConfig(slice, deviceGroupMap)
+		session, ok := buildSessionManagementConfig(slice, deviceGroupMap, dnns, upfs, &c.diagnostics)
 		if ok {
 			sessionConfigs = append(sessionConfigs, *session)
 		}
@@ -247,21 +351,21 @@ func (c *inMemoryConfig) syncSessionManagement(slices []configmodels.Slice, devi
 	logger.NfConfigLog.Debugf("updated Session Management configuration with %d slices: %+v", len(sessionConfigs), c.sessionManagement)
 }
 
-func buildSessionManagementConfig(slice configmodels.Slice, deviceGroupMap map[string]configmodels.DeviceGroups) (*nfConfigApi.SessionManagement, bool) {
+func buildSessionManagementConfig(slice configmodels.Slice, deviceGroupMap map[string]configmodels.DeviceGroups, dnns map[string]configmodels.Dnn, upfs map[string]configmodels.Upf, d *diagnostics) (*nfConfigApi.SessionManagement, bool) {
 	plmn := nfConfigApi.NewPlmnId(slice.SiteInfo.Plmn.Mcc, slice.SiteInfo.Plmn.Mnc)
 
 	snssai, err := parseSnssaiFromSlice(slice.SliceId)
 	if err != nil {
-		logger.NfConfigLog.Errorf("invalid SNSSAI for slice %s: %+v", slice.SliceName, err)
+		d.add(sessionManagementResource, sliceObject(slice.SliceName), "slice-id.sst", invalidSstReason(slice.SliceId))
 		return nil, false
 	}
 	session := nfConfigApi.NewSessionManagement(slice.SliceName, *plmn, snssai)
 
-	if ipDomains := extractIpDomains(slice.SiteDeviceGroup, deviceGroupMap); len(ipDomains) > 0 {
+	if ipDomains := extractIpDomains(slice.SliceName, slice.SiteDeviceGroup, deviceGroupMap, dnns, d); len(ipDomains) > 0 {
 		session.SetIpDomain(ipDomains)
 	}
 
-	if upf := extractUpf(slice); upf != nil {
+	if upf := extractUpf(slice, upfs, d); upf != nil {
 		session.SetUpf(*upf)
 	}
 
@@ -269,48 +373,84 @@ func buildSessionManagementConfig(slice configmodels.Slice, deviceGroupMap map[s
 		session.SetGnbNames(gnbNames)
 	}
 
+	if sliceQos, ok := extractSliceQos(slice); ok {
+		session.AdditionalProperties = map[string]any{sliceQosProperty: sliceQos}
+	}
+
 	return session, true
 }
 
-func extractIpDomains(groupNames []string, deviceGroupMap map[string]configmodels.DeviceGroups) []nfConfigApi.IpDomain {
+// extractIpDomains builds the IP domains of the device groups of the slice. The MTU
+// and DNS server of the DNN catalog are used when the device group does not set them.
+func extractIpDomains(sliceName string, groupNames []string, deviceGroupMap map[string]configmodels.DeviceGroups, dnns map[string]configmodels.Dnn, d *diagnostics) []nfConfigApi.IpDomain {
 	ipDomains := make([]nfConfigApi.IpDomain, 0, len(groupNames))
 
 	for _, name := range groupNames {
 		dg, exists := deviceGroupMap[name]
 		if !exists {
-			logger.NfConfigLog.Warnf("Device group %s not found", name)
+			d.add(sessionManagementResource, sliceObject(sliceName), "site-device-group", fmt.Sprintf("device group %s not found. It is ignored", name))
 			continue
 		}
 		for _, ipDomainExp := range dg.IpDomainsExpanded {
+			dnsPrimary := ipDomainExp.DnsPrimary
+			dnsSecondary := ipDomainExp.DnsSecondary
+			mtu := ipDomainExp.Mtu
+			dnn, inCatalog := dnns[ipDomainExp.Dnn]
+			if inCatalog {
+				if dnsPrimary == "" {
+					dnsPrimary = dnn.DnsPrimary
+				}
+				if dnsSecondary == "" {
+					dnsSecondary = dnn.DnsSecondary
+				}
+				if mtu == 0 {
+					mtu = dnn.Mtu
+				}
+			} else if dnns != nil {
+				d.add(sessionManagementResource, deviceGroupObject(name), "ip-domains.dnn", fmt.Sprintf("DNN %s not found in the DNN catalog", ipDomainExp.Dnn))
+			}
 			ip := nfConfigApi.NewIpDomain(
 				ipDomainExp.Dnn,
-				ipDomainExp.DnsPrimary,
+				dnsPrimary,
 				ipDomainExp.UeIpPool, // Now accessing the correct field from the slice element
-				ipDomainExp.Mtu,
+				mtu,
 			)
 			if ipDomainExp.PcscfPrimary != "" {
 				ip.SetPcscfIpv4(ipDomainExp.PcscfPrimary)
 			}
+			properties := map[string]any{}
+			if dnsSecondary != "" {
+				properties[dnsSecondaryProperty] = dnsSecondary
+			}
+			if inCatalog && len(dnn.PduSessionTypes) > 0 {
+				properties[pduSessionTypesProperty] = dnn.PduSessionTypes
+			}
+			if len(properties) > 0 {
+				ip.AdditionalProperties = properties
+			}
 			ipDomains = append(ipDomains, *ip)
 		}
 	}
 	return ipDomains
 }
 
-func extractUpf(slice configmodels.Slice) *nfConfigApi.Upf {
+// extractUpf builds the UPF of the slice. The N4 address, N3 interfaces, DNNs and
+// capacity of the UPF inventory are added as additional properties.
+func extractUpf(slice configmodels.Slice, upfs map[string]configmodels.Upf, d *diagnostics) *nfConfigApi.Upf {
+	sliceObj := sliceObject(slice.SliceName)
 	upfMap := slice.SiteInfo.Upf
 	if upfMap == nil {
-		logger.NfConfigLog.Warnf("no UPF defined for slice %s", slice.SliceName)
+		d.add(sessionManagementResource, sliceObj, "site-info.upf", "no UPF defined")
 		return nil
 	}
 	hostnameRaw, ok := upfMap["upf-name"]
 	if !ok {
-		logger.NfConfigLog.Warnf("missing UPF hostname for slice %s", slice.SliceName)
+		d.add(sessionManagementResource, sliceObj, "site-info.upf.upf-name", "missing UPF hostname. The UPF is ignored")
 		return nil
 	}
 	hostname, ok := hostnameRaw.(string)
 	if !ok || hostname == "" {
-		logger.NfConfigLog.Warnf("invalid UPF hostname for slice %s: %v", slice.SliceName, hostnameRaw)
+		d.add(sessionManagementResource, sliceObj, "site-info.upf.upf-name", fmt.Sprintf("invalid UPF hostname '%v'. The UPF is ignored", hostnameRaw))
 		return nil
 	}
 	upf := nfConfigApi.NewUpf(hostname)
@@ -321,27 +461,78 @@ func extractUpf(slice configmodels.Slice) *nfConfigApi.Upf {
 			if port, err := strconv.ParseUint(v, 10, 16); err == nil {
 				upf.SetPort(int32(port))
 			} else {
-				logger.NfConfigLog.Warnf("invalid UPF port for slice %s: %+v", slice.SliceName, err)
+				d.add(sessionManagementResource, sliceObj, "site-info.upf.upf-port", fmt.Sprintf("invalid UPF port '%s'. The port is ignored", v))
 			}
 		case float64:
 			if v >= 0 && v <= 65535 {
 				upf.SetPort(int32(v))
 			} else {
-				logger.NfConfigLog.Warnf("UPF port out of valid range (0-65535) for slice %s: %v", slice.SliceName, v)
+				d.add(sessionManagementResource, sliceObj, "site-info.upf.upf-port", fmt.Sprintf("UPF port %v out of valid range (0-65535). The port is ignored", v))
 			}
 		case int:
 			if v >= 0 && v <= 65535 {
 				upf.SetPort(int32(v))
 			} else {
-				logger.NfConfigLog.Warnf("UPF port out of valid range (0-65535) for slice %s: %v", slice.SliceName, v)
+				d.add(sessionManagementResource, sliceObj, "site-info.upf.upf-port", fmt.Sprintf("UPF port %v out of valid range (0-65535). The port is ignored", v))
 			}
 		default:
-			logger.NfConfigLog.Warnf("UPF port should be a string or number for slice %s, got: %T", slice.SliceName, v)
+			d.add(sessionManagementResource, sliceObj, "site-info.upf.upf-port", fmt.Sprintf("UPF port should be a string or number, got %T. The port is ignored", v))
+		}
+	}
+	if upfs != nil {
+		if inventoryUpf, ok := upfs[hostname]; ok {
+			setUpfInventoryProperties(upf, inventoryUpf)
+		} else {
+			d.add(sessionManagementResource, sliceObj, "site-info.upf.upf-name", fmt.Sprintf("UPF %s not found in the inventory", hostname))
 		}
 	}
 	return upf
 }
 
+func setUpfInventoryProperties(upf *nfConfigApi.Upf, inventoryUpf configmodels.Upf) {
+	properties := map[string]any{}
+	if inventoryUpf.N4Address != "" {
+		properties[upfN4AddressProperty] = inventoryUpf.N4Address
+	}
+	if len(inventoryUpf.N3Interfaces) > 0 {
+		n3Interfaces := make([]upfN3Interface, 0, len(inventoryUpf.N3Interfaces))
+		for _, n3Interface := range inventoryUpf.N3Interfaces {
+			n3Interfaces = append(n3Interfaces, upfN3Interface(n3Interface))
+		}
+		properties[upfN3InterfacesProperty] = n3Interfaces
+	}
+	if len(inventoryUpf.Dnns) > 0 {
+		dnns := make([]upfDnn, 0, len(inventoryUpf.Dnns))
+		for _, dnn := range inventoryUpf.Dnns {
+			dnns = append(dnns, upfDnn(dnn))
+		}
+		properties[upfDnnsProperty] = dnns
+	}
+	if inventoryUpf.Capacity != nil {
+		properties[upfCapacityProperty] = upfCapacity(*inventoryUpf.Capacity)
+	}
+	if len(properties) > 0 {
+		upf.AdditionalProperties = properties
+	}
+}
+
+func extractSliceQos(slice configmodels.Slice) (nfConfigApi.ImsiQos, bool) {
+	if slice.SliceQos == nil {
+		return nfConfigApi.ImsiQos{}, false
+	}
+	qos := nfConfigApi.NewImsiQos(
+		configapi.ConvertToString(uint64(slice.SliceQos.Uplink)),
+		configapi.ConvertToString(uint64(slice.SliceQos.Downlink)),
+		0,
+		0,
+	)
+	if slice.SliceQos.TrafficClass != nil {
+		qos.SetFiveQi(slice.SliceQos.TrafficClass.Qci)
+		qos.SetArpPriorityLevel(slice.SliceQos.TrafficClass.Arp)
+	}
+	return *qos, true
+}
+
 func extractGnbNames(slice configmodels.Slice) []string {
 	names := make([]string, 0, len(slice.SiteInfo.GNodeBs))
 	for _, gnb := range slice.SiteInfo.GNodeBs {
@@ -351,17 +542,21 @@ func extractGnbNames(slice configmodels.Slice) []string {
 	return names
 }
 
-func (c *inMemoryConfig) syncPolicyControl(slices []configmodels.Slice, deviceGroupMap map[string]configmodels.DeviceGroups) {
+func (c *inMemoryConfig) syncPolicyControl(slices []configmodels.Slice, deviceGroupMap map[string]configmodels.DeviceGroups, dnns map[string]configmodels.Dnn) {
 	policyControlConfigs := []nfConfigApi.PolicyControl{}
+	now := timeNow()
+	var nextRuleBoundary time.Time
 
 	for _, slice := range slices {
-		policyControl, ok := buildPolicyControlConfig(slice, deviceGroupMap)
+		policyControl, ruleBoundary, ok := buildPolicyControlConfig(slice, deviceGroupMap, dnns, now, &c.diagnostics)
 		if ok {
 			policyControlConfigs = append(policyControlConfigs, *policyControl)
+			nextRuleBoundary = configapi.EarliestBoundary(nextRuleBoundary, ruleBoundary)
 		}
 	}
 	sortPolicyControl(policyControlConfigs)
 	c.policyControl = policyControlConfigs
+	c.nextRuleBoundary = nextRuleBoundary
 	logger.NfConfigLog.Debugf("Updated Policy Control in-memory configuration. New configuration: %+v", c.policyControl)
 }
 
@@ -383,25 +578,49 @@ func sortPolicyControl(policyControl []nfConfigApi.PolicyControl) {
 	})
 }
 
-func buildPolicyControlConfig(slice configmodels.Slice, deviceGroups map[string]configmodels.DeviceGroups) (*nfConfigApi.PolicyControl, bool) {
+func buildPolicyControlConfig(slice configmodels.Slice, deviceGroups map[string]configmodels.DeviceGroups, dnns map[string]configmodels.Dnn, now time.Time, d *diagnostics) (*nfConfigApi.PolicyControl, time.Time, bool) {
 	plmn := nfConfigApi.NewPlmnId(slice.SiteInfo.Plmn.Mcc, slice.SiteInfo.Plmn.Mnc)
 
 	snssai, err := parseSnssaiFromSlice(slice.SliceId)
 	if err != nil {
-		logger.NfConfigLog.Errorf("invalid SNSSAI for slice %s: %+v", slice.SliceName, err)
-		return nil, false
+		d.add(policyControlResource, sliceObject(slice.SliceName), "slice-id.sst", invalidSstReason(slice.SliceId))
+		return nil, time.Time{}, false
+	}
+	pccRules, ruleBoundary := buildSlicePccRules(slice, now, d)
+	supportedDnns := getSupportedDnns(slice, deviceGroups, dnns, d)
+	policyControl := nfConfigApi.NewPolicyControl(*plmn, snssai, supportedDnns, pccRules)
+	if sliceQos, ok := extractSliceQos(slice); ok {
+		policyControl.AdditionalProperties = map[string]any{sliceQosProperty: sliceQos}
 	}
-	pccRules := buildSlicePccRules(slice)
-	dnns := getSupportedDnns(slice, deviceGroups)
-	policyControl := nfConfigApi.NewPolicyControl(*plmn, snssai, dnns, pccRules)
 
-	return policyControl, true
+	return policyControl, ruleBoundary, true
 }
 
-func buildSlicePccRules(slice configmodels.Slice) []nfConfigApi.PccRule {
+// buildSlicePccRules builds the PCC rules of the slice that are active at the given time,
+// and returns the next time one of the scheduled rules becomes active or inactive
+func buildSlicePccRules(slice configmodels.Slice, now time.Time, d *diagnostics) ([]nfConfigApi.PccRule, time.Time) {
 	pccRules := []nfConfigApi.PccRule{}
+	var nextRuleBoundary time.Time
 
 	for _, ruleConfig := range slice.ApplicationFilteringRules {
+		active, ruleBoundary, err := configapi.IsRuleActive(ruleConfig, now)
+		if err != nil {
+			d.add(policyControlResource, sliceObject(slice.SliceName), "application-filtering-rules.schedule", fmt.Sprintf("invalid schedule for rule %s: %v. The rule is ignored", ruleConfig.RuleName, err))
+			continue
+		}
+		nextRuleBoundary = configapi.EarliestBoundary(nextRuleBoundary, ruleBoundary)
+		if !active {
+			logger.NfConfigLog.Debugf("rule %s in slice %s is not active at %s", ruleConfig.RuleName, slice.SliceName, now)
+			continue
+		}
+		if ruleConfig.TrafficClass == nil {
+			d.add(policyControlResource, sliceObject(slice.SliceName), "application-filtering-rules.traffic-class", fmt.Sprintf("rule %s has no traffic class. The rule is ignored", ruleConfig.RuleName))
+			continue
+		}
+		if ruleConfig.AppName != "" && ruleConfig.Endpoint == "" {
+			d.add(policyControlResource, sliceObject(slice.SliceName), "application-filtering-rules.app-name", fmt.Sprintf("rule %s has no endpoint for application %s. The rule is ignored", ruleConfig.RuleName, ruleConfig.AppName))
+			continue
+		}
 		ruleId := ruleConfig.RuleName
 		flows := buildPccFlows(ruleConfig)
 		qos := buildPccQos(ruleConfig)
@@ -413,7 +632,7 @@ func buildSlicePccRules(slice configmodels.Slice) []nfConfigApi.PccRule {
 
 	// If slice has no PCC rules, add a default one
 	if len(pccRules) == 0 {
-		pccRules = append(pccRules, *defaultPccRule)
+		pccRules = append(pccRules, buildDefaultPccRule(slice))
 	}
 	sort.Slice(pccRules, func(i, j int) bool {
 		if pccRules[i].Precedence != pccRules[j].Precedence {
@@ -421,7 +640,7 @@ func buildSlicePccRules(slice configmodels.Slice) []nfConfigApi.PccRule {
 		}
 		return pccRules[i].RuleId < pccRules[j].RuleId
 	})
-	return pccRules
+	return pccRules, nextRuleBoundary
 }
 
 func buildPccFlows(ruleConfig configmodels.SliceApplicationFilteringRules) []nfConfigApi.PccFlow {
@@ -471,32 +690,33 @@ func buildDescription(protocol, endpoint string, startPort, endPort int32) strin
 	}
 }
 
-func getSupportedDnns(slice configmodels.Slice, deviceGroups map[string]configmodels.DeviceGroups) []string {
+// getSupportedDnns returns the DNNs of the device groups of the slice. DNNs
+// missing from the DNN catalog are reported but still returned.
+func getSupportedDnns(slice configmodels.Slice, deviceGroups map[string]configmodels.DeviceGroups, dnnCatalog map[string]configmodels.Dnn, d *diagnostics) []string {
 	dnns := []string{}
 
 	for _, dgName := range slice.SiteDeviceGroup {
 		deviceGroup, exists := deviceGroups[dgName]
 		if !exists {
-			logger.NfConfigLog.Warnf("DeviceGroup %s not found", dgName)
+			d.add(policyControlResource, sliceObject(slice.SliceName), "site-device-group", fmt.Sprintf("device group %s not found. It is ignored", dgName))
 			continue
 		}
 		for _, ipDomainExp := range deviceGroup.IpDomainsExpanded {
 			dnn := ipDomainExp.Dnn
+			if _, ok := dnnCatalog[dnn]; !ok && dnnCatalog != nil {
+				d.add(policyControlResource, deviceGroupObject(dgName), "ip-domains.dnn", fmt.Sprintf("DNN %s not found in the DNN catalog", dnn))
+			}
 			dnns = append(dnns, dnn)
 		}
 	}
 	sort.Strings(dnns)
-	return dnns
+	return slices.Compact(dnns)
 }
 
 func buildPccQos(ruleConfig configmodels.SliceApplicationFilteringRules) nfConfigApi.PccQos {
 	pccQos := nfConfigApi.NewPccQos(
 		ruleConfig.TrafficClass.Qci,
-		*nfConfigApi.NewArp(
-			ruleConfig.TrafficClass.Arp,
-			nfConfigApi.PREEMPTCAP_MAY_PREEMPT,
-			nfConfigApi.PREEMPTVULN_PREEMPTABLE,
-		),
+		buildArp(*ruleConfig.TrafficClass),
 	)
 	if ruleConfig.AppMbrUplink != 0 {
 		pccQos.SetMaxBrUl(configapi.ConvertToString(uint64(ruleConfig.AppMbrUplink)))
@@ -510,7 +730,9 @@ func buildPccQos(ruleConfig configmodels.SliceApplicationFilteringRules) nfConfi
 func (c *inMemoryConfig) syncImsiQos(deviceGroupMap map[string]configmodels.DeviceGroups) {
 	imsiQosConfigs := []imsiQosConfig{}
 
-	for _, dg := range deviceGroupMap {
+	// sorted, so that the same device group wins when an IMSI is in several
+	for _, name := range slices.Sorted(maps.Keys(deviceGroupMap)) {
+		dg := deviceGroupMap[name]
 		if len(dg.IpDomainsExpanded) == 0 {
 			continue
 		}
@@ -522,23 +744,26 @@ func (c *inMemoryConfig) syncImsiQos(deviceGroupMap map[string]configmodels.Devi
 			}
 
 			imsiQosConfigs = append(imsiQosConfigs, imsiQosConfig{
-				imsis: dg.Imsis,
-				dnn:   ipDom.Dnn,
-				qos:   []nfConfigApi.ImsiQos{imsiQos},
+				deviceGroup: name,
+				imsis:       dg.Imsis,
+				dnn:         ipDom.Dnn,
+				qos:         []nfConfigApi.ImsiQos{imsiQos},
 			})
 		}
 	}
 
-	sort.Slice(imsiQosConfigs, func(i, j int) bool {
-		return imsiQosConfigs[i].dnn < imsiQosConfigs[j].dnn
-	})
+	c.imsiQos = newImsiQosIndex(imsiQosConfigs, &c.diagnostics)
 
-	c.imsiQos = imsiQosConfigs
+	logger.NfConfigLog.Debugf("Updated IMSI QoS in-memory configuration for %d IMSIs", c.imsiQos.size())
+}
 
-	logger.NfConfigLog.Debugf(
-		"Updated IMSI QoS in-memory configuration. New configuration: %+v",
-		c.imsiQos,
-	)
+// lookupImsiQos returns the QoS of the IMSI on the DNN, or an empty list when
+// the IMSI has none
+func (c *inMemoryConfig) lookupImsiQos(dnn string, imsi string) []nfConfigApi.ImsiQos {
+	if qos, found := c.imsiQos.lookup(dnn, imsi); found {
+		return qos
+	}
+	return []nfConfigApi.ImsiQos{}
 }
 
 func extractQosConfigFromIpDomain(ipDomain configmodels.DeviceGroupsIpDomainExpanded) (nfConfigApi.ImsiQos, bool) {
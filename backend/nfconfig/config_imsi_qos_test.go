@@ -113,7 +113,7 @@ func TestSyncImsiQos(t *testing.T) {
 			cfg := inMemoryConfig{}
 			cfg.syncImsiQos(deviceGroupMap)
 
-			if !reflect.DeepEqual(cfg.imsiQos, tt.expectedResponse) {
+			if !reflect.DeepEqual(cfg.imsiQos, newImsiQosIndex(tt.expectedResponse, nil)) {
 				t.Errorf("expected %+v, got %+v", tt.expectedResponse, cfg.imsiQos)
 			}
 		})
@@ -7,11 +7,33 @@ import (
 	"fmt"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/omec-project/openapi/v2/nfConfigApi"
 	"github.com/omec-project/webconsole/configmodels"
 )
 
+// defaultPccRule is the PCC rule of a slice without rules or default PCC rule
+var defaultPccRule = nfConfigApi.NewPccRule(
+	"DefaultRule",
+	[]nfConfigApi.PccFlow{
+		{
+			Description: "permit out ip from any to assigned",
+			Direction:   nfConfigApi.DIRECTION_BIDIRECTIONAL,
+			Status:      nfConfigApi.STATUS_ENABLED,
+		},
+	},
+	*nfConfigApi.NewPccQos(
+		9,
+		*nfConfigApi.NewArp(
+			1,
+			nfConfigApi.PREEMPTCAP_MAY_PREEMPT,
+			nfConfigApi.PREEMPTVULN_PREEMPTABLE,
+		),
+	),
+	255,
+)
+
 func makePolicyControlNetworkSlice(mcc, mnc, sst, sd string, dgs []string, filteringRules []configmodels.SliceApplicationFilteringRules) configmodels.Slice {
 	plmnId := configmodels.SliceSiteInfoPlmn{
 		Mcc: mcc,
@@ -269,7 +291,7 @@ func TestSyncPolicyControl(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			cfg := inMemoryConfig{}
-			cfg.syncPolicyControl(tt.networkSlices, tt.deviceGroups)
+			cfg.syncPolicyControl(tt.networkSlices, tt.deviceGroups, nil)
 
 			if !reflect.DeepEqual(cfg.policyControl, tt.expectedResponse) {
 				t.Errorf("expected %+v, got %+v", tt.expectedResponse, cfg.policyControl)
@@ -277,3 +299,199 @@ func TestSyncPolicyControl(t *testing.T) {
 		})
 	}
 }
+
+func TestSyncPolicyControl_ScheduledRules(t *testing.T) {
+	originalTimeNow := timeNow
+	defer func() { timeNow = originalTimeNow }()
+	scheduledRule := validSliceApplicationFilteringRule
+	scheduledRule.RuleTrigger = configmodels.RuleTriggerSchedule
+	scheduledRule.Schedule = &configmodels.RuleSchedule{
+		Windows: []configmodels.RuleTimeWindow{{Start: "09:00", End: "17:00"}},
+	}
+	networkSlices := []configmodels.Slice{
+		makePolicyControlNetworkSlice("001", "01", fmt.Sprintf("%d", testSst), testSd, []string{"testDG"}, []configmodels.SliceApplicationFilteringRules{scheduledRule, anotherSliceApplicationFilteringRule}),
+	}
+
+	tests := []struct {
+		name             string
+		now              time.Time
+		expectedRuleIds  []string
+		expectedBoundary time.Time
+	}{
+		{
+			name:             "scheduled rule outside its window is left out",
+			now:              time.Date(2026, time.March, 2, 8, 0, 0, 0, time.UTC),
+			expectedRuleIds:  []string{"SOME-RULE"},
+			expectedBoundary: time.Date(2026, time.March, 2, 9, 0, 0, 0, time.UTC),
+		},
+		{
+			name:             "scheduled rule inside its window is included",
+			now:              time.Date(2026, time.March, 2, 10, 0, 0, 0, time.UTC),
+			expectedRuleIds:  []string{"SOME-RULE", testRuleName},
+			expectedBoundary: time.Date(2026, time.March, 2, 17, 0, 0, 0, time.UTC),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			timeNow = func() time.Time { return tt.now }
+			cfg := inMemoryConfig{}
+			cfg.syncPolicyControl(networkSlices, testDeviceGroups, nil)
+
+			if len(cfg.policyControl) != 1 {
+				t.Fatalf("expected 1 policy control entry, got %d", len(cfg.policyControl))
+			}
+			ruleIds := []string{}
+			for _, rule := range cfg.policyControl[0].PccRules {
+				ruleIds = append(ruleIds, rule.RuleId)
+			}
+			if !reflect.DeepEqual(ruleIds, tt.expectedRuleIds) {
+				t.Errorf("expected rules %v, got %v", tt.expectedRuleIds, ruleIds)
+			}
+			if !cfg.nextRuleBoundary.Equal(tt.expectedBoundary) {
+				t.Errorf("expected next rule boundary %s, got %s", tt.expectedBoundary, cfg.nextRuleBoundary)
+			}
+		})
+	}
+}
+
+func TestSyncPolicyControl_SliceQos(t *testing.T) {
+	slice := makePolicyControlNetworkSlice("001", "01", fmt.Sprintf("%d", testSst), testSd, []string{"testDG"}, nil)
+	slice.SliceQos = &configmodels.SliceQos{
+		Uplink:   50000000,
+		Downlink: 100000000,
+	}
+
+	cfg := inMemoryConfig{}
+	cfg.syncPolicyControl([]configmodels.Slice{slice}, testDeviceGroups, nil)
+
+	if len(cfg.policyControl) != 1 {
+		t.Fatalf("expected 1 policy control entry, got %d", len(cfg.policyControl))
+	}
+	expected := *nfConfigApi.NewImsiQos("50 Mbps", "100 Mbps", 0, 0)
+	if !reflect.DeepEqual(cfg.policyControl[0].AdditionalProperties[sliceQosProperty], expected) {
+		t.Errorf("expected slice QoS %+v, got %+v", expected, cfg.policyControl[0].AdditionalProperties[sliceQosProperty])
+	}
+}
+
+func TestBuildDefaultPccRule(t *testing.T) {
+	tests := []struct {
+		name               string
+		defaultPccRule     *configmodels.SliceDefaultPccRule
+		expectedFiveQi     int32
+		expectedArp        nfConfigApi.Arp
+		expectedPrecedence int32
+	}{
+		{
+			name:               "slice without default PCC rule uses the defaults",
+			expectedFiveQi:     9,
+			expectedArp:        *nfConfigApi.NewArp(1, nfConfigApi.PREEMPTCAP_MAY_PREEMPT, nfConfigApi.PREEMPTVULN_PREEMPTABLE),
+			expectedPrecedence: 255,
+		},
+		{
+			name: "slice default PCC rule overrides 5QI, ARP and precedence",
+			defaultPccRule: &configmodels.SliceDefaultPccRule{
+				TrafficClass: &configmodels.TrafficClassInfo{
+					Qci:         7,
+					Arp:         4,
+					PreemptCap:  configmodels.PreemptCapNotPreempt,
+					PreemptVuln: configmodels.PreemptVulnNotPreemptable,
+				},
+				Precedence: 200,
+			},
+			expectedFiveQi:     7,
+			expectedArp:        *nfConfigApi.NewArp(4, nfConfigApi.PREEMPTCAP_NOT_PREEMPT, nfConfigApi.PREEMPTVULN_NOT_PREEMPTABLE),
+			expectedPrecedence: 200,
+		},
+		{
+			name:               "slice default PCC rule with precedence only keeps default traffic class",
+			defaultPccRule:     &configmodels.SliceDefaultPccRule{Precedence: 100},
+			expectedFiveQi:     9,
+			expectedArp:        *nfConfigApi.NewArp(1, nfConfigApi.PREEMPTCAP_MAY_PREEMPT, nfConfigApi.PREEMPTVULN_PREEMPTABLE),
+			expectedPrecedence: 100,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule := buildDefaultPccRule(configmodels.Slice{DefaultPccRule: tt.defaultPccRule})
+			if rule.RuleId != "DefaultRule" {
+				t.Errorf("expected rule ID DefaultRule, got %s", rule.RuleId)
+			}
+			if rule.Qos.FiveQi != tt.expectedFiveQi {
+				t.Errorf("expected 5QI %d, got %d", tt.expectedFiveQi, rule.Qos.FiveQi)
+			}
+			if !reflect.DeepEqual(rule.Qos.Arp, tt.expectedArp) {
+				t.Errorf("expected ARP %+v, got %+v", tt.expectedArp, rule.Qos.Arp)
+			}
+			if rule.Precedence != tt.expectedPrecedence {
+				t.Errorf("expected precedence %d, got %d", tt.expectedPrecedence, rule.Precedence)
+			}
+		})
+	}
+}
+
+func TestBuildPccQos_Preemption(t *testing.T) {
+	rule := validSliceApplicationFilteringRule
+	rule.TrafficClass = &configmodels.TrafficClassInfo{
+		Qci:         testRuleQci,
+		Arp:         testRuleArp,
+		PreemptCap:  configmodels.PreemptCapNotPreempt,
+		PreemptVuln: configmodels.PreemptVulnNotPreemptable,
+	}
+
+	qos := buildPccQos(rule)
+
+	expected := *nfConfigApi.NewArp(testRuleArp, nfConfigApi.PREEMPTCAP_NOT_PREEMPT, nfConfigApi.PREEMPTVULN_NOT_PREEMPTABLE)
+	if !reflect.DeepEqual(qos.Arp, expected) {
+		t.Errorf("expected ARP %+v, got %+v", expected, qos.Arp)
+	}
+}
+
+func TestSyncPolicyControl_UnresolvedRuleIsIgnored(t *testing.T) {
+	withoutTrafficClass := validSliceApplicationFilteringRule
+	withoutTrafficClass.TrafficClass = nil
+	withoutTrafficClass.TrafficClassName = "unknown"
+	withoutEndpoint := validSliceApplicationFilteringRule
+	withoutEndpoint.AppName = "unknown"
+	withoutEndpoint.Endpoint = ""
+	tests := []struct {
+		name string
+		rule configmodels.SliceApplicationFilteringRules
+	}{
+		{name: "unknown traffic class", rule: withoutTrafficClass},
+		{name: "unknown application", rule: withoutEndpoint},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			slice := makePolicyControlNetworkSlice("001", "01", fmt.Sprintf("%d", testSst), testSd, []string{"testDG"}, []configmodels.SliceApplicationFilteringRules{tc.rule})
+
+			cfg := inMemoryConfig{}
+			cfg.syncPolicyControl([]configmodels.Slice{slice}, testDeviceGroups, nil)
+
+			if len(cfg.policyControl) != 1 {
+				t.Fatalf("expected 1 policy control entry, got %d", len(cfg.policyControl))
+			}
+			expected := []nfConfigApi.PccRule{*defaultPccRule}
+			if !reflect.DeepEqual(cfg.policyControl[0].PccRules, expected) {
+				t.Errorf("expected only the default rule, got %+v", cfg.policyControl[0].PccRules)
+			}
+		})
+	}
+}
+
+func TestGetSupportedDnns_Deduplicated(t *testing.T) {
+	slice := configmodels.Slice{SiteDeviceGroup: []string{"group-1", "group-2"}}
+	deviceGroups := map[string]configmodels.DeviceGroups{
+		"group-1": {IpDomainsExpanded: []configmodels.DeviceGroupsIpDomainExpanded{{Dnn: "internet"}, {Dnn: "ims"}}},
+		"group-2": {IpDomainsExpanded: []configmodels.DeviceGroupsIpDomainExpanded{{Dnn: "internet"}}},
+	}
+	dnns := map[string]configmodels.Dnn{"internet": {Name: "internet"}}
+
+	supportedDnns := getSupportedDnns(slice, deviceGroups, dnns, nil)
+
+	expected := []string{"ims", "internet"}
+	if !reflect.DeepEqual(supportedDnns, expected) {
+		t.Errorf("expected %v, got %v", expected, supportedDnns)
+	}
+}
@@ -6,6 +6,7 @@
 package nfconfig
 
 import (
+	"encoding/json"
 	"reflect"
 	"testing"
 
@@ -552,7 +553,7 @@ func TestSyncSessionManagement(t *testing.T) {
 
 			slices := prepareMultipleSlices(tt.sliceParams)
 			cfg := inMemoryConfig{}
-			cfg.syncSessionManagement(slices, deviceGroupMap)
+			cfg.syncSessionManagement(slices, deviceGroupMap, nil, nil)
 
 			if !reflect.DeepEqual(cfg.sessionManagement, tt.expectedResponse) {
 				t.Errorf("expected %+v, got %+v", tt.expectedResponse, cfg.sessionManagement)
@@ -560,3 +561,153 @@ func TestSyncSessionManagement(t *testing.T) {
 		})
 	}
 }
+
+func TestSyncSessionManagement_SliceQos(t *testing.T) {
+	slice := prepareNetworkSlice(networkSliceParams{
+		sliceName:   "slice-1",
+		mcc:         "001",
+		mnc:         "01",
+		sst:         "1",
+		sd:          "010203",
+		upfHostname: "upf.local",
+	})
+	slice.SliceQos = &configmodels.SliceQos{
+		Uplink:       200000000,
+		Downlink:     1000000000,
+		BitrateUnit:  "Mbps",
+		TrafficClass: &configmodels.TrafficClassInfo{Name: "platinum", Qci: 8, Arp: 6},
+	}
+
+	cfg := inMemoryConfig{}
+	cfg.syncSessionManagement([]configmodels.Slice{slice}, map[string]configmodels.DeviceGroups{}, nil, nil)
+
+	if len(cfg.sessionManagement) != 1 {
+		t.Fatalf("expected 1 session management entry, got %d", len(cfg.sessionManagement))
+	}
+	body, err := cfg.sessionManagement[0].MarshalJSON()
+	if err != nil {
+		t.Fatalf("failed to marshal session management: %v", err)
+	}
+	var decoded map[string]any
+	if err = json.Unmarshal(body, &decoded); err != nil {
+		t.Fatalf("failed to unmarshal session management: %v", err)
+	}
+	expected := map[string]any{
+		"mbrUplink":        "200 Mbps",
+		"mbrDownlink":      "1 Gbps",
+		"fiveQi":           float64(8),
+		"arpPriorityLevel": float64(6),
+	}
+	if !reflect.DeepEqual(decoded[sliceQosProperty], expected) {
+		t.Errorf("expected slice QoS %v, got %v", expected, decoded[sliceQosProperty])
+	}
+}
+
+func TestExtractIpDomains_DnnCatalogDefaults(t *testing.T) {
+	deviceGroups := map[string]configmodels.DeviceGroups{
+		"group-1": {
+			DeviceGroupName: "group-1",
+			IpDomainsExpanded: []configmodels.DeviceGroupsIpDomainExpanded{
+				{Dnn: "internet", UeIpPool: "10.0.0.0/16"},
+				{Dnn: "ims", UeIpPool: "10.1.0.0/16", DnsPrimary: "1.1.1.1", DnsSecondary: "1.0.0.1", Mtu: 1400},
+				{Dnn: "iot", UeIpPool: "10.2.0.0/16", DnsPrimary: "9.9.9.9", Mtu: 1300},
+			},
+		},
+	}
+	dnns := map[string]configmodels.Dnn{
+		"internet": {Name: "internet", Mtu: 1460, DnsPrimary: "8.8.8.8", DnsSecondary: "8.8.4.4", PduSessionTypes: []string{"IPV4", "IPV4V6"}},
+		"ims":      {Name: "ims", Mtu: 1500, DnsPrimary: "8.8.4.4", DnsSecondary: "8.8.8.8"},
+	}
+
+	ipDomains := extractIpDomains("slice-1", []string{"group-1"}, deviceGroups, dnns, nil)
+
+	internet := nfConfigApi.NewIpDomain("internet", "8.8.8.8", "10.0.0.0/16", 1460)
+	internet.AdditionalProperties = map[string]any{dnsSecondaryProperty: "8.8.4.4", pduSessionTypesProperty: []string{"IPV4", "IPV4V6"}}
+	ims := nfConfigApi.NewIpDomain("ims", "1.1.1.1", "10.1.0.0/16", 1400)
+	ims.AdditionalProperties = map[string]any{dnsSecondaryProperty: "1.0.0.1"}
+	expected := []nfConfigApi.IpDomain{
+		*internet,
+		*ims,
+		*nfConfigApi.NewIpDomain("iot", "9.9.9.9", "10.2.0.0/16", 1300),
+	}
+	if !reflect.DeepEqual(ipDomains, expected) {
+		t.Errorf("expected %+v, got %+v", expected, ipDomains)
+	}
+}
+
+func TestExtractUpf_InventoryProperties(t *testing.T) {
+	slice := prepareNetworkSlice(networkSliceParams{
+		sliceName:   "slice-1",
+		mcc:         "001",
+		mnc:         "01",
+		sst:         "1",
+		sd:          "010203",
+		upfHostname: "upf.local",
+		upfPort:     "8805",
+	})
+	tests := []struct {
+		name               string
+		upfs               map[string]configmodels.Upf
+		expectedProperties map[string]any
+	}{
+		{
+			name:               "no inventory",
+			upfs:               nil,
+			expectedProperties: nil,
+		},
+		{
+			name:               "UPF missing from the inventory",
+			upfs:               map[string]configmodels.Upf{"other.local": {Hostname: "other.local", N4Address: "10.0.0.9"}},
+			expectedProperties: nil,
+		},
+		{
+			name: "UPF with details",
+			upfs: map[string]configmodels.Upf{
+				"upf.local": {
+					Hostname:     "upf.local",
+					Port:         "8805",
+					N4Address:    "10.0.0.1",
+					N3Interfaces: []configmodels.UpfN3Interface{{Name: "access", Address: "192.168.252.3"}},
+					Dnns:         []configmodels.UpfDnn{{Dnn: "internet", UeIpPools: []string{"172.250.0.0/16"}}},
+					Capacity:     &configmodels.UpfCapacity{MaxSessions: 10000},
+				},
+			},
+			expectedProperties: map[string]any{
+				"n4Address":    "10.0.0.1",
+				"n3Interfaces": []any{map[string]any{"name": "access", "address": "192.168.252.3"}},
+				"dnns":         []any{map[string]any{"dnn": "internet", "ueIpPools": []any{"172.250.0.0/16"}}},
+				"capacity":     map[string]any{"maxSessions": float64(10000)},
+			},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			upf := extractUpf(slice, tc.upfs, nil)
+			if upf == nil {
+				t.Fatal("expected a UPF")
+			}
+			if upf.GetHostname() != "upf.local" || upf.GetPort() != 8805 {
+				t.Errorf("expected upf.local:8805, got %s:%d", upf.GetHostname(), upf.GetPort())
+			}
+			body, err := upf.MarshalJSON()
+			if err != nil {
+				t.Fatalf("failed to marshal UPF: %v", err)
+			}
+			var decoded map[string]any
+			if err = json.Unmarshal(body, &decoded); err != nil {
+				t.Fatalf("failed to unmarshal UPF: %v", err)
+			}
+			delete(decoded, "hostname")
+			delete(decoded, "port")
+			if len(tc.expectedProperties) == 0 {
+				if len(decoded) != 0 {
+					t.Errorf("expected no additional properties, got %v", decoded)
+				}
+				return
+			}
+			if !reflect.DeepEqual(decoded, tc.expectedProperties) {
+				t.Errorf("expected %v, got %v", tc.expectedProperties, decoded)
+			}
+		})
+	}
+}
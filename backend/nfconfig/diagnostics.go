@@ -0,0 +1,91 @@
+// SPDX-FileCopyrightText: 2026 Canonical Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package nfconfig
+
+import (
+	"cmp"
+	"slices"
+
+	"github.com/omec-project/webconsole/backend/logger"
+)
+
+// diagnosticsResource is the endpoint listing the configuration issues. With
+// mTLS, only the NF types with diagnostics in their endpoint allow-list may
+// read it.
+const diagnosticsResource configResource = "diagnostics"
+
+// diagnostic is an issue of the stored configuration that a sync ignored or
+// worked around
+type diagnostic struct {
+	// endpoint whose configuration is affected, empty when the object could
+	// not be loaded at all
+	Endpoint configResource `json:"endpoint,omitempty"`
+	// configuration object, e.g. slice/slice1
+	Resource string `json:"resource"`
+	// JSON path of the field in the object
+	Field  string `json:"field"`
+	Reason string `json:"reason"`
+}
+
+// diagnostics collects the issues found by a sync. A nil collector only logs
+// them.
+type diagnostics []diagnostic
+
+// diagnosticsReport is the body of GET /nfconfig/diagnostics
+type diagnosticsReport struct {
+	// version of the snapshot the issues were found building
+	Version     uint64       `json:"version"`
+	Count       int          `json:"count"`
+	Diagnostics []diagnostic `json:"diagnostics"`
+}
+
+func sliceObject(name string) string {
+	return "slice/" + name
+}
+
+func deviceGroupObject(name string) string {
+	return "device-group/" + name
+}
+
+func (d *diagnostics) add(endpoint configResource, resource string, field string, reason string) {
+	logger.NfConfigLog.Warnf("Invalid NF configuration in %s, field %s: %s", resource, field, reason)
+	if d != nil {
+		*d = append(*d, diagnostic{Endpoint: endpoint, Resource: resource, Field: field, Reason: reason})
+	}
+}
+
+// keep adds the issues of the endpoints from a previous sync, for the views
+// that were not rebuilt
+func (d *diagnostics) keep(previous diagnostics, endpoints ...configResource) {
+	for _, issue := range previous {
+		if slices.Contains(endpoints, issue.Endpoint) {
+			*d = append(*d, issue)
+		}
+	}
+}
+
+// sorted returns the issues sorted by endpoint, resource and field, without
+// duplicates, e.g. a device group issue found for each of its slices
+func (d diagnostics) sorted() diagnostics {
+	sorted := slices.Clone(d)
+	slices.SortFunc(sorted, func(a, b diagnostic) int {
+		return cmp.Or(
+			cmp.Compare(a.Endpoint, b.Endpoint),
+			cmp.Compare(a.Resource, b.Resource),
+			cmp.Compare(a.Field, b.Field),
+			cmp.Compare(a.Reason, b.Reason),
+		)
+	})
+	return slices.Compact(sorted)
+}
+
+func (c *inMemoryConfig) diagnosticsReport() diagnosticsReport {
+	issues := c.diagnostics
+	if issues == nil {
+		issues = diagnostics{}
+	}
+	return diagnosticsReport{Version: c.version, Count: len(issues), Diagnostics: issues}
+}
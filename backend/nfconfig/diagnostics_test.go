@@ -0,0 +1,110 @@
+// SPDX-FileCopyrightText: 2026 Canonical Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+package nfconfig
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/omec-project/webconsole/configmodels"
+	"github.com/omec-project/webconsole/dbadapter"
+)
+
+func getDiagnostics(t *testing.T, n *NFConfigServer) diagnosticsReport {
+	t.Helper()
+	w := httptest.NewRecorder()
+	n.Router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/nfconfig/diagnostics", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d", http.StatusOK, w.Code)
+	}
+	var report diagnosticsReport
+	if err := json.Unmarshal(w.Body.Bytes(), &report); err != nil {
+		t.Fatalf("failed to unmarshal diagnostics: %v", err)
+	}
+	return report
+}
+
+func TestGetDiagnostics(t *testing.T) {
+	invalidSlice := makeNetworkSlice("001", "01", "x", "", []int32{1})
+	invalidSlice.SliceName = "invalid-sst"
+	slice := makeNetworkSlice("001", "01", "1", "010203", []int32{1})
+	slice.SiteDeviceGroup = []string{"dg1", "missing"}
+	slice.SiteInfo.Upf = map[string]any{"upf-name": "upf1", "upf-port": "99999"}
+	mockDB := &changeStreamDBClient{}
+	mockDB.setDocuments(t,
+		[]configmodels.Slice{invalidSlice, slice},
+		[]configmodels.DeviceGroups{makeNamedDeviceGroup("dg1", "001010000000001"), makeNamedDeviceGroup("dg2", "001010000000001")})
+	mockDB.collections[devGroupDataColl] = append(mockDB.collections[devGroupDataColl], map[string]any{"group-name": ""})
+	originalDBClient := dbadapter.CommonDBClient
+	defer func() { dbadapter.CommonDBClient = originalDBClient }()
+	dbadapter.CommonDBClient = mockDB
+	n := &NFConfigServer{Router: gin.New(), watcher: newConfigWatcher()}
+	n.setupRoutes()
+	if err := n.syncInMemoryConfig(); err != nil {
+		t.Fatalf("failed to sync: %v", err)
+	}
+
+	report := getDiagnostics(t, n)
+	expected := []diagnostic{
+		{Resource: "device-group/", Field: "group-name", Reason: "empty name. The device group is ignored"},
+		{Endpoint: accessMobilityResource, Resource: "slice/invalid-sst", Field: "slice-id.sst", Reason: "invalid SST 'x'. The slice is ignored"},
+		{Endpoint: plmnSnssaiResource, Resource: "slice/invalid-sst", Field: "slice-id.sst", Reason: "invalid SST 'x'. The slice is ignored"},
+		{Endpoint: policyControlResource, Resource: "slice/slice1010203", Field: "site-device-group", Reason: "device group missing not found. It is ignored"},
+		{Endpoint: imsiQosResource, Resource: "device-group/dg2", Field: "imsis", Reason: "IMSI 001010000000001 already has a QoS on DNN internet. The first one is used"},
+		{Endpoint: sessionManagementResource, Resource: "slice/slice1010203", Field: "site-device-group", Reason: "device group missing not found. It is ignored"},
+		{Endpoint: sessionManagementResource, Resource: "slice/slice1010203", Field: "site-info.upf.upf-port", Reason: "invalid UPF port '99999'. The port is ignored"},
+	}
+	for _, issue := range expected {
+		if !slices.Contains(report.Diagnostics, issue) {
+			t.Errorf("expected diagnostic %+v in %+v", issue, report.Diagnostics)
+		}
+	}
+	if report.Count != len(report.Diagnostics) || report.Version != n.snapshot().version {
+		t.Errorf("expected the count and version of the snapshot, got %+v", report)
+	}
+	if count, ok := gaugeValue(t, "nfconfig_diagnostics", "", ""); !ok || int(count) != report.Count {
+		t.Errorf("expected the diagnostics metric to be %d, got %v (%v)", report.Count, count, ok)
+	}
+
+	// a sync of the device groups keeps the issues of the views built from the slices only
+	mockDB.setDocuments(t, []configmodels.Slice{invalidSlice, slice}, []configmodels.DeviceGroups{makeNamedDeviceGroup("dg1", "001010000000001")})
+	if err := n.syncInMemoryConfigScope(deviceGroupScope); err != nil {
+		t.Fatalf("failed to sync: %v", err)
+	}
+	report = getDiagnostics(t, n)
+	if !slices.Contains(report.Diagnostics, expected[2]) {
+		t.Errorf("expected the PLMN-SNSSAI diagnostic to be kept, got %+v", report.Diagnostics)
+	}
+	for _, issue := range []diagnostic{expected[0], expected[4]} {
+		if slices.Contains(report.Diagnostics, issue) {
+			t.Errorf("expected the fixed diagnostic %+v to be removed", issue)
+		}
+	}
+}
+
+func TestDiagnostics_Sorted(t *testing.T) {
+	var d diagnostics
+	d.add(sessionManagementResource, "device-group/dg1", "ip-domains.dnn", "DNN internet not found in the DNN catalog")
+	d.add(policyControlResource, "slice/slice1", "site-device-group", "device group dg2 not found. It is ignored")
+	d.add(sessionManagementResource, "device-group/dg1", "ip-domains.dnn", "DNN internet not found in the DNN catalog")
+	sorted := d.sorted()
+	if len(sorted) != 2 || sorted[0].Endpoint != policyControlResource {
+		t.Errorf("expected the two distinct diagnostics sorted by endpoint, got %+v", sorted)
+	}
+	var nilCollector *diagnostics
+	nilCollector.add(plmnResource, "slice/slice1", "slice-id.sst", "ignored")
+}
+
+func TestGetDiagnostics_Empty(t *testing.T) {
+	n := &NFConfigServer{Router: gin.New()}
+	n.setupRoutes()
+	report := getDiagnostics(t, n)
+	if report.Count != 0 || report.Diagnostics == nil {
+		t.Errorf("expected an empty list of diagnostics, got %+v", report)
+	}
+}
@@ -0,0 +1,228 @@
+// SPDX-FileCopyrightText: 2026 Canonical Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package nfconfig
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/omec-project/openapi/v2/nfConfigApi"
+)
+
+const (
+	plmnFilter = "plmn"
+	sstFilter  = "sst"
+	sdFilter   = "sd"
+	tacFilter  = "tac"
+	dnnFilter  = "dnn"
+	upfFilter  = "upf"
+)
+
+// supportedFilters lists the query filters each resource can be narrowed with
+var supportedFilters = map[configResource][]string{
+	accessMobilityResource:    {plmnFilter, sstFilter, sdFilter, tacFilter},
+	plmnSnssaiResource:        {plmnFilter, sstFilter, sdFilter},
+	policyControlResource:     {plmnFilter, sstFilter, sdFilter, dnnFilter},
+	sessionManagementResource: {plmnFilter, sstFilter, sdFilter, dnnFilter, upfFilter},
+}
+
+var (
+	plmnFilterPattern = regexp.MustCompile(`^([0-9]{3})-([0-9]{2,3})$`)
+	sdFilterPattern   = regexp.MustCompile(`^[0-9a-fA-F]{6}$`)
+)
+
+// configFilter narrows an nfconfig resource down to the entries matching all
+// the given query filters. Unset filters match everything.
+type configFilter struct {
+	plmn *nfConfigApi.PlmnId
+	sst  *int32
+	sd   string
+	tac  *int64
+	dnn  string
+	upf  string
+	// IMSI of the qos resource, which is narrowed by its DNN and IMSI path
+	// parameters instead of query filters
+	imsi string
+}
+
+func (f configFilter) isEmpty() bool {
+	return f == configFilter{}
+}
+
+func parseConfigFilter(query url.Values, resource configResource) (configFilter, error) {
+	var f configFilter
+	for _, name := range []string{plmnFilter, sstFilter, sdFilter, tacFilter, dnnFilter, upfFilter} {
+		if query.Has(name) && !slices.Contains(supportedFilters[resource], name) {
+			return f, fmt.Errorf("filter '%s' is not supported for %s", name, resource)
+		}
+	}
+	if plmn := query.Get(plmnFilter); query.Has(plmnFilter) {
+		match := plmnFilterPattern.FindStringSubmatch(plmn)
+		if match == nil {
+			return f, fmt.Errorf("invalid plmn filter '%s'. The expected format is 'mcc-mnc'", plmn)
+		}
+		f.plmn = nfConfigApi.NewPlmnId(match[1], match[2])
+	}
+	if sst := query.Get(sstFilter); query.Has(sstFilter) {
+		value, err := strconv.ParseUint(sst, 10, 8)
+		if err != nil {
+			return f, fmt.Errorf("invalid sst filter '%s'. SST must be an integer within the range [0, 255]", sst)
+		}
+		sstValue := int32(value)
+		f.sst = &sstValue
+	}
+	if sd := query.Get(sdFilter); query.Has(sdFilter) {
+		if f.sst == nil {
+			return f, fmt.Errorf("the sd filter requires the sst filter")
+		}
+		if !sdFilterPattern.MatchString(sd) {
+			return f, fmt.Errorf("invalid sd filter '%s'. SD must be 6 hexadecimal digits", sd)
+		}
+		f.sd = strings.ToLower(sd)
+	}
+	if tac := query.Get(tacFilter); query.Has(tacFilter) {
+		value, err := strconv.ParseInt(tac, 10, 64)
+		if err != nil || value < 1 || value > 16777215 {
+			return f, fmt.Errorf("invalid tac filter '%s'. TAC must be an integer within the range [1, 16777215]", tac)
+		}
+		f.tac = &value
+	}
+	if query.Has(dnnFilter) {
+		if f.dnn = query.Get(dnnFilter); f.dnn == "" {
+			return f, fmt.Errorf("the dnn filter cannot be empty")
+		}
+	}
+	if query.Has(upfFilter) {
+		if f.upf = query.Get(upfFilter); f.upf == "" {
+			return f, fmt.Errorf("the upf filter cannot be empty")
+		}
+	}
+	return f, nil
+}
+
+func (f configFilter) matchesPlmn(plmn nfConfigApi.PlmnId) bool {
+	return f.plmn == nil || (f.plmn.Mcc == plmn.Mcc && f.plmn.Mnc == plmn.Mnc)
+}
+
+func (f configFilter) matchesSnssai(snssai nfConfigApi.Snssai) bool {
+	if f.sst == nil {
+		return true
+	}
+	if snssai.Sst != *f.sst {
+		return false
+	}
+	return f.sd == "" || strings.EqualFold(snssai.GetSd(), f.sd)
+}
+
+func (f configFilter) matchesTacs(tacs []string) bool {
+	if f.tac == nil {
+		return true
+	}
+	return slices.ContainsFunc(tacs, func(tac string) bool {
+		value, err := strconv.ParseInt(tac, 10, 64)
+		return err == nil && value == *f.tac
+	})
+}
+
+func (f configFilter) matchesDnns(dnns []string) bool {
+	return f.dnn == "" || slices.Contains(dnns, f.dnn)
+}
+
+func (f configFilter) matchesUpf(upf *nfConfigApi.Upf) bool {
+	return f.upf == "" || (upf != nil && upf.Hostname == f.upf)
+}
+
+func filterAccessAndMobility(accessAndMobility []nfConfigApi.AccessAndMobility, f configFilter) []nfConfigApi.AccessAndMobility {
+	if f.isEmpty() {
+		return accessAndMobility
+	}
+	filtered := []nfConfigApi.AccessAndMobility{}
+	for _, entry := range accessAndMobility {
+		if f.matchesPlmn(entry.PlmnId) && f.matchesSnssai(entry.Snssai) && f.matchesTacs(entry.Tacs) {
+			filtered = append(filtered, entry)
+		}
+	}
+	return filtered
+}
+
+// filterPlmnSnssai keeps the matching S-NSSAIs of the matching PLMNs, and
+// drops the PLMNs left without S-NSSAI
+func filterPlmnSnssai(plmnSnssai []nfConfigApi.PlmnSnssai, f configFilter) []nfConfigApi.PlmnSnssai {
+	if f.isEmpty() {
+		return plmnSnssai
+	}
+	filtered := []nfConfigApi.PlmnSnssai{}
+	for _, entry := range plmnSnssai {
+		if !f.matchesPlmn(entry.PlmnId) {
+			continue
+		}
+		snssaiList := []nfConfigApi.Snssai{}
+		for _, snssai := range entry.SNssaiList {
+			if f.matchesSnssai(snssai) {
+				snssaiList = append(snssaiList, snssai)
+			}
+		}
+		if len(snssaiList) == 0 {
+			continue
+		}
+		entry.SNssaiList = snssaiList
+		filtered = append(filtered, entry)
+	}
+	return filtered
+}
+
+func filterPolicyControl(policyControl []nfConfigApi.PolicyControl, f configFilter) []nfConfigApi.PolicyControl {
+	if f.isEmpty() {
+		return policyControl
+	}
+	filtered := []nfConfigApi.PolicyControl{}
+	for _, entry := range policyControl {
+		if f.matchesPlmn(entry.PlmnId) && f.matchesSnssai(entry.Snssai) && f.matchesDnns(entry.Dnns) {
+			filtered = append(filtered, entry)
+		}
+	}
+	return filtered
+}
+
+func filterSessionManagement(sessionManagement []nfConfigApi.SessionManagement, f configFilter) []nfConfigApi.SessionManagement {
+	if f.isEmpty() {
+		return sessionManagement
+	}
+	filtered := []nfConfigApi.SessionManagement{}
+	for _, entry := range sessionManagement {
+		dnns := make([]string, 0, len(entry.IpDomain))
+		for _, ipDomain := range entry.IpDomain {
+			dnns = append(dnns, ipDomain.DnnName)
+		}
+		if f.matchesPlmn(entry.PlmnId) && f.matchesSnssai(entry.Snssai) && f.matchesDnns(dnns) && f.matchesUpf(entry.Upf) {
+			filtered = append(filtered, entry)
+		}
+	}
+	return filtered
+}
+
+// filteredView returns the list resource narrowed down by the filter
+func (c *inMemoryConfig) filteredView(resource configResource, f configFilter) any {
+	switch resource {
+	case accessMobilityResource:
+		return filterAccessAndMobility(c.accessAndMobility, f)
+	case plmnResource:
+		return c.plmn
+	case plmnSnssaiResource:
+		return filterPlmnSnssai(c.plmnSnssai, f)
+	case policyControlResource:
+		return filterPolicyControl(c.policyControl, f)
+	case sessionManagementResource:
+		return filterSessionManagement(c.sessionManagement, f)
+	case imsiQosResource:
+		return c.lookupImsiQos(f.dnn, f.imsi)
+	}
+	return nil
+}
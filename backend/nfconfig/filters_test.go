@@ -0,0 +1,124 @@
+// SPDX-FileCopyrightText: 2026 Canonical Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+package nfconfig
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/omec-project/openapi/v2/nfConfigApi"
+)
+
+func newFilterTestServer() *NFConfigServer {
+	n := &NFConfigServer{Router: gin.New()}
+	n.inMemoryConfig.Store(&inMemoryConfig{
+		accessAndMobility: []nfConfigApi.AccessAndMobility{
+			{PlmnId: *nfConfigApi.NewPlmnId("001", "01"), Snssai: makeSnssaiWithSd(1, "010203"), Tacs: []string{"1", "2"}},
+			{PlmnId: *nfConfigApi.NewPlmnId("001", "01"), Snssai: makeSnssaiWithSd(2, "abcdef"), Tacs: []string{"3"}},
+			{PlmnId: *nfConfigApi.NewPlmnId("002", "002"), Snssai: makeSnssaiWithSd(1, "010203"), Tacs: []string{"2"}},
+		},
+		plmnSnssai: []nfConfigApi.PlmnSnssai{
+			{PlmnId: *nfConfigApi.NewPlmnId("001", "01"), SNssaiList: []nfConfigApi.Snssai{makeSnssaiWithSd(1, "010203"), makeSnssaiWithSd(2, "abcdef")}},
+			{PlmnId: *nfConfigApi.NewPlmnId("002", "002"), SNssaiList: []nfConfigApi.Snssai{makeSnssaiWithSd(2, "abcdef")}},
+		},
+		policyControl: []nfConfigApi.PolicyControl{
+			{PlmnId: *nfConfigApi.NewPlmnId("001", "01"), Snssai: makeSnssaiWithSd(1, "010203"), Dnns: []string{"internet"}},
+			{PlmnId: *nfConfigApi.NewPlmnId("001", "01"), Snssai: makeSnssaiWithSd(2, "abcdef"), Dnns: []string{"ims", "internet"}},
+		},
+		sessionManagement: []nfConfigApi.SessionManagement{
+			{
+				SliceName: "slice1",
+				PlmnId:    *nfConfigApi.NewPlmnId("001", "01"),
+				Snssai:    makeSnssaiWithSd(1, "010203"),
+				IpDomain:  []nfConfigApi.IpDomain{{DnnName: "internet"}},
+				Upf:       &nfConfigApi.Upf{Hostname: "upf1"},
+			},
+			{
+				SliceName: "slice2",
+				PlmnId:    *nfConfigApi.NewPlmnId("001", "01"),
+				Snssai:    makeSnssaiWithSd(2, "abcdef"),
+				IpDomain:  []nfConfigApi.IpDomain{{DnnName: "ims"}},
+			},
+		},
+	})
+	n.setupRoutes()
+	return n
+}
+
+func TestNfConfigFilters(t *testing.T) {
+	tests := []struct {
+		name          string
+		route         string
+		expectedCode  int
+		expectedCount int
+	}{
+		{name: "access-mobility without filter", route: "/nfconfig/access-mobility", expectedCode: http.StatusOK, expectedCount: 3},
+		{name: "access-mobility by PLMN", route: "/nfconfig/access-mobility?plmn=001-01", expectedCode: http.StatusOK, expectedCount: 2},
+		{name: "access-mobility by 3-digit MNC", route: "/nfconfig/access-mobility?plmn=002-002", expectedCode: http.StatusOK, expectedCount: 1},
+		{name: "access-mobility by TAC", route: "/nfconfig/access-mobility?tac=2", expectedCode: http.StatusOK, expectedCount: 2},
+		{name: "access-mobility by PLMN and TAC", route: "/nfconfig/access-mobility?plmn=001-01&tac=2", expectedCode: http.StatusOK, expectedCount: 1},
+		{name: "access-mobility by SST and SD", route: "/nfconfig/access-mobility?sst=1&sd=010203", expectedCode: http.StatusOK, expectedCount: 2},
+		{name: "access-mobility without match", route: "/nfconfig/access-mobility?tac=9", expectedCode: http.StatusOK, expectedCount: 0},
+		{name: "plmn-snssai by SST", route: "/nfconfig/plmn-snssai?sst=1", expectedCode: http.StatusOK, expectedCount: 1},
+		{name: "plmn-snssai by SST and upper case SD", route: "/nfconfig/plmn-snssai?sst=2&sd=ABCDEF", expectedCode: http.StatusOK, expectedCount: 2},
+		{name: "policy-control by DNN", route: "/nfconfig/policy-control?dnn=ims", expectedCode: http.StatusOK, expectedCount: 1},
+		{name: "session-management by DNN", route: "/nfconfig/session-management?dnn=internet", expectedCode: http.StatusOK, expectedCount: 1},
+		{name: "session-management by UPF", route: "/nfconfig/session-management?upf=upf1", expectedCode: http.StatusOK, expectedCount: 1},
+		{name: "session-management by unknown UPF", route: "/nfconfig/session-management?upf=upf9", expectedCode: http.StatusOK, expectedCount: 0},
+		{name: "invalid PLMN", route: "/nfconfig/access-mobility?plmn=00101", expectedCode: http.StatusBadRequest},
+		{name: "invalid SST", route: "/nfconfig/access-mobility?sst=256", expectedCode: http.StatusBadRequest},
+		{name: "SD without SST", route: "/nfconfig/access-mobility?sd=010203", expectedCode: http.StatusBadRequest},
+		{name: "invalid SD", route: "/nfconfig/access-mobility?sst=1&sd=0102", expectedCode: http.StatusBadRequest},
+		{name: "invalid TAC", route: "/nfconfig/access-mobility?tac=0", expectedCode: http.StatusBadRequest},
+		{name: "empty DNN", route: "/nfconfig/policy-control?dnn=", expectedCode: http.StatusBadRequest},
+		{name: "TAC on session-management", route: "/nfconfig/session-management?tac=1", expectedCode: http.StatusBadRequest},
+		{name: "UPF on policy-control", route: "/nfconfig/policy-control?upf=upf1", expectedCode: http.StatusBadRequest},
+		{name: "filter on plmn", route: "/nfconfig/plmn?plmn=001-01", expectedCode: http.StatusBadRequest},
+	}
+	n := newFilterTestServer()
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodGet, tc.route, nil)
+			if err != nil {
+				t.Fatalf("failed to create request: %v", err)
+			}
+			w := httptest.NewRecorder()
+			n.Router.ServeHTTP(w, req)
+
+			if w.Code != tc.expectedCode {
+				t.Fatalf("expected status %d, got %d with body %s", tc.expectedCode, w.Code, w.Body.String())
+			}
+			if tc.expectedCode != http.StatusOK {
+				return
+			}
+			var entries []json.RawMessage
+			if err = json.Unmarshal(w.Body.Bytes(), &entries); err != nil {
+				t.Fatalf("failed to unmarshal body: %v", err)
+			}
+			if entries == nil {
+				t.Errorf("expected a JSON list, got %s", w.Body.String())
+			}
+			if len(entries) != tc.expectedCount {
+				t.Errorf("expected %d entries, got %d: %s", tc.expectedCount, len(entries), w.Body.String())
+			}
+		})
+	}
+}
+
+func TestFilterPlmnSnssai_KeepsMatchingSnssais(t *testing.T) {
+	plmnSnssai := []nfConfigApi.PlmnSnssai{
+		{PlmnId: *nfConfigApi.NewPlmnId("001", "01"), SNssaiList: []nfConfigApi.Snssai{makeSnssaiWithSd(1, "010203"), makeSnssaiWithSd(2, "abcdef")}},
+	}
+	sst := int32(2)
+	filtered := filterPlmnSnssai(plmnSnssai, configFilter{sst: &sst})
+	if len(filtered) != 1 || len(filtered[0].SNssaiList) != 1 || filtered[0].SNssaiList[0].Sst != 2 {
+		t.Errorf("expected only the S-NSSAI with SST 2, got %+v", filtered)
+	}
+	if len(plmnSnssai[0].SNssaiList) != 2 {
+		t.Errorf("expected the snapshot to be left unchanged, got %+v", plmnSnssai)
+	}
+}
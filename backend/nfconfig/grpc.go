@@ -0,0 +1,218 @@
+// SPDX-FileCopyrightText: 2026 Canonical Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package nfconfig
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/omec-project/webconsole/backend/logger"
+	"github.com/omec-project/webconsole/backend/nfconfig/nfconfigpb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/status"
+)
+
+// defaultGrpcPort is used when the nfconfig-grpc section does not set a port
+const defaultGrpcPort = 5002
+
+// grpcRequest is a validated Get or Watch request
+type grpcRequest struct {
+	resource configResource
+	filter   configFilter
+	// ETag the NF already holds, Watch only
+	etag string
+}
+
+func parseGrpcRequest(req *nfconfigpb.ConfigRequest) (grpcRequest, error) {
+	r := grpcRequest{resource: configResource(req.GetResource()), etag: req.GetEtag()}
+	if r.resource == "" {
+		return r, fmt.Errorf("resource is required")
+	}
+	if !isConfigResource(r.resource) {
+		return r, fmt.Errorf("unknown resource '%s'", r.resource)
+	}
+	// the filters are converted to query parameters, so that both APIs
+	// validate them the same way
+	query := url.Values{}
+	if req.Plmn != nil {
+		query.Set(plmnFilter, req.GetPlmn())
+	}
+	if req.Sst != nil {
+		query.Set(sstFilter, strconv.FormatUint(uint64(req.GetSst()), 10))
+	}
+	if req.Sd != nil {
+		query.Set(sdFilter, req.GetSd())
+	}
+	if req.Tac != nil {
+		query.Set(tacFilter, strconv.FormatInt(req.GetTac(), 10))
+	}
+	if req.Upf != nil {
+		query.Set(upfFilter, req.GetUpf())
+	}
+	if r.resource == imsiQosResource {
+		if req.GetDnn() == "" {
+			return r, fmt.Errorf("dnn is required")
+		}
+		if strings.TrimPrefix(req.GetImsi(), "imsi-") == "" {
+			return r, fmt.Errorf("imsi is required")
+		}
+	} else {
+		if req.Dnn != nil {
+			query.Set(dnnFilter, req.GetDnn())
+		}
+		if req.GetImsi() != "" {
+			return r, fmt.Errorf("imsi is only supported for %s", imsiQosResource)
+		}
+	}
+	var err error
+	if r.filter, err = parseConfigFilter(query, r.resource); err != nil {
+		return r, err
+	}
+	if r.resource == imsiQosResource {
+		r.filter.dnn = req.GetDnn()
+		r.filter.imsi = strings.TrimPrefix(req.GetImsi(), "imsi-")
+	}
+	return r, nil
+}
+
+// view returns the response to the request from the snapshot
+func (r grpcRequest) view(snapshot *inMemoryConfig) (*nfconfigpb.ConfigResponse, error) {
+	response := &nfconfigpb.ConfigResponse{
+		Resource: string(r.resource),
+		Version:  snapshot.resourceVersions[r.resource],
+		Etag:     snapshot.viewETag(r.resource, r.filter),
+	}
+	switch r.resource {
+	case plmnResource:
+		response.Plmn = messages(snapshot.plmn, plmnIdMessage)
+	case plmnSnssaiResource:
+		response.PlmnSnssai = messages(filterPlmnSnssai(snapshot.plmnSnssai, r.filter), plmnSnssaiMessage)
+	case accessMobilityResource:
+		response.AccessMobility = messages(filterAccessAndMobility(snapshot.accessAndMobility, r.filter), accessAndMobilityMessage)
+	case sessionManagementResource:
+		response.SessionManagement = messages(filterSessionManagement(snapshot.sessionManagement, r.filter), sessionManagementMessage)
+	case policyControlResource:
+		response.PolicyControl = messages(filterPolicyControl(snapshot.policyControl, r.filter), policyControlMessage)
+	case imsiQosResource:
+		imsiQos := snapshot.lookupImsiQos(r.filter.dnn, r.filter.imsi)
+		if len(imsiQos) == 0 {
+			return nil, status.Errorf(codes.NotFound, "no QoS for IMSI %s on DNN %s", r.filter.imsi, r.filter.dnn)
+		}
+		response.Qos = messages(imsiQos, imsiQosMessage)
+	}
+	return response, nil
+}
+
+// grpcServer serves the nfconfig.v1.NfConfig service described in
+// nfconfigpb/nfconfig.proto, the gRPC counterpart of the REST endpoints
+type grpcServer struct {
+	nfconfigpb.UnimplementedNfConfigServer
+	n *NFConfigServer
+	// ends the watches when the server shuts down
+	ctx context.Context
+}
+
+func (s *grpcServer) Get(ctx context.Context, req *nfconfigpb.ConfigRequest) (*nfconfigpb.ConfigResponse, error) {
+	r, err := parseGrpcRequest(req)
+	if err != nil {
+		logger.NfConfigLog.Warnf("Invalid gRPC Get request: %v", err)
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if r.etag != "" {
+		logger.NfConfigLog.Warnln("Invalid gRPC Get request: etag is only supported by Watch")
+		return nil, status.Error(codes.InvalidArgument, "etag is only supported by Watch")
+	}
+	if err = s.n.authorizeGrpc(ctx, r.resource); err != nil {
+		return nil, err
+	}
+	logger.NfConfigLog.Debugf("Handling gRPC Get request for %s config", r.resource)
+	snapshot := s.n.snapshot()
+	s.n.clients.record(s.n.grpcClientIdentity(ctx), r.resource, snapshot.resourceVersions[r.resource])
+	return r.view(snapshot)
+}
+
+// Watch sends the resource when its ETag differs from the requested one, or
+// right away without one, and then on every change until the client cancels
+// the stream
+func (s *grpcServer) Watch(req *nfconfigpb.ConfigRequest, stream grpc.ServerStreamingServer[nfconfigpb.ConfigResponse]) error {
+	r, err := parseGrpcRequest(req)
+	if err != nil {
+		logger.NfConfigLog.Warnf("Invalid gRPC Watch request: %v", err)
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err = s.n.authorizeGrpc(stream.Context(), r.resource); err != nil {
+		return err
+	}
+	logger.NfConfigLog.Debugf("Handling gRPC Watch request for %s config", r.resource)
+	ctx, cancel := context.WithCancel(stream.Context())
+	defer cancel()
+	stop := context.AfterFunc(s.ctx, cancel)
+	defer stop()
+	snapshot := s.n.snapshot()
+	if r.etag != "" {
+		var changed bool
+		if snapshot, changed = s.n.waitForChange(ctx, r.resource, r.filter, r.etag); !changed {
+			return nil
+		}
+	}
+	for {
+		response, err := r.view(snapshot)
+		if err != nil && status.Code(err) != codes.NotFound {
+			return err
+		}
+		// a missing IMSI QoS is not sent, the IMSI may get one later
+		if err == nil {
+			if err = stream.Send(response); err != nil {
+				return err
+			}
+			s.n.clients.record(s.n.grpcClientIdentity(stream.Context()), r.resource, snapshot.resourceVersions[r.resource])
+		}
+		var changed bool
+		if snapshot, changed = s.n.waitForChange(ctx, r.resource, r.filter, snapshot.viewETag(r.resource, r.filter)); !changed {
+			return nil
+		}
+	}
+}
+
+// newGrpcServer serves the NF configuration over gRPC, with the nfconfig
+// TLS certificate and mTLS when set. The watches end with the context.
+func (n *NFConfigServer) newGrpcServer(ctx context.Context) (*grpc.Server, error) {
+	var options []grpc.ServerOption
+	if n.config != nil && n.config.NfConfigTLS != nil && n.config.NfConfigTLS.Key != "" && n.config.NfConfigTLS.PEM != "" {
+		cert, err := tls.LoadX509KeyPair(n.config.NfConfigTLS.PEM, n.config.NfConfigTLS.Key)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load gRPC TLS certificate: %w", err)
+		}
+		tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+		if n.authorizer != nil {
+			tlsConfig = n.authorizer.tlsConfig()
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+		options = append(options, grpc.Creds(credentials.NewTLS(tlsConfig)))
+	}
+	server := grpc.NewServer(options...)
+	nfconfigpb.RegisterNfConfigServer(server, &grpcServer{n: n, ctx: ctx})
+	return server, nil
+}
+
+func (n *NFConfigServer) grpcEnabled() bool {
+	return n.config != nil && n.config.NfConfigGrpc != nil && n.config.NfConfigGrpc.Enabled
+}
+
+func (n *NFConfigServer) grpcAddr() string {
+	port := n.config.NfConfigGrpc.Port
+	if port == 0 {
+		port = defaultGrpcPort
+	}
+	return net.JoinHostPort(n.listenerConfig().BindAddress, strconv.Itoa(port))
+}
@@ -0,0 +1,111 @@
+// SPDX-FileCopyrightText: 2026 Canonical Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package nfconfig
+
+import (
+	"github.com/omec-project/openapi/v2/nfConfigApi"
+	"github.com/omec-project/webconsole/backend/nfconfig/nfconfigpb"
+)
+
+// messages converts the views of the REST API to their gRPC messages
+func messages[T any, M any](items []T, message func(T) *M) []*M {
+	result := make([]*M, 0, len(items))
+	for _, item := range items {
+		result = append(result, message(item))
+	}
+	return result
+}
+
+func plmnIdMessage(plmn nfConfigApi.PlmnId) *nfconfigpb.PlmnId {
+	return &nfconfigpb.PlmnId{Mcc: plmn.Mcc, Mnc: plmn.Mnc}
+}
+
+func snssaiMessage(snssai nfConfigApi.Snssai) *nfconfigpb.Snssai {
+	return &nfconfigpb.Snssai{Sst: uint32(snssai.Sst), Sd: snssai.Sd}
+}
+
+func plmnSnssaiMessage(plmnSnssai nfConfigApi.PlmnSnssai) *nfconfigpb.PlmnSnssai {
+	return &nfconfigpb.PlmnSnssai{
+		PlmnId:     plmnIdMessage(plmnSnssai.PlmnId),
+		SnssaiList: messages(plmnSnssai.SNssaiList, snssaiMessage),
+	}
+}
+
+func accessAndMobilityMessage(accessAndMobility nfConfigApi.AccessAndMobility) *nfconfigpb.AccessAndMobility {
+	return &nfconfigpb.AccessAndMobility{
+		PlmnId: plmnIdMessage(accessAndMobility.PlmnId),
+		Snssai: snssaiMessage(accessAndMobility.Snssai),
+		Tacs:   accessAndMobility.Tacs,
+	}
+}
+
+func ipDomainMessage(ipDomain nfConfigApi.IpDomain) *nfconfigpb.IpDomain {
+	return &nfconfigpb.IpDomain{
+		DnnName:   ipDomain.DnnName,
+		DnsIpv4:   ipDomain.DnsIpv4,
+		PcscfIpv4: ipDomain.PcscfIpv4,
+		UeSubnet:  ipDomain.UeSubnet,
+		Mtu:       ipDomain.Mtu,
+	}
+}
+
+func sessionManagementMessage(sessionManagement nfConfigApi.SessionManagement) *nfconfigpb.SessionManagement {
+	message := &nfconfigpb.SessionManagement{
+		SliceName: sessionManagement.SliceName,
+		PlmnId:    plmnIdMessage(sessionManagement.PlmnId),
+		Snssai:    snssaiMessage(sessionManagement.Snssai),
+		IpDomain:  messages(sessionManagement.IpDomain, ipDomainMessage),
+		GnbNames:  sessionManagement.GnbNames,
+	}
+	if sessionManagement.Upf != nil {
+		message.Upf = &nfconfigpb.Upf{Hostname: sessionManagement.Upf.Hostname, Port: sessionManagement.Upf.Port}
+	}
+	return message
+}
+
+func pccFlowMessage(flow nfConfigApi.PccFlow) *nfconfigpb.PccFlow {
+	return &nfconfigpb.PccFlow{
+		Description: flow.Description,
+		Direction:   string(flow.Direction),
+		Status:      string(flow.Status),
+	}
+}
+
+func pccRuleMessage(rule nfConfigApi.PccRule) *nfconfigpb.PccRule {
+	return &nfconfigpb.PccRule{
+		RuleId: rule.RuleId,
+		Flows:  messages(rule.Flows, pccFlowMessage),
+		Qos: &nfconfigpb.PccQos{
+			FiveQi:  rule.Qos.FiveQi,
+			MaxBrUl: rule.Qos.MaxBrUl,
+			MaxBrDl: rule.Qos.MaxBrDl,
+			Arp: &nfconfigpb.Arp{
+				PriorityLevel: rule.Qos.Arp.PriorityLevel,
+				PreemptCap:    string(rule.Qos.Arp.PreemptCap),
+				PreemptVuln:   string(rule.Qos.Arp.PreemptVuln),
+			},
+		},
+		Precedence: rule.Precedence,
+	}
+}
+
+func policyControlMessage(policyControl nfConfigApi.PolicyControl) *nfconfigpb.PolicyControl {
+	return &nfconfigpb.PolicyControl{
+		PlmnId:   plmnIdMessage(policyControl.PlmnId),
+		Snssai:   snssaiMessage(policyControl.Snssai),
+		Dnns:     policyControl.Dnns,
+		PccRules: messages(policyControl.PccRules, pccRuleMessage),
+	}
+}
+
+func imsiQosMessage(imsiQos nfConfigApi.ImsiQos) *nfconfigpb.ImsiQos {
+	return &nfconfigpb.ImsiQos{
+		MbrUplink:        imsiQos.MbrUplink,
+		MbrDownlink:      imsiQos.MbrDownlink,
+		FiveQi:           imsiQos.FiveQi,
+		ArpPriorityLevel: imsiQos.ArpPriorityLevel,
+	}
+}
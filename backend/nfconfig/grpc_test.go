@@ -0,0 +1,276 @@
+// SPDX-FileCopyrightText: 2026 Canonical Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+package nfconfig
+
+import (
+	"context"
+	"maps"
+	"net"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/omec-project/openapi/v2/nfConfigApi"
+	"github.com/omec-project/webconsole/backend/nfconfig/nfconfigpb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/proto"
+)
+
+func newGrpcTestClient(t *testing.T, n *NFConfigServer) *grpc.ClientConn {
+	t.Helper()
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	server, err := n.newGrpcServer(ctx)
+	if err != nil {
+		t.Fatalf("failed to create gRPC server: %v", err)
+	}
+	listener := bufconn.Listen(1024 * 1024)
+	go func() { _ = server.Serve(listener) }()
+	t.Cleanup(server.Stop)
+	conn, err := grpc.NewClient("passthrough:///bufconn",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return listener.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatalf("failed to create gRPC client: %v", err)
+	}
+	t.Cleanup(func() { _ = conn.Close() })
+	return conn
+}
+
+func newGrpcTestServer() *NFConfigServer {
+	n := newFilterTestServer()
+	n.Router = gin.New()
+	n.watcher = newConfigWatcher()
+	config := *n.snapshot()
+	config.plmn = []nfConfigApi.PlmnId{{Mcc: "001", Mnc: "01"}}
+	config.imsiQos = newImsiQosIndex([]imsiQosConfig{
+		{dnn: "internet", imsis: []string{"001010000000001"}, qos: []nfConfigApi.ImsiQos{*nfConfigApi.NewImsiQos("1 Mbps", "2 Mbps", 9, 8)}},
+	}, nil)
+	n.inMemoryConfig.Store(&inMemoryConfig{})
+	storeSnapshot(n, &config)
+	return n
+}
+
+// entries returns the number of configuration entries in the response, which
+// only holds the ones of the requested resource
+func entries(response *nfconfigpb.ConfigResponse) int {
+	return len(response.GetPlmn()) + len(response.GetPlmnSnssai()) + len(response.GetAccessMobility()) +
+		len(response.GetSessionManagement()) + len(response.GetPolicyControl()) + len(response.GetQos())
+}
+
+func TestGrpcGet(t *testing.T) {
+	tests := []struct {
+		name          string
+		request       *nfconfigpb.ConfigRequest
+		expectedCode  codes.Code
+		expectedCount int
+	}{
+		{name: "plmn", request: &nfconfigpb.ConfigRequest{Resource: "plmn"}, expectedCode: codes.OK, expectedCount: 1},
+		{name: "access-mobility", request: &nfconfigpb.ConfigRequest{Resource: "access-mobility"}, expectedCode: codes.OK, expectedCount: 3},
+		{name: "access-mobility by PLMN and TAC", request: &nfconfigpb.ConfigRequest{Resource: "access-mobility", Plmn: proto.String("001-01"), Tac: proto.Int64(2)}, expectedCode: codes.OK, expectedCount: 1},
+		{name: "plmn-snssai by SST", request: &nfconfigpb.ConfigRequest{Resource: "plmn-snssai", Sst: proto.Uint32(1)}, expectedCode: codes.OK, expectedCount: 1},
+		{name: "policy-control by DNN", request: &nfconfigpb.ConfigRequest{Resource: "policy-control", Dnn: proto.String("ims")}, expectedCode: codes.OK, expectedCount: 1},
+		{name: "session-management by UPF", request: &nfconfigpb.ConfigRequest{Resource: "session-management", Upf: proto.String("upf1")}, expectedCode: codes.OK, expectedCount: 1},
+		{name: "qos", request: &nfconfigpb.ConfigRequest{Resource: "qos", Dnn: proto.String("internet"), Imsi: "imsi-001010000000001"}, expectedCode: codes.OK, expectedCount: 1},
+		{name: "qos of unknown IMSI", request: &nfconfigpb.ConfigRequest{Resource: "qos", Dnn: proto.String("internet"), Imsi: "001010000000002"}, expectedCode: codes.NotFound},
+		{name: "qos without IMSI", request: &nfconfigpb.ConfigRequest{Resource: "qos", Dnn: proto.String("internet")}, expectedCode: codes.InvalidArgument},
+		{name: "qos without DNN", request: &nfconfigpb.ConfigRequest{Resource: "qos", Imsi: "001010000000001"}, expectedCode: codes.InvalidArgument},
+		{name: "IMSI of another resource", request: &nfconfigpb.ConfigRequest{Resource: "plmn", Imsi: "001010000000001"}, expectedCode: codes.InvalidArgument},
+		{name: "missing resource", request: &nfconfigpb.ConfigRequest{}, expectedCode: codes.InvalidArgument},
+		{name: "unknown resource", request: &nfconfigpb.ConfigRequest{Resource: "subscribers"}, expectedCode: codes.InvalidArgument},
+		{name: "unsupported filter", request: &nfconfigpb.ConfigRequest{Resource: "plmn", Plmn: proto.String("001-01")}, expectedCode: codes.InvalidArgument},
+		{name: "invalid SST", request: &nfconfigpb.ConfigRequest{Resource: "plmn-snssai", Sst: proto.Uint32(256)}, expectedCode: codes.InvalidArgument},
+		{name: "empty DNN filter", request: &nfconfigpb.ConfigRequest{Resource: "policy-control", Dnn: proto.String("")}, expectedCode: codes.InvalidArgument},
+		{name: "etag", request: &nfconfigpb.ConfigRequest{Resource: "plmn", Etag: `"1"`}, expectedCode: codes.InvalidArgument},
+	}
+	client := nfconfigpb.NewNfConfigClient(newGrpcTestClient(t, newGrpcTestServer()))
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			response, err := client.Get(context.Background(), tc.request)
+			if status.Code(err) != tc.expectedCode {
+				t.Fatalf("expected code %s, got %v", tc.expectedCode, err)
+			}
+			if tc.expectedCode != codes.OK {
+				return
+			}
+			if response.GetResource() != tc.request.GetResource() || response.GetVersion() != 1 || response.GetEtag() == "" {
+				t.Errorf("expected resource %s at version 1 with an ETag, got %v", tc.request.GetResource(), response)
+			}
+			if count := entries(response); count != tc.expectedCount {
+				t.Errorf("expected %d entries, got %d: %v", tc.expectedCount, count, response)
+			}
+		})
+	}
+}
+
+func TestGrpcGet_LargeVersion(t *testing.T) {
+	n := newGrpcTestServer()
+	config := *n.snapshot()
+	config.resourceVersions = maps.Clone(config.resourceVersions)
+	config.resourceVersions[plmnResource] = 1<<53 + 1
+	n.inMemoryConfig.Store(&config)
+	client := nfconfigpb.NewNfConfigClient(newGrpcTestClient(t, n))
+	response, err := client.Get(context.Background(), &nfconfigpb.ConfigRequest{Resource: "plmn"})
+	if err != nil {
+		t.Fatalf("failed to get: %v", err)
+	}
+	if response.GetVersion() != 1<<53+1 {
+		t.Errorf("expected version %d, got %d", uint64(1<<53+1), response.GetVersion())
+	}
+}
+
+func TestGrpcMessages(t *testing.T) {
+	port := int32(8805)
+	sessionManagement := sessionManagementMessage(nfConfigApi.SessionManagement{
+		SliceName: "slice1",
+		PlmnId:    *nfConfigApi.NewPlmnId("001", "01"),
+		Snssai:    makeSnssaiWithSd(1, "010203"),
+		Upf:       &nfConfigApi.Upf{Hostname: "upf1", Port: &port},
+	})
+	if sessionManagement.GetSnssai().GetSd() != "010203" || sessionManagement.GetUpf().GetPort() != port || sessionManagement.GetPlmnId().GetMnc() != "01" {
+		t.Errorf("expected the S-NSSAI, UPF and PLMN of the slice, got %v", sessionManagement)
+	}
+	if upf := sessionManagementMessage(nfConfigApi.SessionManagement{}).GetUpf(); upf != nil {
+		t.Errorf("expected no UPF, got %v", upf)
+	}
+	rule := pccRuleMessage(nfConfigApi.PccRule{
+		RuleId: "rule1",
+		Flows:  []nfConfigApi.PccFlow{{Description: "permit out ip from any to assigned", Direction: nfConfigApi.DIRECTION_BIDIRECTIONAL, Status: nfConfigApi.STATUS_ENABLED}},
+		Qos:    nfConfigApi.PccQos{FiveQi: 9, Arp: nfConfigApi.Arp{PriorityLevel: 1, PreemptCap: nfConfigApi.PREEMPTCAP_MAY_PREEMPT, PreemptVuln: nfConfigApi.PREEMPTVULN_PREEMPTABLE}},
+	})
+	if rule.GetFlows()[0].GetDirection() != "BIDIRECTIONAL" || rule.GetQos().GetArp().GetPreemptCap() != "MAY_PREEMPT" || rule.GetQos().MaxBrUl != nil {
+		t.Errorf("expected the flows and QoS of the rule, got %v", rule)
+	}
+}
+
+func TestGrpcWatch(t *testing.T) {
+	n := newGrpcTestServer()
+	client := nfconfigpb.NewNfConfigClient(newGrpcTestClient(t, n))
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	etag := n.snapshot().resourceETags[plmnResource]
+	stream, err := client.Watch(ctx, &nfconfigpb.ConfigRequest{Resource: "plmn", Etag: etag})
+	if err != nil {
+		t.Fatalf("failed to open stream: %v", err)
+	}
+	received := make(chan *nfconfigpb.ConfigResponse)
+	go func() {
+		for {
+			response, err := stream.Recv()
+			if err != nil {
+				close(received)
+				return
+			}
+			received <- response
+		}
+	}()
+
+	select {
+	case response := <-received:
+		t.Fatalf("expected no response before a change, got %v", response)
+	case <-time.After(100 * time.Millisecond):
+	}
+	updated := *n.snapshot()
+	updated.plmn = []nfConfigApi.PlmnId{{Mcc: "001", Mnc: "01"}, {Mcc: "002", Mnc: "02"}}
+	storeSnapshot(n, &updated)
+	select {
+	case response := <-received:
+		if response.GetVersion() != 2 || response.GetEtag() == etag || len(response.GetPlmn()) != 2 {
+			t.Errorf("expected the two PLMNs at version 2 with a new ETag, got %v", response)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for the change")
+	}
+
+	unrelated := *n.snapshot()
+	unrelated.policyControl = nil
+	storeSnapshot(n, &unrelated)
+	select {
+	case response := <-received:
+		t.Errorf("expected no response for a change of another resource, got %v", response)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+func TestGrpcWatch_Filtered(t *testing.T) {
+	n := newGrpcTestServer()
+	client := nfconfigpb.NewNfConfigClient(newGrpcTestClient(t, n))
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	stream, err := client.Watch(ctx, &nfconfigpb.ConfigRequest{Resource: "session-management", Upf: proto.String("upf1")})
+	if err != nil {
+		t.Fatalf("failed to open stream: %v", err)
+	}
+	received := make(chan *nfconfigpb.ConfigResponse)
+	go func() {
+		for {
+			response, err := stream.Recv()
+			if err != nil {
+				close(received)
+				return
+			}
+			received <- response
+		}
+	}()
+	var etag string
+	select {
+	case response := <-received:
+		if etag = response.GetEtag(); etag == n.snapshot().resourceETags[sessionManagementResource] {
+			t.Errorf("expected the ETag of the filtered view, got %s", etag)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for the configuration")
+	}
+
+	outside := *n.snapshot()
+	outside.sessionManagement = slices.Clone(outside.sessionManagement)
+	outside.sessionManagement[1].IpDomain = []nfConfigApi.IpDomain{{DnnName: "enterprise"}}
+	storeSnapshot(n, &outside)
+	select {
+	case response := <-received:
+		t.Errorf("expected no response for a change outside of the filter, got %v", response)
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	within := *n.snapshot()
+	within.sessionManagement = slices.Clone(within.sessionManagement)
+	within.sessionManagement[0].IpDomain = []nfConfigApi.IpDomain{{DnnName: "enterprise"}}
+	storeSnapshot(n, &within)
+	select {
+	case response := <-received:
+		if response.GetVersion() != 3 || response.GetEtag() == etag || len(response.GetSessionManagement()) != 1 {
+			t.Errorf("expected the filtered view at version 3 with a new ETag, got %v", response)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for the change")
+	}
+}
+
+func TestGrpcWatch_InvalidRequest(t *testing.T) {
+	client := nfconfigpb.NewNfConfigClient(newGrpcTestClient(t, newGrpcTestServer()))
+	stream, err := client.Watch(context.Background(), &nfconfigpb.ConfigRequest{Resource: "plmn", Sst: proto.Uint32(1)})
+	if err != nil {
+		t.Fatalf("failed to open stream: %v", err)
+	}
+	_, err = stream.Recv()
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("expected code %s, got %v", codes.InvalidArgument, err)
+	}
+}
+
+func TestGrpcGet_MutualTLSWithoutCertificate(t *testing.T) {
+	n := newGrpcTestServer()
+	n.authorizer = newTestAuthorizer(t, nil)
+	client := nfconfigpb.NewNfConfigClient(newGrpcTestClient(t, n))
+	_, err := client.Get(context.Background(), &nfconfigpb.ConfigRequest{Resource: "plmn"})
+	if status.Code(err) != codes.PermissionDenied {
+		t.Errorf("expected code %s, got %v", codes.PermissionDenied, err)
+	}
+}
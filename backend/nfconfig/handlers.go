@@ -6,54 +6,168 @@
 package nfconfig
 
 import (
+	"errors"
 	"net/http"
 	"slices"
 	"strings"
 
 	"github.com/gin-gonic/gin"
-	"github.com/omec-project/openapi/v2/nfConfigApi"
+	"github.com/google/uuid"
 	"github.com/omec-project/webconsole/backend/logger"
 )
 
 func (n *NFConfigServer) GetAccessMobilityConfig(c *gin.Context) {
-	logger.NfConfigLog.Debugf("Handling GET request for access-mobility config %+v", n.inMemoryConfig.accessAndMobility)
-	c.JSON(http.StatusOK, n.inMemoryConfig.accessAndMobility)
+	config := n.requestSnapshot(c)
+	filter, err := requestFilter(c, accessMobilityResource)
+	if err != nil {
+		logger.NfConfigLog.Warnf("Invalid access-mobility filter: %v", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	accessAndMobility := filterAccessAndMobility(config.accessAndMobility, filter)
+	logger.NfConfigLog.Debugf("Handling GET request for access-mobility config %+v", accessAndMobility)
+	c.JSON(http.StatusOK, accessAndMobility)
 }
 
 func (n *NFConfigServer) GetPlmnConfig(c *gin.Context) {
-	logger.NfConfigLog.Debugf("Handling GET request for plmn config %+v", n.inMemoryConfig.plmn)
-	c.JSON(http.StatusOK, n.inMemoryConfig.plmn)
+	config := n.requestSnapshot(c)
+	logger.NfConfigLog.Debugf("Handling GET request for plmn config %+v", config.plmn)
+	c.JSON(http.StatusOK, config.plmn)
 }
 
 func (n *NFConfigServer) GetPlmnSnssaiConfig(c *gin.Context) {
-	logger.NfConfigLog.Debugf("Handling GET request for plmn-snssai config %+v", n.inMemoryConfig.plmnSnssai)
-	c.JSON(http.StatusOK, n.inMemoryConfig.plmnSnssai)
+	config := n.requestSnapshot(c)
+	filter, err := requestFilter(c, plmnSnssaiResource)
+	if err != nil {
+		logger.NfConfigLog.Warnf("Invalid plmn-snssai filter: %v", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	plmnSnssai := filterPlmnSnssai(config.plmnSnssai, filter)
+	logger.NfConfigLog.Debugf("Handling GET request for plmn-snssai config %+v", plmnSnssai)
+	c.JSON(http.StatusOK, plmnSnssai)
 }
 
 func (n *NFConfigServer) GetPolicyControlConfig(c *gin.Context) {
-	logger.NfConfigLog.Debugf("Handling GET request for policy-control config %+v", n.inMemoryConfig.policyControl)
-	c.JSON(http.StatusOK, n.inMemoryConfig.policyControl)
+	config := n.requestSnapshot(c)
+	filter, err := requestFilter(c, policyControlResource)
+	if err != nil {
+		logger.NfConfigLog.Warnf("Invalid policy-control filter: %v", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	policyControl := filterPolicyControl(config.policyControl, filter)
+	logger.NfConfigLog.Debugf("Handling GET request for policy-control config %+v", policyControl)
+	c.JSON(http.StatusOK, policyControl)
 }
 
 func (n *NFConfigServer) GetSessionManagementConfig(c *gin.Context) {
-	logger.NfConfigLog.Debugf("Handling GET request for session-management config %+v", n.inMemoryConfig.sessionManagement)
-	c.JSON(http.StatusOK, n.inMemoryConfig.sessionManagement)
+	config := n.requestSnapshot(c)
+	filter, err := requestFilter(c, sessionManagementResource)
+	if err != nil {
+		logger.NfConfigLog.Warnf("Invalid session-management filter: %v", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	sessionManagement := filterSessionManagement(config.sessionManagement, filter)
+	logger.NfConfigLog.Debugf("Handling GET request for session-management config %+v", sessionManagement)
+	c.JSON(http.StatusOK, sessionManagement)
 }
 
 func (n *NFConfigServer) GetImsiQosConfig(c *gin.Context) {
 	dnn := c.Param("dnn")
 	imsi := strings.TrimPrefix(c.Param("imsi"), "imsi-")
+	config := n.requestSnapshot(c)
 	logger.NfConfigLog.Debugf("Handling GET request for QoS config for IMSI %s", imsi)
-	imsiQos := []nfConfigApi.ImsiQos{}
-	for _, imsiQosConfig := range n.inMemoryConfig.imsiQos {
-		if imsiQosConfig.dnn == dnn && slices.Contains(imsiQosConfig.imsis, imsi) {
-			imsiQos = imsiQosConfig.qos
-			break
-		}
-	}
+	imsiQos := config.lookupImsiQos(dnn, imsi)
 	if len(imsiQos) > 0 {
 		c.JSON(http.StatusOK, imsiQos)
 		return
 	}
 	c.JSON(http.StatusNotFound, imsiQos)
 }
+
+func (n *NFConfigServer) PostSubscription(c *gin.Context) {
+	var s subscription
+	if err := c.ShouldBindJSON(&s); err != nil {
+		logger.NfConfigLog.Warnf("Invalid subscription: %v", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid JSON format"})
+		return
+	}
+	if err := n.notifier.validateSubscription(s); err != nil {
+		logger.NfConfigLog.Warnf("Invalid subscription: %v", err)
+		statusCode := http.StatusBadRequest
+		if errors.Is(err, errCallbackNotAllowed) {
+			statusCode = http.StatusForbidden
+		}
+		c.JSON(statusCode, gin.H{"error": err.Error()})
+		return
+	}
+	if !n.authorizeRequest(c, s.Resources...) {
+		return
+	}
+	s.Id = uuid.New().String()
+	s.Owner = n.authorizer.identity(c.Request.TLS)
+	s.Resources = slices.Compact(slices.Sorted(slices.Values(s.Resources)))
+	if err := n.notifier.subscribe(s); err != nil {
+		logger.NfConfigLog.Errorf("Failed to store subscription: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to create subscription"})
+		return
+	}
+	logger.NfConfigLog.Infof("Created subscription %s to %v for %s", s.Id, s.Resources, s.CallbackUri)
+	c.JSON(http.StatusCreated, s)
+}
+
+// ownedSubscription returns the subscription of the request, and rejects with
+// 403 Forbidden the NFs that did not create it
+func (n *NFConfigServer) ownedSubscription(c *gin.Context) (subscription, bool) {
+	id := c.Param("subscription-id")
+	s, exists := n.notifier.getSubscription(id)
+	if !exists {
+		c.JSON(http.StatusNotFound, gin.H{"error": "subscription " + id + " not found"})
+		return s, false
+	}
+	if identity := n.authorizer.identity(c.Request.TLS); identity != s.Owner {
+		logger.NfConfigLog.Warnf("NF %s from %s may not access subscription %s", identity, c.ClientIP(), id)
+		c.JSON(http.StatusForbidden, gin.H{"error": "subscription " + id + " belongs to another NF"})
+		return s, false
+	}
+	return s, true
+}
+
+func (n *NFConfigServer) GetSubscription(c *gin.Context) {
+	s, ok := n.ownedSubscription(c)
+	if !ok {
+		return
+	}
+	c.JSON(http.StatusOK, s)
+}
+
+func (n *NFConfigServer) DeleteSubscription(c *gin.Context) {
+	s, ok := n.ownedSubscription(c)
+	if !ok {
+		return
+	}
+	exists, err := n.notifier.unsubscribe(s.Id)
+	if err != nil {
+		logger.NfConfigLog.Errorf("Failed to delete subscription %s: %v", s.Id, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to delete subscription"})
+		return
+	}
+	if !exists {
+		c.JSON(http.StatusNotFound, gin.H{"error": "subscription " + s.Id + " not found"})
+		return
+	}
+	logger.NfConfigLog.Infof("Deleted subscription %s", s.Id)
+	c.Status(http.StatusNoContent)
+}
+
+func (n *NFConfigServer) GetClients(c *gin.Context) {
+	logger.NfConfigLog.Debugln("Handling GET request for the NF configuration clients")
+	c.JSON(http.StatusOK, n.clients.list(n.snapshot()))
+}
+
+func (n *NFConfigServer) GetDiagnostics(c *gin.Context) {
+	logger.NfConfigLog.Debugln("Handling GET request for the NF configuration diagnostics")
+	c.JSON(http.StatusOK, n.snapshot().diagnosticsReport())
+}
@@ -102,9 +102,9 @@ func TestGetImsiQosConfig(t *testing.T) {
 			router := gin.New()
 
 			nfServer := &NFConfigServer{
-				Router:         router,
-				inMemoryConfig: inMemoryConfig{imsiQos: tc.inMemoryData},
+				Router: router,
 			}
+			nfServer.inMemoryConfig.Store(&inMemoryConfig{imsiQos: newImsiQosIndex(tc.inMemoryData, nil)})
 			nfServer.setupRoutes()
 			w := httptest.NewRecorder()
 			req, err := http.NewRequest(http.MethodGet, "/nfconfig/qos/"+"internet/"+tc.imsi, nil)
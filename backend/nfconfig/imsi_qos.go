@@ -0,0 +1,108 @@
+// SPDX-FileCopyrightText: 2026 Canonical Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package nfconfig
+
+import (
+	"cmp"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/omec-project/openapi/v2/nfConfigApi"
+)
+
+// imsiQosKey identifies the QoS of an IMSI on a DNN
+type imsiQosKey struct {
+	dnn  string
+	imsi string
+}
+
+// imsiQosIndex resolves the QoS of an IMSI on a DNN in constant time
+type imsiQosIndex struct {
+	imsis map[imsiQosKey][]nfConfigApi.ImsiQos
+	// SHA-256 of the IMSIs and their QoS, set once built
+	hash []byte
+}
+
+// newImsiQosIndex indexes the QoS of the device groups. An IMSI with several
+// QoS on a DNN keeps the first one.
+func newImsiQosIndex(configs []imsiQosConfig, d *diagnostics) *imsiQosIndex {
+	index := &imsiQosIndex{imsis: make(map[imsiQosKey][]nfConfigApi.ImsiQos)}
+	for _, config := range configs {
+		for _, imsi := range config.imsis {
+			if !index.add(config.dnn, imsi, config.qos) {
+				d.add(imsiQosResource, deviceGroupObject(config.deviceGroup), "imsis", fmt.Sprintf("IMSI %s already has a QoS on DNN %s. The first one is used", imsi, config.dnn))
+			}
+		}
+	}
+	index.hash = index.computeHash()
+	return index
+}
+
+// computeHash hashes the IMSIs sorted by DNN and IMSI with their QoS. It is
+// computed once when the index is built, so that the syncs compare the hashes
+// rather than the indexes.
+func (x *imsiQosIndex) computeHash() []byte {
+	hash := sha256.New()
+	keys := slices.SortedFunc(maps.Keys(x.imsis), func(a, b imsiQosKey) int {
+		return cmp.Or(strings.Compare(a.dnn, b.dnn), compareImsis(a.imsi, b.imsi))
+	})
+	for _, key := range keys {
+		qos, err := json.Marshal(x.imsis[key])
+		if err != nil {
+			qos = []byte(err.Error())
+		}
+		fmt.Fprintf(hash, "%s\x00%s\x00%s\n", key.dnn, key.imsi, qos)
+	}
+	return hash.Sum(nil)
+}
+
+// contentHash returns the hash of the index, computed when it was built
+func (x *imsiQosIndex) contentHash() []byte {
+	if x == nil || x.hash == nil {
+		return (&imsiQosIndex{}).computeHash()
+	}
+	return x.hash
+}
+
+// add indexes the QoS of the IMSI on the DNN. It returns false when the IMSI
+// already has a QoS on the DNN, which is kept.
+func (x *imsiQosIndex) add(dnn string, imsi string, qos []nfConfigApi.ImsiQos) bool {
+	key := imsiQosKey{dnn: dnn, imsi: imsi}
+	if _, exists := x.imsis[key]; exists {
+		return false
+	}
+	x.imsis[key] = qos
+	return true
+}
+
+// lookup returns the QoS of the IMSI on the DNN
+func (x *imsiQosIndex) lookup(dnn string, imsi string) ([]nfConfigApi.ImsiQos, bool) {
+	if x == nil {
+		return nil, false
+	}
+	qos, exists := x.imsis[imsiQosKey{dnn: dnn, imsi: imsi}]
+	return qos, exists
+}
+
+// size returns the number of IMSIs with a QoS, on any DNN
+func (x *imsiQosIndex) size() int {
+	if x == nil {
+		return 0
+	}
+	return len(x.imsis)
+}
+
+// compareImsis orders IMSIs by length, then by value
+func compareImsis(a string, b string) int {
+	if c := cmp.Compare(len(a), len(b)); c != 0 {
+		return c
+	}
+	return strings.Compare(a, b)
+}
@@ -0,0 +1,91 @@
+// SPDX-FileCopyrightText: 2026 Canonical Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+package nfconfig
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/omec-project/openapi/v2/nfConfigApi"
+)
+
+var (
+	listedQos = []nfConfigApi.ImsiQos{*nfConfigApi.NewImsiQos("1 Mbps", "2 Mbps", 9, 1)}
+	otherQos  = []nfConfigApi.ImsiQos{*nfConfigApi.NewImsiQos("3 Mbps", "4 Mbps", 8, 2)}
+)
+
+func TestImsiQosIndex_Lookup(t *testing.T) {
+	index := newImsiQosIndex([]imsiQosConfig{
+		{dnn: "internet", imsis: []string{"001010000000001", "001010000000150"}, qos: listedQos},
+		{dnn: "ims", imsis: []string{"001010000000150"}, qos: otherQos},
+	}, nil)
+	tests := []struct {
+		name        string
+		dnn         string
+		imsi        string
+		expectedQos []nfConfigApi.ImsiQos
+	}{
+		{name: "listed IMSI", dnn: "internet", imsi: "001010000000001", expectedQos: listedQos},
+		{name: "listed IMSI on another DNN", dnn: "ims", imsi: "001010000000001"},
+		{name: "IMSI with a QoS on each DNN", dnn: "ims", imsi: "001010000000150", expectedQos: otherQos},
+		{name: "unknown IMSI", dnn: "internet", imsi: "999990000000001"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			qos, found := index.lookup(tc.dnn, tc.imsi)
+			if found != (tc.expectedQos != nil) {
+				t.Fatalf("expected found %v, got %v", tc.expectedQos != nil, found)
+			}
+			if found && qos[0].FiveQi != tc.expectedQos[0].FiveQi {
+				t.Errorf("expected %+v, got %+v", tc.expectedQos, qos)
+			}
+		})
+	}
+	if size := index.size(); size != 3 {
+		t.Errorf("expected 3 IMSIs, got %d", size)
+	}
+}
+
+func TestNewImsiQosIndex_KeepsFirstQos(t *testing.T) {
+	var d diagnostics
+	index := newImsiQosIndex([]imsiQosConfig{
+		{deviceGroup: "dg1", dnn: "internet", imsis: []string{"001010000000001"}, qos: listedQos},
+		{deviceGroup: "dg2", dnn: "internet", imsis: []string{"001010000000001"}, qos: otherQos},
+	}, &d)
+	if qos, found := index.lookup("internet", "001010000000001"); !found || qos[0].FiveQi != listedQos[0].FiveQi {
+		t.Errorf("expected the QoS of the first device group, got %+v", qos)
+	}
+	if len(d) != 1 || d[0].Resource != "device-group/dg2" {
+		t.Errorf("expected a diagnostic for the second device group, got %+v", d)
+	}
+}
+
+func newBenchmarkImsiQosIndex(imsiCount int) *imsiQosIndex {
+	const groupSize = 1000
+	configs := make([]imsiQosConfig, 0, imsiCount/groupSize)
+	for group := 0; group < imsiCount/groupSize; group++ {
+		imsis := make([]string, groupSize)
+		for i := range imsis {
+			imsis[i] = fmt.Sprintf("00101%010d", group*groupSize+i)
+		}
+		configs = append(configs, imsiQosConfig{dnn: "internet", imsis: imsis, qos: listedQos})
+	}
+	return newImsiQosIndex(configs, nil)
+}
+
+// BenchmarkImsiQosLookup shows that the lookup time does not depend on the
+// number of IMSIs
+func BenchmarkImsiQosLookup(b *testing.B) {
+	for _, imsiCount := range []int{1000, 100000, 1000000} {
+		index := newBenchmarkImsiQosIndex(imsiCount)
+		imsis := []string{"001010000000000", fmt.Sprintf("00101%010d", imsiCount/2), fmt.Sprintf("00101%010d", imsiCount-1)}
+		b.Run(fmt.Sprintf("%d IMSIs", imsiCount), func(b *testing.B) {
+			for i := 0; b.Loop(); i++ {
+				if _, found := index.lookup("internet", imsis[i%len(imsis)]); !found {
+					b.Fatal("expected the IMSI to be found")
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,182 @@
+// SPDX-FileCopyrightText: 2026 Canonical Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package nfconfig
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/omec-project/webconsole/backend/factory"
+	"github.com/omec-project/webconsole/backend/logger"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// defaultEndpointAllowList lists the resources each NF type may read when
+// mTLS is enabled
+var defaultEndpointAllowList = map[string][]configResource{
+	"amf":  {accessMobilityResource},
+	"ausf": {plmnResource},
+	"nrf":  {plmnResource},
+	"nssf": {plmnSnssaiResource},
+	"pcf":  {policyControlResource, imsiQosResource},
+	"smf":  {sessionManagementResource, imsiQosResource},
+	"udm":  {plmnResource},
+	"udr":  {plmnResource},
+}
+
+// nfAuthorizer authenticates the NFs with their client certificate and
+// authorizes them by NF type. A nil authorizer means mTLS is disabled and
+// allows everything.
+type nfAuthorizer struct {
+	clientCAs *x509.CertPool
+	allowList map[string][]configResource
+}
+
+func newNfAuthorizer(mtlsConfig *factory.NfConfigMtls) (*nfAuthorizer, error) {
+	if mtlsConfig == nil {
+		return nil, nil
+	}
+	caPEM, err := os.ReadFile(mtlsConfig.ClientCA)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read client CA: %w", err)
+	}
+	clientCAs := x509.NewCertPool()
+	if !clientCAs.AppendCertsFromPEM(caPEM) {
+		return nil, fmt.Errorf("no certificate found in client CA %s", mtlsConfig.ClientCA)
+	}
+	allowList := make(map[string][]configResource, len(defaultEndpointAllowList))
+	for nfType, resources := range defaultEndpointAllowList {
+		allowList[nfType] = resources
+	}
+	for nfType, resources := range mtlsConfig.EndpointAllowList {
+		nfType = strings.ToLower(strings.TrimSpace(nfType))
+		if nfType == "" {
+			return nil, fmt.Errorf("NF type of the endpoint allow-list cannot be empty")
+		}
+		allowList[nfType] = []configResource{}
+		for _, resource := range resources {
+			if !isConfigResource(configResource(resource)) && configResource(resource) != clientsResource && configResource(resource) != diagnosticsResource {
+				return nil, fmt.Errorf("unknown resource '%s' in the endpoint allow-list of %s", resource, nfType)
+			}
+			allowList[nfType] = append(allowList[nfType], configResource(resource))
+		}
+	}
+	return &nfAuthorizer{clientCAs: clientCAs, allowList: allowList}, nil
+}
+
+// tlsConfig requires a client certificate signed by the client CA
+func (a *nfAuthorizer) tlsConfig() *tls.Config {
+	return &tls.Config{
+		ClientAuth: tls.RequireAndVerifyClientCert,
+		ClientCAs:  a.clientCAs,
+		MinVersion: tls.VersionTLS12,
+	}
+}
+
+// nfType returns the NF type of the certificate: the first OU naming a known
+// NF type, or else the first DNS SAN whose first label is a known NF type,
+// optionally followed by a dash and a suffix (e.g. smf-0.smf.svc)
+func (a *nfAuthorizer) nfType(cert *x509.Certificate) (string, bool) {
+	for _, ou := range cert.Subject.OrganizationalUnit {
+		if nfType := strings.ToLower(strings.TrimSpace(ou)); a.isNfType(nfType) {
+			return nfType, true
+		}
+	}
+	for _, dnsName := range cert.DNSNames {
+		label, _, _ := strings.Cut(strings.ToLower(dnsName), ".")
+		if a.isNfType(label) {
+			return label, true
+		}
+		if nfType, _, found := strings.Cut(label, "-"); found && a.isNfType(nfType) {
+			return nfType, true
+		}
+	}
+	return "", false
+}
+
+func (a *nfAuthorizer) isNfType(nfType string) bool {
+	_, exists := a.allowList[nfType]
+	return exists
+}
+
+// authorize checks that the NF of the verified connection may read all the
+// resources. It returns the NF type.
+func (a *nfAuthorizer) authorize(state *tls.ConnectionState, resources ...configResource) (string, error) {
+	if a == nil {
+		return "", nil
+	}
+	if state == nil || len(state.VerifiedChains) == 0 || len(state.VerifiedChains[0]) == 0 {
+		return "", fmt.Errorf("a verified client certificate is required")
+	}
+	cert := state.VerifiedChains[0][0]
+	nfType, ok := a.nfType(cert)
+	if !ok {
+		return "", fmt.Errorf("no known NF type in the certificate of %s", cert.Subject.CommonName)
+	}
+	for _, resource := range resources {
+		if !slices.Contains(a.allowList[nfType], resource) {
+			return nfType, fmt.Errorf("NF type %s may not read %s", nfType, resource)
+		}
+	}
+	return nfType, nil
+}
+
+// identity returns the subject of the verified client certificate, which
+// identifies the NF instance. It is empty when mTLS is disabled.
+func (a *nfAuthorizer) identity(state *tls.ConnectionState) string {
+	if a == nil || state == nil || len(state.VerifiedChains) == 0 || len(state.VerifiedChains[0]) == 0 {
+		return ""
+	}
+	return state.VerifiedChains[0][0].Subject.String()
+}
+
+// authorizeResource rejects with 403 Forbidden the requests of NFs that may
+// not read the resource
+func (n *NFConfigServer) authorizeResource(resource configResource) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !n.authorizeRequest(c, resource) {
+			c.Abort()
+		}
+	}
+}
+
+func (n *NFConfigServer) authorizeRequest(c *gin.Context, resources ...configResource) bool {
+	if _, err := n.authorizer.authorize(c.Request.TLS, resources...); err != nil {
+		logger.NfConfigLog.Warnf("Unauthorized NF configuration request from %s: %v", c.ClientIP(), err)
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		return false
+	}
+	return true
+}
+
+// authorizeGrpc fails with PERMISSION_DENIED the requests of NFs that may not
+// read the resource
+func (n *NFConfigServer) authorizeGrpc(ctx context.Context, resource configResource) error {
+	if n.authorizer == nil {
+		return nil
+	}
+	var state *tls.ConnectionState
+	if p, ok := peer.FromContext(ctx); ok {
+		if tlsInfo, ok := p.AuthInfo.(credentials.TLSInfo); ok {
+			state = &tlsInfo.State
+		}
+	}
+	if _, err := n.authorizer.authorize(state, resource); err != nil {
+		logger.NfConfigLog.Warnf("Unauthorized NF configuration gRPC request: %v", err)
+		return status.Error(codes.PermissionDenied, err.Error())
+	}
+	return nil
+}
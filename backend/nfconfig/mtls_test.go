@@ -0,0 +1,276 @@
+// SPDX-FileCopyrightText: 2026 Canonical Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+package nfconfig
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/omec-project/openapi/v2/nfConfigApi"
+	"github.com/omec-project/webconsole/backend/factory"
+)
+
+type testCA struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+	path string
+}
+
+func newTestCA(t *testing.T) *testCA {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate CA key: %v", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("failed to create CA certificate: %v", err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatalf("failed to parse CA certificate: %v", err)
+	}
+	path := filepath.Join(t.TempDir(), "ca.pem")
+	if err = os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600); err != nil {
+		t.Fatalf("failed to write CA certificate: %v", err)
+	}
+	return &testCA{cert: cert, key: key, path: path}
+}
+
+// clientCertificate issues a client certificate with the OU and DNS SANs
+func (ca *testCA) clientCertificate(t *testing.T, ou string, dnsNames ...string) tls.Certificate {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate client key: %v", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      pkix.Name{CommonName: "nf", OrganizationalUnit: []string{ou}},
+		DNSNames:     dnsNames,
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, ca.cert, &key.PublicKey, ca.key)
+	if err != nil {
+		t.Fatalf("failed to create client certificate: %v", err)
+	}
+	return tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key}
+}
+
+func newTestAuthorizer(t *testing.T, allowList map[string][]string) *nfAuthorizer {
+	t.Helper()
+	authorizer, err := newNfAuthorizer(&factory.NfConfigMtls{ClientCA: newTestCA(t).path, EndpointAllowList: allowList})
+	if err != nil {
+		t.Fatalf("failed to create authorizer: %v", err)
+	}
+	return authorizer
+}
+
+func verifiedState(ou string, dnsNames ...string) *tls.ConnectionState {
+	cert := &x509.Certificate{
+		Subject:  pkix.Name{CommonName: "nf", OrganizationalUnit: []string{ou}},
+		DNSNames: dnsNames,
+	}
+	return &tls.ConnectionState{VerifiedChains: [][]*x509.Certificate{{cert}}}
+}
+
+func TestNewNfAuthorizer(t *testing.T) {
+	ca := newTestCA(t)
+	invalidCA := filepath.Join(t.TempDir(), "invalid.pem")
+	if err := os.WriteFile(invalidCA, []byte("not a certificate"), 0o600); err != nil {
+		t.Fatalf("failed to write file: %v", err)
+	}
+	tests := []struct {
+		name        string
+		mtlsConfig  *factory.NfConfigMtls
+		expectError bool
+	}{
+		{name: "disabled", mtlsConfig: nil},
+		{name: "default allow-list", mtlsConfig: &factory.NfConfigMtls{ClientCA: ca.path}},
+		{name: "custom allow-list", mtlsConfig: &factory.NfConfigMtls{ClientCA: ca.path, EndpointAllowList: map[string][]string{"UPF": {"session-management"}}}},
+		{name: "missing client CA", mtlsConfig: &factory.NfConfigMtls{ClientCA: filepath.Join(t.TempDir(), "missing.pem")}, expectError: true},
+		{name: "invalid client CA", mtlsConfig: &factory.NfConfigMtls{ClientCA: invalidCA}, expectError: true},
+		{name: "unknown resource", mtlsConfig: &factory.NfConfigMtls{ClientCA: ca.path, EndpointAllowList: map[string][]string{"smf": {"subscribers"}}}, expectError: true},
+		{name: "empty NF type", mtlsConfig: &factory.NfConfigMtls{ClientCA: ca.path, EndpointAllowList: map[string][]string{" ": {"plmn"}}}, expectError: true},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			authorizer, err := newNfAuthorizer(tc.mtlsConfig)
+			if (err != nil) != tc.expectError {
+				t.Fatalf("expected error %v, got %v", tc.expectError, err)
+			}
+			if err == nil && (authorizer == nil) != (tc.mtlsConfig == nil) {
+				t.Errorf("expected an authorizer only with an mTLS configuration, got %+v", authorizer)
+			}
+		})
+	}
+}
+
+func TestNfAuthorizer_Authorize(t *testing.T) {
+	authorizer := newTestAuthorizer(t, map[string][]string{"upf": {"session-management"}, "smf": {"session-management", "plmn"}})
+	tests := []struct {
+		name           string
+		state          *tls.ConnectionState
+		resources      []configResource
+		expectedNfType string
+		expectError    bool
+	}{
+		{name: "NF type from OU", state: verifiedState("PCF"), resources: []configResource{policyControlResource, imsiQosResource}, expectedNfType: "pcf"},
+		{name: "NF type from DNS SAN", state: verifiedState("core", "amf.sdcore.svc"), resources: []configResource{accessMobilityResource}, expectedNfType: "amf"},
+		{name: "NF type from DNS SAN with suffix", state: verifiedState("", "smf-0.smf.sdcore.svc"), resources: []configResource{plmnResource}, expectedNfType: "smf"},
+		{name: "custom NF type", state: verifiedState("upf"), resources: []configResource{sessionManagementResource}, expectedNfType: "upf"},
+		{name: "resource not allowed", state: verifiedState("pcf"), resources: []configResource{policyControlResource, sessionManagementResource}, expectedNfType: "pcf", expectError: true},
+		{name: "replaced default entry", state: verifiedState("smf"), resources: []configResource{policyControlResource}, expectedNfType: "smf", expectError: true},
+		{name: "unknown NF type", state: verifiedState("core", "smfx.sdcore.svc"), resources: []configResource{plmnResource}, expectError: true},
+		{name: "no client certificate", state: &tls.ConnectionState{}, resources: []configResource{plmnResource}, expectError: true},
+		{name: "no TLS", state: nil, resources: []configResource{plmnResource}, expectError: true},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			nfType, err := authorizer.authorize(tc.state, tc.resources...)
+			if (err != nil) != tc.expectError {
+				t.Fatalf("expected error %v, got %v", tc.expectError, err)
+			}
+			if nfType != tc.expectedNfType {
+				t.Errorf("expected NF type '%s', got '%s'", tc.expectedNfType, nfType)
+			}
+		})
+	}
+}
+
+func TestAuthorizeResource_Routes(t *testing.T) {
+	n, _ := newSubscriptionTestServer(t)
+	n.authorizer = newTestAuthorizer(t, nil)
+	n.Router = gin.New()
+	n.setupRoutes()
+	storeSnapshot(n, &inMemoryConfig{
+		plmn: []nfConfigApi.PlmnId{{Mcc: "001", Mnc: "01"}},
+		imsiQos: newImsiQosIndex([]imsiQosConfig{
+			{dnn: "internet", imsis: []string{"001010000000001"}, qos: []nfConfigApi.ImsiQos{*nfConfigApi.NewImsiQos("1 Mbps", "2 Mbps", 9, 8)}},
+		}, nil),
+	})
+	tests := []struct {
+		name         string
+		method       string
+		route        string
+		body         string
+		state        *tls.ConnectionState
+		expectedCode int
+	}{
+		{name: "PCF reads policy-control", method: http.MethodGet, route: "/nfconfig/policy-control", state: verifiedState("pcf"), expectedCode: http.StatusOK},
+		{name: "PCF reads session-management", method: http.MethodGet, route: "/nfconfig/session-management", state: verifiedState("pcf"), expectedCode: http.StatusForbidden},
+		{name: "SMF reads session-management", method: http.MethodGet, route: "/nfconfig/session-management", state: verifiedState("smf"), expectedCode: http.StatusOK},
+		{name: "SMF reads the QoS of an IMSI", method: http.MethodGet, route: "/nfconfig/qos/internet/imsi-001010000000001", state: verifiedState("smf"), expectedCode: http.StatusOK},
+		{name: "AMF reads the QoS of an IMSI", method: http.MethodGet, route: "/nfconfig/qos/internet/imsi-001010000000001", state: verifiedState("amf"), expectedCode: http.StatusForbidden},
+		{name: "unknown NF type", method: http.MethodGet, route: "/nfconfig/plmn", state: verifiedState("core"), expectedCode: http.StatusForbidden},
+		{name: "no client certificate", method: http.MethodGet, route: "/nfconfig/plmn", expectedCode: http.StatusForbidden},
+		{
+			name:         "SMF subscribes to session-management",
+			method:       http.MethodPost,
+			route:        "/nfconfig/subscriptions",
+			body:         `{"callbackUri": "http://smf:8080/notify", "resources": ["session-management"]}`,
+			state:        verifiedState("smf"),
+			expectedCode: http.StatusCreated,
+		},
+		{
+			name:         "SMF subscribes to policy-control",
+			method:       http.MethodPost,
+			route:        "/nfconfig/subscriptions",
+			body:         `{"callbackUri": "http://smf:8080/notify", "resources": ["session-management", "policy-control"]}`,
+			state:        verifiedState("smf"),
+			expectedCode: http.StatusForbidden,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(tc.method, tc.route, strings.NewReader(tc.body))
+			req.Header.Set("Content-Type", "application/json")
+			req.TLS = tc.state
+			w := httptest.NewRecorder()
+			n.Router.ServeHTTP(w, req)
+			if w.Code != tc.expectedCode {
+				t.Errorf("expected status %d, got %d with body %s", tc.expectedCode, w.Code, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestMutualTLS_Handshake(t *testing.T) {
+	ca := newTestCA(t)
+	authorizer, err := newNfAuthorizer(&factory.NfConfigMtls{ClientCA: ca.path})
+	if err != nil {
+		t.Fatalf("failed to create authorizer: %v", err)
+	}
+	n := &NFConfigServer{Router: gin.New(), authorizer: authorizer}
+	n.inMemoryConfig.Store(&inMemoryConfig{
+		policyControl: []nfConfigApi.PolicyControl{{PlmnId: *nfConfigApi.NewPlmnId("001", "01"), Snssai: makeSnssaiWithSd(1, "010203")}},
+	})
+	n.setupRoutes()
+	server := httptest.NewUnstartedServer(n.Router)
+	server.TLS = authorizer.tlsConfig()
+	server.StartTLS()
+	defer server.Close()
+
+	otherCA := newTestCA(t)
+	tests := []struct {
+		name         string
+		certificates []tls.Certificate
+		route        string
+		expectedCode int
+	}{
+		{name: "PCF certificate", certificates: []tls.Certificate{ca.clientCertificate(t, "pcf")}, route: "/nfconfig/policy-control", expectedCode: http.StatusOK},
+		{name: "PCF certificate on session-management", certificates: []tls.Certificate{ca.clientCertificate(t, "pcf")}, route: "/nfconfig/session-management", expectedCode: http.StatusForbidden},
+		{name: "no client certificate", route: "/nfconfig/policy-control"},
+		{name: "certificate of another CA", certificates: []tls.Certificate{otherCA.clientCertificate(t, "pcf")}, route: "/nfconfig/policy-control"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := server.Client()
+			transport := client.Transport.(*http.Transport).Clone()
+			transport.TLSClientConfig.Certificates = tc.certificates
+			client.Transport = transport
+			resp, err := client.Get(server.URL + tc.route)
+			if tc.expectedCode == 0 {
+				if err == nil {
+					_ = resp.Body.Close()
+					t.Fatalf("expected the handshake to fail, got status %d", resp.StatusCode)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("request failed: %v", err)
+			}
+			defer resp.Body.Close()
+			if resp.StatusCode != tc.expectedCode {
+				t.Errorf("expected status %d, got %d", tc.expectedCode, resp.StatusCode)
+			}
+		})
+	}
+}
@@ -0,0 +1,10 @@
+// SPDX-FileCopyrightText: 2026 Canonical Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+// Package nfconfigpb holds the messages and the gRPC service of the NF
+// configuration, generated from nfconfig.proto
+package nfconfigpb
+
+//go:generate protoc --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative nfconfig.proto
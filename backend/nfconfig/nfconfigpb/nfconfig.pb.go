@@ -0,0 +1,1278 @@
+// SPDX-FileCopyrightText: 2026 Canonical Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+// gRPC interface of the NF configuration service, served alongside the REST
+// API when the nfconfig-grpc section of the configuration enables it.
+//
+// The messages mirror the JSON views of the REST endpoints. Enumerations, e.g.
+// the direction of a PCC flow, hold the same strings as the REST API.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        (unknown)
+// source: nfconfig.proto
+
+package nfconfigpb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type ConfigRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// one of "plmn", "plmn-snssai", "access-mobility", "session-management",
+	// "policy-control" or "qos"
+	Resource string `protobuf:"bytes,1,opt,name=resource,proto3" json:"resource,omitempty"`
+	// the query filters the resource supports in the REST API
+	Plmn *string `protobuf:"bytes,2,opt,name=plmn,proto3,oneof" json:"plmn,omitempty"`
+	Sst  *uint32 `protobuf:"varint,3,opt,name=sst,proto3,oneof" json:"sst,omitempty"`
+	Sd   *string `protobuf:"bytes,4,opt,name=sd,proto3,oneof" json:"sd,omitempty"`
+	Tac  *int64  `protobuf:"varint,5,opt,name=tac,proto3,oneof" json:"tac,omitempty"`
+	// a filter, except for "qos" which requires it with the imsi
+	Dnn *string `protobuf:"bytes,6,opt,name=dnn,proto3,oneof" json:"dnn,omitempty"`
+	Upf *string `protobuf:"bytes,7,opt,name=upf,proto3,oneof" json:"upf,omitempty"`
+	// required by "qos", with or without the "imsi-" prefix
+	Imsi string `protobuf:"bytes,8,opt,name=imsi,proto3" json:"imsi,omitempty"`
+	// Watch only: the ETag of the configuration the NF already holds
+	Etag          string `protobuf:"bytes,9,opt,name=etag,proto3" json:"etag,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConfigRequest) Reset() {
+	*x = ConfigRequest{}
+	mi := &file_nfconfig_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConfigRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConfigRequest) ProtoMessage() {}
+
+func (x *ConfigRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_nfconfig_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConfigRequest.ProtoReflect.Descriptor instead.
+func (*ConfigRequest) Descriptor() ([]byte, []int) {
+	return file_nfconfig_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ConfigRequest) GetResource() string {
+	if x != nil {
+		return x.Resource
+	}
+	return ""
+}
+
+func (x *ConfigRequest) GetPlmn() string {
+	if x != nil && x.Plmn != nil {
+		return *x.Plmn
+	}
+	return ""
+}
+
+func (x *ConfigRequest) GetSst() uint32 {
+	if x != nil && x.Sst != nil {
+		return *x.Sst
+	}
+	return 0
+}
+
+func (x *ConfigRequest) GetSd() string {
+	if x != nil && x.Sd != nil {
+		return *x.Sd
+	}
+	return ""
+}
+
+func (x *ConfigRequest) GetTac() int64 {
+	if x != nil && x.Tac != nil {
+		return *x.Tac
+	}
+	return 0
+}
+
+func (x *ConfigRequest) GetDnn() string {
+	if x != nil && x.Dnn != nil {
+		return *x.Dnn
+	}
+	return ""
+}
+
+func (x *ConfigRequest) GetUpf() string {
+	if x != nil && x.Upf != nil {
+		return *x.Upf
+	}
+	return ""
+}
+
+func (x *ConfigRequest) GetImsi() string {
+	if x != nil {
+		return x.Imsi
+	}
+	return ""
+}
+
+func (x *ConfigRequest) GetEtag() string {
+	if x != nil {
+		return x.Etag
+	}
+	return ""
+}
+
+// ConfigResponse holds the configuration of the requested resource. Only the
+// field of that resource is set.
+type ConfigResponse struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Resource string                 `protobuf:"bytes,1,opt,name=resource,proto3" json:"resource,omitempty"`
+	// version of the resource in this webconsole
+	Version           uint64               `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`
+	Etag              string               `protobuf:"bytes,3,opt,name=etag,proto3" json:"etag,omitempty"`
+	Plmn              []*PlmnId            `protobuf:"bytes,4,rep,name=plmn,proto3" json:"plmn,omitempty"`
+	PlmnSnssai        []*PlmnSnssai        `protobuf:"bytes,5,rep,name=plmn_snssai,json=plmnSnssai,proto3" json:"plmn_snssai,omitempty"`
+	AccessMobility    []*AccessAndMobility `protobuf:"bytes,6,rep,name=access_mobility,json=accessMobility,proto3" json:"access_mobility,omitempty"`
+	SessionManagement []*SessionManagement `protobuf:"bytes,7,rep,name=session_management,json=sessionManagement,proto3" json:"session_management,omitempty"`
+	PolicyControl     []*PolicyControl     `protobuf:"bytes,8,rep,name=policy_control,json=policyControl,proto3" json:"policy_control,omitempty"`
+	Qos               []*ImsiQos           `protobuf:"bytes,9,rep,name=qos,proto3" json:"qos,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *ConfigResponse) Reset() {
+	*x = ConfigResponse{}
+	mi := &file_nfconfig_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConfigResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConfigResponse) ProtoMessage() {}
+
+func (x *ConfigResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_nfconfig_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConfigResponse.ProtoReflect.Descriptor instead.
+func (*ConfigResponse) Descriptor() ([]byte, []int) {
+	return file_nfconfig_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ConfigResponse) GetResource() string {
+	if x != nil {
+		return x.Resource
+	}
+	return ""
+}
+
+func (x *ConfigResponse) GetVersion() uint64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *ConfigResponse) GetEtag() string {
+	if x != nil {
+		return x.Etag
+	}
+	return ""
+}
+
+func (x *ConfigResponse) GetPlmn() []*PlmnId {
+	if x != nil {
+		return x.Plmn
+	}
+	return nil
+}
+
+func (x *ConfigResponse) GetPlmnSnssai() []*PlmnSnssai {
+	if x != nil {
+		return x.PlmnSnssai
+	}
+	return nil
+}
+
+func (x *ConfigResponse) GetAccessMobility() []*AccessAndMobility {
+	if x != nil {
+		return x.AccessMobility
+	}
+	return nil
+}
+
+func (x *ConfigResponse) GetSessionManagement() []*SessionManagement {
+	if x != nil {
+		return x.SessionManagement
+	}
+	return nil
+}
+
+func (x *ConfigResponse) GetPolicyControl() []*PolicyControl {
+	if x != nil {
+		return x.PolicyControl
+	}
+	return nil
+}
+
+func (x *ConfigResponse) GetQos() []*ImsiQos {
+	if x != nil {
+		return x.Qos
+	}
+	return nil
+}
+
+type PlmnId struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Mcc           string                 `protobuf:"bytes,1,opt,name=mcc,proto3" json:"mcc,omitempty"`
+	Mnc           string                 `protobuf:"bytes,2,opt,name=mnc,proto3" json:"mnc,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PlmnId) Reset() {
+	*x = PlmnId{}
+	mi := &file_nfconfig_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PlmnId) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PlmnId) ProtoMessage() {}
+
+func (x *PlmnId) ProtoReflect() protoreflect.Message {
+	mi := &file_nfconfig_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PlmnId.ProtoReflect.Descriptor instead.
+func (*PlmnId) Descriptor() ([]byte, []int) {
+	return file_nfconfig_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *PlmnId) GetMcc() string {
+	if x != nil {
+		return x.Mcc
+	}
+	return ""
+}
+
+func (x *PlmnId) GetMnc() string {
+	if x != nil {
+		return x.Mnc
+	}
+	return ""
+}
+
+type Snssai struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Sst           uint32                 `protobuf:"varint,1,opt,name=sst,proto3" json:"sst,omitempty"`
+	Sd            *string                `protobuf:"bytes,2,opt,name=sd,proto3,oneof" json:"sd,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Snssai) Reset() {
+	*x = Snssai{}
+	mi := &file_nfconfig_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Snssai) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Snssai) ProtoMessage() {}
+
+func (x *Snssai) ProtoReflect() protoreflect.Message {
+	mi := &file_nfconfig_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Snssai.ProtoReflect.Descriptor instead.
+func (*Snssai) Descriptor() ([]byte, []int) {
+	return file_nfconfig_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *Snssai) GetSst() uint32 {
+	if x != nil {
+		return x.Sst
+	}
+	return 0
+}
+
+func (x *Snssai) GetSd() string {
+	if x != nil && x.Sd != nil {
+		return *x.Sd
+	}
+	return ""
+}
+
+type PlmnSnssai struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PlmnId        *PlmnId                `protobuf:"bytes,1,opt,name=plmn_id,json=plmnId,proto3" json:"plmn_id,omitempty"`
+	SnssaiList    []*Snssai              `protobuf:"bytes,2,rep,name=snssai_list,json=snssaiList,proto3" json:"snssai_list,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PlmnSnssai) Reset() {
+	*x = PlmnSnssai{}
+	mi := &file_nfconfig_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PlmnSnssai) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PlmnSnssai) ProtoMessage() {}
+
+func (x *PlmnSnssai) ProtoReflect() protoreflect.Message {
+	mi := &file_nfconfig_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PlmnSnssai.ProtoReflect.Descriptor instead.
+func (*PlmnSnssai) Descriptor() ([]byte, []int) {
+	return file_nfconfig_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *PlmnSnssai) GetPlmnId() *PlmnId {
+	if x != nil {
+		return x.PlmnId
+	}
+	return nil
+}
+
+func (x *PlmnSnssai) GetSnssaiList() []*Snssai {
+	if x != nil {
+		return x.SnssaiList
+	}
+	return nil
+}
+
+type AccessAndMobility struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PlmnId        *PlmnId                `protobuf:"bytes,1,opt,name=plmn_id,json=plmnId,proto3" json:"plmn_id,omitempty"`
+	Snssai        *Snssai                `protobuf:"bytes,2,opt,name=snssai,proto3" json:"snssai,omitempty"`
+	Tacs          []string               `protobuf:"bytes,3,rep,name=tacs,proto3" json:"tacs,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AccessAndMobility) Reset() {
+	*x = AccessAndMobility{}
+	mi := &file_nfconfig_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AccessAndMobility) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AccessAndMobility) ProtoMessage() {}
+
+func (x *AccessAndMobility) ProtoReflect() protoreflect.Message {
+	mi := &file_nfconfig_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AccessAndMobility.ProtoReflect.Descriptor instead.
+func (*AccessAndMobility) Descriptor() ([]byte, []int) {
+	return file_nfconfig_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *AccessAndMobility) GetPlmnId() *PlmnId {
+	if x != nil {
+		return x.PlmnId
+	}
+	return nil
+}
+
+func (x *AccessAndMobility) GetSnssai() *Snssai {
+	if x != nil {
+		return x.Snssai
+	}
+	return nil
+}
+
+func (x *AccessAndMobility) GetTacs() []string {
+	if x != nil {
+		return x.Tacs
+	}
+	return nil
+}
+
+type IpDomain struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DnnName       string                 `protobuf:"bytes,1,opt,name=dnn_name,json=dnnName,proto3" json:"dnn_name,omitempty"`
+	DnsIpv4       string                 `protobuf:"bytes,2,opt,name=dns_ipv4,json=dnsIpv4,proto3" json:"dns_ipv4,omitempty"`
+	PcscfIpv4     *string                `protobuf:"bytes,3,opt,name=pcscf_ipv4,json=pcscfIpv4,proto3,oneof" json:"pcscf_ipv4,omitempty"`
+	UeSubnet      string                 `protobuf:"bytes,4,opt,name=ue_subnet,json=ueSubnet,proto3" json:"ue_subnet,omitempty"`
+	Mtu           int32                  `protobuf:"varint,5,opt,name=mtu,proto3" json:"mtu,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IpDomain) Reset() {
+	*x = IpDomain{}
+	mi := &file_nfconfig_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IpDomain) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IpDomain) ProtoMessage() {}
+
+func (x *IpDomain) ProtoReflect() protoreflect.Message {
+	mi := &file_nfconfig_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IpDomain.ProtoReflect.Descriptor instead.
+func (*IpDomain) Descriptor() ([]byte, []int) {
+	return file_nfconfig_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *IpDomain) GetDnnName() string {
+	if x != nil {
+		return x.DnnName
+	}
+	return ""
+}
+
+func (x *IpDomain) GetDnsIpv4() string {
+	if x != nil {
+		return x.DnsIpv4
+	}
+	return ""
+}
+
+func (x *IpDomain) GetPcscfIpv4() string {
+	if x != nil && x.PcscfIpv4 != nil {
+		return *x.PcscfIpv4
+	}
+	return ""
+}
+
+func (x *IpDomain) GetUeSubnet() string {
+	if x != nil {
+		return x.UeSubnet
+	}
+	return ""
+}
+
+func (x *IpDomain) GetMtu() int32 {
+	if x != nil {
+		return x.Mtu
+	}
+	return 0
+}
+
+type Upf struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Hostname      string                 `protobuf:"bytes,1,opt,name=hostname,proto3" json:"hostname,omitempty"`
+	Port          *int32                 `protobuf:"varint,2,opt,name=port,proto3,oneof" json:"port,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Upf) Reset() {
+	*x = Upf{}
+	mi := &file_nfconfig_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Upf) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Upf) ProtoMessage() {}
+
+func (x *Upf) ProtoReflect() protoreflect.Message {
+	mi := &file_nfconfig_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Upf.ProtoReflect.Descriptor instead.
+func (*Upf) Descriptor() ([]byte, []int) {
+	return file_nfconfig_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *Upf) GetHostname() string {
+	if x != nil {
+		return x.Hostname
+	}
+	return ""
+}
+
+func (x *Upf) GetPort() int32 {
+	if x != nil && x.Port != nil {
+		return *x.Port
+	}
+	return 0
+}
+
+type SessionManagement struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	SliceName string                 `protobuf:"bytes,1,opt,name=slice_name,json=sliceName,proto3" json:"slice_name,omitempty"`
+	PlmnId    *PlmnId                `protobuf:"bytes,2,opt,name=plmn_id,json=plmnId,proto3" json:"plmn_id,omitempty"`
+	Snssai    *Snssai                `protobuf:"bytes,3,opt,name=snssai,proto3" json:"snssai,omitempty"`
+	IpDomain  []*IpDomain            `protobuf:"bytes,4,rep,name=ip_domain,json=ipDomain,proto3" json:"ip_domain,omitempty"`
+	// unset when the slice has no UPF
+	Upf           *Upf     `protobuf:"bytes,5,opt,name=upf,proto3" json:"upf,omitempty"`
+	GnbNames      []string `protobuf:"bytes,6,rep,name=gnb_names,json=gnbNames,proto3" json:"gnb_names,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SessionManagement) Reset() {
+	*x = SessionManagement{}
+	mi := &file_nfconfig_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SessionManagement) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SessionManagement) ProtoMessage() {}
+
+func (x *SessionManagement) ProtoReflect() protoreflect.Message {
+	mi := &file_nfconfig_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SessionManagement.ProtoReflect.Descriptor instead.
+func (*SessionManagement) Descriptor() ([]byte, []int) {
+	return file_nfconfig_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *SessionManagement) GetSliceName() string {
+	if x != nil {
+		return x.SliceName
+	}
+	return ""
+}
+
+func (x *SessionManagement) GetPlmnId() *PlmnId {
+	if x != nil {
+		return x.PlmnId
+	}
+	return nil
+}
+
+func (x *SessionManagement) GetSnssai() *Snssai {
+	if x != nil {
+		return x.Snssai
+	}
+	return nil
+}
+
+func (x *SessionManagement) GetIpDomain() []*IpDomain {
+	if x != nil {
+		return x.IpDomain
+	}
+	return nil
+}
+
+func (x *SessionManagement) GetUpf() *Upf {
+	if x != nil {
+		return x.Upf
+	}
+	return nil
+}
+
+func (x *SessionManagement) GetGnbNames() []string {
+	if x != nil {
+		return x.GnbNames
+	}
+	return nil
+}
+
+type PccFlow struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Description   string                 `protobuf:"bytes,1,opt,name=description,proto3" json:"description,omitempty"`
+	Direction     string                 `protobuf:"bytes,2,opt,name=direction,proto3" json:"direction,omitempty"`
+	Status        string                 `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PccFlow) Reset() {
+	*x = PccFlow{}
+	mi := &file_nfconfig_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PccFlow) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PccFlow) ProtoMessage() {}
+
+func (x *PccFlow) ProtoReflect() protoreflect.Message {
+	mi := &file_nfconfig_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PccFlow.ProtoReflect.Descriptor instead.
+func (*PccFlow) Descriptor() ([]byte, []int) {
+	return file_nfconfig_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *PccFlow) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *PccFlow) GetDirection() string {
+	if x != nil {
+		return x.Direction
+	}
+	return ""
+}
+
+func (x *PccFlow) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+type Arp struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PriorityLevel int32                  `protobuf:"varint,1,opt,name=priority_level,json=priorityLevel,proto3" json:"priority_level,omitempty"`
+	PreemptCap    string                 `protobuf:"bytes,2,opt,name=preempt_cap,json=preemptCap,proto3" json:"preempt_cap,omitempty"`
+	PreemptVuln   string                 `protobuf:"bytes,3,opt,name=preempt_vuln,json=preemptVuln,proto3" json:"preempt_vuln,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Arp) Reset() {
+	*x = Arp{}
+	mi := &file_nfconfig_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Arp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Arp) ProtoMessage() {}
+
+func (x *Arp) ProtoReflect() protoreflect.Message {
+	mi := &file_nfconfig_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Arp.ProtoReflect.Descriptor instead.
+func (*Arp) Descriptor() ([]byte, []int) {
+	return file_nfconfig_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *Arp) GetPriorityLevel() int32 {
+	if x != nil {
+		return x.PriorityLevel
+	}
+	return 0
+}
+
+func (x *Arp) GetPreemptCap() string {
+	if x != nil {
+		return x.PreemptCap
+	}
+	return ""
+}
+
+func (x *Arp) GetPreemptVuln() string {
+	if x != nil {
+		return x.PreemptVuln
+	}
+	return ""
+}
+
+type PccQos struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FiveQi        int32                  `protobuf:"varint,1,opt,name=five_qi,json=fiveQi,proto3" json:"five_qi,omitempty"`
+	MaxBrUl       *string                `protobuf:"bytes,2,opt,name=max_br_ul,json=maxBrUl,proto3,oneof" json:"max_br_ul,omitempty"`
+	MaxBrDl       *string                `protobuf:"bytes,3,opt,name=max_br_dl,json=maxBrDl,proto3,oneof" json:"max_br_dl,omitempty"`
+	Arp           *Arp                   `protobuf:"bytes,4,opt,name=arp,proto3" json:"arp,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PccQos) Reset() {
+	*x = PccQos{}
+	mi := &file_nfconfig_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PccQos) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PccQos) ProtoMessage() {}
+
+func (x *PccQos) ProtoReflect() protoreflect.Message {
+	mi := &file_nfconfig_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PccQos.ProtoReflect.Descriptor instead.
+func (*PccQos) Descriptor() ([]byte, []int) {
+	return file_nfconfig_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *PccQos) GetFiveQi() int32 {
+	if x != nil {
+		return x.FiveQi
+	}
+	return 0
+}
+
+func (x *PccQos) GetMaxBrUl() string {
+	if x != nil && x.MaxBrUl != nil {
+		return *x.MaxBrUl
+	}
+	return ""
+}
+
+func (x *PccQos) GetMaxBrDl() string {
+	if x != nil && x.MaxBrDl != nil {
+		return *x.MaxBrDl
+	}
+	return ""
+}
+
+func (x *PccQos) GetArp() *Arp {
+	if x != nil {
+		return x.Arp
+	}
+	return nil
+}
+
+type PccRule struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RuleId        string                 `protobuf:"bytes,1,opt,name=rule_id,json=ruleId,proto3" json:"rule_id,omitempty"`
+	Flows         []*PccFlow             `protobuf:"bytes,2,rep,name=flows,proto3" json:"flows,omitempty"`
+	Qos           *PccQos                `protobuf:"bytes,3,opt,name=qos,proto3" json:"qos,omitempty"`
+	Precedence    int32                  `protobuf:"varint,4,opt,name=precedence,proto3" json:"precedence,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PccRule) Reset() {
+	*x = PccRule{}
+	mi := &file_nfconfig_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PccRule) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PccRule) ProtoMessage() {}
+
+func (x *PccRule) ProtoReflect() protoreflect.Message {
+	mi := &file_nfconfig_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PccRule.ProtoReflect.Descriptor instead.
+func (*PccRule) Descriptor() ([]byte, []int) {
+	return file_nfconfig_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *PccRule) GetRuleId() string {
+	if x != nil {
+		return x.RuleId
+	}
+	return ""
+}
+
+func (x *PccRule) GetFlows() []*PccFlow {
+	if x != nil {
+		return x.Flows
+	}
+	return nil
+}
+
+func (x *PccRule) GetQos() *PccQos {
+	if x != nil {
+		return x.Qos
+	}
+	return nil
+}
+
+func (x *PccRule) GetPrecedence() int32 {
+	if x != nil {
+		return x.Precedence
+	}
+	return 0
+}
+
+type PolicyControl struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PlmnId        *PlmnId                `protobuf:"bytes,1,opt,name=plmn_id,json=plmnId,proto3" json:"plmn_id,omitempty"`
+	Snssai        *Snssai                `protobuf:"bytes,2,opt,name=snssai,proto3" json:"snssai,omitempty"`
+	Dnns          []string               `protobuf:"bytes,3,rep,name=dnns,proto3" json:"dnns,omitempty"`
+	PccRules      []*PccRule             `protobuf:"bytes,4,rep,name=pcc_rules,json=pccRules,proto3" json:"pcc_rules,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PolicyControl) Reset() {
+	*x = PolicyControl{}
+	mi := &file_nfconfig_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PolicyControl) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PolicyControl) ProtoMessage() {}
+
+func (x *PolicyControl) ProtoReflect() protoreflect.Message {
+	mi := &file_nfconfig_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PolicyControl.ProtoReflect.Descriptor instead.
+func (*PolicyControl) Descriptor() ([]byte, []int) {
+	return file_nfconfig_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *PolicyControl) GetPlmnId() *PlmnId {
+	if x != nil {
+		return x.PlmnId
+	}
+	return nil
+}
+
+func (x *PolicyControl) GetSnssai() *Snssai {
+	if x != nil {
+		return x.Snssai
+	}
+	return nil
+}
+
+func (x *PolicyControl) GetDnns() []string {
+	if x != nil {
+		return x.Dnns
+	}
+	return nil
+}
+
+func (x *PolicyControl) GetPccRules() []*PccRule {
+	if x != nil {
+		return x.PccRules
+	}
+	return nil
+}
+
+type ImsiQos struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	MbrUplink        string                 `protobuf:"bytes,1,opt,name=mbr_uplink,json=mbrUplink,proto3" json:"mbr_uplink,omitempty"`
+	MbrDownlink      string                 `protobuf:"bytes,2,opt,name=mbr_downlink,json=mbrDownlink,proto3" json:"mbr_downlink,omitempty"`
+	FiveQi           int32                  `protobuf:"varint,3,opt,name=five_qi,json=fiveQi,proto3" json:"five_qi,omitempty"`
+	ArpPriorityLevel int32                  `protobuf:"varint,4,opt,name=arp_priority_level,json=arpPriorityLevel,proto3" json:"arp_priority_level,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *ImsiQos) Reset() {
+	*x = ImsiQos{}
+	mi := &file_nfconfig_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImsiQos) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImsiQos) ProtoMessage() {}
+
+func (x *ImsiQos) ProtoReflect() protoreflect.Message {
+	mi := &file_nfconfig_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImsiQos.ProtoReflect.Descriptor instead.
+func (*ImsiQos) Descriptor() ([]byte, []int) {
+	return file_nfconfig_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *ImsiQos) GetMbrUplink() string {
+	if x != nil {
+		return x.MbrUplink
+	}
+	return ""
+}
+
+func (x *ImsiQos) GetMbrDownlink() string {
+	if x != nil {
+		return x.MbrDownlink
+	}
+	return ""
+}
+
+func (x *ImsiQos) GetFiveQi() int32 {
+	if x != nil {
+		return x.FiveQi
+	}
+	return 0
+}
+
+func (x *ImsiQos) GetArpPriorityLevel() int32 {
+	if x != nil {
+		return x.ArpPriorityLevel
+	}
+	return 0
+}
+
+var File_nfconfig_proto protoreflect.FileDescriptor
+
+const file_nfconfig_proto_rawDesc = "" +
+	"\n" +
+	"\x0enfconfig.proto\x12\vnfconfig.v1\"\x8d\x02\n" +
+	"\rConfigRequest\x12\x1a\n" +
+	"\bresource\x18\x01 \x01(\tR\bresource\x12\x17\n" +
+	"\x04plmn\x18\x02 \x01(\tH\x00R\x04plmn\x88\x01\x01\x12\x15\n" +
+	"\x03sst\x18\x03 \x01(\rH\x01R\x03sst\x88\x01\x01\x12\x13\n" +
+	"\x02sd\x18\x04 \x01(\tH\x02R\x02sd\x88\x01\x01\x12\x15\n" +
+	"\x03tac\x18\x05 \x01(\x03H\x03R\x03tac\x88\x01\x01\x12\x15\n" +
+	"\x03dnn\x18\x06 \x01(\tH\x04R\x03dnn\x88\x01\x01\x12\x15\n" +
+	"\x03upf\x18\a \x01(\tH\x05R\x03upf\x88\x01\x01\x12\x12\n" +
+	"\x04imsi\x18\b \x01(\tR\x04imsi\x12\x12\n" +
+	"\x04etag\x18\t \x01(\tR\x04etagB\a\n" +
+	"\x05_plmnB\x06\n" +
+	"\x04_sstB\x05\n" +
+	"\x03_sdB\x06\n" +
+	"\x04_tacB\x06\n" +
+	"\x04_dnnB\x06\n" +
+	"\x04_upf\"\xc0\x03\n" +
+	"\x0eConfigResponse\x12\x1a\n" +
+	"\bresource\x18\x01 \x01(\tR\bresource\x12\x18\n" +
+	"\aversion\x18\x02 \x01(\x04R\aversion\x12\x12\n" +
+	"\x04etag\x18\x03 \x01(\tR\x04etag\x12'\n" +
+	"\x04plmn\x18\x04 \x03(\v2\x13.nfconfig.v1.PlmnIdR\x04plmn\x128\n" +
+	"\vplmn_snssai\x18\x05 \x03(\v2\x17.nfconfig.v1.PlmnSnssaiR\n" +
+	"plmnSnssai\x12G\n" +
+	"\x0faccess_mobility\x18\x06 \x03(\v2\x1e.nfconfig.v1.AccessAndMobilityR\x0eaccessMobility\x12M\n" +
+	"\x12session_management\x18\a \x03(\v2\x1e.nfconfig.v1.SessionManagementR\x11sessionManagement\x12A\n" +
+	"\x0epolicy_control\x18\b \x03(\v2\x1a.nfconfig.v1.PolicyControlR\rpolicyControl\x12&\n" +
+	"\x03qos\x18\t \x03(\v2\x14.nfconfig.v1.ImsiQosR\x03qos\",\n" +
+	"\x06PlmnId\x12\x10\n" +
+	"\x03mcc\x18\x01 \x01(\tR\x03mcc\x12\x10\n" +
+	"\x03mnc\x18\x02 \x01(\tR\x03mnc\"6\n" +
+	"\x06Snssai\x12\x10\n" +
+	"\x03sst\x18\x01 \x01(\rR\x03sst\x12\x13\n" +
+	"\x02sd\x18\x02 \x01(\tH\x00R\x02sd\x88\x01\x01B\x05\n" +
+	"\x03_sd\"p\n" +
+	"\n" +
+	"PlmnSnssai\x12,\n" +
+	"\aplmn_id\x18\x01 \x01(\v2\x13.nfconfig.v1.PlmnIdR\x06plmnId\x124\n" +
+	"\vsnssai_list\x18\x02 \x03(\v2\x13.nfconfig.v1.SnssaiR\n" +
+	"snssaiList\"\x82\x01\n" +
+	"\x11AccessAndMobility\x12,\n" +
+	"\aplmn_id\x18\x01 \x01(\v2\x13.nfconfig.v1.PlmnIdR\x06plmnId\x12+\n" +
+	"\x06snssai\x18\x02 \x01(\v2\x13.nfconfig.v1.SnssaiR\x06snssai\x12\x12\n" +
+	"\x04tacs\x18\x03 \x03(\tR\x04tacs\"\xa2\x01\n" +
+	"\bIpDomain\x12\x19\n" +
+	"\bdnn_name\x18\x01 \x01(\tR\adnnName\x12\x19\n" +
+	"\bdns_ipv4\x18\x02 \x01(\tR\adnsIpv4\x12\"\n" +
+	"\n" +
+	"pcscf_ipv4\x18\x03 \x01(\tH\x00R\tpcscfIpv4\x88\x01\x01\x12\x1b\n" +
+	"\tue_subnet\x18\x04 \x01(\tR\bueSubnet\x12\x10\n" +
+	"\x03mtu\x18\x05 \x01(\x05R\x03mtuB\r\n" +
+	"\v_pcscf_ipv4\"C\n" +
+	"\x03Upf\x12\x1a\n" +
+	"\bhostname\x18\x01 \x01(\tR\bhostname\x12\x17\n" +
+	"\x04port\x18\x02 \x01(\x05H\x00R\x04port\x88\x01\x01B\a\n" +
+	"\x05_port\"\x82\x02\n" +
+	"\x11SessionManagement\x12\x1d\n" +
+	"\n" +
+	"slice_name\x18\x01 \x01(\tR\tsliceName\x12,\n" +
+	"\aplmn_id\x18\x02 \x01(\v2\x13.nfconfig.v1.PlmnIdR\x06plmnId\x12+\n" +
+	"\x06snssai\x18\x03 \x01(\v2\x13.nfconfig.v1.SnssaiR\x06snssai\x122\n" +
+	"\tip_domain\x18\x04 \x03(\v2\x15.nfconfig.v1.IpDomainR\bipDomain\x12\"\n" +
+	"\x03upf\x18\x05 \x01(\v2\x10.nfconfig.v1.UpfR\x03upf\x12\x1b\n" +
+	"\tgnb_names\x18\x06 \x03(\tR\bgnbNames\"a\n" +
+	"\aPccFlow\x12 \n" +
+	"\vdescription\x18\x01 \x01(\tR\vdescription\x12\x1c\n" +
+	"\tdirection\x18\x02 \x01(\tR\tdirection\x12\x16\n" +
+	"\x06status\x18\x03 \x01(\tR\x06status\"p\n" +
+	"\x03Arp\x12%\n" +
+	"\x0epriority_level\x18\x01 \x01(\x05R\rpriorityLevel\x12\x1f\n" +
+	"\vpreempt_cap\x18\x02 \x01(\tR\n" +
+	"preemptCap\x12!\n" +
+	"\fpreempt_vuln\x18\x03 \x01(\tR\vpreemptVuln\"\xa3\x01\n" +
+	"\x06PccQos\x12\x17\n" +
+	"\afive_qi\x18\x01 \x01(\x05R\x06fiveQi\x12\x1f\n" +
+	"\tmax_br_ul\x18\x02 \x01(\tH\x00R\amaxBrUl\x88\x01\x01\x12\x1f\n" +
+	"\tmax_br_dl\x18\x03 \x01(\tH\x01R\amaxBrDl\x88\x01\x01\x12\"\n" +
+	"\x03arp\x18\x04 \x01(\v2\x10.nfconfig.v1.ArpR\x03arpB\f\n" +
+	"\n" +
+	"_max_br_ulB\f\n" +
+	"\n" +
+	"_max_br_dl\"\x95\x01\n" +
+	"\aPccRule\x12\x17\n" +
+	"\arule_id\x18\x01 \x01(\tR\x06ruleId\x12*\n" +
+	"\x05flows\x18\x02 \x03(\v2\x14.nfconfig.v1.PccFlowR\x05flows\x12%\n" +
+	"\x03qos\x18\x03 \x01(\v2\x13.nfconfig.v1.PccQosR\x03qos\x12\x1e\n" +
+	"\n" +
+	"precedence\x18\x04 \x01(\x05R\n" +
+	"precedence\"\xb1\x01\n" +
+	"\rPolicyControl\x12,\n" +
+	"\aplmn_id\x18\x01 \x01(\v2\x13.nfconfig.v1.PlmnIdR\x06plmnId\x12+\n" +
+	"\x06snssai\x18\x02 \x01(\v2\x13.nfconfig.v1.SnssaiR\x06snssai\x12\x12\n" +
+	"\x04dnns\x18\x03 \x03(\tR\x04dnns\x121\n" +
+	"\tpcc_rules\x18\x04 \x03(\v2\x14.nfconfig.v1.PccRuleR\bpccRules\"\x92\x01\n" +
+	"\aImsiQos\x12\x1d\n" +
+	"\n" +
+	"mbr_uplink\x18\x01 \x01(\tR\tmbrUplink\x12!\n" +
+	"\fmbr_downlink\x18\x02 \x01(\tR\vmbrDownlink\x12\x17\n" +
+	"\afive_qi\x18\x03 \x01(\x05R\x06fiveQi\x12,\n" +
+	"\x12arp_priority_level\x18\x04 \x01(\x05R\x10arpPriorityLevel2\x8e\x01\n" +
+	"\bNfConfig\x12>\n" +
+	"\x03Get\x12\x1a.nfconfig.v1.ConfigRequest\x1a\x1b.nfconfig.v1.ConfigResponse\x12B\n" +
+	"\x05Watch\x12\x1a.nfconfig.v1.ConfigRequest\x1a\x1b.nfconfig.v1.ConfigResponse0\x01B@Z>github.com/omec-project/webconsole/backend/nfconfig/nfconfigpbb\x06proto3"
+
+var (
+	file_nfconfig_proto_rawDescOnce sync.Once
+	file_nfconfig_proto_rawDescData []byte
+)
+
+func file_nfconfig_proto_rawDescGZIP() []byte {
+	file_nfconfig_proto_rawDescOnce.Do(func() {
+		file_nfconfig_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_nfconfig_proto_rawDesc), len(file_nfconfig_proto_rawDesc)))
+	})
+	return file_nfconfig_proto_rawDescData
+}
+
+var file_nfconfig_proto_msgTypes = make([]protoimpl.MessageInfo, 15)
+var file_nfconfig_proto_goTypes = []any{
+	(*ConfigRequest)(nil),     // 0: nfconfig.v1.ConfigRequest
+	(*ConfigResponse)(nil),    // 1: nfconfig.v1.ConfigResponse
+	(*PlmnId)(nil),            // 2: nfconfig.v1.PlmnId
+	(*Snssai)(nil),            // 3: nfconfig.v1.Snssai
+	(*PlmnSnssai)(nil),        // 4: nfconfig.v1.PlmnSnssai
+	(*AccessAndMobility)(nil), // 5: nfconfig.v1.AccessAndMobility
+	(*IpDomain)(nil),          // 6: nfconfig.v1.IpDomain
+	(*Upf)(nil),               // 7: nfconfig.v1.Upf
+	(*SessionManagement)(nil), // 8: nfconfig.v1.SessionManagement
+	(*PccFlow)(nil),           // 9: nfconfig.v1.PccFlow
+	(*Arp)(nil),               // 10: nfconfig.v1.Arp
+	(*PccQos)(nil),            // 11: nfconfig.v1.PccQos
+	(*PccRule)(nil),           // 12: nfconfig.v1.PccRule
+	(*PolicyControl)(nil),     // 13: nfconfig.v1.PolicyControl
+	(*ImsiQos)(nil),           // 14: nfconfig.v1.ImsiQos
+}
+var file_nfconfig_proto_depIdxs = []int32{
+	2,  // 0: nfconfig.v1.ConfigResponse.plmn:type_name -> nfconfig.v1.PlmnId
+	4,  // 1: nfconfig.v1.ConfigResponse.plmn_snssai:type_name -> nfconfig.v1.PlmnSnssai
+	5,  // 2: nfconfig.v1.ConfigResponse.access_mobility:type_name -> nfconfig.v1.AccessAndMobility
+	8,  // 3: nfconfig.v1.ConfigResponse.session_management:type_name -> nfconfig.v1.SessionManagement
+	13, // 4: nfconfig.v1.ConfigResponse.policy_control:type_name -> nfconfig.v1.PolicyControl
+	14, // 5: nfconfig.v1.ConfigResponse.qos:type_name -> nfconfig.v1.ImsiQos
+	2,  // 6: nfconfig.v1.PlmnSnssai.plmn_id:type_name -> nfconfig.v1.PlmnId
+	3,  // 7: nfconfig.v1.PlmnSnssai.snssai_list:type_name -> nfconfig.v1.Snssai
+	2,  // 8: nfconfig.v1.AccessAndMobility.plmn_id:type_name -> nfconfig.v1.PlmnId
+	3,  // 9: nfconfig.v1.AccessAndMobility.snssai:type_name -> nfconfig.v1.Snssai
+	2,  // 10: nfconfig.v1.SessionManagement.plmn_id:type_name -> nfconfig.v1.PlmnId
+	3,  // 11: nfconfig.v1.SessionManagement.snssai:type_name -> nfconfig.v1.Snssai
+	6,  // 12: nfconfig.v1.SessionManagement.ip_domain:type_name -> nfconfig.v1.IpDomain
+	7,  // 13: nfconfig.v1.SessionManagement.upf:type_name -> nfconfig.v1.Upf
+	10, // 14: nfconfig.v1.PccQos.arp:type_name -> nfconfig.v1.Arp
+	9,  // 15: nfconfig.v1.PccRule.flows:type_name -> nfconfig.v1.PccFlow
+	11, // 16: nfconfig.v1.PccRule.qos:type_name -> nfconfig.v1.PccQos
+	2,  // 17: nfconfig.v1.PolicyControl.plmn_id:type_name -> nfconfig.v1.PlmnId
+	3,  // 18: nfconfig.v1.PolicyControl.snssai:type_name -> nfconfig.v1.Snssai
+	12, // 19: nfconfig.v1.PolicyControl.pcc_rules:type_name -> nfconfig.v1.PccRule
+	0,  // 20: nfconfig.v1.NfConfig.Get:input_type -> nfconfig.v1.ConfigRequest
+	0,  // 21: nfconfig.v1.NfConfig.Watch:input_type -> nfconfig.v1.ConfigRequest
+	1,  // 22: nfconfig.v1.NfConfig.Get:output_type -> nfconfig.v1.ConfigResponse
+	1,  // 23: nfconfig.v1.NfConfig.Watch:output_type -> nfconfig.v1.ConfigResponse
+	22, // [22:24] is the sub-list for method output_type
+	20, // [20:22] is the sub-list for method input_type
+	20, // [20:20] is the sub-list for extension type_name
+	20, // [20:20] is the sub-list for extension extendee
+	0,  // [0:20] is the sub-list for field type_name
+}
+
+func init() { file_nfconfig_proto_init() }
+func file_nfconfig_proto_init() {
+	if File_nfconfig_proto != nil {
+		return
+	}
+	file_nfconfig_proto_msgTypes[0].OneofWrappers = []any{}
+	file_nfconfig_proto_msgTypes[3].OneofWrappers = []any{}
+	file_nfconfig_proto_msgTypes[6].OneofWrappers = []any{}
+	file_nfconfig_proto_msgTypes[7].OneofWrappers = []any{}
+	file_nfconfig_proto_msgTypes[11].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_nfconfig_proto_rawDesc), len(file_nfconfig_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   15,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_nfconfig_proto_goTypes,
+		DependencyIndexes: file_nfconfig_proto_depIdxs,
+		MessageInfos:      file_nfconfig_proto_msgTypes,
+	}.Build()
+	File_nfconfig_proto = out.File
+	file_nfconfig_proto_goTypes = nil
+	file_nfconfig_proto_depIdxs = nil
+}
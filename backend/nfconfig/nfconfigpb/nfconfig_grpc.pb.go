@@ -0,0 +1,184 @@
+// SPDX-FileCopyrightText: 2026 Canonical Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+// gRPC interface of the NF configuration service, served alongside the REST
+// API when the nfconfig-grpc section of the configuration enables it.
+//
+// The messages mirror the JSON views of the REST endpoints. Enumerations, e.g.
+// the direction of a PCC flow, hold the same strings as the REST API.
+
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: nfconfig.proto
+
+package nfconfigpb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	NfConfig_Get_FullMethodName   = "/nfconfig.v1.NfConfig/Get"
+	NfConfig_Watch_FullMethodName = "/nfconfig.v1.NfConfig/Watch"
+)
+
+// NfConfigClient is the client API for NfConfig service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type NfConfigClient interface {
+	// Get returns the current configuration of a resource. It fails with
+	// INVALID_ARGUMENT on invalid requests and NOT_FOUND for an IMSI without
+	// QoS.
+	Get(ctx context.Context, in *ConfigRequest, opts ...grpc.CallOption) (*ConfigResponse, error)
+	// Watch sends the configuration of a resource, right away or once its ETag
+	// differs from etag, and then on every change.
+	Watch(ctx context.Context, in *ConfigRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ConfigResponse], error)
+}
+
+type nfConfigClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewNfConfigClient(cc grpc.ClientConnInterface) NfConfigClient {
+	return &nfConfigClient{cc}
+}
+
+func (c *nfConfigClient) Get(ctx context.Context, in *ConfigRequest, opts ...grpc.CallOption) (*ConfigResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ConfigResponse)
+	err := c.cc.Invoke(ctx, NfConfig_Get_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nfConfigClient) Watch(ctx context.Context, in *ConfigRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ConfigResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &NfConfig_ServiceDesc.Streams[0], NfConfig_Watch_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ConfigRequest, ConfigResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type NfConfig_WatchClient = grpc.ServerStreamingClient[ConfigResponse]
+
+// NfConfigServer is the server API for NfConfig service.
+// All implementations must embed UnimplementedNfConfigServer
+// for forward compatibility.
+type NfConfigServer interface {
+	// Get returns the current configuration of a resource. It fails with
+	// INVALID_ARGUMENT on invalid requests and NOT_FOUND for an IMSI without
+	// QoS.
+	Get(context.Context, *ConfigRequest) (*ConfigResponse, error)
+	// Watch sends the configuration of a resource, right away or once its ETag
+	// differs from etag, and then on every change.
+	Watch(*ConfigRequest, grpc.ServerStreamingServer[ConfigResponse]) error
+	mustEmbedUnimplementedNfConfigServer()
+}
+
+// UnimplementedNfConfigServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedNfConfigServer struct{}
+
+func (UnimplementedNfConfigServer) Get(context.Context, *ConfigRequest) (*ConfigResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Get not implemented")
+}
+func (UnimplementedNfConfigServer) Watch(*ConfigRequest, grpc.ServerStreamingServer[ConfigResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method Watch not implemented")
+}
+func (UnimplementedNfConfigServer) mustEmbedUnimplementedNfConfigServer() {}
+func (UnimplementedNfConfigServer) testEmbeddedByValue()                  {}
+
+// UnsafeNfConfigServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to NfConfigServer will
+// result in compilation errors.
+type UnsafeNfConfigServer interface {
+	mustEmbedUnimplementedNfConfigServer()
+}
+
+func RegisterNfConfigServer(s grpc.ServiceRegistrar, srv NfConfigServer) {
+	// If the following call pancis, it indicates UnimplementedNfConfigServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&NfConfig_ServiceDesc, srv)
+}
+
+func _NfConfig_Get_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ConfigRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NfConfigServer).Get(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NfConfig_Get_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NfConfigServer).Get(ctx, req.(*ConfigRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NfConfig_Watch_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ConfigRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(NfConfigServer).Watch(m, &grpc.GenericServerStream[ConfigRequest, ConfigResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type NfConfig_WatchServer = grpc.ServerStreamingServer[ConfigResponse]
+
+// NfConfig_ServiceDesc is the grpc.ServiceDesc for NfConfig service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var NfConfig_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "nfconfig.v1.NfConfig",
+	HandlerType: (*NfConfigServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Get",
+			Handler:    _NfConfig_Get_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Watch",
+			Handler:       _NfConfig_Watch_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "nfconfig.proto",
+}
@@ -0,0 +1,371 @@
+// SPDX-FileCopyrightText: 2026 Canonical Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package nfconfig
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/omec-project/webconsole/backend/factory"
+	"github.com/omec-project/webconsole/backend/logger"
+	"github.com/omec-project/webconsole/configmodels"
+	"github.com/omec-project/webconsole/dbadapter"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+const subscriptionDataColl = "webconsoleData.snapshots.nfConfigSubscriptionData"
+
+var (
+	notificationTimeout          = 5 * time.Second
+	notificationRetryInterval    = 1 * time.Second
+	notificationMaxRetryInterval = 30 * time.Second
+	// an undelivered notification is dropped after this time, and the
+	// subscription waits for the next change
+	notificationMaxRetryAge = 10 * time.Minute
+)
+
+// callbackAllowList holds the schemes and hosts the callback URIs may use. A
+// nil allow-list refuses every callback URI.
+type callbackAllowList struct {
+	schemes []string
+	// hostnames, and domains of the '*.' patterns with their leading dot
+	hostnames []string
+	domains   []string
+	prefixes  []netip.Prefix
+}
+
+func newCallbackAllowList(subscriptionsConfig *factory.NfConfigSubscriptions) (*callbackAllowList, error) {
+	if subscriptionsConfig == nil || len(subscriptionsConfig.CallbackHosts) == 0 {
+		return nil, nil
+	}
+	allowList := &callbackAllowList{schemes: []string{"https"}}
+	if len(subscriptionsConfig.CallbackSchemes) > 0 {
+		allowList.schemes = nil
+		for _, scheme := range subscriptionsConfig.CallbackSchemes {
+			scheme = strings.ToLower(strings.TrimSpace(scheme))
+			if scheme != "http" && scheme != "https" {
+				return nil, fmt.Errorf("invalid callback scheme '%s'. Scheme must be http or https", scheme)
+			}
+			allowList.schemes = append(allowList.schemes, scheme)
+		}
+	}
+	for _, host := range subscriptionsConfig.CallbackHosts {
+		host = strings.ToLower(strings.TrimSpace(host))
+		if prefix, err := netip.ParsePrefix(host); err == nil {
+			allowList.prefixes = append(allowList.prefixes, prefix.Masked())
+			continue
+		}
+		if addr, err := netip.ParseAddr(host); err == nil {
+			allowList.prefixes = append(allowList.prefixes, netip.PrefixFrom(addr, addr.BitLen()))
+			continue
+		}
+		if domain, found := strings.CutPrefix(host, "*"); found {
+			if !strings.HasPrefix(domain, ".") || len(domain) < 2 || strings.Contains(domain, "*") {
+				return nil, fmt.Errorf("invalid callback host '%s'", host)
+			}
+			allowList.domains = append(allowList.domains, domain)
+			continue
+		}
+		if host == "" || strings.ContainsAny(host, "*/:") {
+			return nil, fmt.Errorf("invalid callback host '%s'", host)
+		}
+		allowList.hostnames = append(allowList.hostnames, host)
+	}
+	return allowList, nil
+}
+
+// allows reports whether the scheme and the host of the callback URI are in
+// the allow-list
+func (a *callbackAllowList) allows(callbackUri *url.URL) bool {
+	if a == nil || !slices.Contains(a.schemes, strings.ToLower(callbackUri.Scheme)) {
+		return false
+	}
+	host := strings.ToLower(callbackUri.Hostname())
+	if addr, err := netip.ParseAddr(host); err == nil {
+		return slices.ContainsFunc(a.prefixes, func(prefix netip.Prefix) bool { return prefix.Contains(addr.Unmap()) })
+	}
+	if slices.Contains(a.hostnames, host) {
+		return true
+	}
+	return slices.ContainsFunc(a.domains, func(domain string) bool {
+		return strings.HasSuffix(host, domain) && len(host) > len(domain)
+	})
+}
+
+// subscription asks for a notification on the callback URI whenever one of
+// the resources changes
+type subscription struct {
+	Id          string           `json:"id"`
+	CallbackUri string           `json:"callbackUri"`
+	Resources   []configResource `json:"resources"`
+	// certificate subject of the NF that created the subscription, the only
+	// one that may read or delete it. It is empty without mTLS.
+	Owner string `json:"owner,omitempty"`
+}
+
+type resourceVersion struct {
+	Resource configResource `json:"resource"`
+	Version  uint64         `json:"version"`
+	ETag     string         `json:"etag"`
+}
+
+// changeNotification holds the current version of all the resources of the
+// subscription, so that a notification replaces any undelivered one
+type changeNotification struct {
+	SubscriptionId string            `json:"subscriptionId"`
+	Resources      []resourceVersion `json:"resources"`
+}
+
+type subscriber struct {
+	subscription subscription
+	pending      chan changeNotification
+	cancel       context.CancelFunc
+}
+
+// changeNotifier delivers the change notifications of the subscriptions, each
+// from its own worker retrying with an exponential backoff until the delivery
+// succeeds, the retries reach their maximum age or the subscription is deleted
+type changeNotifier struct {
+	mutex sync.Mutex
+	ctx   context.Context
+	// delivery goroutines, waited for on shutdown
+	workers     *sync.WaitGroup
+	subscribers map[string]*subscriber
+	client      *http.Client
+	allowList   *callbackAllowList
+}
+
+func newChangeNotifier(allowList *callbackAllowList) *changeNotifier {
+	return &changeNotifier{
+		subscribers: map[string]*subscriber{},
+		client: &http.Client{
+			Timeout: notificationTimeout,
+			// a redirect could send the notification outside of the allow-list
+			CheckRedirect: func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse },
+		},
+		allowList: allowList,
+	}
+}
+
+func isConfigResource(resource configResource) bool {
+	_, exists := emptyInMemoryConfig.resources()[resource]
+	return exists
+}
+
+// errCallbackNotAllowed is returned for a callback URI outside of the
+// allow-list
+var errCallbackNotAllowed = errors.New("callback URI not allowed")
+
+func (cn *changeNotifier) validateSubscription(s subscription) error {
+	callbackUri, err := url.Parse(s.CallbackUri)
+	if err != nil || (callbackUri.Scheme != "http" && callbackUri.Scheme != "https") || callbackUri.Host == "" {
+		return fmt.Errorf("invalid callback URI '%s'. It must be an absolute HTTP or HTTPS URI", s.CallbackUri)
+	}
+	if !cn.allowList.allows(callbackUri) {
+		return fmt.Errorf("%w: the scheme and host of '%s' are not in the callback allow-list", errCallbackNotAllowed, s.CallbackUri)
+	}
+	if len(s.Resources) == 0 {
+		return fmt.Errorf("at least one resource is required")
+	}
+	for _, resource := range s.Resources {
+		if !isConfigResource(resource) {
+			return fmt.Errorf("unknown resource '%s'", resource)
+		}
+	}
+	return nil
+}
+
+// start loads the persisted subscriptions and notifies them of the current
+// versions, which restart from 1 with the server. The deliveries run in
+// workers until ctx ends.
+func (cn *changeNotifier) start(ctx context.Context, snapshot *inMemoryConfig, workers *sync.WaitGroup) error {
+	if cn == nil {
+		return nil
+	}
+	cn.mutex.Lock()
+	defer cn.mutex.Unlock()
+	cn.ctx = ctx
+	cn.workers = workers
+	rawSubscriptions, err := dbadapter.CommonDBClient.RestfulAPIGetMany(subscriptionDataColl, bson.M{})
+	if err != nil {
+		return fmt.Errorf("failed to fetch subscriptions: %w", err)
+	}
+	for _, rawSubscription := range rawSubscriptions {
+		var s subscription
+		if err = json.Unmarshal(configmodels.MapToByte(rawSubscription), &s); err != nil || s.Id == "" || cn.validateSubscription(s) != nil {
+			logger.NfConfigLog.Warnf("Ignoring invalid subscription: %+v", rawSubscription)
+			continue
+		}
+		if _, exists := cn.subscribers[s.Id]; !exists {
+			cn.subscribers[s.Id] = &subscriber{subscription: s}
+		}
+	}
+	for _, sub := range cn.subscribers {
+		cn.startWorker(sub)
+		sub.enqueue(buildChangeNotification(sub.subscription, snapshot))
+	}
+	logger.NfConfigLog.Infof("Loaded %d NF configuration subscriptions", len(cn.subscribers))
+	return nil
+}
+
+func (cn *changeNotifier) startWorker(sub *subscriber) {
+	workerCtx, cancel := context.WithCancel(cn.ctx)
+	sub.pending = make(chan changeNotification, 1)
+	sub.cancel = cancel
+	cn.workers.Go(func() { cn.deliver(workerCtx, sub) })
+}
+
+// enqueue replaces the undelivered notification
+func (sub *subscriber) enqueue(notification changeNotification) {
+	if sub.pending == nil {
+		return
+	}
+	select {
+	case <-sub.pending:
+	default:
+	}
+	sub.pending <- notification
+}
+
+func (cn *changeNotifier) subscribe(s subscription) error {
+	if err := dbadapter.CommonDBClient.RestfulAPIPostMany(subscriptionDataColl, bson.M{"id": s.Id}, []any{configmodels.ToBsonM(s)}); err != nil {
+		return err
+	}
+	cn.mutex.Lock()
+	defer cn.mutex.Unlock()
+	sub := &subscriber{subscription: s}
+	cn.subscribers[s.Id] = sub
+	if cn.ctx != nil {
+		cn.startWorker(sub)
+	}
+	return nil
+}
+
+func (cn *changeNotifier) getSubscription(id string) (subscription, bool) {
+	cn.mutex.Lock()
+	defer cn.mutex.Unlock()
+	sub, exists := cn.subscribers[id]
+	if !exists {
+		return subscription{}, false
+	}
+	return sub.subscription, true
+}
+
+// unsubscribe returns false when the subscription does not exist
+func (cn *changeNotifier) unsubscribe(id string) (bool, error) {
+	if _, exists := cn.getSubscription(id); !exists {
+		return false, nil
+	}
+	if err := dbadapter.CommonDBClient.RestfulAPIDeleteOne(subscriptionDataColl, bson.M{"id": id}); err != nil {
+		return true, err
+	}
+	cn.mutex.Lock()
+	defer cn.mutex.Unlock()
+	if sub, exists := cn.subscribers[id]; exists {
+		if sub.cancel != nil {
+			sub.cancel()
+		}
+		delete(cn.subscribers, id)
+	}
+	return true, nil
+}
+
+// notify queues a notification for the subscriptions to a resource that
+// changed between the snapshots
+func (cn *changeNotifier) notify(previous *inMemoryConfig, current *inMemoryConfig) {
+	if cn == nil {
+		return
+	}
+	cn.mutex.Lock()
+	defer cn.mutex.Unlock()
+	for _, sub := range cn.subscribers {
+		changed := slices.ContainsFunc(sub.subscription.Resources, func(resource configResource) bool {
+			return previous.resourceETags[resource] != current.resourceETags[resource]
+		})
+		if changed {
+			sub.enqueue(buildChangeNotification(sub.subscription, current))
+		}
+	}
+}
+
+func buildChangeNotification(s subscription, snapshot *inMemoryConfig) changeNotification {
+	notification := changeNotification{SubscriptionId: s.Id}
+	for _, resource := range s.Resources {
+		notification.Resources = append(notification.Resources, resourceVersion{
+			Resource: resource,
+			Version:  snapshot.resourceVersions[resource],
+			ETag:     snapshot.resourceETags[resource],
+		})
+	}
+	return notification
+}
+
+func (cn *changeNotifier) deliver(ctx context.Context, sub *subscriber) {
+	for {
+		var notification changeNotification
+		select {
+		case <-ctx.Done():
+			return
+		case notification = <-sub.pending:
+		}
+		interval := notificationRetryInterval
+		// a newer notification does not extend the retries, so that frequent
+		// changes do not retry an unreachable callback forever
+		giveUp := time.Now().Add(notificationMaxRetryAge)
+		for {
+			err := cn.post(ctx, sub.subscription.CallbackUri, notification)
+			if err == nil {
+				logger.NfConfigLog.Debugf("Delivered change notification to subscription %s", sub.subscription.Id)
+				break
+			}
+			if time.Now().After(giveUp) {
+				logger.NfConfigLog.Errorf("Dropping change notification to subscription %s after retrying for %s, waiting for the next change: %v", sub.subscription.Id, notificationMaxRetryAge, err)
+				break
+			}
+			logger.NfConfigLog.Warnf("Change notification to subscription %s failed, retrying in %s: %v", sub.subscription.Id, interval, err)
+			select {
+			case <-ctx.Done():
+				return
+			case newer := <-sub.pending:
+				notification = newer
+				interval = notificationRetryInterval
+			case <-time.After(interval):
+				interval = min(2*interval, notificationMaxRetryInterval)
+			}
+		}
+	}
+}
+
+func (cn *changeNotifier) post(ctx context.Context, callbackUri string, notification changeNotification) error {
+	body, err := json.Marshal(notification)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, callbackUri, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := cn.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("callback returned status %d", resp.StatusCode)
+	}
+	return nil
+}
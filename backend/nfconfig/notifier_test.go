@@ -0,0 +1,488 @@
+// SPDX-FileCopyrightText: 2026 Canonical Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+package nfconfig
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/omec-project/openapi/v2/nfConfigApi"
+	"github.com/omec-project/webconsole/backend/factory"
+	"github.com/omec-project/webconsole/dbadapter"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+type SubscriptionMockDBClient struct {
+	dbadapter.DBInterface
+	mutex         sync.Mutex
+	subscriptions []map[string]any
+}
+
+func (m *SubscriptionMockDBClient) RestfulAPIGetMany(coll string, filter bson.M) ([]map[string]any, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	if coll != subscriptionDataColl {
+		return nil, nil
+	}
+	return append([]map[string]any{}, m.subscriptions...), nil
+}
+
+func (m *SubscriptionMockDBClient) RestfulAPIPostMany(coll string, filter bson.M, postDataArray []any) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	for _, postData := range postDataArray {
+		m.subscriptions = append(m.subscriptions, postData.(bson.M))
+	}
+	return nil
+}
+
+func (m *SubscriptionMockDBClient) RestfulAPIDeleteOne(coll string, filter bson.M) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	for i, s := range m.subscriptions {
+		if s["id"] == filter["id"] {
+			m.subscriptions = append(m.subscriptions[:i], m.subscriptions[i+1:]...)
+			break
+		}
+	}
+	return nil
+}
+
+// notificationReceiver is an NF receiving change notifications. It fails the
+// first failures requests.
+type notificationReceiver struct {
+	server   *httptest.Server
+	mutex    sync.Mutex
+	failures int
+	requests int
+	received chan changeNotification
+}
+
+func newNotificationReceiver(t *testing.T, failures int) *notificationReceiver {
+	r := &notificationReceiver{failures: failures, received: make(chan changeNotification, 10)}
+	r.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		r.mutex.Lock()
+		defer r.mutex.Unlock()
+		r.requests++
+		if r.failures > 0 {
+			r.failures--
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		var notification changeNotification
+		if err := json.NewDecoder(req.Body).Decode(&notification); err != nil {
+			t.Errorf("failed to decode notification: %v", err)
+		}
+		r.received <- notification
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	t.Cleanup(r.server.Close)
+	return r
+}
+
+func (r *notificationReceiver) requestCount() int {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	return r.requests
+}
+
+func (r *notificationReceiver) next(t *testing.T) changeNotification {
+	t.Helper()
+	select {
+	case notification := <-r.received:
+		return notification
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for a notification")
+		return changeNotification{}
+	}
+}
+
+func (r *notificationReceiver) expectNone(t *testing.T) {
+	t.Helper()
+	select {
+	case notification := <-r.received:
+		t.Errorf("expected no notification, got %+v", notification)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+func versionOf(notification changeNotification, resource configResource) uint64 {
+	for _, rv := range notification.Resources {
+		if rv.Resource == resource {
+			return rv.Version
+		}
+	}
+	return 0
+}
+
+// newTestCallbackAllowList allows the SMF and the local notification receivers
+func newTestCallbackAllowList(t *testing.T) *callbackAllowList {
+	t.Helper()
+	allowList, err := newCallbackAllowList(&factory.NfConfigSubscriptions{
+		CallbackSchemes: []string{"http", "https"},
+		CallbackHosts:   []string{"smf", "127.0.0.0/8"},
+	})
+	if err != nil {
+		t.Fatalf("failed to build the callback allow-list: %v", err)
+	}
+	return allowList
+}
+
+func newSubscriptionTestServer(t *testing.T) (*NFConfigServer, *SubscriptionMockDBClient) {
+	t.Helper()
+	originalDBClient := dbadapter.CommonDBClient
+	t.Cleanup(func() { dbadapter.CommonDBClient = originalDBClient })
+	mockDB := &SubscriptionMockDBClient{}
+	dbadapter.CommonDBClient = mockDB
+	originalRetryInterval := notificationRetryInterval
+	t.Cleanup(func() { notificationRetryInterval = originalRetryInterval })
+	notificationRetryInterval = 10 * time.Millisecond
+	n := &NFConfigServer{
+		Router:   gin.New(),
+		watcher:  newConfigWatcher(),
+		notifier: newChangeNotifier(newTestCallbackAllowList(t)),
+	}
+	storeSnapshot(n, &inMemoryConfig{plmn: []nfConfigApi.PlmnId{{Mcc: "001", Mnc: "01"}}})
+	n.setupRoutes()
+	return n, mockDB
+}
+
+func subscribe(t *testing.T, n *NFConfigServer, body string) (int, subscription) {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodPost, "/nfconfig/subscriptions", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	n.Router.ServeHTTP(w, req)
+	var s subscription
+	if w.Code == http.StatusCreated {
+		if err := json.Unmarshal(w.Body.Bytes(), &s); err != nil {
+			t.Fatalf("failed to unmarshal subscription: %v", err)
+		}
+	}
+	return w.Code, s
+}
+
+func syncSnapshot(n *NFConfigServer, config *inMemoryConfig) {
+	previous := n.snapshot()
+	if config.setVersions(previous) {
+		n.inMemoryConfig.Store(config)
+		n.notifier.notify(previous, config)
+		return
+	}
+	n.inMemoryConfig.Store(config)
+}
+
+func TestPostSubscription_Validation(t *testing.T) {
+	tests := []struct {
+		name         string
+		body         string
+		expectedCode int
+	}{
+		{
+			name:         "valid subscription",
+			body:         `{"callbackUri": "http://smf:8080/notify", "resources": ["session-management", "policy-control", "session-management"]}`,
+			expectedCode: http.StatusCreated,
+		},
+		{
+			name:         "relative callback URI",
+			body:         `{"callbackUri": "/notify", "resources": ["plmn"]}`,
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "unsupported callback scheme",
+			body:         `{"callbackUri": "ftp://smf/notify", "resources": ["plmn"]}`,
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "callback host not allowed",
+			body:         `{"callbackUri": "http://169.254.169.254/latest/meta-data", "resources": ["plmn"]}`,
+			expectedCode: http.StatusForbidden,
+		},
+		{
+			name:         "no resource",
+			body:         `{"callbackUri": "http://smf:8080/notify", "resources": []}`,
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "unknown resource",
+			body:         `{"callbackUri": "http://smf:8080/notify", "resources": ["subscribers"]}`,
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "invalid JSON",
+			body:         `{"callbackUri": `,
+			expectedCode: http.StatusBadRequest,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			n, mockDB := newSubscriptionTestServer(t)
+			code, s := subscribe(t, n, tc.body)
+			if code != tc.expectedCode {
+				t.Fatalf("expected status %d, got %d", tc.expectedCode, code)
+			}
+			if code != http.StatusCreated {
+				if len(mockDB.subscriptions) != 0 {
+					t.Errorf("expected no stored subscription, got %+v", mockDB.subscriptions)
+				}
+				return
+			}
+			if s.Id == "" || len(s.Resources) != 2 {
+				t.Errorf("expected an ID and two distinct resources, got %+v", s)
+			}
+			if len(mockDB.subscriptions) != 1 || mockDB.subscriptions[0]["id"] != s.Id {
+				t.Errorf("expected subscription %s to be stored, got %+v", s.Id, mockDB.subscriptions)
+			}
+		})
+	}
+}
+
+func TestSubscriptionNotifications(t *testing.T) {
+	n, mockDB := newSubscriptionTestServer(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	if err := n.notifier.start(ctx, n.snapshot(), &n.workers); err != nil {
+		t.Fatalf("failed to start notifier: %v", err)
+	}
+	plmnReceiver := newNotificationReceiver(t, 0)
+	smReceiver := newNotificationReceiver(t, 2)
+	_, plmnSubscription := subscribe(t, n, `{"callbackUri": "`+plmnReceiver.server.URL+`", "resources": ["plmn"]}`)
+	_, smSubscription := subscribe(t, n, `{"callbackUri": "`+smReceiver.server.URL+`", "resources": ["session-management"]}`)
+
+	syncSnapshot(n, &inMemoryConfig{plmn: []nfConfigApi.PlmnId{{Mcc: "001", Mnc: "01"}, {Mcc: "002", Mnc: "02"}}})
+	notification := plmnReceiver.next(t)
+	if notification.SubscriptionId != plmnSubscription.Id || versionOf(notification, plmnResource) != 2 {
+		t.Errorf("expected plmn version 2 for subscription %s, got %+v", plmnSubscription.Id, notification)
+	}
+	smReceiver.expectNone(t)
+
+	syncSnapshot(n, &inMemoryConfig{
+		plmn:              []nfConfigApi.PlmnId{{Mcc: "001", Mnc: "01"}, {Mcc: "002", Mnc: "02"}},
+		sessionManagement: []nfConfigApi.SessionManagement{{SliceName: "slice1"}},
+	})
+	notification = smReceiver.next(t)
+	if notification.SubscriptionId != smSubscription.Id || versionOf(notification, sessionManagementResource) != 2 {
+		t.Errorf("expected session-management version 2 after retries, got %+v", notification)
+	}
+	plmnReceiver.expectNone(t)
+
+	req := httptest.NewRequest(http.MethodGet, "/nfconfig/subscriptions/"+smSubscription.Id, nil)
+	w := httptest.NewRecorder()
+	n.Router.ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Errorf("expected status %d, got %d", http.StatusOK, w.Code)
+	}
+	req = httptest.NewRequest(http.MethodDelete, "/nfconfig/subscriptions/"+smSubscription.Id, nil)
+	w = httptest.NewRecorder()
+	n.Router.ServeHTTP(w, req)
+	if w.Code != http.StatusNoContent {
+		t.Fatalf("expected status %d, got %d", http.StatusNoContent, w.Code)
+	}
+	if len(mockDB.subscriptions) != 1 {
+		t.Errorf("expected one stored subscription left, got %+v", mockDB.subscriptions)
+	}
+	syncSnapshot(n, &inMemoryConfig{
+		plmn:              []nfConfigApi.PlmnId{{Mcc: "001", Mnc: "01"}, {Mcc: "002", Mnc: "02"}},
+		sessionManagement: []nfConfigApi.SessionManagement{{SliceName: "slice2"}},
+	})
+	smReceiver.expectNone(t)
+	for _, method := range []string{http.MethodGet, http.MethodDelete} {
+		req = httptest.NewRequest(method, "/nfconfig/subscriptions/"+smSubscription.Id, nil)
+		w = httptest.NewRecorder()
+		n.Router.ServeHTTP(w, req)
+		if w.Code != http.StatusNotFound {
+			t.Errorf("expected status %d for %s of a deleted subscription, got %d", http.StatusNotFound, method, w.Code)
+		}
+	}
+}
+
+func TestSubscriptionNotifications_AfterRestart(t *testing.T) {
+	n, mockDB := newSubscriptionTestServer(t)
+	receiver := newNotificationReceiver(t, 0)
+	_, s := subscribe(t, n, `{"callbackUri": "`+receiver.server.URL+`", "resources": ["plmn", "qos"]}`)
+
+	restarted := &NFConfigServer{
+		Router:   gin.New(),
+		watcher:  newConfigWatcher(),
+		notifier: newChangeNotifier(newTestCallbackAllowList(t)),
+	}
+	storeSnapshot(restarted, &inMemoryConfig{plmn: []nfConfigApi.PlmnId{{Mcc: "001", Mnc: "01"}}})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	if err := restarted.notifier.start(ctx, restarted.snapshot(), &restarted.workers); err != nil {
+		t.Fatalf("failed to start notifier: %v", err)
+	}
+
+	notification := receiver.next(t)
+	if notification.SubscriptionId != s.Id || versionOf(notification, plmnResource) != 1 || versionOf(notification, imsiQosResource) != 1 {
+		t.Errorf("expected the versions of the restarted server for subscription %s, got %+v", s.Id, notification)
+	}
+	if len(mockDB.subscriptions) != 1 {
+		t.Errorf("expected the subscription to stay stored, got %+v", mockDB.subscriptions)
+	}
+}
+
+func TestSubscriptionNotifications_Redirect(t *testing.T) {
+	n, _ := newSubscriptionTestServer(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	if err := n.notifier.start(ctx, n.snapshot(), &n.workers); err != nil {
+		t.Fatalf("failed to start notifier: %v", err)
+	}
+	target := newNotificationReceiver(t, 0)
+	redirected := make(chan struct{}, 10)
+	redirect := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		redirected <- struct{}{}
+		http.Redirect(w, req, target.server.URL, http.StatusTemporaryRedirect)
+	}))
+	defer redirect.Close()
+	subscribe(t, n, `{"callbackUri": "`+redirect.URL+`", "resources": ["plmn"]}`)
+
+	syncSnapshot(n, &inMemoryConfig{plmn: []nfConfigApi.PlmnId{{Mcc: "001", Mnc: "01"}, {Mcc: "002", Mnc: "02"}}})
+	select {
+	case <-redirected:
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for a notification")
+	}
+	target.expectNone(t)
+}
+
+func TestSubscriptionNotifications_MaxRetryAge(t *testing.T) {
+	n, _ := newSubscriptionTestServer(t)
+	originalMaxRetryAge := notificationMaxRetryAge
+	defer func() { notificationMaxRetryAge = originalMaxRetryAge }()
+	notificationMaxRetryAge = 50 * time.Millisecond
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	if err := n.notifier.start(ctx, n.snapshot(), &n.workers); err != nil {
+		t.Fatalf("failed to start notifier: %v", err)
+	}
+	receiver := newNotificationReceiver(t, math.MaxInt)
+	_, s := subscribe(t, n, `{"callbackUri": "`+receiver.server.URL+`", "resources": ["plmn"]}`)
+
+	syncSnapshot(n, &inMemoryConfig{plmn: []nfConfigApi.PlmnId{{Mcc: "001", Mnc: "01"}, {Mcc: "002", Mnc: "02"}}})
+	time.Sleep(200 * time.Millisecond)
+	requests := receiver.requestCount()
+	if requests < 2 {
+		t.Errorf("expected the notification to be retried, got %d requests", requests)
+	}
+	time.Sleep(400 * time.Millisecond)
+	if after := receiver.requestCount(); after != requests {
+		t.Errorf("expected the retries to stop after %s, got %d more requests", notificationMaxRetryAge, after-requests)
+	}
+	if _, exists := n.notifier.getSubscription(s.Id); !exists {
+		t.Fatalf("expected subscription %s to be kept", s.Id)
+	}
+
+	receiver.mutex.Lock()
+	receiver.failures = 0
+	receiver.mutex.Unlock()
+	syncSnapshot(n, &inMemoryConfig{plmn: []nfConfigApi.PlmnId{{Mcc: "002", Mnc: "02"}}})
+	if notification := receiver.next(t); versionOf(notification, plmnResource) != 3 {
+		t.Errorf("expected plmn version 3 after the next change, got %+v", notification)
+	}
+}
+
+func TestCallbackAllowList(t *testing.T) {
+	allowList, err := newCallbackAllowList(&factory.NfConfigSubscriptions{
+		CallbackHosts: []string{"smf.core", "*.nf.svc", "10.0.0.0/8", "2001:db8::1"},
+	})
+	if err != nil {
+		t.Fatalf("failed to build the callback allow-list: %v", err)
+	}
+	tests := []struct {
+		callbackUri string
+		allowed     bool
+	}{
+		{callbackUri: "https://smf.core:8443/notify", allowed: true},
+		{callbackUri: "https://SMF.core/notify", allowed: true},
+		{callbackUri: "https://smf-1.nf.svc/notify", allowed: true},
+		{callbackUri: "https://nf.svc/notify", allowed: false},
+		{callbackUri: "https://evilnf.svc/notify", allowed: false},
+		{callbackUri: "https://10.1.2.3/notify", allowed: true},
+		{callbackUri: "https://[::ffff:10.1.2.3]/notify", allowed: true},
+		{callbackUri: "https://[2001:db8::1]/notify", allowed: true},
+		{callbackUri: "https://192.168.1.1/notify", allowed: false},
+		{callbackUri: "https://smf.core.attacker.com/notify", allowed: false},
+		{callbackUri: "http://smf.core/notify", allowed: false},
+	}
+	for _, tc := range tests {
+		callbackUri, err := url.Parse(tc.callbackUri)
+		if err != nil {
+			t.Fatalf("failed to parse %s: %v", tc.callbackUri, err)
+		}
+		if allowed := allowList.allows(callbackUri); allowed != tc.allowed {
+			t.Errorf("expected %s to be allowed %t, got %t", tc.callbackUri, tc.allowed, allowed)
+		}
+	}
+
+	for _, invalid := range []factory.NfConfigSubscriptions{
+		{CallbackHosts: []string{"smf"}, CallbackSchemes: []string{"ftp"}},
+		{CallbackHosts: []string{"*smf.core"}},
+		{CallbackHosts: []string{"smf.core:8080"}},
+	} {
+		if _, err = newCallbackAllowList(&invalid); err == nil {
+			t.Errorf("expected an error for %+v", invalid)
+		}
+	}
+}
+
+func TestPostSubscription_WithoutAllowList(t *testing.T) {
+	n, mockDB := newSubscriptionTestServer(t)
+	n.notifier.allowList = nil
+	if code, _ := subscribe(t, n, `{"callbackUri": "https://smf/notify", "resources": ["plmn"]}`); code != http.StatusForbidden {
+		t.Errorf("expected status %d, got %d", http.StatusForbidden, code)
+	}
+	if len(mockDB.subscriptions) != 0 {
+		t.Errorf("expected no stored subscription, got %+v", mockDB.subscriptions)
+	}
+}
+
+func TestSubscription_Owner(t *testing.T) {
+	n, _ := newSubscriptionTestServer(t)
+	n.authorizer = newTestAuthorizer(t, nil)
+	request := func(method string, path string, body string, state *tls.ConnectionState) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.TLS = state
+		w := httptest.NewRecorder()
+		n.Router.ServeHTTP(w, req)
+		return w
+	}
+	w := request(http.MethodPost, "/nfconfig/subscriptions", `{"callbackUri": "https://smf/notify", "resources": ["plmn"], "owner": "OU=udr,CN=nf"}`, verifiedState("udm"))
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected status %d, got %d", http.StatusCreated, w.Code)
+	}
+	var s subscription
+	if err := json.Unmarshal(w.Body.Bytes(), &s); err != nil {
+		t.Fatalf("failed to unmarshal subscription: %v", err)
+	}
+	if s.Owner != "CN=nf,OU=udm" {
+		t.Errorf("expected the subscription to belong to the certificate of the request, got %s", s.Owner)
+	}
+	for _, method := range []string{http.MethodGet, http.MethodDelete} {
+		if w = request(method, "/nfconfig/subscriptions/"+s.Id, "", verifiedState("udr")); w.Code != http.StatusForbidden {
+			t.Errorf("expected status %d for %s by another NF, got %d", http.StatusForbidden, method, w.Code)
+		}
+		if w = request(method, "/nfconfig/subscriptions/"+s.Id, "", nil); w.Code != http.StatusForbidden {
+			t.Errorf("expected status %d for %s without certificate, got %d", http.StatusForbidden, method, w.Code)
+		}
+	}
+	if w = request(http.MethodGet, "/nfconfig/subscriptions/"+s.Id, "", verifiedState("udm")); w.Code != http.StatusOK {
+		t.Errorf("expected status %d for the owner, got %d", http.StatusOK, w.Code)
+	}
+	if w = request(http.MethodDelete, "/nfconfig/subscriptions/"+s.Id, "", verifiedState("udm")); w.Code != http.StatusNoContent {
+		t.Errorf("expected status %d for the owner, got %d", http.StatusNoContent, w.Code)
+	}
+}
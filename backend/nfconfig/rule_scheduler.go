@@ -0,0 +1,64 @@
+// SPDX-FileCopyrightText: 2026 Canonical Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package nfconfig
+
+import (
+	"context"
+	"time"
+
+	"github.com/omec-project/webconsole/backend/logger"
+)
+
+// ruleScheduler triggers an in-memory configuration sync whenever a scheduled
+// application filtering rule becomes active or inactive.
+type ruleScheduler struct {
+	boundaryChan chan time.Time
+	triggerChan  chan struct{}
+}
+
+func newRuleScheduler() *ruleScheduler {
+	return &ruleScheduler{
+		boundaryChan: make(chan time.Time, 1),
+		triggerChan:  make(chan struct{}, 1),
+	}
+}
+
+// schedule replaces the pending boundary. A zero boundary cancels it.
+func (s *ruleScheduler) schedule(boundary time.Time) {
+	if s == nil {
+		return
+	}
+	select {
+	case <-s.boundaryChan:
+	default:
+	}
+	s.boundaryChan <- boundary
+}
+
+func (s *ruleScheduler) run(ctx context.Context) {
+	timer := time.NewTimer(time.Hour)
+	timer.Stop()
+	defer timer.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case boundary := <-s.boundaryChan:
+			timer.Stop()
+			if boundary.IsZero() {
+				logger.NfConfigLog.Debugln("No scheduled application filtering rule boundary")
+				continue
+			}
+			logger.NfConfigLog.Infof("Next scheduled application filtering rule boundary at %s", boundary)
+			timer.Reset(max(time.Until(boundary), 0))
+		case <-timer.C:
+			select {
+			case s.triggerChan <- struct{}{}:
+			default:
+			}
+		}
+	}
+}
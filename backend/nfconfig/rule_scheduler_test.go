@@ -0,0 +1,39 @@
+// SPDX-FileCopyrightText: 2026 Canonical Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+package nfconfig
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestRuleScheduler_TriggersAtBoundary(t *testing.T) {
+	scheduler := newRuleScheduler()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go scheduler.run(ctx)
+
+	scheduler.schedule(time.Now().Add(50 * time.Millisecond))
+	select {
+	case <-scheduler.triggerChan:
+	case <-time.After(2 * time.Second):
+		t.Fatal("expected a sync trigger at the rule boundary")
+	}
+}
+
+func TestRuleScheduler_ZeroBoundaryCancelsPendingTrigger(t *testing.T) {
+	scheduler := newRuleScheduler()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go scheduler.run(ctx)
+
+	scheduler.schedule(time.Now().Add(100 * time.Millisecond))
+	scheduler.schedule(time.Time{})
+	select {
+	case <-scheduler.triggerChan:
+		t.Fatal("expected no sync trigger after the boundary was cancelled")
+	case <-time.After(300 * time.Millisecond):
+	}
+}
@@ -9,26 +9,55 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"net"
 	"net/http"
+	"strconv"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/omec-project/webconsole/backend/factory"
 	"github.com/omec-project/webconsole/backend/logger"
+	"github.com/omec-project/webconsole/backend/metrics"
+	"github.com/omec-project/webconsole/configapi"
 	"github.com/omec-project/webconsole/configmodels"
 	"github.com/omec-project/webconsole/dbadapter"
 	"go.mongodb.org/mongo-driver/v2/bson"
 	"go.uber.org/zap"
+	"google.golang.org/grpc"
 )
 
 type NFConfigServer struct {
 	config         *factory.Configuration
 	Router         *gin.Engine
-	inMemoryConfig inMemoryConfig
+	inMemoryConfig atomic.Pointer[inMemoryConfig]
 	syncMutex      sync.Mutex
+	ruleScheduler  *ruleScheduler
+	watcher        *configWatcher
+	notifier       *changeNotifier
+	authorizer     *nfAuthorizer
+	clients        *clientRegistry
+	// slices and device groups of the last sync, reused by the syncs of
+	// the other collection
+	sources *configSources
+	// syncScope of the changes not synced yet
+	pendingScope atomic.Uint32
+	// triggers a sync of the changes seen by the change stream
+	changeChan chan struct{}
+	// set while the change stream is open, which then syncs the WebUI writes
+	changeStreamOpen atomic.Bool
+	// resume token of the change stream, stored after the syncs
+	resumeTokens atomic.Pointer[resumeTokenTracker]
+	// sync worker goroutines, waited for on shutdown
+	workers sync.WaitGroup
 }
 
+const (
+	defaultPort            = 5001
+	defaultShutdownTimeout = 5 * time.Second
+)
+
 const (
 	devGroupDataColl = "webconsoleData.snapshots.devGroupData"
 	sliceDataColl    = "webconsoleData.snapshots.sliceData"
@@ -36,6 +65,7 @@ const (
 
 type Route struct {
 	Pattern     string
+	Resource    configResource
 	HandlerFunc gin.HandlerFunc
 }
 
@@ -59,9 +89,24 @@ func NewNFConfigServer(config *factory.Config) (NFConfigInterface, error) {
 	router.Use(gin.Recovery())
 	router.Use(enforceAcceptJSON())
 
+	authorizer, err := newNfAuthorizer(config.Configuration.NfConfigMtls)
+	if err != nil {
+		return nil, fmt.Errorf("failed to set up NF configuration mTLS: %w", err)
+	}
+	callbackAllowList, err := newCallbackAllowList(config.Configuration.NfConfigSubscriptions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to set up NF configuration subscriptions: %w", err)
+	}
+
 	nfconfigServer := &NFConfigServer{
-		config: config.Configuration,
-		Router: router,
+		config:        config.Configuration,
+		Router:        router,
+		ruleScheduler: newRuleScheduler(),
+		watcher:       newConfigWatcher(),
+		notifier:      newChangeNotifier(callbackAllowList),
+		authorizer:    authorizer,
+		clients:       newClientRegistry(),
+		changeChan:    make(chan struct{}, 1),
 	}
 
 	if err := nfconfigServer.syncInMemoryConfig(); err != nil {
@@ -74,13 +119,39 @@ func NewNFConfigServer(config *factory.Config) (NFConfigInterface, error) {
 }
 
 func (n *NFConfigServer) Start(ctx context.Context, syncChan <-chan struct{}) error {
+	if err := n.notifier.start(ctx, n.snapshot(), &n.workers); err != nil {
+		logger.NfConfigLog.Errorf("Failed to start change notifications: %v", err)
+	}
 	n.startSyncWorker(ctx, syncChan)
-	addr := ":5001"
-	srv := &http.Server{
-		Addr:    addr,
-		Handler: n.Router,
+	n.startChangeStream(ctx)
+	if n.clients != nil {
+		n.workers.Go(func() { n.clients.run(ctx) })
+	}
+	// the requests outlive ctx so that the shutdown drains them
+	requestCtx, cancelRequests := context.WithCancel(context.WithoutCancel(ctx))
+	defer cancelRequests()
+	srv := n.newHTTPServer(requestCtx)
+	addr := srv.Addr
+	if n.authorizer != nil {
+		srv.TLSConfig = n.authorizer.tlsConfig()
+	}
+	serverErrChan := make(chan error, 2)
+	var grpcSrv *grpc.Server
+	if n.grpcEnabled() {
+		var err error
+		if grpcSrv, err = n.newGrpcServer(ctx); err != nil {
+			return err
+		}
+		grpcAddr := n.grpcAddr()
+		listener, err := net.Listen("tcp", grpcAddr)
+		if err != nil {
+			return fmt.Errorf("failed to listen for gRPC on %s: %w", grpcAddr, err)
+		}
+		go func() {
+			logger.NfConfigLog.Infoln("Starting gRPC server on", grpcAddr)
+			serverErrChan <- grpcSrv.Serve(listener)
+		}()
 	}
-	serverErrChan := make(chan error, 1)
 	go func() {
 		if n.config.NfConfigTLS != nil && n.config.NfConfigTLS.Key != "" && n.config.NfConfigTLS.PEM != "" {
 			logger.NfConfigLog.Infoln("Starting HTTPS server on", addr)
@@ -93,17 +164,114 @@ func (n *NFConfigServer) Start(ctx context.Context, syncChan <-chan struct{}) er
 	select {
 	case <-ctx.Done():
 		logger.NfConfigLog.Infoln("NFConfig context cancelled, shutting down server.")
-		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), n.shutdownTimeout())
 		defer cancel()
-		return srv.Shutdown(shutdownCtx)
+		// drains the in-flight requests, the watches answer right away
+		n.watcher.stop()
+		err := srv.Shutdown(shutdownCtx)
+		if err != nil {
+			logger.NfConfigLog.Warnf("NFConfig server did not drain in time, closing it: %v", err)
+			_ = srv.Close()
+		}
+		cancelRequests()
+		if grpcSrv != nil {
+			stopGrpcServer(shutdownCtx, grpcSrv)
+		}
+		n.waitForWorkers(shutdownCtx)
+		return err
 
 	case err := <-serverErrChan:
+		if grpcSrv != nil {
+			grpcSrv.Stop()
+		}
 		return err
 	}
 }
 
-func (n *NFConfigServer) startSyncWorker(ctx context.Context, syncChan <-chan struct{}) {
+// listenerConfig returns the nfconfig listener configuration with defaults
+func (n *NFConfigServer) listenerConfig() factory.NfConfig {
+	listenerConfig := factory.NfConfig{}
+	if n.config != nil && n.config.NfConfig != nil {
+		listenerConfig = *n.config.NfConfig
+	}
+	if listenerConfig.Port == 0 {
+		listenerConfig.Port = defaultPort
+	}
+	return listenerConfig
+}
+
+func (n *NFConfigServer) shutdownTimeout() time.Duration {
+	if seconds := n.listenerConfig().ShutdownTimeoutSeconds; seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+	return defaultShutdownTimeout
+}
+
+func (n *NFConfigServer) newHTTPServer(ctx context.Context) *http.Server {
+	listenerConfig := n.listenerConfig()
+	srv := &http.Server{
+		Addr:           net.JoinHostPort(listenerConfig.BindAddress, strconv.Itoa(listenerConfig.Port)),
+		Handler:        n.Router,
+		ReadTimeout:    time.Duration(listenerConfig.ReadTimeoutSeconds) * time.Second,
+		WriteTimeout:   time.Duration(listenerConfig.WriteTimeoutSeconds) * time.Second,
+		MaxHeaderBytes: listenerConfig.MaxHeaderBytes,
+		// cancelled once the server is shut down
+		BaseContext: func(net.Listener) context.Context { return ctx },
+	}
+	switch listenerConfig.HttpVersion {
+	case 1:
+		srv.Protocols = new(http.Protocols)
+		srv.Protocols.SetHTTP1(true)
+	case 2:
+		srv.Protocols = new(http.Protocols)
+		srv.Protocols.SetHTTP1(true)
+		srv.Protocols.SetHTTP2(true)
+		srv.Protocols.SetUnencryptedHTTP2(true)
+	}
+	if srv.WriteTimeout > 0 && srv.WriteTimeout <= watchTimeout {
+		logger.NfConfigLog.Warnf("NFConfig write timeout %s does not exceed the watch timeout %s. Watch requests may fail", srv.WriteTimeout, watchTimeout)
+	}
+	return srv
+}
+
+// stopGrpcServer waits for the in-flight gRPC requests until ctx ends
+func stopGrpcServer(ctx context.Context, grpcSrv *grpc.Server) {
+	stopped := make(chan struct{})
+	go func() {
+		grpcSrv.GracefulStop()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+	case <-ctx.Done():
+		logger.NfConfigLog.Warnln("NFConfig gRPC server did not drain in time, stopping it")
+		grpcSrv.Stop()
+	}
+}
+
+// waitForWorkers waits for the sync worker and the notification deliveries to
+// stop until ctx ends
+func (n *NFConfigServer) waitForWorkers(ctx context.Context) {
+	stopped := make(chan struct{})
 	go func() {
+		n.workers.Wait()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+		logger.NfConfigLog.Infoln("NFConfig workers stopped")
+	case <-ctx.Done():
+		logger.NfConfigLog.Warnln("Timed out waiting for the NFConfig workers to stop")
+	}
+}
+
+func (n *NFConfigServer) startSyncWorker(ctx context.Context, syncChan <-chan struct{}) {
+	var ruleTriggerChan <-chan struct{}
+	if n.ruleScheduler != nil {
+		n.workers.Go(func() { n.ruleScheduler.run(ctx) })
+		ruleTriggerChan = n.ruleScheduler.triggerChan
+	}
+	n.workers.Go(func() {
 		var currentCancel context.CancelFunc
 
 		for {
@@ -115,18 +283,35 @@ func (n *NFConfigServer) startSyncWorker(ctx context.Context, syncChan <-chan st
 				return
 
 			case <-syncChan:
-				// Cancel current sync if running
-				if currentCancel != nil {
-					logger.NfConfigLog.Infoln("Cancelling ongoing sync due to new trigger")
-					currentCancel()
+				if n.changeStreamOpen.Load() {
+					logger.NfConfigLog.Debugln("WebUI write left to the change stream")
+					continue
 				}
+				n.requestSync(fullScope)
+				currentCancel = n.restartSync(ctx, currentCancel)
+
+			case <-ruleTriggerChan:
+				logger.NfConfigLog.Infoln("Scheduled application filtering rule boundary reached")
+				n.requestSync(fullScope)
+				currentCancel = n.restartSync(ctx, currentCancel)
 
-				var syncCtx context.Context
-				syncCtx, currentCancel = context.WithCancel(context.Background())
-				go n.syncWithRetry(syncCtx)
+			case <-n.changeChan:
+				currentCancel = n.restartSync(ctx, currentCancel)
 			}
 		}
-	}()
+	})
+}
+
+func (n *NFConfigServer) restartSync(ctx context.Context, currentCancel context.CancelFunc) context.CancelFunc {
+	// Cancel current sync if running
+	if currentCancel != nil {
+		logger.NfConfigLog.Infoln("Cancelling ongoing sync due to new trigger")
+		currentCancel()
+	}
+
+	syncCtx, cancel := context.WithCancel(ctx)
+	n.workers.Go(func() { n.syncWithRetry(syncCtx) })
+	return cancel
 }
 
 func (n *NFConfigServer) syncWithRetry(ctx context.Context) {
@@ -151,60 +336,215 @@ func (n *NFConfigServer) syncWithRetry(ctx context.Context) {
 }
 
 var syncInMemoryConfigFunc = func(n *NFConfigServer) error {
-	return n.syncInMemoryConfig()
+	// taken before the pending scope, which then holds the changes up to
+	// the resume token
+	resumeTokens := n.resumeTokens.Load()
+	resumeToken := resumeTokens.last()
+	scope := n.pendingScope.Swap(0)
+	if scope == 0 {
+		logger.NfConfigLog.Debugln("No-op. The pending changes were already synced")
+		resumeTokens.synced(resumeToken)
+		return nil
+	}
+	if err := n.syncInMemoryConfigScope(syncScope(scope)); err != nil {
+		n.pendingScope.Or(scope)
+		return err
+	}
+	resumeTokens.synced(resumeToken)
+	return nil
+}
+
+// requestSync adds the scope to the next sync
+func (n *NFConfigServer) requestSync(scope syncScope) {
+	n.pendingScope.Or(uint32(scope))
 }
 
+// syncInMemoryConfig reloads the whole NF configuration
 func (n *NFConfigServer) syncInMemoryConfig() error {
+	return n.syncInMemoryConfigScope(fullScope)
+}
+
+// syncInMemoryConfigScope reloads the collections of the scope and rebuilds
+// the views derived from them. The other views are kept from the current
+// snapshot.
+func (n *NFConfigServer) syncInMemoryConfigScope(scope syncScope) error {
+	if n.sources == nil {
+		scope = fullScope
+	}
+	sources := &configSources{}
+	if n.sources != nil {
+		*sources = *n.sources
+	}
+	if scope&sliceScope != 0 {
+		if err := sources.loadSlices(); err != nil {
+			return err
+		}
+	}
+	if scope&deviceGroupScope != 0 {
+		if err := sources.loadDeviceGroups(); err != nil {
+			return err
+		}
+	}
+	dnns, err := configapi.GetDnnCatalog()
+	if err != nil {
+		return err
+	}
+	upfs, err := configapi.GetUpfInventory()
+	if err != nil {
+		return err
+	}
+	if scope&sliceScope != 0 {
+		if err = sources.resolveSlices(); err != nil {
+			return err
+		}
+	}
+	if scope&deviceGroupScope != 0 {
+		if err = sources.resolveDeviceGroups(); err != nil {
+			return err
+		}
+	}
+
+	previous := n.snapshot()
+	config := &inMemoryConfig{}
+	if scope&sliceScope != 0 {
+		config.syncPlmn(sources.slices)
+		config.syncPlmnSnssai(sources.slices)
+		config.syncAccessAndMobility(sources.slices)
+	} else {
+		config.plmn = previous.plmn
+		config.plmnSnssai = previous.plmnSnssai
+		config.accessAndMobility = previous.accessAndMobility
+		config.diagnostics.keep(previous.diagnostics, plmnResource, plmnSnssaiResource, accessMobilityResource)
+	}
+	config.syncSessionManagement(sources.slices, sources.deviceGroups, dnns, upfs)
+	config.syncPolicyControl(sources.slices, sources.deviceGroups, dnns)
+	if scope&deviceGroupScope != 0 {
+		config.syncImsiQos(sources.deviceGroups)
+	} else {
+		config.imsiQos = previous.imsiQos
+		config.diagnostics.keep(previous.diagnostics, imsiQosResource)
+	}
+	config.diagnostics = append(config.diagnostics, sources.sliceDiagnostics...)
+	config.diagnostics = append(config.diagnostics, sources.deviceGroupDiagnostics...)
+	config.diagnostics = config.diagnostics.sorted()
+	n.sources = sources
+	changed := config.setVersions(previous)
+	n.inMemoryConfig.Store(config)
+	if changed {
+		n.watcher.notify()
+		n.notifier.notify(previous, config)
+		recordResourceVersions(config)
+	}
+	metrics.SetNfConfigDiagnostics(len(config.diagnostics))
+	n.ruleScheduler.schedule(config.nextRuleBoundary)
+	logger.NfConfigLog.Infof("Updated NF in-memory configuration to version %d (%s) with %d diagnostics", config.version, scope, len(config.diagnostics))
+	return nil
+}
+
+func (s *configSources) loadSlices() error {
 	rawSlices, err := dbadapter.CommonDBClient.RestfulAPIGetMany(sliceDataColl, bson.M{})
 	if err != nil {
 		return err
 	}
 
-	slices := []configmodels.Slice{}
+	s.slices = []configmodels.Slice{}
+	s.sliceDiagnostics = nil
 	for _, rawSlice := range rawSlices {
-		var s configmodels.Slice
-		if err = json.Unmarshal(configmodels.MapToByte(rawSlice), &s); err != nil {
-			logger.NfConfigLog.Warnf("Failed to unmarshal slice: %+v. Raw slice will be ignored", err)
+		var slice configmodels.Slice
+		if err = json.Unmarshal(configmodels.MapToByte(rawSlice), &slice); err != nil {
+			name, _ := rawSlice["slice-name"].(string)
+			s.sliceDiagnostics.add("", sliceObject(name), "", fmt.Sprintf("failed to unmarshal slice: %v. The slice is ignored", err))
 			continue
 		}
-		slices = append(slices, s)
+		s.slices = append(s.slices, slice)
 	}
-	logger.NfConfigLog.Debugf("Retrieved %d network slices", len(slices))
+	logger.NfConfigLog.Debugf("Retrieved %d network slices", len(s.slices))
+	return nil
+}
 
+func (s *configSources) loadDeviceGroups() error {
 	rawDeviceGroups, err := dbadapter.CommonDBClient.RestfulAPIGetMany(devGroupDataColl, bson.M{})
 	if err != nil {
 		return fmt.Errorf("failed to fetch device groups: %w", err)
 	}
 
-	deviceGroups := make(map[string]configmodels.DeviceGroups)
+	s.deviceGroups = make(map[string]configmodels.DeviceGroups)
+	s.deviceGroupDiagnostics = nil
 	for _, rawDG := range rawDeviceGroups {
 		var dg configmodels.DeviceGroups
 		if err = json.Unmarshal(configmodels.MapToByte(rawDG), &dg); err != nil {
-			logger.NfConfigLog.Warnf("Failed to unmarshal device group: raw=%+v, error=%v", rawDG, err)
+			name, _ := rawDG["group-name"].(string)
+			s.deviceGroupDiagnostics.add("", deviceGroupObject(name), "", fmt.Sprintf("failed to unmarshal device group: %v. The device group is ignored", err))
 			continue
 		}
 		if dg.DeviceGroupName == "" {
-			logger.NfConfigLog.Warnf("Skipping device group: %+v with empty name", dg)
+			s.deviceGroupDiagnostics.add("", deviceGroupObject(""), "group-name", "empty name. The device group is ignored")
 			continue
 		}
-		deviceGroups[dg.DeviceGroupName] = dg
+		s.deviceGroups[dg.DeviceGroupName] = dg
+	}
+	logger.NfConfigLog.Debugf("Parsed %d device groups", len(s.deviceGroups))
+	return nil
+}
+
+// resolveSlices resolves the applications and traffic classes the slices
+// refer to
+func (s *configSources) resolveSlices() error {
+	applications, err := configapi.GetApplicationCatalog()
+	if err != nil {
+		return err
+	}
+	trafficClasses, err := configapi.GetTrafficClassCatalog()
+	if err != nil {
+		return err
+	}
+	for i := range s.slices {
+		if err = configapi.ResolveSliceApplications(&s.slices[i], applications); err != nil {
+			s.sliceDiagnostics.add("", sliceObject(s.slices[i].SliceName), "application-filtering-rules.app-name", fmt.Sprintf("failed to resolve applications: %v", err))
+		}
+		if err = configapi.ResolveSliceTrafficClasses(&s.slices[i], trafficClasses); err != nil {
+			s.sliceDiagnostics.add("", sliceObject(s.slices[i].SliceName), "traffic-class-name", fmt.Sprintf("failed to resolve traffic classes: %v", err))
+		}
 	}
-	logger.NfConfigLog.Debugf("Parsed %d device groups", len(deviceGroups))
+	return nil
+}
 
-	n.inMemoryConfig.syncPlmn(slices)
-	n.inMemoryConfig.syncPlmnSnssai(slices)
-	n.inMemoryConfig.syncAccessAndMobility(slices)
-	n.inMemoryConfig.syncSessionManagement(slices, deviceGroups)
-	n.inMemoryConfig.syncPolicyControl(slices, deviceGroups)
-	n.inMemoryConfig.syncImsiQos(deviceGroups)
-	logger.NfConfigLog.Infoln("Updated NF in-memory configuration")
+// resolveDeviceGroups resolves the QoS profiles and traffic classes the
+// device groups refer to
+func (s *configSources) resolveDeviceGroups() error {
+	qosProfiles, err := configapi.GetQosProfileCatalog()
+	if err != nil {
+		return err
+	}
+	trafficClasses, err := configapi.GetTrafficClassCatalog()
+	if err != nil {
+		return err
+	}
+	for name, dg := range s.deviceGroups {
+		if err = configapi.ResolveDeviceGroupQosProfiles(&dg, qosProfiles); err != nil {
+			s.deviceGroupDiagnostics.add("", deviceGroupObject(name), "ip-domains.qos-profile-name", fmt.Sprintf("failed to resolve QoS profiles: %v", err))
+		}
+		if err = configapi.ResolveDeviceGroupTrafficClasses(&dg, trafficClasses); err != nil {
+			s.deviceGroupDiagnostics.add("", deviceGroupObject(name), "ip-domains.ue-dnn-qos.traffic-class-name", fmt.Sprintf("failed to resolve traffic classes: %v", err))
+		}
+		s.deviceGroups[name] = dg
+	}
 	return nil
 }
 
 func (n *NFConfigServer) setupRoutes() {
 	api := n.Router.Group("/nfconfig")
 	for _, route := range n.getRoutes() {
-		api.GET(route.Pattern, route.HandlerFunc)
+		api.GET(route.Pattern, n.authorizeResource(route.Resource), n.serveSnapshot(route.Resource, route.HandlerFunc))
+	}
+	if n.clients != nil {
+		api.GET("/clients", n.authorizeResource(clientsResource), n.GetClients)
+	}
+	api.GET("/diagnostics", n.authorizeResource(diagnosticsResource), n.GetDiagnostics)
+	if n.notifier != nil {
+		api.POST("/subscriptions", n.PostSubscription)
+		api.GET("/subscriptions/:subscription-id", n.GetSubscription)
+		api.DELETE("/subscriptions/:subscription-id", n.DeleteSubscription)
 	}
 }
 
@@ -212,26 +552,32 @@ func (n *NFConfigServer) getRoutes() []Route {
 	return []Route{
 		{
 			Pattern:     "/access-mobility",
+			Resource:    accessMobilityResource,
 			HandlerFunc: n.GetAccessMobilityConfig,
 		},
 		{
 			Pattern:     "/plmn",
+			Resource:    plmnResource,
 			HandlerFunc: n.GetPlmnConfig,
 		},
 		{
 			Pattern:     "/plmn-snssai",
+			Resource:    plmnSnssaiResource,
 			HandlerFunc: n.GetPlmnSnssaiConfig,
 		},
 		{
 			Pattern:     "/policy-control",
+			Resource:    policyControlResource,
 			HandlerFunc: n.GetPolicyControlConfig,
 		},
 		{
 			Pattern:     "/session-management",
+			Resource:    sessionManagementResource,
 			HandlerFunc: n.GetSessionManagementConfig,
 		},
 		{
 			Pattern:     "/qos/:dnn/:imsi",
+			Resource:    imsiQosResource,
 			HandlerFunc: n.GetImsiQosConfig,
 		},
 	}
@@ -7,9 +7,11 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net"
 	"net/http"
 	"net/http/httptest"
 	"reflect"
+	"strconv"
 	"testing"
 	"time"
 
@@ -577,28 +579,26 @@ func TestSyncInMemoryConfig_UpdateAllConfigs(t *testing.T) {
 			originalDBClient := dbadapter.CommonDBClient
 			defer func() { dbadapter.CommonDBClient = originalDBClient }()
 			dbadapter.CommonDBClient = mockDB
-			n := &NFConfigServer{
-				inMemoryConfig: inMemoryConfig{},
-			}
+			n := &NFConfigServer{}
 
 			err := n.syncInMemoryConfig()
 			if err != nil {
 				t.Errorf("expected no error. Got %s", err)
 			}
-			if !reflect.DeepEqual(tc.expectedPlmn, n.inMemoryConfig.plmn) {
-				t.Errorf("expected PLMN %+v, got %+v", tc.expectedPlmn, n.inMemoryConfig.plmn)
+			if !reflect.DeepEqual(tc.expectedPlmn, n.snapshot().plmn) {
+				t.Errorf("expected PLMN %+v, got %+v", tc.expectedPlmn, n.snapshot().plmn)
 			}
-			if !reflect.DeepEqual(tc.expectedPlmnSnssai, n.inMemoryConfig.plmnSnssai) {
-				t.Errorf("expected PLMN-SNSSAI %+v, got %+v", tc.expectedPlmnSnssai, n.inMemoryConfig.plmnSnssai)
+			if !reflect.DeepEqual(tc.expectedPlmnSnssai, n.snapshot().plmnSnssai) {
+				t.Errorf("expected PLMN-SNSSAI %+v, got %+v", tc.expectedPlmnSnssai, n.snapshot().plmnSnssai)
 			}
-			if !reflect.DeepEqual(tc.expectedAccessAndMobility, n.inMemoryConfig.accessAndMobility) {
-				t.Errorf("expected Access and Mobility %+v, got %+v", tc.expectedAccessAndMobility, n.inMemoryConfig.accessAndMobility)
+			if !reflect.DeepEqual(tc.expectedAccessAndMobility, n.snapshot().accessAndMobility) {
+				t.Errorf("expected Access and Mobility %+v, got %+v", tc.expectedAccessAndMobility, n.snapshot().accessAndMobility)
 			}
-			if !reflect.DeepEqual(tc.expectedSessionManagement, n.inMemoryConfig.sessionManagement) {
-				t.Errorf("expected Session Management %+v, got %+v", tc.expectedSessionManagement, n.inMemoryConfig.sessionManagement)
+			if !reflect.DeepEqual(tc.expectedSessionManagement, n.snapshot().sessionManagement) {
+				t.Errorf("expected Session Management %+v, got %+v", tc.expectedSessionManagement, n.snapshot().sessionManagement)
 			}
-			if !reflect.DeepEqual(tc.expectedPolicyControl, n.inMemoryConfig.policyControl) {
-				t.Errorf("expected Policy Control %+v, got %+v", tc.expectedPolicyControl, n.inMemoryConfig.policyControl)
+			if !reflect.DeepEqual(tc.expectedPolicyControl, n.snapshot().policyControl) {
+				t.Errorf("expected Policy Control %+v, got %+v", tc.expectedPolicyControl, n.snapshot().policyControl)
 			}
 		})
 	}
@@ -670,36 +670,197 @@ func TestSyncInMemoryConfig_DBError_KeepsPreviousConfig(t *testing.T) {
 			originalDBClient := dbadapter.CommonDBClient
 			defer func() { dbadapter.CommonDBClient = originalDBClient }()
 			dbadapter.CommonDBClient = mockDB
-			n := &NFConfigServer{
-				inMemoryConfig: inMemoryConfig{
-					plmn:              tc.expectedPlmn,
-					plmnSnssai:        tc.expectedPlmnSnssai,
-					accessAndMobility: tc.expectedAccessAndMobility,
-					sessionManagement: tc.expectedSessionManagement,
-					policyControl:     tc.expectedPolicyControl,
-				},
-			}
+			n := &NFConfigServer{}
+			n.inMemoryConfig.Store(&inMemoryConfig{
+				plmn:              tc.expectedPlmn,
+				plmnSnssai:        tc.expectedPlmnSnssai,
+				accessAndMobility: tc.expectedAccessAndMobility,
+				sessionManagement: tc.expectedSessionManagement,
+				policyControl:     tc.expectedPolicyControl,
+			})
 
 			err := n.syncInMemoryConfig()
 
 			if err == nil {
 				t.Errorf("expected error. Got nil")
 			}
-			if !reflect.DeepEqual(tc.expectedPlmn, n.inMemoryConfig.plmn) {
-				t.Errorf("expected PLMN %v, got %v", tc.expectedPlmn, n.inMemoryConfig.plmn)
+			if !reflect.DeepEqual(tc.expectedPlmn, n.snapshot().plmn) {
+				t.Errorf("expected PLMN %v, got %v", tc.expectedPlmn, n.snapshot().plmn)
 			}
-			if !reflect.DeepEqual(tc.expectedPlmnSnssai, n.inMemoryConfig.plmnSnssai) {
-				t.Errorf("expected PLMN-SNSSAI %v, got %v", tc.expectedPlmnSnssai, n.inMemoryConfig.plmnSnssai)
+			if !reflect.DeepEqual(tc.expectedPlmnSnssai, n.snapshot().plmnSnssai) {
+				t.Errorf("expected PLMN-SNSSAI %v, got %v", tc.expectedPlmnSnssai, n.snapshot().plmnSnssai)
 			}
-			if !reflect.DeepEqual(tc.expectedAccessAndMobility, n.inMemoryConfig.accessAndMobility) {
-				t.Errorf("expected Access and Mobility %v, got %v", tc.expectedAccessAndMobility, n.inMemoryConfig.accessAndMobility)
+			if !reflect.DeepEqual(tc.expectedAccessAndMobility, n.snapshot().accessAndMobility) {
+				t.Errorf("expected Access and Mobility %v, got %v", tc.expectedAccessAndMobility, n.snapshot().accessAndMobility)
 			}
-			if !reflect.DeepEqual(tc.expectedSessionManagement, n.inMemoryConfig.sessionManagement) {
-				t.Errorf("expected Session Management %+v, got %+v", tc.expectedSessionManagement, n.inMemoryConfig.sessionManagement)
+			if !reflect.DeepEqual(tc.expectedSessionManagement, n.snapshot().sessionManagement) {
+				t.Errorf("expected Session Management %+v, got %+v", tc.expectedSessionManagement, n.snapshot().sessionManagement)
 			}
-			if !reflect.DeepEqual(tc.expectedPolicyControl, n.inMemoryConfig.policyControl) {
-				t.Errorf("expected Policy Control %+v, got %+v", tc.expectedPolicyControl, n.inMemoryConfig.policyControl)
+			if !reflect.DeepEqual(tc.expectedPolicyControl, n.snapshot().policyControl) {
+				t.Errorf("expected Policy Control %+v, got %+v", tc.expectedPolicyControl, n.snapshot().policyControl)
 			}
 		})
 	}
 }
+
+func TestNewHTTPServer(t *testing.T) {
+	tests := []struct {
+		name                  string
+		nfConfig              *factory.NfConfig
+		expectedAddr          string
+		expectedHTTP2         bool
+		expectedH2c           bool
+		expectedDefault       bool
+		expectedReadTimeout   time.Duration
+		expectedMaxHeaderSize int
+	}{
+		{
+			name:            "defaults",
+			expectedAddr:    ":5001",
+			expectedDefault: true,
+		},
+		{
+			name:         "HTTP/1.1 only",
+			nfConfig:     &factory.NfConfig{BindAddress: "127.0.0.1", Port: 8080, HttpVersion: 1},
+			expectedAddr: "127.0.0.1:8080",
+		},
+		{
+			name:                  "HTTP/2 with limits",
+			nfConfig:              &factory.NfConfig{BindAddress: "::1", HttpVersion: 2, ReadTimeoutSeconds: 10, MaxHeaderBytes: 4096},
+			expectedAddr:          "[::1]:5001",
+			expectedHTTP2:         true,
+			expectedH2c:           true,
+			expectedReadTimeout:   10 * time.Second,
+			expectedMaxHeaderSize: 4096,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			n := &NFConfigServer{config: &factory.Configuration{NfConfig: tc.nfConfig}, Router: gin.New()}
+			srv := n.newHTTPServer(context.Background())
+			if srv.Addr != tc.expectedAddr {
+				t.Errorf("expected address %s, got %s", tc.expectedAddr, srv.Addr)
+			}
+			if tc.expectedDefault {
+				if srv.Protocols != nil {
+					t.Errorf("expected the default protocols, got %v", srv.Protocols)
+				}
+			} else if !srv.Protocols.HTTP1() || srv.Protocols.HTTP2() != tc.expectedHTTP2 || srv.Protocols.UnencryptedHTTP2() != tc.expectedH2c {
+				t.Errorf("expected HTTP/2 %v and h2c %v, got %v", tc.expectedHTTP2, tc.expectedH2c, srv.Protocols)
+			}
+			if srv.ReadTimeout != tc.expectedReadTimeout || srv.MaxHeaderBytes != tc.expectedMaxHeaderSize {
+				t.Errorf("expected read timeout %s and max header size %d, got %s and %d",
+					tc.expectedReadTimeout, tc.expectedMaxHeaderSize, srv.ReadTimeout, srv.MaxHeaderBytes)
+			}
+		})
+	}
+}
+
+func freePort(t *testing.T) int {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to find a free port: %v", err)
+	}
+	defer listener.Close()
+	return listener.Addr().(*net.TCPAddr).Port
+}
+
+func TestNFConfigStart_H2cAndGracefulShutdown(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	originalDBClient := dbadapter.CommonDBClient
+	defer func() { dbadapter.CommonDBClient = originalDBClient }()
+	dbadapter.CommonDBClient = &MockDBClient{}
+	port := freePort(t)
+	n := &NFConfigServer{
+		config:  &factory.Configuration{NfConfig: &factory.NfConfig{BindAddress: "127.0.0.1", Port: port, HttpVersion: 2}},
+		Router:  gin.New(),
+		watcher: newConfigWatcher(),
+	}
+	n.setupRoutes()
+	n.Router.GET("/slow", func(c *gin.Context) {
+		select {
+		case <-time.After(300 * time.Millisecond):
+			c.Status(http.StatusOK)
+		case <-c.Request.Context().Done():
+			c.Status(http.StatusServiceUnavailable)
+		}
+	})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	errChan := make(chan error, 1)
+	go func() {
+		errChan <- n.Start(ctx, make(chan struct{}))
+	}()
+
+	protocols := new(http.Protocols)
+	protocols.SetUnencryptedHTTP2(true)
+	client := &http.Client{Transport: &http.Transport{Protocols: protocols}}
+	baseUrl := "http://127.0.0.1:" + strconv.Itoa(port)
+	var resp *http.Response
+	var err error
+	for range 50 {
+		if resp, err = client.Get(baseUrl + "/nfconfig/plmn"); err == nil {
+			break
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+	if err != nil {
+		t.Fatalf("server did not start: %v", err)
+	}
+	resp.Body.Close()
+	if resp.ProtoMajor != 2 {
+		t.Errorf("expected an h2c response, got %s", resp.Proto)
+	}
+
+	slowResult := make(chan error, 1)
+	go func() {
+		slowResp, err := client.Get(baseUrl + "/slow")
+		if err == nil {
+			slowResp.Body.Close()
+			if slowResp.StatusCode != http.StatusOK {
+				err = fmt.Errorf("unexpected status %d", slowResp.StatusCode)
+			}
+		}
+		slowResult <- err
+	}()
+	watchResult := make(chan int, 1)
+	go func() {
+		req, _ := http.NewRequest(http.MethodGet, baseUrl+"/nfconfig/plmn?watch=true", nil)
+		req.Header.Set("If-None-Match", "*")
+		watchResp, err := client.Do(req)
+		if err != nil {
+			watchResult <- 0
+			return
+		}
+		watchResp.Body.Close()
+		watchResult <- watchResp.StatusCode
+	}()
+	time.Sleep(100 * time.Millisecond)
+	cancel()
+
+	if err = <-slowResult; err != nil {
+		t.Errorf("expected the in-flight request to complete, got %v", err)
+	}
+	if code := <-watchResult; code != http.StatusNotModified {
+		t.Errorf("expected the pending watch to end with status %d, got %d", http.StatusNotModified, code)
+	}
+	select {
+	case err = <-errChan:
+		if err != nil {
+			t.Errorf("expected a clean shutdown, got %v", err)
+		}
+	case <-time.After(4 * time.Second):
+		t.Fatal("timed out waiting for the server to stop")
+	}
+	stopped := make(chan struct{})
+	go func() {
+		n.workers.Wait()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+	case <-time.After(time.Second):
+		t.Error("expected the sync worker to be stopped")
+	}
+}
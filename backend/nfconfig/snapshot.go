@@ -0,0 +1,123 @@
+// SPDX-FileCopyrightText: 2026 Canonical Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package nfconfig
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/omec-project/webconsole/backend/logger"
+)
+
+// snapshotContextKey holds the snapshot a request is served from, so that the
+// headers and the body of the response always match
+const snapshotContextKey = "nfconfigSnapshot"
+
+// filterContextKey holds the query filters of the request, validated before
+// serving it
+const filterContextKey = "nfconfigFilter"
+
+var emptyInMemoryConfig = &inMemoryConfig{}
+
+// snapshot returns the current NF configuration
+func (n *NFConfigServer) snapshot() *inMemoryConfig {
+	if snapshot := n.inMemoryConfig.Load(); snapshot != nil {
+		return snapshot
+	}
+	return emptyInMemoryConfig
+}
+
+// requestSnapshot returns the snapshot the request is served from
+func (n *NFConfigServer) requestSnapshot(c *gin.Context) *inMemoryConfig {
+	if snapshot, ok := c.Get(snapshotContextKey); ok {
+		return snapshot.(*inMemoryConfig)
+	}
+	return n.snapshot()
+}
+
+// requestFilter returns the query filters of the request
+func requestFilter(c *gin.Context, resource configResource) (configFilter, error) {
+	if filter, ok := c.Get(filterContextKey); ok {
+		return filter.(configFilter), nil
+	}
+	return parseConfigFilter(c.Request.URL.Query(), resource)
+}
+
+func (c *inMemoryConfig) resources() map[configResource]any {
+	return map[configResource]any{
+		accessMobilityResource:    c.accessAndMobility,
+		plmnResource:              c.plmn,
+		plmnSnssaiResource:        c.plmnSnssai,
+		policyControlResource:     c.policyControl,
+		sessionManagementResource: c.sessionManagement,
+		imsiQosResource:           c.imsiQos,
+	}
+}
+
+// setVersions versions the snapshot after the previous one. The ETag of a
+// resource is a hash of its content, and its version only increases when the
+// ETag differs from the previous snapshot. It returns whether any resource
+// changed.
+func (c *inMemoryConfig) setVersions(previous *inMemoryConfig) bool {
+	c.version = previous.version + 1
+	c.resourceVersions = make(map[configResource]uint64)
+	c.resourceETags = make(map[configResource]string)
+	changed := false
+	for resource, content := range c.resources() {
+		etag := contentETag(resource, content)
+		version := previous.resourceVersions[resource]
+		if version == 0 || previous.resourceETags[resource] != etag {
+			version++
+			changed = true
+		}
+		c.resourceVersions[resource] = version
+		c.resourceETags[resource] = etag
+	}
+	return changed
+}
+
+// contentETag returns the ETag of a view, built from the hash of its JSON
+// serialization so that the replicas and restarts of the webconsole serve the
+// same ETag for the same configuration. The IMSI QoS index is hashed when it
+// is built.
+func contentETag(resource configResource, content any) string {
+	if index, ok := content.(*imsiQosIndex); ok {
+		return resourceETag(index.contentHash())
+	}
+	raw, err := json.Marshal(content)
+	if err != nil {
+		logger.NfConfigLog.Errorf("Failed to hash the %s configuration: %v", resource, err)
+	}
+	hash := sha256.Sum256(raw)
+	return resourceETag(hash[:])
+}
+
+// viewETag returns the ETag of the view of the resource narrowed by the
+// filter, so that the changes outside the filter do not modify it
+func (c *inMemoryConfig) viewETag(resource configResource, f configFilter) string {
+	if f.isEmpty() {
+		return c.resourceETags[resource]
+	}
+	return contentETag(resource, c.filteredView(resource, f))
+}
+
+func resourceETag(hash []byte) string {
+	return `"` + hex.EncodeToString(hash[:16]) + `"`
+}
+
+// matchesETag reports whether an If-None-Match header matches the ETag
+func matchesETag(ifNoneMatch string, etag string) bool {
+	for tag := range strings.SplitSeq(ifNoneMatch, ",") {
+		tag = strings.TrimPrefix(strings.TrimSpace(tag), "W/")
+		if tag == "*" || tag == etag {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,131 @@
+// SPDX-FileCopyrightText: 2026 Canonical Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+package nfconfig
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/omec-project/openapi/v2/nfConfigApi"
+)
+
+func TestInMemoryConfigSetVersions(t *testing.T) {
+	first := &inMemoryConfig{plmn: []nfConfigApi.PlmnId{{Mcc: "001", Mnc: "01"}}}
+	if !first.setVersions(emptyInMemoryConfig) {
+		t.Error("expected the first snapshot to change every resource")
+	}
+	for _, resource := range []configResource{plmnResource, accessMobilityResource, imsiQosResource} {
+		if version := first.resourceVersions[resource]; version != 1 {
+			t.Errorf("expected version 1 of %s in the first snapshot, got %d", resource, version)
+		}
+	}
+
+	unchanged := &inMemoryConfig{plmn: []nfConfigApi.PlmnId{{Mcc: "001", Mnc: "01"}}}
+	if unchanged.setVersions(first) {
+		t.Error("expected no change when the configuration is the same")
+	}
+	if unchanged.version != 2 || unchanged.resourceVersions[plmnResource] != 1 {
+		t.Errorf("expected snapshot version 2 with plmn version 1, got %d and %d", unchanged.version, unchanged.resourceVersions[plmnResource])
+	}
+
+	changed := &inMemoryConfig{plmn: []nfConfigApi.PlmnId{{Mcc: "001", Mnc: "01"}, {Mcc: "002", Mnc: "02"}}}
+	if !changed.setVersions(unchanged) {
+		t.Error("expected a change when a resource differs")
+	}
+	if changed.version != 3 || changed.resourceVersions[plmnResource] != 2 || changed.resourceVersions[accessMobilityResource] != 1 {
+		t.Errorf("expected snapshot version 3 with plmn version 2 and access-mobility version 1, got %d, %+v", changed.version, changed.resourceVersions)
+	}
+	if changed.resourceETags[plmnResource] == first.resourceETags[plmnResource] || changed.resourceETags[accessMobilityResource] != first.resourceETags[accessMobilityResource] {
+		t.Errorf("expected only the plmn ETag to change, got %+v and %+v", first.resourceETags, changed.resourceETags)
+	}
+}
+
+func TestInMemoryConfigSetVersions_ETagOfContent(t *testing.T) {
+	qos := []nfConfigApi.ImsiQos{{FiveQi: 9}}
+	build := func(imsis ...string) *inMemoryConfig {
+		config := &inMemoryConfig{
+			plmn:    []nfConfigApi.PlmnId{{Mcc: "001", Mnc: "01"}},
+			imsiQos: newImsiQosIndex([]imsiQosConfig{{deviceGroup: "dg1", dnn: "internet", imsis: imsis, qos: qos}}, nil),
+		}
+		config.setVersions(emptyInMemoryConfig)
+		return config
+	}
+	// another replica, or this one after a restart, with a different history
+	replica := build("001010000000002", "001010000000001")
+	replica.setVersions(build("001010000000003"))
+
+	local := build("001010000000001", "001010000000002")
+	for _, resource := range []configResource{plmnResource, imsiQosResource} {
+		if local.resourceETags[resource] != replica.resourceETags[resource] {
+			t.Errorf("expected the same %s ETag for the same content, got %s and %s", resource, local.resourceETags[resource], replica.resourceETags[resource])
+		}
+	}
+	if other := build("001010000000003"); other.resourceETags[imsiQosResource] == local.resourceETags[imsiQosResource] {
+		t.Error("expected another ETag for other IMSIs")
+	}
+}
+
+func TestNfConfigETag(t *testing.T) {
+	config := &inMemoryConfig{plmn: []nfConfigApi.PlmnId{{Mcc: "001", Mnc: "01"}}}
+	config.setVersions(emptyInMemoryConfig)
+	etag := config.resourceETags[plmnResource]
+	tests := []struct {
+		name         string
+		ifNoneMatch  string
+		expectedCode int
+	}{
+		{
+			name:         "no If-None-Match",
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:         "matching ETag",
+			ifNoneMatch:  etag,
+			expectedCode: http.StatusNotModified,
+		},
+		{
+			name:         "matching weak ETag in a list",
+			ifNoneMatch:  `"7", W/` + etag,
+			expectedCode: http.StatusNotModified,
+		},
+		{
+			name:         "any ETag",
+			ifNoneMatch:  "*",
+			expectedCode: http.StatusNotModified,
+		},
+		{
+			name:         "outdated ETag",
+			ifNoneMatch:  `"1"`,
+			expectedCode: http.StatusOK,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			nfServer := &NFConfigServer{Router: gin.New()}
+			nfServer.inMemoryConfig.Store(config)
+			nfServer.setupRoutes()
+			req, err := http.NewRequest(http.MethodGet, "/nfconfig/plmn", nil)
+			if err != nil {
+				t.Fatalf("failed to create request: %v", err)
+			}
+			if tc.ifNoneMatch != "" {
+				req.Header.Set("If-None-Match", tc.ifNoneMatch)
+			}
+			w := httptest.NewRecorder()
+			nfServer.Router.ServeHTTP(w, req)
+
+			if w.Code != tc.expectedCode {
+				t.Errorf("expected status %d, got %d", tc.expectedCode, w.Code)
+			}
+			if header := w.Header().Get("ETag"); header != etag {
+				t.Errorf("expected ETag %s, got %s", etag, header)
+			}
+			if tc.expectedCode == http.StatusNotModified && w.Body.Len() != 0 {
+				t.Errorf("expected no body, got %s", w.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,176 @@
+// SPDX-FileCopyrightText: 2026 Canonical Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package nfconfig
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/omec-project/webconsole/backend/logger"
+)
+
+type configResource string
+
+const (
+	accessMobilityResource    configResource = "access-mobility"
+	plmnResource              configResource = "plmn"
+	plmnSnssaiResource        configResource = "plmn-snssai"
+	policyControlResource     configResource = "policy-control"
+	sessionManagementResource configResource = "session-management"
+	imsiQosResource           configResource = "qos"
+)
+
+// resourceVersionHeader carries the version of the returned configuration in
+// this webconsole. NFs pass it back in the resourceVersion query parameter to
+// watch for changes, or the ETag in If-None-Match, as the ETag identifies the
+// configuration across replicas and restarts.
+const resourceVersionHeader = "X-Resource-Version"
+
+// watchTimeout bounds how long a watch request waits for a change before
+// returning 304 Not Modified
+var watchTimeout = 60 * time.Second
+
+// configWatcher wakes up the watch requests when a sync changes the
+// configuration
+type configWatcher struct {
+	mutex   sync.Mutex
+	changed chan struct{}
+	// closed on shutdown to end the watches
+	stopped  chan struct{}
+	stopOnce sync.Once
+}
+
+func newConfigWatcher() *configWatcher {
+	return &configWatcher{changed: make(chan struct{}), stopped: make(chan struct{})}
+}
+
+// stop ends the pending and future watches, so that they do not hold the
+// shutdown
+func (w *configWatcher) stop() {
+	if w == nil {
+		return
+	}
+	w.stopOnce.Do(func() { close(w.stopped) })
+}
+
+func (w *configWatcher) notify() {
+	if w == nil {
+		return
+	}
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	close(w.changed)
+	w.changed = make(chan struct{})
+}
+
+func (w *configWatcher) changes() <-chan struct{} {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	return w.changed
+}
+
+// waitForChange blocks until the ETag of the filtered view of the resource
+// does not match the If-None-Match value and returns the snapshot holding it.
+// It returns false when the context ends or the watcher stops first.
+func (n *NFConfigServer) waitForChange(ctx context.Context, resource configResource, filter configFilter, ifNoneMatch string) (*inMemoryConfig, bool) {
+	for {
+		changed := n.watcher.changes()
+		snapshot := n.snapshot()
+		if !matchesETag(ifNoneMatch, snapshot.viewETag(resource, filter)) {
+			return snapshot, true
+		}
+		select {
+		case <-ctx.Done():
+			return snapshot, false
+		case <-n.watcher.stopped:
+			return snapshot, false
+		case <-changed:
+		}
+	}
+}
+
+// serveSnapshot serves the resource from a single snapshot, with its version
+// and the ETag of the view narrowed by the request filters. With ?watch=true, it waits until the version differs from the
+// resourceVersion query parameter or, without it, until the ETag does not
+// match the If-None-Match header.
+func (n *NFConfigServer) serveSnapshot(resource configResource, handler gin.HandlerFunc) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		filter, err := parseConfigFilter(c.Request.URL.Query(), resource)
+		if err != nil {
+			logger.NfConfigLog.Warnf("Invalid %s filter: %v", resource, err)
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		if resource == imsiQosResource {
+			filter.dnn = c.Param("dnn")
+			filter.imsi = strings.TrimPrefix(c.Param("imsi"), "imsi-")
+		}
+		c.Set(filterContextKey, filter)
+		snapshot := n.snapshot()
+		watch := false
+		if watchParam := c.Query("watch"); watchParam != "" {
+			if watch, err = strconv.ParseBool(watchParam); err != nil {
+				logger.NfConfigLog.Warnf("Invalid watch query parameter: '%s'", watchParam)
+				c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "watch must be a boolean"})
+				return
+			}
+		}
+		ifNoneMatch := c.GetHeader("If-None-Match")
+		if watch && n.watcher != nil {
+			watchedETag := ifNoneMatch
+			if versionParam := c.Query("resourceVersion"); versionParam != "" {
+				resourceVersion, err := strconv.ParseUint(versionParam, 10, 64)
+				if err != nil {
+					logger.NfConfigLog.Warnf("Invalid resourceVersion query parameter: '%s'", versionParam)
+					c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "resourceVersion must be a non-negative integer"})
+					return
+				}
+				// a version this webconsole does not serve, from another
+				// replica or before a restart, returns the configuration
+				// right away
+				watchedETag = ""
+				if snapshot.resourceVersions[resource] == resourceVersion {
+					watchedETag = snapshot.viewETag(resource, filter)
+				}
+			} else if ifNoneMatch == "" {
+				logger.NfConfigLog.Warnln("Watch request without resourceVersion or If-None-Match")
+				c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "resourceVersion or If-None-Match is required when watching"})
+				return
+			}
+			ctx, cancel := context.WithTimeout(c.Request.Context(), watchTimeout)
+			defer cancel()
+			logger.NfConfigLog.Debugf("Watching NF configuration resource %s from ETag %s", resource, watchedETag)
+			var changed bool
+			if snapshot, changed = n.waitForChange(ctx, resource, filter, watchedETag); !changed {
+				n.clients.record(n.httpClientIdentity(c), resource, snapshot.resourceVersions[resource])
+				setVersionHeaders(c, snapshot, resource, filter)
+				c.Status(http.StatusNotModified)
+				return
+			}
+		}
+		n.clients.record(n.httpClientIdentity(c), resource, snapshot.resourceVersions[resource])
+		if etag := setVersionHeaders(c, snapshot, resource, filter); ifNoneMatch != "" && matchesETag(ifNoneMatch, etag) {
+			c.Status(http.StatusNotModified)
+			return
+		}
+		c.Set(snapshotContextKey, snapshot)
+		handler(c)
+	}
+}
+
+// setVersionHeaders sets the version of the resource and the ETag of its
+// filtered view, and returns the ETag
+func setVersionHeaders(c *gin.Context, snapshot *inMemoryConfig, resource configResource, filter configFilter) string {
+	etag := snapshot.viewETag(resource, filter)
+	c.Header(resourceVersionHeader, strconv.FormatUint(snapshot.resourceVersions[resource], 10))
+	c.Header("ETag", etag)
+	return etag
+}
@@ -0,0 +1,224 @@
+// SPDX-FileCopyrightText: 2026 Canonical Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+package nfconfig
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/omec-project/openapi/v2/nfConfigApi"
+)
+
+func storeSnapshot(n *NFConfigServer, config *inMemoryConfig) {
+	changed := config.setVersions(n.snapshot())
+	n.inMemoryConfig.Store(config)
+	if changed {
+		n.watcher.notify()
+	}
+}
+
+func TestWatchNfConfig(t *testing.T) {
+	originalWatchTimeout := watchTimeout
+	defer func() { watchTimeout = originalWatchTimeout }()
+	watchTimeout = 200 * time.Millisecond
+	initialPlmns := []nfConfigApi.PlmnId{{Mcc: "001", Mnc: "01"}}
+	updatedPlmns := []nfConfigApi.PlmnId{{Mcc: "001", Mnc: "01"}, {Mcc: "002", Mnc: "02"}}
+	initial := &inMemoryConfig{plmn: initialPlmns}
+	initial.setVersions(emptyInMemoryConfig)
+	initialETag := initial.resourceETags[plmnResource]
+
+	tests := []struct {
+		name            string
+		query           string
+		ifNoneMatch     string
+		update          bool
+		expectedCode    int
+		expectedVersion string
+		expectedPlmns   []nfConfigApi.PlmnId
+	}{
+		{
+			name:            "no watch",
+			query:           "",
+			expectedCode:    http.StatusOK,
+			expectedVersion: "1",
+			expectedPlmns:   initialPlmns,
+		},
+		{
+			name:            "watch from another version",
+			query:           "?watch=true&resourceVersion=5",
+			expectedCode:    http.StatusOK,
+			expectedVersion: "1",
+			expectedPlmns:   initialPlmns,
+		},
+		{
+			name:            "watch version until the configuration changes",
+			query:           "?watch=true&resourceVersion=1",
+			update:          true,
+			expectedCode:    http.StatusOK,
+			expectedVersion: "2",
+			expectedPlmns:   updatedPlmns,
+		},
+		{
+			name:            "watch version without change",
+			query:           "?watch=true&resourceVersion=1",
+			expectedCode:    http.StatusNotModified,
+			expectedVersion: "1",
+		},
+		{
+			name:         "invalid resource version",
+			query:        "?watch=true&resourceVersion=latest",
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:            "watch from another configuration",
+			query:           "?watch=true",
+			ifNoneMatch:     `"0"`,
+			expectedCode:    http.StatusOK,
+			expectedVersion: "1",
+			expectedPlmns:   initialPlmns,
+		},
+		{
+			name:            "watch until the configuration changes",
+			query:           "?watch=true",
+			ifNoneMatch:     initialETag,
+			update:          true,
+			expectedCode:    http.StatusOK,
+			expectedVersion: "2",
+			expectedPlmns:   updatedPlmns,
+		},
+		{
+			name:            "watch without change",
+			query:           "?watch=true",
+			ifNoneMatch:     initialETag,
+			expectedCode:    http.StatusNotModified,
+			expectedVersion: "1",
+		},
+		{
+			name:         "watch without version or ETag",
+			query:        "?watch=true",
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "invalid watch",
+			query:        "?watch=sometimes",
+			ifNoneMatch:  initialETag,
+			expectedCode: http.StatusBadRequest,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			nfServer := &NFConfigServer{
+				Router:  gin.New(),
+				watcher: newConfigWatcher(),
+			}
+			storeSnapshot(nfServer, &inMemoryConfig{plmn: initialPlmns})
+			nfServer.setupRoutes()
+			if tc.update {
+				go func() {
+					time.Sleep(20 * time.Millisecond)
+					storeSnapshot(nfServer, &inMemoryConfig{plmn: updatedPlmns})
+				}()
+			}
+			req, err := http.NewRequest(http.MethodGet, "/nfconfig/plmn"+tc.query, nil)
+			if err != nil {
+				t.Fatalf("failed to create request: %v", err)
+			}
+			if tc.ifNoneMatch != "" {
+				req.Header.Set("If-None-Match", tc.ifNoneMatch)
+			}
+			w := httptest.NewRecorder()
+			nfServer.Router.ServeHTTP(w, req)
+
+			if w.Code != tc.expectedCode {
+				t.Fatalf("expected status %d, got %d", tc.expectedCode, w.Code)
+			}
+			if version := w.Header().Get(resourceVersionHeader); version != tc.expectedVersion {
+				t.Errorf("expected resource version '%s', got '%s'", tc.expectedVersion, version)
+			}
+			if tc.expectedPlmns == nil {
+				return
+			}
+			var plmns []nfConfigApi.PlmnId
+			if err = json.Unmarshal(w.Body.Bytes(), &plmns); err != nil {
+				t.Fatalf("failed to unmarshal body: %v", err)
+			}
+			if len(plmns) != len(tc.expectedPlmns) {
+				t.Errorf("expected PLMNs %v, got %v", tc.expectedPlmns, plmns)
+			}
+		})
+	}
+}
+
+func TestWatchNfConfig_Filtered(t *testing.T) {
+	originalWatchTimeout := watchTimeout
+	defer func() { watchTimeout = originalWatchTimeout }()
+	watchTimeout = 200 * time.Millisecond
+
+	tests := []struct {
+		name            string
+		update          func(config *inMemoryConfig)
+		expectedCode    int
+		expectedVersion string
+	}{
+		{
+			name: "change outside of the filter",
+			update: func(config *inMemoryConfig) {
+				config.sessionManagement[1].IpDomain = []nfConfigApi.IpDomain{{DnnName: "enterprise"}}
+			},
+			expectedCode:    http.StatusNotModified,
+			expectedVersion: "2",
+		},
+		{
+			name: "change within the filter",
+			update: func(config *inMemoryConfig) {
+				config.sessionManagement[0].IpDomain = []nfConfigApi.IpDomain{{DnnName: "enterprise"}}
+			},
+			expectedCode:    http.StatusOK,
+			expectedVersion: "2",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			nfServer := newFilterTestServer()
+			nfServer.watcher = newConfigWatcher()
+			config := *nfServer.snapshot()
+			nfServer.inMemoryConfig.Store(&inMemoryConfig{})
+			storeSnapshot(nfServer, &config)
+
+			w := httptest.NewRecorder()
+			nfServer.Router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/nfconfig/session-management?upf=upf1", nil))
+			etag := w.Header().Get("ETag")
+			if etag == "" || etag == config.resourceETags[sessionManagementResource] {
+				t.Fatalf("expected the ETag of the filtered view, got '%s'", etag)
+			}
+
+			go func() {
+				time.Sleep(20 * time.Millisecond)
+				updated := *nfServer.snapshot()
+				updated.sessionManagement = slices.Clone(updated.sessionManagement)
+				tc.update(&updated)
+				storeSnapshot(nfServer, &updated)
+			}()
+			req := httptest.NewRequest(http.MethodGet, "/nfconfig/session-management?upf=upf1&watch=true", nil)
+			req.Header.Set("If-None-Match", etag)
+			w = httptest.NewRecorder()
+			nfServer.Router.ServeHTTP(w, req)
+
+			if w.Code != tc.expectedCode {
+				t.Fatalf("expected status %d, got %d", tc.expectedCode, w.Code)
+			}
+			if version := w.Header().Get(resourceVersionHeader); version != tc.expectedVersion {
+				t.Errorf("expected resource version '%s', got '%s'", tc.expectedVersion, version)
+			}
+			if changed := w.Header().Get("ETag") != etag; changed != (tc.expectedCode == http.StatusOK) {
+				t.Errorf("expected the ETag to change only with the filtered view, got '%s' from '%s'", w.Header().Get("ETag"), etag)
+			}
+		})
+	}
+}
@@ -105,7 +105,6 @@ func (context *WEBUIContext) GetOamUris(targetNfType models.NFType) (uris []stri
 	for _, oamInstance := range context.NFOamInstances {
 		if oamInstance.NfType == targetNfType {
 			uris = append(uris, oamInstance.Uri)
-			break
 		}
 	}
 	return
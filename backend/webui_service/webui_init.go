@@ -33,13 +33,14 @@ type WebUIInterface interface {
 	Start(ctx context.Context, syncChan chan<- struct{})
 }
 
-func setupAuthenticationFeature(subconfig_router *gin.Engine, nfSyncMiddelware gin.HandlerFunc) {
+func setupAuthenticationFeature(subconfig_router *gin.Engine, nfSyncMiddelware gin.HandlerFunc, syncChan chan<- struct{}) {
 	jwtSecret, err := auth.GenerateJWTSecret()
 	if err != nil {
 		logger.InitLog.Error(err)
 		return
 	}
 	configapi.AddUserAccountService(subconfig_router, jwtSecret)
+	configapi.AddUpfRegistrationService(subconfig_router, jwtSecret, syncChan)
 	auth.AddAuthenticationService(subconfig_router, jwtSecret)
 	authMiddleware := auth.AdminOrUserAuthMiddleware(jwtSecret)
 	configapi.AddApiService(subconfig_router, authMiddleware)
@@ -50,8 +51,9 @@ func (webui *WEBUI) Start(ctx context.Context, syncChan chan<- struct{}) {
 	subconfig_router := utilLogger.NewGinWithZap(logger.GinLog)
 	nFConfigSyncMiddleware := triggerNFConfigSyncMiddleware(syncChan)
 	if factory.WebUIConfig.Configuration.EnableAuthentication {
-		setupAuthenticationFeature(subconfig_router, nFConfigSyncMiddleware)
+		setupAuthenticationFeature(subconfig_router, nFConfigSyncMiddleware, syncChan)
 	} else {
+		configapi.AddUpfRegistrationService(subconfig_router, nil, syncChan)
 		configapi.AddApiService(subconfig_router)
 		configapi.AddConfigV1Service(subconfig_router, nFConfigSyncMiddleware)
 	}
@@ -112,6 +114,10 @@ func (webui *WEBUI) Start(ctx context.Context, syncChan chan<- struct{}) {
 
 	self := webui_context.WEBUI_Self()
 	self.UpdateNfProfiles()
+	if err := configapi.BackfillDnnCatalog(ctx); err != nil {
+		logger.InitLog.Errorf("failed to add the DNNs of the device groups to the DNN catalog: %+v", err)
+	}
+	go configapi.StartGnbDiscovery(ctx, syncChan)
 
 	// fetch one time configuration from the simapp/roc on startup
 	// this is to fetch existing config
@@ -0,0 +1,206 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2026 Canonical Ltd
+
+package configapi
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/omec-project/webconsole/backend/logger"
+	"github.com/omec-project/webconsole/configmodels"
+	"github.com/omec-project/webconsole/dbadapter"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// GetApplications godoc
+//
+// @Description Return the list of applications
+// @Tags        Applications
+// @Produce     json
+// @Security    BearerAuth
+// @Success     200  {array}   configmodels.SliceApplicationsInformation  "List of applications"
+// @Failure     401  {object}  nil                                        "Authorization failed"
+// @Failure     403  {object}  nil                                        "Forbidden"
+// @Failure     500  {object}  nil                                        "Error retrieving applications"
+// @Router      /config/v1/application  [get]
+func GetApplications(c *gin.Context) {
+	setInventoryCorsHeader(c)
+	logger.WebUILog.Infoln("received a GET applications request")
+	applications := make([]*configmodels.SliceApplicationsInformation, 0)
+	rawApplications, err := dbadapter.CommonDBClient.RestfulAPIGetMany(configmodels.ApplicationDataColl, bson.M{})
+	if err != nil {
+		logger.DbLog.Errorf("failed to retrieve applications with error: %+v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to retrieve applications"})
+		return
+	}
+
+	for _, rawApplication := range rawApplications {
+		var application configmodels.SliceApplicationsInformation
+		if err = json.Unmarshal(configmodels.MapToByte(rawApplication), &application); err != nil {
+			logger.DbLog.Errorf("could not unmarshal application %s", rawApplication)
+			continue
+		}
+		applications = append(applications, &application)
+	}
+	logger.WebUILog.Infoln("successfully executed GET applications request")
+	c.JSON(http.StatusOK, applications)
+}
+
+// GetApplicationByName godoc
+//
+// @Description Return the application
+// @Tags        Applications
+// @Produce     json
+// @Param       app-name  path  string  true  "Name of the application"
+// @Security    BearerAuth
+// @Success     200  {object}  configmodels.SliceApplicationsInformation  "Application"
+// @Failure     401  {object}  nil                                        "Authorization failed"
+// @Failure     403  {object}  nil                                        "Forbidden"
+// @Failure     404  {object}  nil                                        "Application not found"
+// @Failure     500  {object}  nil                                        "Error retrieving application"
+// @Router      /config/v1/application/{app-name}  [get]
+func GetApplicationByName(c *gin.Context) {
+	setInventoryCorsHeader(c)
+	logger.WebUILog.Infoln("received a GET application request")
+	name, _ := c.Params.Get("app-name")
+	application, err := getApplicationByName(name)
+	if err != nil {
+		logger.DbLog.Errorf("failed to retrieve application %s with error: %+v", name, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to retrieve application"})
+		return
+	}
+	if application == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": fmt.Sprintf("application %s not found", name)})
+		return
+	}
+	c.JSON(http.StatusOK, application)
+}
+
+// PostApplication godoc
+//
+// @Description Create a new application
+// @Tags        Applications
+// @Produce     json
+// @Param       application  body  configmodels.SliceApplicationsInformation  true  "Application to create"
+// @Security    BearerAuth
+// @Success     201  {object}  nil  "Application successfully created"
+// @Failure     400  {object}  nil  "Bad request"
+// @Failure     401  {object}  nil  "Authorization failed"
+// @Failure     403  {object}  nil  "Forbidden"
+// @Failure     500  {object}  nil  "Error creating application"
+// @Router      /config/v1/application  [post]
+func PostApplication(c *gin.Context) {
+	setInventoryCorsHeader(c)
+	logger.WebUILog.Infoln("received a POST application request")
+	var application configmodels.SliceApplicationsInformation
+	if err := c.ShouldBindJSON(&application); err != nil {
+		logger.WebUILog.Errorf("invalid application POST input parameters with error: %+v", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid JSON format"})
+		return
+	}
+	if err := validateApplication(application); err != nil {
+		logger.WebUILog.Errorln(err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	filter := bson.M{"app-name": application.AppName}
+	applicationBson := configmodels.ToBsonM(application)
+	err := dbadapter.CommonDBClient.RestfulAPIPostManyWithContext(c.Request.Context(), configmodels.ApplicationDataColl, filter, []any{applicationBson})
+	if err != nil {
+		if strings.Contains(err.Error(), "E11000") {
+			logger.WebUILog.Errorf("duplicate application name found error: %+v", err)
+			c.JSON(http.StatusBadRequest, gin.H{"error": "application already exists"})
+			return
+		}
+		logger.WebUILog.Errorf("failed to create application %s with error: %+v", application.AppName, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to create application"})
+		return
+	}
+	logger.WebUILog.Infof("successfully executed POST application %s request", application.AppName)
+	c.JSON(http.StatusCreated, gin.H{})
+}
+
+// PutApplication godoc
+//
+// @Description Create or update an application. Network slice rules referencing the application use the new flow at the next sync.
+// @Tags        Applications
+// @Produce     json
+// @Param       app-name     path  string                              true  "Name of the application"
+// @Param       application  body  configmodels.PutApplicationRequest  true  "Application parameters"
+// @Security    BearerAuth
+// @Success     200  {object}  nil  "Application successfully updated"
+// @Failure     400  {object}  nil  "Bad request"
+// @Failure     401  {object}  nil  "Authorization failed"
+// @Failure     403  {object}  nil  "Forbidden"
+// @Failure     500  {object}  nil  "Error updating application"
+// @Router      /config/v1/application/{app-name}  [put]
+func PutApplication(c *gin.Context) {
+	setInventoryCorsHeader(c)
+	logger.WebUILog.Infoln("received a PUT application request")
+	name, _ := c.Params.Get("app-name")
+	var putApplicationParams configmodels.PutApplicationRequest
+	if err := c.ShouldBindJSON(&putApplicationParams); err != nil {
+		logger.WebUILog.Errorf("invalid application PUT input parameters for %s with error: %+v", name, err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid JSON format"})
+		return
+	}
+	application := configmodels.SliceApplicationsInformation{
+		AppName:   name,
+		Endpoint:  putApplicationParams.Endpoint,
+		StartPort: putApplicationParams.StartPort,
+		EndPort:   putApplicationParams.EndPort,
+		Protocol:  putApplicationParams.Protocol,
+	}
+	if err := validateApplication(application); err != nil {
+		logger.WebUILog.Errorln(err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	filter := bson.M{"app-name": name}
+	applicationBson := configmodels.ToBsonM(application)
+	if _, err := dbadapter.CommonDBClient.RestfulAPIPutOne(configmodels.ApplicationDataColl, filter, applicationBson); err != nil {
+		logger.WebUILog.Errorf("failed to PUT application %s with error: %+v", name, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to PUT application"})
+		return
+	}
+	logger.WebUILog.Infof("successfully executed PUT application %s request", name)
+	c.JSON(http.StatusOK, gin.H{})
+}
+
+// DeleteApplication godoc
+//
+// @Description Delete an existing application. The application cannot be deleted while network slice rules reference it.
+// @Tags        Applications
+// @Produce     json
+// @Param       app-name  path  string  true  "Name of the application"
+// @Security    BearerAuth
+// @Success     200  {object}  nil  "Application deleted"
+// @Failure     401  {object}  nil  "Authorization failed"
+// @Failure     403  {object}  nil  "Forbidden"
+// @Failure     409  {object}  nil  "Application is in use"
+// @Failure     500  {object}  nil  "Failed to delete application"
+// @Router      /config/v1/application/{app-name}  [delete]
+func DeleteApplication(c *gin.Context) {
+	setInventoryCorsHeader(c)
+	logger.WebUILog.Infoln("received a DELETE application request")
+	name, _ := c.Params.Get("app-name")
+	err := deleteUnusedItem(c.Request.Context(), "application "+name, configmodels.ApplicationDataColl, bson.M{"app-name": name}, sliceReference(bson.M{"application-filtering-rules.app-name": name}))
+	if err != nil {
+		var inUse *inUseError
+		if errors.As(err, &inUse) {
+			logger.WebUILog.Errorf("%s by %v", inUse.Error(), inUse.references)
+			c.JSON(http.StatusConflict, inUse.body())
+			return
+		}
+		logger.WebUILog.Errorf("failed to delete application %s with error: %+v", name, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to delete application"})
+		return
+	}
+	logger.WebUILog.Infof("successfully executed DELETE application %s request", name)
+	c.JSON(http.StatusOK, gin.H{})
+}
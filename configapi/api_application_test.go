@@ -0,0 +1,396 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2026 Canonical Ltd
+
+package configapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/omec-project/webconsole/configmodels"
+	"github.com/omec-project/webconsole/dbadapter"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+type ApplicationMockDBClient struct {
+	dbadapter.DBInterface
+	applications []configmodels.SliceApplicationsInformation
+	slices       []configmodels.Slice
+	putData      []map[string]any
+	deleted      []bson.M
+	err          error
+}
+
+func (db *ApplicationMockDBClient) RestfulAPIGetOne(coll string, filter bson.M) (map[string]any, error) {
+	if db.err != nil {
+		return nil, db.err
+	}
+	if coll == configmodels.ApplicationDataColl {
+		for _, application := range db.applications {
+			if application.AppName == filter["app-name"] {
+				return configmodels.ToBsonM(application), nil
+			}
+		}
+	}
+	return nil, nil
+}
+
+func (db *ApplicationMockDBClient) RestfulAPIGetMany(coll string, filter bson.M) ([]map[string]any, error) {
+	if db.err != nil {
+		return nil, db.err
+	}
+	var results []map[string]any
+	switch coll {
+	case configmodels.ApplicationDataColl:
+		for _, application := range db.applications {
+			results = append(results, configmodels.ToBsonM(application))
+		}
+	case sliceDataColl:
+		for _, slice := range db.slices {
+			results = append(results, configmodels.ToBsonM(slice))
+		}
+	}
+	return results, nil
+}
+
+func (db *ApplicationMockDBClient) RestfulAPIGetManyWithContext(context context.Context, coll string, filter bson.M) ([]map[string]any, error) {
+	return db.RestfulAPIGetMany(coll, filter)
+}
+
+func (db *ApplicationMockDBClient) StartSession() (dbadapter.DBSession, error) {
+	return nil, nil
+}
+
+func (db *ApplicationMockDBClient) RestfulAPIPostManyWithContext(context context.Context, collName string, filter bson.M, postDataArray []any) error {
+	if db.err != nil {
+		return db.err
+	}
+	for _, application := range db.applications {
+		if application.AppName == filter["app-name"] {
+			return errors.New("E11000")
+		}
+	}
+	return nil
+}
+
+func (db *ApplicationMockDBClient) RestfulAPIPutOne(collName string, filter bson.M, putData map[string]any) (bool, error) {
+	if db.err != nil {
+		return false, db.err
+	}
+	db.putData = append(db.putData, putData)
+	return true, nil
+}
+
+func (db *ApplicationMockDBClient) RestfulAPIDeleteOne(collName string, filter bson.M) error {
+	if db.err != nil {
+		return db.err
+	}
+	db.deleted = append(db.deleted, filter)
+	return nil
+}
+
+func (db *ApplicationMockDBClient) RestfulAPIDeleteOneWithContext(context context.Context, collName string, filter bson.M) error {
+	return db.RestfulAPIDeleteOne(collName, filter)
+}
+
+var videoApplication = configmodels.SliceApplicationsInformation{
+	AppName:   "video",
+	Endpoint:  "10.10.0.0/16",
+	StartPort: 8000,
+	EndPort:   8080,
+	Protocol:  6,
+}
+
+func TestApplicationGetHandlers(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+	AddConfigV1Service(router)
+
+	testCases := []struct {
+		name         string
+		route        string
+		dbAdapter    dbadapter.DBInterface
+		expectedCode int
+		expectedBody string
+	}{
+		{
+			name:         "List applications",
+			route:        "/config/v1/application",
+			dbAdapter:    &ApplicationMockDBClient{applications: []configmodels.SliceApplicationsInformation{videoApplication}},
+			expectedCode: http.StatusOK,
+			expectedBody: `[{"app-name":"video","endpoint":"10.10.0.0/16","start-port":8000,"end-port":8080,"protocol":6}]`,
+		},
+		{
+			name:         "List applications when there are none",
+			route:        "/config/v1/application",
+			dbAdapter:    &ApplicationMockDBClient{},
+			expectedCode: http.StatusOK,
+			expectedBody: `[]`,
+		},
+		{
+			name:         "Get existing application",
+			route:        "/config/v1/application/video",
+			dbAdapter:    &ApplicationMockDBClient{applications: []configmodels.SliceApplicationsInformation{videoApplication}},
+			expectedCode: http.StatusOK,
+			expectedBody: `{"app-name":"video","endpoint":"10.10.0.0/16","start-port":8000,"end-port":8080,"protocol":6}`,
+		},
+		{
+			name:         "Get missing application",
+			route:        "/config/v1/application/gaming",
+			dbAdapter:    &ApplicationMockDBClient{},
+			expectedCode: http.StatusNotFound,
+			expectedBody: `{"error":"application gaming not found"}`,
+		},
+		{
+			name:         "List applications DB error",
+			route:        "/config/v1/application",
+			dbAdapter:    &ApplicationMockDBClient{err: errors.New("mock error")},
+			expectedCode: http.StatusInternalServerError,
+			expectedBody: `{"error":"failed to retrieve applications"}`,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			originalDBClient := dbadapter.CommonDBClient
+			defer func() { dbadapter.CommonDBClient = originalDBClient }()
+			dbadapter.CommonDBClient = tc.dbAdapter
+			req, err := http.NewRequest(http.MethodGet, tc.route, nil)
+			if err != nil {
+				t.Fatalf("failed to create request: %v", err)
+			}
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			if tc.expectedCode != w.Code {
+				t.Errorf("expected `%v`, got `%v`", tc.expectedCode, w.Code)
+			}
+			if w.Body.String() != tc.expectedBody {
+				t.Errorf("expected `%v`, got `%v`", tc.expectedBody, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestApplicationWriteHandlers(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+	AddConfigV1Service(router)
+
+	testCases := []struct {
+		name          string
+		method        string
+		route         string
+		dbAdapter     dbadapter.DBInterface
+		inputData     string
+		expectedCode  int
+		expectedError string
+	}{
+		{
+			name:         "Create a new application",
+			method:       http.MethodPost,
+			route:        "/config/v1/application",
+			dbAdapter:    &ApplicationMockDBClient{},
+			inputData:    `{"app-name": "video", "endpoint": "10.10.0.0/16", "start-port": 8000, "end-port": 8080, "protocol": 6}`,
+			expectedCode: http.StatusCreated,
+		},
+		{
+			name:          "Create an existing application",
+			method:        http.MethodPost,
+			route:         "/config/v1/application",
+			dbAdapter:     &ApplicationMockDBClient{applications: []configmodels.SliceApplicationsInformation{videoApplication}},
+			inputData:     `{"app-name": "video", "endpoint": "10.10.0.1"}`,
+			expectedCode:  http.StatusBadRequest,
+			expectedError: "application already exists",
+		},
+		{
+			name:          "Create an application with invalid name",
+			method:        http.MethodPost,
+			route:         "/config/v1/application",
+			dbAdapter:     &ApplicationMockDBClient{},
+			inputData:     `{"app-name": "vid&o", "endpoint": "10.10.0.1"}`,
+			expectedCode:  http.StatusBadRequest,
+			expectedError: "invalid application name",
+		},
+		{
+			name:          "Create an application with invalid endpoint",
+			method:        http.MethodPost,
+			route:         "/config/v1/application",
+			dbAdapter:     &ApplicationMockDBClient{},
+			inputData:     `{"app-name": "video", "endpoint": "video.example.com"}`,
+			expectedCode:  http.StatusBadRequest,
+			expectedError: "invalid endpoint",
+		},
+		{
+			name:          "Create an application with invalid port range",
+			method:        http.MethodPost,
+			route:         "/config/v1/application",
+			dbAdapter:     &ApplicationMockDBClient{},
+			inputData:     `{"app-name": "video", "endpoint": "10.10.0.1", "start-port": 8080, "end-port": 8000}`,
+			expectedCode:  http.StatusBadRequest,
+			expectedError: "invalid port range",
+		},
+		{
+			name:          "Create an application with invalid protocol",
+			method:        http.MethodPost,
+			route:         "/config/v1/application",
+			dbAdapter:     &ApplicationMockDBClient{},
+			inputData:     `{"app-name": "video", "endpoint": "10.10.0.1", "protocol": 300}`,
+			expectedCode:  http.StatusBadRequest,
+			expectedError: "invalid protocol",
+		},
+		{
+			name:         "Update an application",
+			method:       http.MethodPut,
+			route:        "/config/v1/application/video",
+			dbAdapter:    &ApplicationMockDBClient{applications: []configmodels.SliceApplicationsInformation{videoApplication}},
+			inputData:    `{"endpoint": "10.20.0.0/16", "protocol": 17}`,
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:          "Update an application DB error",
+			method:        http.MethodPut,
+			route:         "/config/v1/application/video",
+			dbAdapter:     &ApplicationMockDBClient{err: errors.New("mock error")},
+			inputData:     `{"endpoint": "10.20.0.0/16"}`,
+			expectedCode:  http.StatusInternalServerError,
+			expectedError: "failed to PUT application",
+		},
+		{
+			name:         "Delete an unused application",
+			method:       http.MethodDelete,
+			route:        "/config/v1/application/video",
+			dbAdapter:    &ApplicationMockDBClient{applications: []configmodels.SliceApplicationsInformation{videoApplication}},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:   "Delete an application used by a network slice",
+			method: http.MethodDelete,
+			route:  "/config/v1/application/video",
+			dbAdapter: &ApplicationMockDBClient{
+				applications: []configmodels.SliceApplicationsInformation{videoApplication},
+				slices:       []configmodels.Slice{{SliceName: "slice1"}},
+			},
+			expectedCode:  http.StatusConflict,
+			expectedError: "application video is in use",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			originalDBClient := dbadapter.CommonDBClient
+			defer func() { dbadapter.CommonDBClient = originalDBClient }()
+			dbadapter.CommonDBClient = tc.dbAdapter
+			req, err := http.NewRequest(tc.method, tc.route, strings.NewReader(tc.inputData))
+			if err != nil {
+				t.Fatalf("failed to create request: %v", err)
+			}
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			if tc.expectedCode != w.Code {
+				t.Errorf("expected `%v`, got `%v`", tc.expectedCode, w.Code)
+			}
+			var body map[string]any
+			if err = json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+				t.Fatalf("failed to unmarshal JSON: %v", err)
+			}
+			if tc.expectedError == "" {
+				if len(body) != 0 {
+					t.Errorf("expected empty body, got %v", body)
+				}
+				return
+			}
+			errorMessage, _ := body["error"].(string)
+			if !strings.Contains(errorMessage, tc.expectedError) {
+				t.Errorf("expected error containing `%s`, got `%v`", tc.expectedError, body)
+			}
+		})
+	}
+}
+
+func TestResolveSliceApplications(t *testing.T) {
+	catalog := map[string]configmodels.SliceApplicationsInformation{"video": videoApplication}
+	slice := configmodels.Slice{
+		SliceName: "slice1",
+		ApplicationFilteringRules: []configmodels.SliceApplicationFilteringRules{
+			{RuleName: "by-app", AppName: "video", Priority: 10},
+			{RuleName: "inline", Endpoint: "8.8.8.8/32", Protocol: 17},
+			{RuleName: "unknown", AppName: "gaming"},
+		},
+	}
+	original := slice.ApplicationFilteringRules
+
+	err := ResolveSliceApplications(&slice, catalog)
+	if err == nil || !strings.Contains(err.Error(), "rule unknown: application gaming not found") {
+		t.Errorf("expected error for unknown application, got %v", err)
+	}
+	expected := []configmodels.SliceApplicationFilteringRules{
+		{RuleName: "by-app", AppName: "video", Priority: 10, Endpoint: "10.10.0.0/16", Protocol: 6, StartPort: 8000, EndPort: 8080},
+		{RuleName: "inline", Endpoint: "8.8.8.8/32", Protocol: 17},
+		{RuleName: "unknown", AppName: "gaming"},
+	}
+	if !reflect.DeepEqual(slice.ApplicationFilteringRules, expected) {
+		t.Errorf("expected %+v, got %+v", expected, slice.ApplicationFilteringRules)
+	}
+	if original[0].Endpoint != "" {
+		t.Errorf("expected original rules not to be modified")
+	}
+}
+
+func TestNetworkSlicePost_ApplicationReference(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+	AddConfigV1Service(router)
+
+	testCases := []struct {
+		name          string
+		rule          configmodels.SliceApplicationFilteringRules
+		expectedError string
+	}{
+		{
+			name:          "Unknown application",
+			rule:          configmodels.SliceApplicationFilteringRules{RuleName: "rule-1", AppName: "gaming", TrafficClass: &platinumTrafficClass},
+			expectedError: "application gaming not found",
+		},
+		{
+			name:          "Application and endpoint",
+			rule:          configmodels.SliceApplicationFilteringRules{RuleName: "rule-1", AppName: "video", Endpoint: "8.8.8.8", TrafficClass: &platinumTrafficClass},
+			expectedError: "application video and endpoint, protocol or ports cannot be set together",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			originalDBClient := dbadapter.CommonDBClient
+			defer func() { dbadapter.CommonDBClient = originalDBClient }()
+			dbadapter.CommonDBClient = &ApplicationMockDBClient{applications: []configmodels.SliceApplicationsInformation{videoApplication}}
+			slice := networkSliceWithGnbParams("slice-1", "valid-gnb", 3)
+			slice.ApplicationFilteringRules = []configmodels.SliceApplicationFilteringRules{tc.rule}
+			jsonBody, err := json.Marshal(slice)
+			if err != nil {
+				t.Fatalf("failed to marshal network slice %v", err)
+			}
+			req, err := http.NewRequest(http.MethodPost, "/config/v1/network-slice/slice-1", strings.NewReader(string(jsonBody)))
+			if err != nil {
+				t.Fatalf("failed to create request: %v", err)
+			}
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != http.StatusBadRequest {
+				t.Errorf("expected `%v`, got `%v`", http.StatusBadRequest, w.Code)
+			}
+			if !strings.Contains(w.Body.String(), tc.expectedError) {
+				t.Errorf("expected body to contain `%s`, got `%v`", tc.expectedError, w.Body.String())
+			}
+		})
+	}
+}
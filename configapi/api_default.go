@@ -15,6 +15,7 @@ package configapi
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"strings"
@@ -105,14 +106,16 @@ func GetDeviceGroupByName(c *gin.Context) {
 
 // DeviceGroupGroupNameDelete godoc
 //
-// @Description  Delete an existing device group
+// @Description  Delete an existing device group. The device group cannot be deleted while network slices use it, unless cascade is set to remove it from them.
 // @Tags         Device Groups
-// @Param        deviceGroupName    path    string    true    " "
+// @Param        deviceGroupName    path     string    true     " "
+// @Param        cascade            query    bool      false    "Remove the device group from the network slices using it"
 // @Security     BearerAuth
 // @Success      200  {object}  nil  "Device group deleted successfully"
 // @Failure      400  {object}  nil  "Bad request"
 // @Failure      401  {object}  nil  "Authorization failed"
 // @Failure      403  {object}  nil  "Forbidden"
+// @Failure      409  {object}  nil  "Device group is in use"
 // @Failure      500  {object}  nil  "Device Group Deletion Failed"
 // @Router       /config/v1/device-group/{deviceGroupName}  [delete]
 func DeviceGroupGroupNameDelete(c *gin.Context) {
@@ -127,8 +130,30 @@ func DeviceGroupGroupNameDelete(c *gin.Context) {
 		})
 		return
 	}
+	cascade, err := isCascadeDelete(c)
+	if err != nil {
+		logger.WebUILog.Errorf("Request ID: %s %+v", requestID, err)
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":      err.Error(),
+			"request_id": requestID,
+		})
+		return
+	}
 	logger.WebUILog.Debugf("Request ID: %s Attempting to delete device group: %s", requestID, groupName)
-	if err := deviceGroupDeleteHelper(groupName); err != nil {
+	if cascade {
+		err = deviceGroupDeleteHelper(groupName)
+	} else {
+		err = deleteUnusedDeviceGroup(c.Request.Context(), groupName)
+	}
+	if err != nil {
+		var inUse *inUseError
+		if errors.As(err, &inUse) {
+			logger.WebUILog.Errorf("Request ID: %s %s by %v", requestID, inUse.Error(), inUse.references)
+			body := inUse.body()
+			body["request_id"] = requestID
+			c.JSON(http.StatusConflict, body)
+			return
+		}
 		logger.WebUILog.Errorf("Request ID: %s Device group delete failed: %+v", requestID, err)
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"error":      fmt.Sprintf("Failed to delete device group %s with error: %+v.", groupName, err),
@@ -0,0 +1,206 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2026 Canonical Ltd
+
+package configapi
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/omec-project/webconsole/backend/logger"
+	"github.com/omec-project/webconsole/configmodels"
+	"github.com/omec-project/webconsole/dbadapter"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// GetDnns godoc
+//
+// @Description Return the list of DNNs
+// @Tags        DNNs
+// @Produce     json
+// @Security    BearerAuth
+// @Success     200  {array}   configmodels.Dnn  "List of DNNs"
+// @Failure     401  {object}  nil               "Authorization failed"
+// @Failure     403  {object}  nil               "Forbidden"
+// @Failure     500  {object}  nil               "Error retrieving DNNs"
+// @Router      /config/v1/dnn  [get]
+func GetDnns(c *gin.Context) {
+	setInventoryCorsHeader(c)
+	logger.WebUILog.Infoln("received a GET DNNs request")
+	dnns := make([]*configmodels.Dnn, 0)
+	rawDnns, err := dbadapter.CommonDBClient.RestfulAPIGetMany(configmodels.DnnDataColl, bson.M{})
+	if err != nil {
+		logger.DbLog.Errorf("failed to retrieve DNNs with error: %+v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to retrieve DNNs"})
+		return
+	}
+
+	for _, rawDnn := range rawDnns {
+		var dnn configmodels.Dnn
+		if err = json.Unmarshal(configmodels.MapToByte(rawDnn), &dnn); err != nil {
+			logger.DbLog.Errorf("could not unmarshal DNN %s", rawDnn)
+			continue
+		}
+		dnns = append(dnns, &dnn)
+	}
+	logger.WebUILog.Infoln("successfully executed GET DNNs request")
+	c.JSON(http.StatusOK, dnns)
+}
+
+// GetDnnByName godoc
+//
+// @Description Return the DNN
+// @Tags        DNNs
+// @Produce     json
+// @Param       dnn-name  path  string  true  "Name of the DNN"
+// @Security    BearerAuth
+// @Success     200  {object}  configmodels.Dnn  "DNN"
+// @Failure     401  {object}  nil               "Authorization failed"
+// @Failure     403  {object}  nil               "Forbidden"
+// @Failure     404  {object}  nil               "DNN not found"
+// @Failure     500  {object}  nil               "Error retrieving DNN"
+// @Router      /config/v1/dnn/{dnn-name}  [get]
+func GetDnnByName(c *gin.Context) {
+	setInventoryCorsHeader(c)
+	logger.WebUILog.Infoln("received a GET DNN request")
+	name, _ := c.Params.Get("dnn-name")
+	dnn, err := getDnnByName(name)
+	if err != nil {
+		logger.DbLog.Errorf("failed to retrieve DNN %s with error: %+v", name, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to retrieve DNN"})
+		return
+	}
+	if dnn == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": fmt.Sprintf("DNN %s not found", name)})
+		return
+	}
+	c.JSON(http.StatusOK, dnn)
+}
+
+// PostDnn godoc
+//
+// @Description Create a new DNN
+// @Tags        DNNs
+// @Produce     json
+// @Param       dnn  body  configmodels.Dnn  true  "DNN to create"
+// @Security    BearerAuth
+// @Success     201  {object}  nil  "DNN successfully created"
+// @Failure     400  {object}  nil  "Bad request"
+// @Failure     401  {object}  nil  "Authorization failed"
+// @Failure     403  {object}  nil  "Forbidden"
+// @Failure     500  {object}  nil  "Error creating DNN"
+// @Router      /config/v1/dnn  [post]
+func PostDnn(c *gin.Context) {
+	setInventoryCorsHeader(c)
+	logger.WebUILog.Infoln("received a POST DNN request")
+	var dnn configmodels.Dnn
+	if err := c.ShouldBindJSON(&dnn); err != nil {
+		logger.WebUILog.Errorf("invalid DNN POST input parameters with error: %+v", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid JSON format"})
+		return
+	}
+	if err := validateDnn(dnn); err != nil {
+		logger.WebUILog.Errorln(err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	filter := bson.M{"name": dnn.Name}
+	dnnBson := configmodels.ToBsonM(dnn)
+	err := dbadapter.CommonDBClient.RestfulAPIPostManyWithContext(c.Request.Context(), configmodels.DnnDataColl, filter, []any{dnnBson})
+	if err != nil {
+		if strings.Contains(err.Error(), "E11000") {
+			logger.WebUILog.Errorf("duplicate DNN name found error: %+v", err)
+			c.JSON(http.StatusBadRequest, gin.H{"error": "DNN already exists"})
+			return
+		}
+		logger.WebUILog.Errorf("failed to create DNN %s with error: %+v", dnn.Name, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to create DNN"})
+		return
+	}
+	logger.WebUILog.Infof("successfully executed POST DNN %s request", dnn.Name)
+	c.JSON(http.StatusCreated, gin.H{})
+}
+
+// PutDnn godoc
+//
+// @Description Create or update a DNN. Device groups using the DNN get the new defaults at the next sync.
+// @Tags        DNNs
+// @Produce     json
+// @Param       dnn-name  path  string                      true  "Name of the DNN"
+// @Param       dnn       body  configmodels.PutDnnRequest  true  "DNN parameters"
+// @Security    BearerAuth
+// @Success     200  {object}  nil  "DNN successfully updated"
+// @Failure     400  {object}  nil  "Bad request"
+// @Failure     401  {object}  nil  "Authorization failed"
+// @Failure     403  {object}  nil  "Forbidden"
+// @Failure     500  {object}  nil  "Error updating DNN"
+// @Router      /config/v1/dnn/{dnn-name}  [put]
+func PutDnn(c *gin.Context) {
+	setInventoryCorsHeader(c)
+	logger.WebUILog.Infoln("received a PUT DNN request")
+	name, _ := c.Params.Get("dnn-name")
+	var putDnnParams configmodels.PutDnnRequest
+	if err := c.ShouldBindJSON(&putDnnParams); err != nil {
+		logger.WebUILog.Errorf("invalid DNN PUT input parameters for %s with error: %+v", name, err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid JSON format"})
+		return
+	}
+	dnn := configmodels.Dnn{
+		Name:            name,
+		Mtu:             putDnnParams.Mtu,
+		DnsPrimary:      putDnnParams.DnsPrimary,
+		DnsSecondary:    putDnnParams.DnsSecondary,
+		PduSessionTypes: putDnnParams.PduSessionTypes,
+	}
+	if err := validateDnn(dnn); err != nil {
+		logger.WebUILog.Errorln(err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	filter := bson.M{"name": name}
+	dnnBson := configmodels.ToBsonM(dnn)
+	if _, err := dbadapter.CommonDBClient.RestfulAPIPutOne(configmodels.DnnDataColl, filter, dnnBson); err != nil {
+		logger.WebUILog.Errorf("failed to PUT DNN %s with error: %+v", name, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to PUT DNN"})
+		return
+	}
+	logger.WebUILog.Infof("successfully executed PUT DNN %s request", name)
+	c.JSON(http.StatusOK, gin.H{})
+}
+
+// DeleteDnn godoc
+//
+// @Description Delete an existing DNN. The DNN cannot be deleted while device groups or UPFs use it.
+// @Tags        DNNs
+// @Produce     json
+// @Param       dnn-name  path  string  true  "Name of the DNN"
+// @Security    BearerAuth
+// @Success     200  {object}  nil  "DNN deleted"
+// @Failure     401  {object}  nil  "Authorization failed"
+// @Failure     403  {object}  nil  "Forbidden"
+// @Failure     409  {object}  nil  "DNN is in use"
+// @Failure     500  {object}  nil  "Failed to delete DNN"
+// @Router      /config/v1/dnn/{dnn-name}  [delete]
+func DeleteDnn(c *gin.Context) {
+	setInventoryCorsHeader(c)
+	logger.WebUILog.Infoln("received a DELETE DNN request")
+	name, _ := c.Params.Get("dnn-name")
+	err := deleteUnusedItem(c.Request.Context(), "DNN "+name, configmodels.DnnDataColl, bson.M{"name": name}, dnnReferences(name)...)
+	if err != nil {
+		var inUse *inUseError
+		if errors.As(err, &inUse) {
+			logger.WebUILog.Errorf("%s by %v", inUse.Error(), inUse.references)
+			c.JSON(http.StatusConflict, inUse.body())
+			return
+		}
+		logger.WebUILog.Errorf("failed to delete DNN %s with error: %+v", name, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to delete DNN"})
+		return
+	}
+	logger.WebUILog.Infof("successfully executed DELETE DNN %s request", name)
+	c.JSON(http.StatusOK, gin.H{})
+}
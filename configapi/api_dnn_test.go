@@ -0,0 +1,397 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2026 Canonical Ltd
+
+package configapi
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/omec-project/webconsole/configmodels"
+	"github.com/omec-project/webconsole/dbadapter"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+type DnnMockDBClient struct {
+	dbadapter.DBInterface
+	dnns         []configmodels.Dnn
+	deviceGroups []configmodels.DeviceGroups
+	upfs         []configmodels.Upf
+	putData      []map[string]any
+	posted       []any
+	deleted      []bson.M
+	err          error
+}
+
+func (db *DnnMockDBClient) RestfulAPIGetOne(coll string, filter bson.M) (map[string]any, error) {
+	if db.err != nil {
+		return nil, db.err
+	}
+	if coll == configmodels.DnnDataColl {
+		for _, dnn := range db.dnns {
+			if dnn.Name == filter["name"] {
+				return configmodels.ToBsonM(dnn), nil
+			}
+		}
+	}
+	return nil, nil
+}
+
+func (db *DnnMockDBClient) RestfulAPIGetMany(coll string, filter bson.M) ([]map[string]any, error) {
+	if db.err != nil {
+		return nil, db.err
+	}
+	var results []map[string]any
+	switch coll {
+	case configmodels.DnnDataColl:
+		for _, dnn := range db.dnns {
+			results = append(results, configmodels.ToBsonM(dnn))
+		}
+	case devGroupDataColl:
+		for _, deviceGroup := range db.deviceGroups {
+			results = append(results, configmodels.ToBsonM(deviceGroup))
+		}
+	case configmodels.UpfDataColl:
+		for _, upf := range db.upfs {
+			results = append(results, configmodels.ToBsonM(upf))
+		}
+	}
+	return results, nil
+}
+
+func (db *DnnMockDBClient) RestfulAPIGetManyWithContext(context context.Context, coll string, filter bson.M) ([]map[string]any, error) {
+	return db.RestfulAPIGetMany(coll, filter)
+}
+
+func (db *DnnMockDBClient) StartSession() (dbadapter.DBSession, error) {
+	return nil, nil
+}
+
+func (db *DnnMockDBClient) RestfulAPIPostManyWithContext(context context.Context, collName string, filter bson.M, postDataArray []any) error {
+	if db.err != nil {
+		return db.err
+	}
+	for _, dnn := range db.dnns {
+		if dnn.Name == filter["name"] {
+			return errors.New("E11000")
+		}
+	}
+	db.posted = append(db.posted, postDataArray...)
+	return nil
+}
+
+func (db *DnnMockDBClient) RestfulAPIPutOne(collName string, filter bson.M, putData map[string]any) (bool, error) {
+	if db.err != nil {
+		return false, db.err
+	}
+	db.putData = append(db.putData, putData)
+	return true, nil
+}
+
+func (db *DnnMockDBClient) RestfulAPIDeleteOne(collName string, filter bson.M) error {
+	if db.err != nil {
+		return db.err
+	}
+	db.deleted = append(db.deleted, filter)
+	return nil
+}
+
+func (db *DnnMockDBClient) RestfulAPIDeleteOneWithContext(context context.Context, collName string, filter bson.M) error {
+	return db.RestfulAPIDeleteOne(collName, filter)
+}
+
+var internetDnn = configmodels.Dnn{
+	Name:            "internet",
+	Mtu:             1460,
+	DnsPrimary:      "8.8.8.8",
+	PduSessionTypes: []string{"IPV4"},
+}
+
+func TestDnnGetHandlers(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+	AddConfigV1Service(router)
+
+	testCases := []struct {
+		name         string
+		route        string
+		dbAdapter    dbadapter.DBInterface
+		expectedCode int
+		expectedBody string
+	}{
+		{
+			name:         "List DNNs",
+			route:        "/config/v1/dnn",
+			dbAdapter:    &DnnMockDBClient{dnns: []configmodels.Dnn{internetDnn}},
+			expectedCode: http.StatusOK,
+			expectedBody: `[{"name":"internet","mtu":1460,"dns-primary":"8.8.8.8","pdu-session-types":["IPV4"]}]`,
+		},
+		{
+			name:         "List DNNs when there are none",
+			route:        "/config/v1/dnn",
+			dbAdapter:    &DnnMockDBClient{},
+			expectedCode: http.StatusOK,
+			expectedBody: `[]`,
+		},
+		{
+			name:         "Get existing DNN",
+			route:        "/config/v1/dnn/internet",
+			dbAdapter:    &DnnMockDBClient{dnns: []configmodels.Dnn{internetDnn}},
+			expectedCode: http.StatusOK,
+			expectedBody: `{"name":"internet","mtu":1460,"dns-primary":"8.8.8.8","pdu-session-types":["IPV4"]}`,
+		},
+		{
+			name:         "Get missing DNN",
+			route:        "/config/v1/dnn/ims",
+			dbAdapter:    &DnnMockDBClient{},
+			expectedCode: http.StatusNotFound,
+			expectedBody: `{"error":"DNN ims not found"}`,
+		},
+		{
+			name:         "List DNNs DB error",
+			route:        "/config/v1/dnn",
+			dbAdapter:    &DnnMockDBClient{err: errors.New("mock error")},
+			expectedCode: http.StatusInternalServerError,
+			expectedBody: `{"error":"failed to retrieve DNNs"}`,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			originalDBClient := dbadapter.CommonDBClient
+			defer func() { dbadapter.CommonDBClient = originalDBClient }()
+			dbadapter.CommonDBClient = tc.dbAdapter
+			req, err := http.NewRequest(http.MethodGet, tc.route, nil)
+			if err != nil {
+				t.Fatalf("failed to create request: %v", err)
+			}
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			if tc.expectedCode != w.Code {
+				t.Errorf("expected `%v`, got `%v`", tc.expectedCode, w.Code)
+			}
+			if w.Body.String() != tc.expectedBody {
+				t.Errorf("expected `%v`, got `%v`", tc.expectedBody, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestDnnWriteHandlers(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+	AddConfigV1Service(router)
+
+	testCases := []struct {
+		name          string
+		method        string
+		route         string
+		dbAdapter     dbadapter.DBInterface
+		inputData     string
+		expectedCode  int
+		expectedError string
+	}{
+		{
+			name:         "Create a new DNN",
+			method:       http.MethodPost,
+			route:        "/config/v1/dnn",
+			dbAdapter:    &DnnMockDBClient{},
+			inputData:    `{"name": "internet", "mtu": 1460, "dns-primary": "8.8.8.8", "dns-secondary": "2001:4860:4860::8888", "pdu-session-types": ["IPV4", "IPV4V6"]}`,
+			expectedCode: http.StatusCreated,
+		},
+		{
+			name:         "Create a DNN with a dotted name",
+			method:       http.MethodPost,
+			route:        "/config/v1/dnn",
+			dbAdapter:    &DnnMockDBClient{},
+			inputData:    `{"name": "ims.mnc001.mcc001.gprs"}`,
+			expectedCode: http.StatusCreated,
+		},
+		{
+			name:          "Create an existing DNN",
+			method:        http.MethodPost,
+			route:         "/config/v1/dnn",
+			dbAdapter:     &DnnMockDBClient{dnns: []configmodels.Dnn{internetDnn}},
+			inputData:     `{"name": "internet"}`,
+			expectedCode:  http.StatusBadRequest,
+			expectedError: "DNN already exists",
+		},
+		{
+			name:          "Create a DNN with invalid name",
+			method:        http.MethodPost,
+			route:         "/config/v1/dnn",
+			dbAdapter:     &DnnMockDBClient{},
+			inputData:     `{"name": "inter net"}`,
+			expectedCode:  http.StatusBadRequest,
+			expectedError: "invalid DNN name",
+		},
+		{
+			name:          "Create a DNN with invalid MTU",
+			method:        http.MethodPost,
+			route:         "/config/v1/dnn",
+			dbAdapter:     &DnnMockDBClient{},
+			inputData:     `{"name": "internet", "mtu": 100}`,
+			expectedCode:  http.StatusBadRequest,
+			expectedError: "invalid MTU",
+		},
+		{
+			name:          "Create a DNN with invalid DNS server",
+			method:        http.MethodPost,
+			route:         "/config/v1/dnn",
+			dbAdapter:     &DnnMockDBClient{},
+			inputData:     `{"name": "internet", "dns-primary": "dns.example.com"}`,
+			expectedCode:  http.StatusBadRequest,
+			expectedError: "invalid DNS server",
+		},
+		{
+			name:          "Create a DNN with invalid PDU session type",
+			method:        http.MethodPost,
+			route:         "/config/v1/dnn",
+			dbAdapter:     &DnnMockDBClient{},
+			inputData:     `{"name": "internet", "pdu-session-types": ["IPV5"]}`,
+			expectedCode:  http.StatusBadRequest,
+			expectedError: "invalid PDU session type",
+		},
+		{
+			name:         "Update a DNN",
+			method:       http.MethodPut,
+			route:        "/config/v1/dnn/internet",
+			dbAdapter:    &DnnMockDBClient{dnns: []configmodels.Dnn{internetDnn}},
+			inputData:    `{"mtu": 1500, "dns-primary": "1.1.1.1"}`,
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:          "Update a DNN DB error",
+			method:        http.MethodPut,
+			route:         "/config/v1/dnn/internet",
+			dbAdapter:     &DnnMockDBClient{err: errors.New("mock error")},
+			inputData:     `{"mtu": 1500}`,
+			expectedCode:  http.StatusInternalServerError,
+			expectedError: "failed to PUT DNN",
+		},
+		{
+			name:         "Delete an unused DNN",
+			method:       http.MethodDelete,
+			route:        "/config/v1/dnn/internet",
+			dbAdapter:    &DnnMockDBClient{dnns: []configmodels.Dnn{internetDnn}},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:   "Delete a DNN used by a device group",
+			method: http.MethodDelete,
+			route:  "/config/v1/dnn/internet",
+			dbAdapter: &DnnMockDBClient{
+				dnns:         []configmodels.Dnn{internetDnn},
+				deviceGroups: []configmodels.DeviceGroups{{DeviceGroupName: "group1"}},
+			},
+			expectedCode:  http.StatusConflict,
+			expectedError: "DNN internet is in use",
+		},
+		{
+			name:   "Delete a DNN served by a UPF",
+			method: http.MethodDelete,
+			route:  "/config/v1/dnn/internet",
+			dbAdapter: &DnnMockDBClient{
+				dnns: []configmodels.Dnn{internetDnn},
+				upfs: []configmodels.Upf{{Hostname: "upf1.example.com", Dnns: []configmodels.UpfDnn{{Dnn: "internet", UeIpPools: []string{"172.250.0.0/16"}}}}},
+			},
+			expectedCode:  http.StatusConflict,
+			expectedError: "DNN internet is in use",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			originalDBClient := dbadapter.CommonDBClient
+			defer func() { dbadapter.CommonDBClient = originalDBClient }()
+			dbadapter.CommonDBClient = tc.dbAdapter
+			req, err := http.NewRequest(tc.method, tc.route, strings.NewReader(tc.inputData))
+			if err != nil {
+				t.Fatalf("failed to create request: %v", err)
+			}
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			if tc.expectedCode != w.Code {
+				t.Errorf("expected `%v`, got `%v`", tc.expectedCode, w.Code)
+			}
+			var body map[string]any
+			if err = json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+				t.Fatalf("failed to unmarshal JSON: %v", err)
+			}
+			if tc.expectedError == "" {
+				if len(body) != 0 {
+					t.Errorf("expected empty body, got %v", body)
+				}
+				return
+			}
+			errorMessage, _ := body["error"].(string)
+			if !strings.Contains(errorMessage, tc.expectedError) {
+				t.Errorf("expected error containing `%s`, got `%v`", tc.expectedError, body)
+			}
+		})
+	}
+}
+
+func TestDeviceGroupPost_UnknownDnn(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+	AddConfigV1Service(router)
+	originalDBClient := dbadapter.CommonDBClient
+	defer func() { dbadapter.CommonDBClient = originalDBClient }()
+	dbadapter.CommonDBClient = &DnnMockDBClient{dnns: []configmodels.Dnn{internetDnn}}
+
+	group := deviceGroup("group1")
+	group.IpDomainsExpanded[0].Dnn = "intenet"
+	jsonBody, err := json.Marshal(group)
+	if err != nil {
+		t.Fatalf("failed to marshal device group %v", err)
+	}
+	req, err := http.NewRequest(http.MethodPost, "/config/v1/device-group/group1", bytes.NewReader(jsonBody))
+	if err != nil {
+		t.Fatalf("failed to create request: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected `%v`, got `%v`", http.StatusBadRequest, w.Code)
+	}
+	if !strings.Contains(w.Body.String(), "DNN intenet not found") {
+		t.Errorf("expected body to mention the missing DNN, got `%v`", w.Body.String())
+	}
+}
+
+func TestBackfillDnnCatalog(t *testing.T) {
+	group1 := deviceGroup("group1")
+	group1.IpDomainsExpanded[0].Dnn = "internet"
+	group2 := deviceGroup("group2")
+	group2.IpDomainsExpanded[0].Dnn = "ims"
+	group3 := deviceGroup("group3")
+	group3.IpDomainsExpanded[0].Dnn = "ims"
+	mockDB := &DnnMockDBClient{
+		dnns:         []configmodels.Dnn{internetDnn},
+		deviceGroups: []configmodels.DeviceGroups{group1, group2, group3},
+	}
+	originalDBClient := dbadapter.CommonDBClient
+	defer func() { dbadapter.CommonDBClient = originalDBClient }()
+	dbadapter.CommonDBClient = mockDB
+
+	if err := BackfillDnnCatalog(context.Background()); err != nil {
+		t.Fatalf("failed to backfill the DNN catalog: %v", err)
+	}
+	expected := []any{configmodels.ToBsonM(configmodels.Dnn{Name: "ims"})}
+	if !reflect.DeepEqual(mockDB.posted, expected) {
+		t.Errorf("expected only the missing DNN to be added once, got %+v", mockDB.posted)
+	}
+}
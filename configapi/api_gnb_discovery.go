@@ -0,0 +1,66 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2026 Canonical Ltd
+
+package configapi
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/omec-project/webconsole/backend/logger"
+)
+
+// GetGnbDiscovery godoc
+//
+// @Description  Return the latest comparison of the gNBs connected to the AMFs with the inventory
+// @Tags         gNBs
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {object}  configmodels.GnbDiscoveryReport  "gNB discovery report"
+// @Failure      401  {object}  nil                              "Authorization failed"
+// @Failure      403  {object}  nil                              "Forbidden"
+// @Failure      404  {object}  nil                              "No gNB discovery has run yet"
+// @Router       /config/v1/inventory/gnb-discovery  [get]
+func GetGnbDiscovery(c *gin.Context) {
+	setInventoryCorsHeader(c)
+	logger.WebUILog.Infoln("received a GET gNB discovery request")
+	report := getLastGnbDiscoveryReport()
+	if report == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "no gNB discovery has run yet"})
+		return
+	}
+	c.JSON(http.StatusOK, report)
+}
+
+// PostGnbDiscovery godoc
+//
+// @Description  Ask each AMF for its connected gNBs and compare them with the inventory. The gNB discovery must be enabled with the AMF path listing the connected gNBs.
+// @Tags         gNBs
+// @Produce      json
+// @Param        auto-import    query    bool    false    "Create the inventory entries of the unknown gNBs"
+// @Security     BearerAuth
+// @Success      200  {object}  configmodels.GnbDiscoveryReport  "gNB discovery report"
+// @Failure      400  {object}  nil                              "Bad request"
+// @Failure      401  {object}  nil                              "Authorization failed"
+// @Failure      403  {object}  nil                              "Forbidden"
+// @Failure      404  {object}  nil                              "gNB discovery is disabled"
+// @Router       /config/v1/inventory/gnb-discovery  [post]
+func PostGnbDiscovery(c *gin.Context) {
+	setInventoryCorsHeader(c)
+	logger.WebUILog.Infoln("received a POST gNB discovery request")
+	autoImport, err := parseBoolQuery(c, "auto-import")
+	if err != nil {
+		logger.WebUILog.Errorln(err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	discoveryConfig := gnbDiscoveryConfig()
+	if discoveryConfig == nil {
+		logger.WebUILog.Errorln("gNB discovery is disabled")
+		c.JSON(http.StatusNotFound, gin.H{"error": "gNB discovery is disabled"})
+		return
+	}
+	report := RunGnbDiscovery(c.Request.Context(), discoveryConfig.AmfPath, autoImport)
+	logger.WebUILog.Infoln("successfully executed POST gNB discovery request")
+	c.JSON(http.StatusOK, report)
+}
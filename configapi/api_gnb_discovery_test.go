@@ -0,0 +1,268 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2026 Canonical Ltd
+
+package configapi
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/omec-project/openapi/v2/models"
+	"github.com/omec-project/webconsole/backend/factory"
+	"github.com/omec-project/webconsole/backend/webui_context"
+	"github.com/omec-project/webconsole/configmodels"
+	"github.com/omec-project/webconsole/dbadapter"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+const testAmfConnectedGnbsPath = "/namf-oam/v1/connected-gnbs"
+
+// newTestAmf returns a stand-in AMF reporting the connected gNBs on its OAM API
+func newTestAmf(t *testing.T, statusCode int, body string) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != testAmfConnectedGnbsPath {
+			t.Errorf("unexpected AMF request %s", r.URL.Path)
+		}
+		w.WriteHeader(statusCode)
+		if _, err := w.Write([]byte(body)); err != nil {
+			t.Errorf("failed to write AMF response: %v", err)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func gnbNamesOf(gnbs []configmodels.DiscoveredGnb) []string {
+	names := []string{}
+	for _, gnb := range gnbs {
+		names = append(names, gnb.Name)
+	}
+	return names
+}
+
+func TestReconcileGnbs(t *testing.T) {
+	tac1, tac2 := int32(1), int32(2)
+	inventory := []configmodels.Gnb{
+		{Name: "gnb1", Tac: &tac1},
+		{Name: "renamed", Tac: &tac2, GnbId: &configmodels.GnbId{Value: 2, BitLength: 22}},
+		{Name: "gnb3"},
+	}
+	amf1Gnbs := `[
+		{"gnbName": "gnb1", "plmns": [{"mcc": "001", "mnc": "01"}], "tacs": ["000001"]},
+		{"gnbName": "gnb2", "gnbId": {"bitLength": 22, "gNBValue": "000002"}, "tacs": ["000002"]},
+		{"gnbName": "gnb4", "gnbId": {"bitLength": 22, "gNBValue": "000004"}, "plmns": [{"mcc": "001", "mnc": "01"}], "tacs": ["00000a"]}
+	]`
+	amf2Gnbs := `[{"gnbName": "gnb4", "gnbId": {"bitLength": 22, "gNBValue": "000004"}, "tacs": ["00000a"]}]`
+
+	testCases := []struct {
+		name                 string
+		amfs                 []*httptest.Server
+		autoImport           bool
+		expectedUnknown      []string
+		expectedNotConnected []string
+		expectedImported     []string
+		expectedErrors       int
+		expectedPosts        int
+	}{
+		{
+			name:                 "Unknown and not connected gNBs are reported",
+			amfs:                 []*httptest.Server{newTestAmf(t, http.StatusOK, amf1Gnbs)},
+			expectedUnknown:      []string{"gnb4"},
+			expectedNotConnected: []string{"gnb3"},
+		},
+		{
+			name:                 "gNB connected to several AMFs is reported once",
+			amfs:                 []*httptest.Server{newTestAmf(t, http.StatusOK, amf1Gnbs), newTestAmf(t, http.StatusOK, amf2Gnbs)},
+			expectedUnknown:      []string{"gnb4"},
+			expectedNotConnected: []string{"gnb3"},
+		},
+		{
+			name:                 "Unknown gNBs are imported",
+			amfs:                 []*httptest.Server{newTestAmf(t, http.StatusOK, amf1Gnbs)},
+			autoImport:           true,
+			expectedUnknown:      []string{"gnb4"},
+			expectedNotConnected: []string{"gnb3"},
+			expectedImported:     []string{"gnb4"},
+			expectedPosts:        1,
+		},
+		{
+			name:                 "Not connected gNBs are not reported when an AMF fails",
+			amfs:                 []*httptest.Server{newTestAmf(t, http.StatusOK, amf1Gnbs), newTestAmf(t, http.StatusInternalServerError, "")},
+			expectedUnknown:      []string{"gnb4"},
+			expectedNotConnected: []string{},
+			expectedErrors:       2,
+		},
+		{
+			name:                 "Invalid gNB of the AMF is ignored",
+			amfs:                 []*httptest.Server{newTestAmf(t, http.StatusOK, `[{"gnbName": "gnb1"}, {"gnbName": "gnb5", "tacs": ["xyz"]}]`)},
+			expectedUnknown:      []string{},
+			expectedNotConnected: []string{"gnb3", "renamed"},
+			expectedErrors:       1,
+		},
+		{
+			name:                 "No AMF",
+			expectedUnknown:      []string{},
+			expectedNotConnected: []string{},
+			expectedErrors:       1,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			originalDBClient := dbadapter.CommonDBClient
+			defer func() { dbadapter.CommonDBClient = originalDBClient }()
+			mock := &BulkInventoryMockDBClient{gnbs: inventory}
+			dbadapter.CommonDBClient = mock
+			amfUris := []string{}
+			for _, amf := range tc.amfs {
+				amfUris = append(amfUris, amf.URL)
+			}
+
+			report := reconcileGnbs(context.Background(), amfUris, testAmfConnectedGnbsPath, tc.autoImport)
+
+			if unknown := gnbNamesOf(report.UnknownGnbs); !reflect.DeepEqual(unknown, tc.expectedUnknown) {
+				t.Errorf("expected unknown gNBs %v, got %v", tc.expectedUnknown, unknown)
+			}
+			if !reflect.DeepEqual(report.NotConnectedGnbs, tc.expectedNotConnected) {
+				t.Errorf("expected not connected gNBs %v, got %v", tc.expectedNotConnected, report.NotConnectedGnbs)
+			}
+			if !reflect.DeepEqual(report.ImportedGnbs, tc.expectedImported) {
+				t.Errorf("expected imported gNBs %v, got %v", tc.expectedImported, report.ImportedGnbs)
+			}
+			if len(report.Errors) != tc.expectedErrors {
+				t.Errorf("expected %d errors, got %v", tc.expectedErrors, report.Errors)
+			}
+			if posts := len(mock.posts[configmodels.GnbDataColl]); posts != tc.expectedPosts {
+				t.Errorf("expected %d gNB writes, got %d", tc.expectedPosts, posts)
+			}
+		})
+	}
+}
+
+func TestReconcileGnbs_ImportedGnbDetails(t *testing.T) {
+	originalDBClient := dbadapter.CommonDBClient
+	defer func() { dbadapter.CommonDBClient = originalDBClient }()
+	mock := &BulkInventoryMockDBClient{}
+	dbadapter.CommonDBClient = mock
+	amf := newTestAmf(t, http.StatusOK, `[{"gnbName": "gnb4", "gnbId": {"bitLength": 22, "gNBValue": "00000f"}, "plmns": [{"mcc": "001", "mnc": "01"}], "tacs": ["00000a"]}]`)
+
+	report := reconcileGnbs(context.Background(), []string{amf.URL}, testAmfConnectedGnbsPath, true)
+
+	if !reflect.DeepEqual(report.ImportedGnbs, []string{"gnb4"}) {
+		t.Fatalf("expected gnb4 to be imported, got %+v", report)
+	}
+	var importedGnb configmodels.Gnb
+	if err := json.Unmarshal(configmodels.MapToByte(mock.posts[configmodels.GnbDataColl][0].(bson.M)), &importedGnb); err != nil {
+		t.Fatalf("failed to unmarshal gNB: %v", err)
+	}
+	tac := int32(10)
+	expectedGnb := configmodels.Gnb{
+		Name:  "gnb4",
+		Tac:   &tac,
+		GnbId: &configmodels.GnbId{Value: 15, BitLength: 22},
+		Plmns: []configmodels.SliceSiteInfoPlmn{{Mcc: "001", Mnc: "01"}},
+	}
+	if !reflect.DeepEqual(importedGnb, expectedGnb) {
+		t.Errorf("expected imported gNB %+v, got %+v", expectedGnb, importedGnb)
+	}
+}
+
+func TestGnbDiscoveryHandlers(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+	AddConfigV1Service(router)
+	originalDBClient := dbadapter.CommonDBClient
+	defer func() { dbadapter.CommonDBClient = originalDBClient }()
+	dbadapter.CommonDBClient = &BulkInventoryMockDBClient{}
+	webuiSelf := webui_context.WEBUI_Self()
+	originalInstances := webuiSelf.NFOamInstances
+	defer func() { webuiSelf.NFOamInstances = originalInstances }()
+	amf := newTestAmf(t, http.StatusOK, `[{"gnbName": "gnb1"}]`)
+	webuiSelf.NFOamInstances = []webui_context.NfOamInstance{{NfId: "amf1", NfType: models.NFTYPE_AMF, Uri: amf.URL}}
+	gnbDiscoveryMutex.Lock()
+	lastGnbDiscoveryReport = nil
+	gnbDiscoveryMutex.Unlock()
+	originalConfig := factory.WebUIConfig
+	defer func() { factory.WebUIConfig = originalConfig }()
+	factory.WebUIConfig = &factory.Config{Configuration: &factory.Configuration{}}
+
+	testCases := []struct {
+		name            string
+		method          string
+		route           string
+		discovery       *factory.GnbDiscovery
+		expectedCode    int
+		expectedUnknown []string
+	}{
+		{
+			name:         "Report before any discovery",
+			method:       http.MethodGet,
+			route:        "/config/v1/inventory/gnb-discovery",
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			name:         "Invalid auto import",
+			method:       http.MethodPost,
+			route:        "/config/v1/inventory/gnb-discovery?auto-import=sometimes",
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "Discovery disabled",
+			method:       http.MethodPost,
+			route:        "/config/v1/inventory/gnb-discovery",
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			name:         "Discovery without AMF path",
+			method:       http.MethodPost,
+			route:        "/config/v1/inventory/gnb-discovery",
+			discovery:    &factory.GnbDiscovery{Enabled: true},
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			name:            "Run discovery",
+			method:          http.MethodPost,
+			route:           "/config/v1/inventory/gnb-discovery",
+			discovery:       &factory.GnbDiscovery{Enabled: true, AmfPath: testAmfConnectedGnbsPath},
+			expectedCode:    http.StatusOK,
+			expectedUnknown: []string{"gnb1"},
+		},
+		{
+			name:            "Report of the last discovery",
+			method:          http.MethodGet,
+			route:           "/config/v1/inventory/gnb-discovery",
+			expectedCode:    http.StatusOK,
+			expectedUnknown: []string{"gnb1"},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			factory.WebUIConfig.Configuration.GnbDiscovery = tc.discovery
+			req, err := http.NewRequest(tc.method, tc.route, nil)
+			if err != nil {
+				t.Fatalf("failed to create request: %v", err)
+			}
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			if tc.expectedCode != w.Code {
+				t.Errorf("expected `%v`, got `%v`", tc.expectedCode, w.Code)
+			}
+			if tc.expectedUnknown == nil {
+				return
+			}
+			var report configmodels.GnbDiscoveryReport
+			if err = json.Unmarshal(w.Body.Bytes(), &report); err != nil {
+				t.Fatalf("failed to unmarshal JSON: %v", err)
+			}
+			if unknown := gnbNamesOf(report.UnknownGnbs); !reflect.DeepEqual(unknown, tc.expectedUnknown) {
+				t.Errorf("expected unknown gNBs %v, got %v", tc.expectedUnknown, unknown)
+			}
+		})
+	}
+}
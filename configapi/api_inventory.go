@@ -6,10 +6,13 @@ package configapi
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"slices"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/omec-project/webconsole/backend/logger"
@@ -18,6 +21,25 @@ import (
 	"go.mongodb.org/mongo-driver/v2/bson"
 )
 
+// isCascadeDelete reports whether the delete request asks to also remove the
+// item from the resources using it
+func isCascadeDelete(c *gin.Context) (bool, error) {
+	return parseBoolQuery(c, "cascade")
+}
+
+// parseBoolQuery returns the value of an optional boolean query parameter
+func parseBoolQuery(c *gin.Context, name string) (bool, error) {
+	rawValue := c.Query(name)
+	if rawValue == "" {
+		return false, nil
+	}
+	value, err := strconv.ParseBool(rawValue)
+	if err != nil {
+		return false, fmt.Errorf("invalid %s value '%s'. Value must be true or false", name, rawValue)
+	}
+	return value, nil
+}
+
 func setInventoryCorsHeader(c *gin.Context) {
 	c.Writer.Header().Set("Access-Control-Allow-Origin", "*")
 	c.Writer.Header().Set("Access-Control-Allow-Credentials", "true")
@@ -60,6 +82,37 @@ func GetGnbs(c *gin.Context) {
 	c.JSON(http.StatusOK, gnbs)
 }
 
+// GetGnbByName godoc
+//
+// @Description Return the gNB
+// @Tags        gNBs
+// @Produce     json
+// @Param       gnb-name    path    string    true    "Name of the gNB"
+// @Security    BearerAuth
+// @Success     200  {object}  configmodels.Gnb  "gNB"
+// @Failure     401  {object}  nil               "Authorization failed"
+// @Failure     403  {object}  nil               "Forbidden"
+// @Failure     404  {object}  nil               "gNB not found"
+// @Failure     500  {object}  nil               "Error retrieving gNB"
+// @Router      /config/v1/inventory/gnb/{gnb-name}  [get]
+func GetGnbByName(c *gin.Context) {
+	setInventoryCorsHeader(c)
+	logger.WebUILog.Infoln("received a GET gNB request")
+	gnbName, _ := c.Params.Get("gnb-name")
+	gnb, err := getGnbByName(gnbName)
+	if err != nil {
+		logger.DbLog.Errorf("failed to retrieve gNB %s with error: %+v", gnbName, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to retrieve gNB"})
+		return
+	}
+	if gnb == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": fmt.Sprintf("gNB %s not found", gnbName)})
+		return
+	}
+	logger.WebUILog.Infof("successfully executed GET gNB %s request", gnbName)
+	c.JSON(http.StatusOK, gnb)
+}
+
 // PostGnb godoc
 //
 // @Description Create a new gNB
@@ -98,7 +151,18 @@ func PostGnb(c *gin.Context) {
 		}
 	}
 	gnb := configmodels.Gnb(postGnbParams)
-	if err := executeGnbTransaction(c.Request.Context(), gnb, updateGnbInNetworkSlices, postGnbOperation); err != nil {
+	if err := validateGnb(gnb); err != nil {
+		logger.WebUILog.Errorln(err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err := executeGnbTransaction(c.Request.Context(), gnb, updateGnbInNetworkSlices, withGnbTacCheck(postGnbOperation)); err != nil {
+		var tacConflict *gnbTacConflictError
+		if errors.As(err, &tacConflict) {
+			logger.WebUILog.Errorln(err.Error())
+			c.JSON(http.StatusBadRequest, gin.H{"error": tacConflict.Error()})
+			return
+		}
 		if strings.Contains(err.Error(), "E11000") {
 			logger.WebUILog.Errorf("duplicate gNB name found error: %+v", err)
 			c.JSON(http.StatusBadRequest, gin.H{"error": "gNB already exists"})
@@ -155,10 +219,27 @@ func PutGnb(c *gin.Context) {
 		return
 	}
 	putGnb := configmodels.Gnb{
-		Name: gnbName,
-		Tac:  &putGnbParams.Tac,
+		Name:      gnbName,
+		Tac:       &putGnbParams.Tac,
+		GnbId:     putGnbParams.GnbId,
+		Plmns:     putGnbParams.Plmns,
+		N2Address: putGnbParams.N2Address,
+		Vendor:    putGnbParams.Vendor,
+		Model:     putGnbParams.Model,
+		Labels:    putGnbParams.Labels,
+	}
+	if err := validateGnb(putGnb); err != nil {
+		logger.WebUILog.Errorln(err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
 	}
-	if err := executeGnbTransaction(c.Request.Context(), putGnb, updateGnbInNetworkSlices, putGnbOperation); err != nil {
+	if err := executeGnbTransaction(c.Request.Context(), putGnb, updateGnbInNetworkSlices, withGnbTacCheck(putGnbOperation)); err != nil {
+		var tacConflict *gnbTacConflictError
+		if errors.As(err, &tacConflict) {
+			logger.WebUILog.Errorln(err.Error())
+			c.JSON(http.StatusBadRequest, gin.H{"error": tacConflict.Error()})
+			return
+		}
 		logger.WebUILog.Errorf("failed to PUT gNB name: %s error: %+v", gnbName, err)
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to PUT gNB"})
 		return
@@ -174,17 +255,21 @@ func putGnbOperation(sc context.Context, gnb configmodels.Gnb) error {
 	return err
 }
 
-func updateGnbInNetworkSlices(gnb configmodels.Gnb) error {
-	filterByGnb := bson.M{
-		"site-info.gNodeBs.name": gnb.Name,
-	}
-	statusCode, err := updateInventoryInNetworkSlices(filterByGnb, func(networkSlice *configmodels.Slice) {
+func setGnbInNetworkSlice(gnb configmodels.Gnb) func(*configmodels.Slice) {
+	return func(networkSlice *configmodels.Slice) {
 		for i := range networkSlice.SiteInfo.GNodeBs {
 			if networkSlice.SiteInfo.GNodeBs[i].Name == gnb.Name {
 				networkSlice.SiteInfo.GNodeBs[i].Tac = *gnb.Tac
 			}
 		}
-	})
+	}
+}
+
+func updateGnbInNetworkSlices(gnb configmodels.Gnb) error {
+	filterByGnb := bson.M{
+		"site-info.gNodeBs.name": gnb.Name,
+	}
+	statusCode, err := updateInventoryInNetworkSlices(filterByGnb, setGnbInNetworkSlice(gnb))
 	if err != nil {
 		logger.ConfigLog.Errorf("failed to update gNB in network slices: %+v", err)
 	}
@@ -194,15 +279,17 @@ func updateGnbInNetworkSlices(gnb configmodels.Gnb) error {
 
 // DeleteGnb godoc
 //
-// @Description  Delete an existing gNB
+// @Description  Delete an existing gNB. The gNB cannot be deleted while network slices or sites use it, unless cascade is set to remove it from them.
 // @Tags         gNBs
 // @Produce      json
-// @Param        gnb-name    path    string    true    "Name of the gNB"
+// @Param        gnb-name    path     string    true     "Name of the gNB"
+// @Param        cascade     query    bool      false    "Remove the gNB from the network slices and sites using it"
 // @Security     BearerAuth
 // @Success      200  {object}  nil  "gNB deleted"
 // @Failure      400  {object}  nil  "Bad request"
 // @Failure      401  {object}  nil  "Authorization failed"
 // @Failure      403  {object}  nil  "Forbidden"
+// @Failure      409  {object}  nil  "gNB is in use"
 // @Failure      500  {object}  nil  "Failed to delete gNB"
 // @Router       /config/v1/inventory/gnb/{gnb-name}  [delete]
 func DeleteGnb(c *gin.Context) {
@@ -215,11 +302,32 @@ func DeleteGnb(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": errorMessage})
 		return
 	}
+	cascade, err := isCascadeDelete(c)
+	if err != nil {
+		logger.WebUILog.Errorln(err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
 	gnb := configmodels.Gnb{
 		Name: gnbName,
 	}
-	err := executeGnbTransaction(c.Request.Context(), gnb, removeGnbFromNetworkSlices, deleteGnbOperation)
+	gnbOperation := deleteGnbOperation
+	if !cascade {
+		gnbOperation = func(sc context.Context, gnb configmodels.Gnb) error {
+			if err := checkNotInUse(sc, "gNB "+gnb.Name, sliceReference(bson.M{"site-info.gNodeBs.name": gnb.Name}), siteReference(bson.M{"gNodeBs": gnb.Name})); err != nil {
+				return err
+			}
+			return deleteGnbOperation(sc, gnb)
+		}
+	}
+	err = executeGnbTransaction(c.Request.Context(), gnb, removeGnbFromSitesAndNetworkSlices, gnbOperation)
 	if err != nil {
+		var inUse *inUseError
+		if errors.As(err, &inUse) {
+			logger.WebUILog.Errorf("%s by %v", inUse.Error(), inUse.references)
+			c.JSON(http.StatusConflict, inUse.body())
+			return
+		}
 		logger.WebUILog.Errorf("failed to delete GNB with name %s error: %+v", gnbName, err)
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to delete gNB"})
 		return
@@ -233,6 +341,14 @@ func deleteGnbOperation(sc context.Context, gnb configmodels.Gnb) error {
 	return dbadapter.CommonDBClient.RestfulAPIDeleteOneWithContext(sc, configmodels.GnbDataColl, filter)
 }
 
+func removeGnbFromSitesAndNetworkSlices(gnb configmodels.Gnb) error {
+	if err := removeGnbFromSites(gnb.Name); err != nil {
+		logger.ConfigLog.Errorf("failed to remove gNB from sites: %+v", err)
+		return err
+	}
+	return removeGnbFromNetworkSlices(gnb)
+}
+
 func removeGnbFromNetworkSlices(gnb configmodels.Gnb) error {
 	filterByGnb := bson.M{
 		"site-info.gNodeBs.name": gnb.Name,
@@ -250,15 +366,23 @@ func removeGnbFromNetworkSlices(gnb configmodels.Gnb) error {
 }
 
 func executeGnbTransaction(ctx context.Context, gnb configmodels.Gnb, nsOperation func(configmodels.Gnb) error, gnbOperation func(context.Context, configmodels.Gnb) error) error {
+	return executeInventoryTransaction(ctx,
+		func() error { return nsOperation(gnb) },
+		func(sc context.Context) error { return gnbOperation(sc, gnb) })
+}
+
+// executeInventoryTransaction runs the inventory write and then the update of
+// the network slices using the written items in a single transaction
+func executeInventoryTransaction(ctx context.Context, nsOperation func() error, itemOperation func(context.Context) error) error {
 	session, err := dbadapter.CommonDBClient.StartSession()
 	if err != nil {
 		return fmt.Errorf("failed to initialize DB session: %w", err)
 	}
 	if session == nil {
-		if err = gnbOperation(ctx, gnb); err != nil {
+		if err = itemOperation(ctx); err != nil {
 			return err
 		}
-		if err = nsOperation(gnb); err != nil {
+		if err = nsOperation(); err != nil {
 			return fmt.Errorf("failed to update network slices: %w", err)
 		}
 		return nil
@@ -269,13 +393,13 @@ func executeGnbTransaction(ctx context.Context, gnb configmodels.Gnb, nsOperatio
 		if err = session.StartTransaction(); err != nil {
 			return fmt.Errorf("failed to start transaction: %w", err)
 		}
-		if err = gnbOperation(sc, gnb); err != nil {
+		if err = itemOperation(sc); err != nil {
 			if abortErr := session.AbortTransaction(sc); abortErr != nil {
 				logger.DbLog.Errorf("failed to abort transaction with error: %+v", abortErr)
 			}
 			return err
 		}
-		err = nsOperation(gnb)
+		err = nsOperation()
 		if err != nil {
 			if abortErr := session.AbortTransaction(sc); abortErr != nil {
 				logger.DbLog.Errorf("failed to abort transaction with error: %+v", abortErr)
@@ -288,20 +412,34 @@ func executeGnbTransaction(ctx context.Context, gnb configmodels.Gnb, nsOperatio
 
 // GetUpfs godoc
 //
-// @Description  Return the list of UPFs
+// @Description  Return the list of UPFs with the time self-registered UPFs were last seen
 // @Tags         UPFs
 // @Produce      json
+// @Param        stale    query    bool    false    "Only return the self-registered UPFs that stopped sending heartbeats"
 // @Security     BearerAuth
-// @Success      200  {array}   configmodels.Upf  "List of UPFs"
-// @Failure      401  {object}  nil               "Authorization failed"
-// @Failure      403  {object}  nil               "Forbidden"
-// @Failure      500  {object}  nil               "Error retrieving UPFs"
+// @Success      200  {array}   configmodels.UpfStatus  "List of UPFs"
+// @Failure      400  {object}  nil                     "Bad request"
+// @Failure      401  {object}  nil                     "Authorization failed"
+// @Failure      403  {object}  nil                     "Forbidden"
+// @Failure      500  {object}  nil                     "Error retrieving UPFs"
 // @Router       /config/v1/inventory/upf  [get]
 func GetUpfs(c *gin.Context) {
 	setInventoryCorsHeader(c)
 	logger.WebUILog.Infoln("received a GET UPFs request")
-	var upfs []*configmodels.Upf
-	upfs = make([]*configmodels.Upf, 0)
+	onlyStale, err := parseBoolQuery(c, "stale")
+	if err != nil {
+		logger.WebUILog.Errorln(err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	registrations, err := getUpfRegistrations()
+	if err != nil {
+		logger.DbLog.Errorf("failed to retrieve UPF registrations with error: %+v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to retrieve UPFs"})
+		return
+	}
+	var upfs []*configmodels.UpfStatus
+	upfs = make([]*configmodels.UpfStatus, 0)
 	rawUpfs, err := dbadapter.CommonDBClient.RestfulAPIGetMany(configmodels.UpfDataColl, bson.M{})
 	if err != nil {
 		logger.DbLog.Errorf("failed to retrieve UPFs with error: %+v", err)
@@ -309,18 +447,58 @@ func GetUpfs(c *gin.Context) {
 		return
 	}
 
+	now := time.Now()
 	for _, rawUpf := range rawUpfs {
 		var upfData configmodels.Upf
 		err := json.Unmarshal(configmodels.MapToByte(rawUpf), &upfData)
 		if err != nil {
 			logger.DbLog.Errorf("could not unmarshal UPF %s", rawUpf)
 		}
-		upfs = append(upfs, &upfData)
+		upfStatus := configmodels.UpfStatus{Upf: upfData}
+		if registration, ok := registrations[upfData.Hostname]; ok {
+			upfStatus.LastSeen = &registration.LastSeen
+			upfStatus.Stale = isStaleUpf(registration, now)
+		}
+		if onlyStale && !upfStatus.Stale {
+			continue
+		}
+		upfs = append(upfs, &upfStatus)
 	}
 	logger.WebUILog.Infoln("successfully executed GET UPFs request")
 	c.JSON(http.StatusOK, upfs)
 }
 
+// GetUpfByHostname godoc
+//
+// @Description  Return the UPF
+// @Tags         UPFs
+// @Produce      json
+// @Param        upf-hostname    path    string    true    "Name of the UPF"
+// @Security     BearerAuth
+// @Success      200  {object}  configmodels.Upf  "UPF"
+// @Failure      401  {object}  nil               "Authorization failed"
+// @Failure      403  {object}  nil               "Forbidden"
+// @Failure      404  {object}  nil               "UPF not found"
+// @Failure      500  {object}  nil               "Error retrieving UPF"
+// @Router       /config/v1/inventory/upf/{upf-hostname}  [get]
+func GetUpfByHostname(c *gin.Context) {
+	setInventoryCorsHeader(c)
+	logger.WebUILog.Infoln("received a GET UPF request")
+	hostname, _ := c.Params.Get("upf-hostname")
+	upf, err := getUpfByHostname(hostname)
+	if err != nil {
+		logger.DbLog.Errorf("failed to retrieve UPF %s with error: %+v", hostname, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to retrieve UPF"})
+		return
+	}
+	if upf == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": fmt.Sprintf("UPF %s not found", hostname)})
+		return
+	}
+	logger.WebUILog.Infof("successfully executed GET UPF %s request", hostname)
+	c.JSON(http.StatusOK, upf)
+}
+
 // PostUpf godoc
 //
 // @Description  Create a new UPF
@@ -358,6 +536,11 @@ func PostUpf(c *gin.Context) {
 		return
 	}
 	upf := configmodels.Upf(postUpfParams)
+	if err = validateUpf(upf); err != nil {
+		logger.WebUILog.Errorln(err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
 	if err = executeUpfTransaction(c.Request.Context(), upf, updateUpfInNetworkSlices, postUpfOperation); err != nil {
 		if strings.Contains(err.Error(), "E11000") {
 			logger.WebUILog.Errorf("duplicate hostname found with error: %+v", err)
@@ -419,8 +602,17 @@ func PutUpf(c *gin.Context) {
 		return
 	}
 	putUpf := configmodels.Upf{
-		Hostname: hostname,
-		Port:     putUpfParams.Port,
+		Hostname:     hostname,
+		Port:         putUpfParams.Port,
+		N4Address:    putUpfParams.N4Address,
+		N3Interfaces: putUpfParams.N3Interfaces,
+		Dnns:         putUpfParams.Dnns,
+		Capacity:     putUpfParams.Capacity,
+	}
+	if err = validateUpf(putUpf); err != nil {
+		logger.WebUILog.Errorln(err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
 	}
 	if err := executeUpfTransaction(c.Request.Context(), putUpf, updateUpfInNetworkSlices, putUpfOperation); err != nil {
 		logger.WebUILog.Errorf("failed to PUT UPF with hostname: %s with error: %+v", hostname, err)
@@ -441,14 +633,18 @@ func putUpfOperation(sc context.Context, upf configmodels.Upf) error {
 	return err
 }
 
-func updateUpfInNetworkSlices(upf configmodels.Upf) error {
-	filterByUpf := bson.M{"site-info.upf.upf-name": upf.Hostname}
-	statusCode, err := updateInventoryInNetworkSlices(filterByUpf, func(networkSlice *configmodels.Slice) {
+func setUpfInNetworkSlice(upf configmodels.Upf) func(*configmodels.Slice) {
+	return func(networkSlice *configmodels.Slice) {
 		networkSlice.SiteInfo.Upf = map[string]any{
 			"upf-name": upf.Hostname,
 			"upf-port": upf.Port,
 		}
-	})
+	}
+}
+
+func updateUpfInNetworkSlices(upf configmodels.Upf) error {
+	filterByUpf := bson.M{"site-info.upf.upf-name": upf.Hostname}
+	statusCode, err := updateInventoryInNetworkSlices(filterByUpf, setUpfInNetworkSlice(upf))
 	if err != nil {
 		logger.ConfigLog.Errorf("failed to update UPF in network slices: %+v", err)
 	}
@@ -458,15 +654,17 @@ func updateUpfInNetworkSlices(upf configmodels.Upf) error {
 
 // DeleteUpf godoc
 //
-// @Description  Delete an existing UPF
+// @Description  Delete an existing UPF. The UPF cannot be deleted while network slices or sites use it, unless cascade is set to remove it from them.
 // @Tags         UPFs
 // @Produce      json
-// @Param        upf-hostname    path    string    true    "Name of the UPF"
+// @Param        upf-hostname    path     string    true     "Name of the UPF"
+// @Param        cascade         query    bool      false    "Remove the UPF from the network slices and sites using it"
 // @Security     BearerAuth
 // @Success      200  {object}  nil  "UPF deleted"
 // @Failure      400  {object}  nil  "Bad request"
 // @Failure      401  {object}  nil  "Authorization failed"
 // @Failure      403  {object}  nil  "Forbidden"
+// @Failure      409  {object}  nil  "UPF is in use"
 // @Failure      500  {object}  nil  "Failed to delete UPF"
 // @Router       /config/v1/inventory/upf/{upf-hostname}  [delete]
 func DeleteUpf(c *gin.Context) {
@@ -479,10 +677,31 @@ func DeleteUpf(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": errorMessage})
 		return
 	}
+	cascade, err := isCascadeDelete(c)
+	if err != nil {
+		logger.WebUILog.Errorln(err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
 	upf := configmodels.Upf{
 		Hostname: hostname,
 	}
-	if err := executeUpfTransaction(c.Request.Context(), upf, removeUpfFromNetworkSlices, deleteUpfOperation); err != nil {
+	upfOperation := deleteUpfOperation
+	if !cascade {
+		upfOperation = func(sc context.Context, upf configmodels.Upf) error {
+			if err := checkNotInUse(sc, "UPF "+upf.Hostname, sliceReference(bson.M{"site-info.upf.upf-name": upf.Hostname}), siteReference(bson.M{"upf": upf.Hostname})); err != nil {
+				return err
+			}
+			return deleteUpfOperation(sc, upf)
+		}
+	}
+	if err = executeUpfTransaction(c.Request.Context(), upf, removeUpfFromSitesAndNetworkSlices, upfOperation); err != nil {
+		var inUse *inUseError
+		if errors.As(err, &inUse) {
+			logger.WebUILog.Errorf("%s by %v", inUse.Error(), inUse.references)
+			c.JSON(http.StatusConflict, inUse.body())
+			return
+		}
 		logger.WebUILog.Errorf("failed to delete UPF with hostname: %s with error: %+v", hostname, err)
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to delete UPF"})
 		return
@@ -493,7 +712,18 @@ func DeleteUpf(c *gin.Context) {
 
 func deleteUpfOperation(sc context.Context, upf configmodels.Upf) error {
 	filter := bson.M{"hostname": upf.Hostname}
-	return dbadapter.CommonDBClient.RestfulAPIDeleteOneWithContext(sc, configmodels.UpfDataColl, filter)
+	if err := dbadapter.CommonDBClient.RestfulAPIDeleteOneWithContext(sc, configmodels.UpfDataColl, filter); err != nil {
+		return err
+	}
+	return dbadapter.CommonDBClient.RestfulAPIDeleteOneWithContext(sc, configmodels.UpfRegistrationDataColl, filter)
+}
+
+func removeUpfFromSitesAndNetworkSlices(upf configmodels.Upf) error {
+	if err := removeUpfFromSites(upf.Hostname); err != nil {
+		logger.ConfigLog.Errorf("failed to remove UPF from sites: %+v", err)
+		return err
+	}
+	return removeUpfFromNetworkSlices(upf)
 }
 
 func removeUpfFromNetworkSlices(upf configmodels.Upf) error {
@@ -509,40 +739,9 @@ func removeUpfFromNetworkSlices(upf configmodels.Upf) error {
 }
 
 func executeUpfTransaction(ctx context.Context, upf configmodels.Upf, nsOperation func(configmodels.Upf) error, upfOperation func(context.Context, configmodels.Upf) error) error {
-	session, err := dbadapter.CommonDBClient.StartSession()
-	if err != nil {
-		return fmt.Errorf("failed to initialize DB session: %w", err)
-	}
-	if session == nil {
-		if err = upfOperation(ctx, upf); err != nil {
-			return err
-		}
-		if err = nsOperation(upf); err != nil {
-			return fmt.Errorf("failed to update network slices: %w", err)
-		}
-		return nil
-	}
-	defer session.EndSession(ctx)
-
-	return session.WithSession(ctx, func(sc context.Context) error {
-		if err = session.StartTransaction(); err != nil {
-			return fmt.Errorf("failed to start transaction: %w", err)
-		}
-		if err = upfOperation(sc, upf); err != nil {
-			if abortErr := session.AbortTransaction(sc); abortErr != nil {
-				logger.DbLog.Errorf("failed to abort transaction with error: %+v", abortErr)
-			}
-			return err
-		}
-		err = nsOperation(upf)
-		if err != nil {
-			if abortErr := session.AbortTransaction(sc); abortErr != nil {
-				logger.DbLog.Errorf("failed to abort transaction with error: %+v", abortErr)
-			}
-			return fmt.Errorf("failed to update network slices: %w", err)
-		}
-		return session.CommitTransaction(sc)
-	})
+	return executeInventoryTransaction(ctx,
+		func() error { return nsOperation(upf) },
+		func(sc context.Context) error { return upfOperation(sc, upf) })
 }
 
 func updateInventoryInNetworkSlices(filter bson.M, updateFunc func(*configmodels.Slice)) (int, error) {
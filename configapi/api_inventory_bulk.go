@@ -0,0 +1,265 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2026 Canonical Ltd
+
+package configapi
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/omec-project/webconsole/backend/logger"
+	"github.com/omec-project/webconsole/configmodels"
+	"github.com/omec-project/webconsole/dbadapter"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// ImportGnbs godoc
+//
+// @Description  Create or update gNBs in bulk. Nothing is written unless every row is valid. A CSV file starts with a header naming its columns: name, tac, gnb-id, gnb-id-bit-length, plmns (MCC-MNC separated by ';'), n2-address, vendor, model and labels (key=value separated by ';').
+// @Tags         gNBs
+// @Accept       json
+// @Accept       text/csv
+// @Produce      json
+// @Param        format    query    string                           false    "File format: csv, json or yaml. Defaults to the content type, or json"
+// @Param        gnbs      body     []configmodels.PostGnbRequest    true     "gNBs to import"
+// @Security     BearerAuth
+// @Success      200  {object}  configmodels.InventoryImportResponse  "gNBs successfully imported"
+// @Failure      400  {object}  configmodels.InventoryImportResponse  "Bad request"
+// @Failure      401  {object}  nil                                    "Authorization failed"
+// @Failure      403  {object}  nil                                    "Forbidden"
+// @Failure      413  {object}  nil                                    "Import file too large"
+// @Failure      500  {object}  nil                                    "Error importing gNBs"
+// @Router       /config/v1/inventory/import/gnb  [post]
+func ImportGnbs(c *gin.Context) {
+	setInventoryCorsHeader(c)
+	logger.WebUILog.Infoln("received a gNB import request")
+	format, body, ok := readInventoryImport(c)
+	if !ok {
+		return
+	}
+	rows, err := decodeGnbRows(format, body)
+	if err != nil {
+		logger.WebUILog.Errorf("invalid gNB import file with error: %+v", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid " + strings.ToUpper(format) + " format: " + err.Error()})
+		return
+	}
+	if len(rows) == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "no gNBs to import"})
+		return
+	}
+	results, gnbs, err := validateGnbImport(rows)
+	if err != nil {
+		logger.WebUILog.Errorf("failed to validate gNB import with error: %+v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to import gNBs"})
+		return
+	}
+	if gnbs == nil {
+		logger.WebUILog.Errorln("invalid gNB import, no gNB was written")
+		c.JSON(http.StatusBadRequest, configmodels.InventoryImportResponse{Error: "invalid gNBs", Results: results})
+		return
+	}
+	if err = importGnbs(c.Request.Context(), gnbs); err != nil {
+		var tacConflict *gnbTacConflictError
+		if errors.As(err, &tacConflict) {
+			logger.WebUILog.Errorf("invalid gNB import, no gNB was written: %s", tacConflict.Error())
+			for i := range results {
+				if results[i].Name == tacConflict.gnb {
+					results[i].Status = configmodels.InventoryImportInvalid
+					results[i].Error = tacConflict.Error()
+				}
+			}
+			c.JSON(http.StatusBadRequest, configmodels.InventoryImportResponse{Error: "invalid gNBs", Results: results})
+			return
+		}
+		logger.WebUILog.Errorf("failed to import gNBs with error: %+v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to import gNBs"})
+		return
+	}
+	logger.WebUILog.Infof("successfully imported %d gNBs", len(gnbs))
+	c.JSON(http.StatusOK, configmodels.InventoryImportResponse{Results: results})
+}
+
+// ExportGnbs godoc
+//
+// @Description  Return all the gNBs in a file that can be imported back
+// @Tags         gNBs
+// @Produce      json
+// @Produce      plain
+// @Param        format    query    string    false    "File format: csv, json or yaml. Defaults to json"
+// @Security     BearerAuth
+// @Success      200  {array}   configmodels.Gnb  "gNBs"
+// @Failure      400  {object}  nil               "Bad request"
+// @Failure      401  {object}  nil               "Authorization failed"
+// @Failure      403  {object}  nil               "Forbidden"
+// @Failure      500  {object}  nil               "Error exporting gNBs"
+// @Router       /config/v1/inventory/export/gnb  [get]
+func ExportGnbs(c *gin.Context) {
+	setInventoryCorsHeader(c)
+	logger.WebUILog.Infoln("received a gNB export request")
+	format, err := getInventoryFormat(c)
+	if err != nil {
+		logger.WebUILog.Errorln(err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	rawGnbs, err := dbadapter.CommonDBClient.RestfulAPIGetMany(configmodels.GnbDataColl, bson.M{})
+	if err != nil {
+		logger.DbLog.Errorf("failed to retrieve gNBs with error: %+v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to export gNBs"})
+		return
+	}
+	gnbs := make([]configmodels.Gnb, 0, len(rawGnbs))
+	for _, rawGnb := range rawGnbs {
+		var gnb configmodels.Gnb
+		if err = json.Unmarshal(configmodels.MapToByte(rawGnb), &gnb); err != nil {
+			logger.DbLog.Errorf("could not unmarshal gNB %s", rawGnb)
+			continue
+		}
+		gnbs = append(gnbs, gnb)
+	}
+	slices.SortFunc(gnbs, func(a, b configmodels.Gnb) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+	records := make([][]string, 0, len(gnbs))
+	for _, gnb := range gnbs {
+		records = append(records, gnbToCsvRecord(gnb))
+	}
+	data, err := encodeInventory(format, gnbs, gnbCsvHeader, records)
+	if err != nil {
+		logger.WebUILog.Errorf("failed to encode gNBs with error: %+v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to export gNBs"})
+		return
+	}
+	logger.WebUILog.Infof("successfully exported %d gNBs", len(gnbs))
+	c.Data(http.StatusOK, inventoryContentTypes[format], data)
+}
+
+// ImportUpfs godoc
+//
+// @Description  Create or update UPFs in bulk. Nothing is written unless every row is valid. A CSV file starts with a header naming its columns: hostname, port, n4-address, n3-interfaces (name=address separated by ';'), dnns (dnn=pool|pool separated by ';'), max-sessions and max-throughput-mbps.
+// @Tags         UPFs
+// @Accept       json
+// @Accept       text/csv
+// @Produce      json
+// @Param        format    query    string                           false    "File format: csv, json or yaml. Defaults to the content type, or json"
+// @Param        upfs      body     []configmodels.PostUpfRequest    true     "UPFs to import"
+// @Security     BearerAuth
+// @Success      200  {object}  configmodels.InventoryImportResponse  "UPFs successfully imported"
+// @Failure      400  {object}  configmodels.InventoryImportResponse  "Bad request"
+// @Failure      401  {object}  nil                                    "Authorization failed"
+// @Failure      403  {object}  nil                                    "Forbidden"
+// @Failure      413  {object}  nil                                    "Import file too large"
+// @Failure      500  {object}  nil                                    "Error importing UPFs"
+// @Router       /config/v1/inventory/import/upf  [post]
+func ImportUpfs(c *gin.Context) {
+	setInventoryCorsHeader(c)
+	logger.WebUILog.Infoln("received a UPF import request")
+	format, body, ok := readInventoryImport(c)
+	if !ok {
+		return
+	}
+	rows, err := decodeUpfRows(format, body)
+	if err != nil {
+		logger.WebUILog.Errorf("invalid UPF import file with error: %+v", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid " + strings.ToUpper(format) + " format: " + err.Error()})
+		return
+	}
+	if len(rows) == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "no UPFs to import"})
+		return
+	}
+	results, upfs, err := validateUpfImport(rows)
+	if err != nil {
+		logger.WebUILog.Errorf("failed to validate UPF import with error: %+v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to import UPFs"})
+		return
+	}
+	if upfs == nil {
+		logger.WebUILog.Errorln("invalid UPF import, no UPF was written")
+		c.JSON(http.StatusBadRequest, configmodels.InventoryImportResponse{Error: "invalid UPFs", Results: results})
+		return
+	}
+	if err = importUpfs(c.Request.Context(), upfs); err != nil {
+		logger.WebUILog.Errorf("failed to import UPFs with error: %+v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to import UPFs"})
+		return
+	}
+	logger.WebUILog.Infof("successfully imported %d UPFs", len(upfs))
+	c.JSON(http.StatusOK, configmodels.InventoryImportResponse{Results: results})
+}
+
+// ExportUpfs godoc
+//
+// @Description  Return all the UPFs in a file that can be imported back
+// @Tags         UPFs
+// @Produce      json
+// @Produce      plain
+// @Param        format    query    string    false    "File format: csv, json or yaml. Defaults to json"
+// @Security     BearerAuth
+// @Success      200  {array}   configmodels.Upf  "UPFs"
+// @Failure      400  {object}  nil               "Bad request"
+// @Failure      401  {object}  nil               "Authorization failed"
+// @Failure      403  {object}  nil               "Forbidden"
+// @Failure      500  {object}  nil               "Error exporting UPFs"
+// @Router       /config/v1/inventory/export/upf  [get]
+func ExportUpfs(c *gin.Context) {
+	setInventoryCorsHeader(c)
+	logger.WebUILog.Infoln("received a UPF export request")
+	format, err := getInventoryFormat(c)
+	if err != nil {
+		logger.WebUILog.Errorln(err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	inventory, err := GetUpfInventory()
+	if err != nil {
+		logger.DbLog.Errorf("failed to retrieve UPFs with error: %+v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to export UPFs"})
+		return
+	}
+	upfs := make([]configmodels.Upf, 0, len(inventory))
+	records := make([][]string, 0, len(inventory))
+	for _, hostname := range slices.Sorted(maps.Keys(inventory)) {
+		upfs = append(upfs, inventory[hostname])
+		records = append(records, upfToCsvRecord(inventory[hostname]))
+	}
+	data, err := encodeInventory(format, upfs, upfCsvHeader, records)
+	if err != nil {
+		logger.WebUILog.Errorf("failed to encode UPFs with error: %+v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to export UPFs"})
+		return
+	}
+	logger.WebUILog.Infof("successfully exported %d UPFs", len(upfs))
+	c.Data(http.StatusOK, inventoryContentTypes[format], data)
+}
+
+// readInventoryImport returns the format and content of an import request. It
+// writes the error response and returns false when they cannot be read.
+func readInventoryImport(c *gin.Context) (string, []byte, bool) {
+	format, err := getInventoryFormat(c)
+	if err != nil {
+		logger.WebUILog.Errorln(err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return "", nil, false
+	}
+	body, err := io.ReadAll(http.MaxBytesReader(c.Writer, c.Request.Body, maxInventoryImportSize))
+	var maxBytesErr *http.MaxBytesError
+	if errors.As(err, &maxBytesErr) {
+		logger.WebUILog.Errorf("import request larger than %d bytes", maxBytesErr.Limit)
+		c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": fmt.Sprintf("import file must be at most %d bytes", maxBytesErr.Limit)})
+		return "", nil, false
+	}
+	if err != nil {
+		logger.WebUILog.Errorf("failed to read import request with error: %+v", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "failed to read request body"})
+		return "", nil, false
+	}
+	return format, body, true
+}
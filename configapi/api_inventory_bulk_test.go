@@ -0,0 +1,553 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2026 Canonical Ltd
+
+package configapi
+
+import (
+	"context"
+	"encoding/json"
+	"maps"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/omec-project/webconsole/backend/factory"
+	"github.com/omec-project/webconsole/configmodels"
+	"github.com/omec-project/webconsole/dbadapter"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+type BulkInventoryMockDBClient struct {
+	dbadapter.DBInterface
+	gnbs          []configmodels.Gnb
+	upfs          []configmodels.Upf
+	sites         []configmodels.Site
+	networkSlices []configmodels.Slice
+	dnns          []string
+	puts          map[string][]map[string]any
+	posts         map[string][]any
+}
+
+func (db *BulkInventoryMockDBClient) RestfulAPIGetMany(coll string, filter bson.M) ([]map[string]any, error) {
+	var results []map[string]any
+	switch coll {
+	case configmodels.GnbDataColl:
+		for _, gnb := range db.gnbs {
+			results = append(results, configmodels.ToBsonM(gnb))
+		}
+	case configmodels.UpfDataColl:
+		for _, upf := range db.upfs {
+			results = append(results, configmodels.ToBsonM(upf))
+		}
+	case configmodels.SiteDataColl:
+		for _, site := range db.sites {
+			if gnbName, _ := filter["gNodeBs"].(string); slices.Contains(site.GNodeBs, gnbName) {
+				results = append(results, configmodels.ToBsonM(site))
+			}
+		}
+	case sliceDataColl:
+		for _, networkSlice := range db.networkSlices {
+			gnbName, _ := filter["site-info.gNodeBs.name"].(string)
+			usesGnb := slices.ContainsFunc(networkSlice.SiteInfo.GNodeBs, func(gnb configmodels.SliceSiteInfoGNodeBs) bool { return gnb.Name == gnbName })
+			upfName, _ := filter["site-info.upf.upf-name"].(string)
+			if usesGnb || (upfName != "" && networkSlice.SiteInfo.Upf["upf-name"] == upfName) {
+				results = append(results, configmodels.ToBsonM(networkSlice))
+			}
+		}
+	}
+	return results, nil
+}
+
+func (db *BulkInventoryMockDBClient) RestfulAPIGetManyWithContext(context context.Context, coll string, filter bson.M) ([]map[string]any, error) {
+	return db.RestfulAPIGetMany(coll, filter)
+}
+
+func (db *BulkInventoryMockDBClient) RestfulAPIGetOne(coll string, filter bson.M) (map[string]any, error) {
+	if name, ok := filter["name"].(string); ok && coll == configmodels.DnnDataColl && slices.Contains(db.dnns, name) {
+		return map[string]any{"name": name}, nil
+	}
+	return nil, nil
+}
+
+func (db *BulkInventoryMockDBClient) RestfulAPIPutOneWithContext(context context.Context, collName string, filter bson.M, putData map[string]any) (bool, error) {
+	if db.puts == nil {
+		db.puts = map[string][]map[string]any{}
+	}
+	db.puts[collName] = append(db.puts[collName], putData)
+	if collName == configmodels.GnbDataColl {
+		var gnb configmodels.Gnb
+		if err := json.Unmarshal(configmodels.MapToByte(putData), &gnb); err != nil {
+			return false, err
+		}
+		db.gnbs = slices.DeleteFunc(db.gnbs, func(existing configmodels.Gnb) bool { return existing.Name == gnb.Name })
+		db.gnbs = append(db.gnbs, gnb)
+	}
+	return true, nil
+}
+
+func (db *BulkInventoryMockDBClient) RestfulAPIPostManyWithContext(context context.Context, collName string, filter bson.M, postDataArray []any) error {
+	if db.posts == nil {
+		db.posts = map[string][]any{}
+	}
+	db.posts[collName] = append(db.posts[collName], postDataArray...)
+	return nil
+}
+
+func (db *BulkInventoryMockDBClient) StartSession() (dbadapter.DBSession, error) {
+	return nil, nil
+}
+
+// stubImportedSliceSync records the network slices provisioned after an import
+func stubImportedSliceSync(t *testing.T) *[]string {
+	synced := []string{}
+	origSync := syncSubscribersOnSliceCreateOrUpdate
+	origConfig := factory.WebUIConfig
+	syncSubscribersOnSliceCreateOrUpdate = func(slice, _ configmodels.Slice) (int, error) {
+		synced = append(synced, slice.SliceName)
+		return http.StatusOK, nil
+	}
+	factory.WebUIConfig = &factory.Config{Configuration: &factory.Configuration{}}
+	t.Cleanup(func() {
+		syncSubscribersOnSliceCreateOrUpdate = origSync
+		factory.WebUIConfig = origConfig
+	})
+	return &synced
+}
+
+func writtenSlices(t *testing.T, mock *BulkInventoryMockDBClient) []configmodels.Slice {
+	networkSlices := []configmodels.Slice{}
+	for _, putData := range mock.puts[sliceDataColl] {
+		var networkSlice configmodels.Slice
+		if err := json.Unmarshal(configmodels.MapToByte(putData), &networkSlice); err != nil {
+			t.Fatalf("failed to unmarshal network slice: %v", err)
+		}
+		networkSlices = append(networkSlices, networkSlice)
+	}
+	return networkSlices
+}
+
+func importResultStatuses(t *testing.T, body []byte) ([]string, []string) {
+	var response configmodels.InventoryImportResponse
+	if err := json.Unmarshal(body, &response); err != nil {
+		t.Fatalf("failed to unmarshal JSON: %v", err)
+	}
+	statuses := []string{}
+	errors := []string{}
+	for i, result := range response.Results {
+		if result.Row != i+1 {
+			t.Errorf("expected row %d, got %d", i+1, result.Row)
+		}
+		statuses = append(statuses, result.Status)
+		if result.Error != "" {
+			errors = append(errors, result.Error)
+		}
+	}
+	return statuses, errors
+}
+
+func TestImportGnbs(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+	AddConfigV1Service(router)
+	tac1 := int32(1)
+	existingGnbs := []configmodels.Gnb{{Name: "gnb1", Tac: &tac1, Plmns: []configmodels.SliceSiteInfoPlmn{{Mcc: "001", Mnc: "01"}}}}
+	existingSlices := []configmodels.Slice{
+		{SliceName: "slice1", SiteInfo: configmodels.SliceSiteInfo{GNodeBs: []configmodels.SliceSiteInfoGNodeBs{{Name: "gnb1", Tac: 1}}}},
+		{SliceName: "slice2", SiteInfo: configmodels.SliceSiteInfo{GNodeBs: []configmodels.SliceSiteInfoGNodeBs{{Name: "gnb3", Tac: 3}}}},
+	}
+
+	testCases := []struct {
+		name             string
+		route            string
+		contentType      string
+		body             string
+		expectedCode     int
+		expectedStatuses []string
+		expectedError    string
+		expectedWrites   int
+		sites            []configmodels.Site
+		sliceTacs        map[string]int32
+	}{
+		{
+			name:             "JSON import creates and updates gNBs",
+			route:            "/config/v1/inventory/import/gnb",
+			contentType:      "application/json",
+			body:             `[{"name": "gnb1", "tac": 2}, {"name": "gnb2", "tac": 1, "plmns": [{"mcc": "001", "mnc": "02"}]}]`,
+			expectedCode:     http.StatusOK,
+			expectedStatuses: []string{"updated", "created"},
+			expectedWrites:   2,
+		},
+		{
+			name:             "Import updates the TAC of the gNB in the network slices using it",
+			route:            "/config/v1/inventory/import/gnb",
+			body:             `[{"name": "gnb1", "tac": 2, "plmns": [{"mcc": "001", "mnc": "01"}]}, {"name": "gnb2", "tac": 3}]`,
+			expectedCode:     http.StatusOK,
+			expectedStatuses: []string{"updated", "created"},
+			expectedWrites:   2,
+			sliceTacs:        map[string]int32{"slice1": 2},
+		},
+		{
+			name:             "CSV import with content type",
+			route:            "/config/v1/inventory/import/gnb",
+			contentType:      "text/csv",
+			body:             "name,tac,plmns,labels\ngnb2,2,001-01;001-02,rack=r1;zone=north\ngnb3,,,\n",
+			expectedCode:     http.StatusOK,
+			expectedStatuses: []string{"created", "created"},
+			expectedWrites:   2,
+		},
+		{
+			name:             "YAML import with format query",
+			route:            "/config/v1/inventory/import/gnb?format=yaml",
+			contentType:      "text/plain",
+			body:             "- name: gnb2\n  tac: 2\n  gnb-id:\n    value: 5\n    bit-length: 22\n",
+			expectedCode:     http.StatusOK,
+			expectedStatuses: []string{"created"},
+			expectedWrites:   1,
+		},
+		{
+			name:             "Invalid CSV row writes nothing",
+			route:            "/config/v1/inventory/import/gnb?format=csv",
+			body:             "name,tac\ngnb2,2\ngnb3,abc\n",
+			expectedCode:     http.StatusBadRequest,
+			expectedStatuses: []string{"created", "invalid"},
+			expectedError:    "invalid TAC 'abc'. TAC must be an integer",
+		},
+		{
+			name:             "Duplicate gNB writes nothing",
+			route:            "/config/v1/inventory/import/gnb",
+			body:             `[{"name": "gnb2", "tac": 2}, {"name": "gnb2", "tac": 3}]`,
+			expectedCode:     http.StatusBadRequest,
+			expectedStatuses: []string{"created", "invalid"},
+			expectedError:    "duplicate gNB gnb2",
+		},
+		{
+			name:             "TAC conflict with an existing gNB writes nothing",
+			route:            "/config/v1/inventory/import/gnb",
+			body:             `[{"name": "gnb2", "tac": 1, "plmns": [{"mcc": "001", "mnc": "01"}]}]`,
+			expectedCode:     http.StatusBadRequest,
+			expectedStatuses: []string{"invalid"},
+			expectedError:    "TAC 1 is already used by gNB gnb1 in PLMN 00101",
+			sliceTacs:        map[string]int32{},
+		},
+		{
+			name:             "TAC freed by the import is not a conflict",
+			route:            "/config/v1/inventory/import/gnb",
+			body:             `[{"name": "gnb1", "tac": 5, "plmns": [{"mcc": "001", "mnc": "01"}]}, {"name": "gnb2", "tac": 1, "plmns": [{"mcc": "001", "mnc": "01"}]}]`,
+			expectedCode:     http.StatusOK,
+			expectedStatuses: []string{"updated", "created"},
+			expectedWrites:   2,
+		},
+		{
+			name:             "TAC conflict in the PLMN of the site of a gNB without served PLMNs",
+			route:            "/config/v1/inventory/import/gnb",
+			body:             `[{"name": "gnb2", "tac": 1}]`,
+			sites:            []configmodels.Site{{SiteName: "berlin", Plmn: configmodels.SliceSiteInfoPlmn{Mcc: "001", Mnc: "01"}, GNodeBs: []string{"gnb2"}}},
+			expectedCode:     http.StatusBadRequest,
+			expectedStatuses: []string{"invalid"},
+			expectedError:    "TAC 1 is already used by gNB gnb1 in PLMN 00101",
+			// the mock runs without transaction, a database aborts the write
+			expectedWrites: 1,
+		},
+		{
+			name:             "Update without TAC writes nothing",
+			route:            "/config/v1/inventory/import/gnb",
+			body:             `[{"name": "gnb1"}]`,
+			expectedCode:     http.StatusBadRequest,
+			expectedStatuses: []string{"invalid"},
+			expectedError:    "TAC is required to update gNB gnb1",
+		},
+		{
+			name:             "Unknown field writes nothing",
+			route:            "/config/v1/inventory/import/gnb",
+			body:             `[{"name": "gnb2", "tac": 2, "colour": "red"}]`,
+			expectedCode:     http.StatusBadRequest,
+			expectedStatuses: []string{"invalid"},
+			expectedError:    `json: unknown field "colour"`,
+		},
+		{
+			name:          "Invalid format",
+			route:         "/config/v1/inventory/import/gnb?format=xml",
+			body:          `<gnbs/>`,
+			expectedCode:  http.StatusBadRequest,
+			expectedError: "invalid format 'xml'. Format must be csv, json or yaml",
+		},
+		{
+			name:          "Unknown CSV column",
+			route:         "/config/v1/inventory/import/gnb?format=csv",
+			body:          "name,colour\ngnb2,red\n",
+			expectedCode:  http.StatusBadRequest,
+			expectedError: "invalid CSV format: unknown CSV column 'colour'",
+		},
+		{
+			name:          "Empty import",
+			route:         "/config/v1/inventory/import/gnb",
+			body:          `[]`,
+			expectedCode:  http.StatusBadRequest,
+			expectedError: "no gNBs to import",
+		},
+		{
+			name:          "Import larger than the limit",
+			route:         "/config/v1/inventory/import/gnb?format=csv",
+			body:          "name\n" + strings.Repeat("gnb\n", maxInventoryImportSize/4),
+			expectedCode:  http.StatusRequestEntityTooLarge,
+			expectedError: "import file must be at most 10485760 bytes",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			originalDBClient := dbadapter.CommonDBClient
+			defer func() { dbadapter.CommonDBClient = originalDBClient }()
+			mock := &BulkInventoryMockDBClient{gnbs: slices.Clone(existingGnbs), sites: tc.sites, networkSlices: existingSlices}
+			dbadapter.CommonDBClient = mock
+			synced := stubImportedSliceSync(t)
+			req, err := http.NewRequest(http.MethodPost, tc.route, strings.NewReader(tc.body))
+			if err != nil {
+				t.Fatalf("failed to create request: %v", err)
+			}
+			if tc.contentType != "" {
+				req.Header.Set("Content-Type", tc.contentType)
+			}
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			if tc.expectedCode != w.Code {
+				t.Errorf("expected `%v`, got `%v` with body %s", tc.expectedCode, w.Code, w.Body.String())
+			}
+			if tc.expectedStatuses != nil {
+				statuses, errors := importResultStatuses(t, w.Body.Bytes())
+				if !reflect.DeepEqual(statuses, tc.expectedStatuses) {
+					t.Errorf("expected statuses %v, got %v", tc.expectedStatuses, statuses)
+				}
+				if tc.expectedError != "" && !slices.Contains(errors, tc.expectedError) {
+					t.Errorf("expected error `%s`, got %v", tc.expectedError, errors)
+				}
+			} else if tc.expectedError != "" && !strings.Contains(w.Body.String(), tc.expectedError) {
+				t.Errorf("expected error `%s`, got `%s`", tc.expectedError, w.Body.String())
+			}
+			if writes := len(mock.puts[configmodels.GnbDataColl]); writes != tc.expectedWrites {
+				t.Errorf("expected %d gNB writes, got %d", tc.expectedWrites, writes)
+			}
+			if tc.sliceTacs != nil {
+				sliceTacs := map[string]int32{}
+				for _, networkSlice := range writtenSlices(t, mock) {
+					sliceTacs[networkSlice.SliceName] = networkSlice.SiteInfo.GNodeBs[0].Tac
+				}
+				if !reflect.DeepEqual(sliceTacs, tc.sliceTacs) {
+					t.Errorf("expected network slice TACs %v, got %v", tc.sliceTacs, sliceTacs)
+				}
+				if !slices.Equal(*synced, slices.Sorted(maps.Keys(tc.sliceTacs))) {
+					t.Errorf("expected synced network slices %v, got %v", slices.Sorted(maps.Keys(tc.sliceTacs)), *synced)
+				}
+			}
+		})
+	}
+}
+
+func TestImportUpfs(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+	AddConfigV1Service(router)
+	existingUpfs := []configmodels.Upf{{Hostname: "upf1.example.com", Port: "8805"}}
+	existingSlices := []configmodels.Slice{
+		{SliceName: "slice1", SiteInfo: configmodels.SliceSiteInfo{Upf: map[string]any{"upf-name": "upf1.example.com", "upf-port": "8805"}}},
+		{SliceName: "slice2", SiteInfo: configmodels.SliceSiteInfo{Upf: map[string]any{"upf-name": "upf3.example.com", "upf-port": "8805"}}},
+	}
+
+	testCases := []struct {
+		name             string
+		route            string
+		body             string
+		expectedCode     int
+		expectedStatuses []string
+		expectedError    string
+		expectedWrites   int
+		slicePorts       map[string]any
+	}{
+		{
+			name:             "JSON import creates and updates UPFs",
+			route:            "/config/v1/inventory/import/upf",
+			body:             `[{"hostname": "upf1.example.com", "port": "8806"}, {"hostname": "upf2.example.com", "port": "8805", "dnns": [{"dnn": "internet", "ue-ip-pools": ["10.0.0.0/16"]}]}]`,
+			expectedCode:     http.StatusOK,
+			expectedStatuses: []string{"updated", "created"},
+			expectedWrites:   2,
+			slicePorts:       map[string]any{"slice1": "8806"},
+		},
+		{
+			name:             "CSV import",
+			route:            "/config/v1/inventory/import/upf?format=csv",
+			body:             "hostname,port,n4-address,n3-interfaces,dnns,max-sessions\nupf2.example.com,8805,10.1.1.1,n3=10.2.2.2,internet=10.0.0.0/16|10.1.0.0/16,1000\n",
+			expectedCode:     http.StatusOK,
+			expectedStatuses: []string{"created"},
+			expectedWrites:   1,
+		},
+		{
+			name:             "Unknown DNN writes nothing",
+			route:            "/config/v1/inventory/import/upf",
+			body:             `[{"hostname": "upf2.example.com", "port": "8805"}, {"hostname": "upf3.example.com", "port": "8805", "dnns": [{"dnn": "ims", "ue-ip-pools": ["10.0.0.0/16"]}]}]`,
+			expectedCode:     http.StatusBadRequest,
+			expectedStatuses: []string{"created", "invalid"},
+			expectedError:    "DNN ims not found",
+			slicePorts:       map[string]any{},
+		},
+		{
+			name:             "Invalid port writes nothing",
+			route:            "/config/v1/inventory/import/upf?format=yaml",
+			body:             "- hostname: upf2.example.com\n  port: \"abc\"\n",
+			expectedCode:     http.StatusBadRequest,
+			expectedStatuses: []string{"invalid"},
+			expectedError:    "invalid UPF port 'abc'. Port must be a numeric string within the range [0, 65535]",
+		},
+		{
+			name:          "Malformed JSON",
+			route:         "/config/v1/inventory/import/upf",
+			body:          `[{"hostname": `,
+			expectedCode:  http.StatusBadRequest,
+			expectedError: "invalid JSON format",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			originalDBClient := dbadapter.CommonDBClient
+			defer func() { dbadapter.CommonDBClient = originalDBClient }()
+			mock := &BulkInventoryMockDBClient{upfs: existingUpfs, dnns: []string{"internet"}, networkSlices: existingSlices}
+			dbadapter.CommonDBClient = mock
+			synced := stubImportedSliceSync(t)
+			req, err := http.NewRequest(http.MethodPost, tc.route, strings.NewReader(tc.body))
+			if err != nil {
+				t.Fatalf("failed to create request: %v", err)
+			}
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			if tc.expectedCode != w.Code {
+				t.Errorf("expected `%v`, got `%v` with body %s", tc.expectedCode, w.Code, w.Body.String())
+			}
+			if tc.expectedStatuses != nil {
+				statuses, errors := importResultStatuses(t, w.Body.Bytes())
+				if !reflect.DeepEqual(statuses, tc.expectedStatuses) {
+					t.Errorf("expected statuses %v, got %v", tc.expectedStatuses, statuses)
+				}
+				if tc.expectedError != "" && !slices.Contains(errors, tc.expectedError) {
+					t.Errorf("expected error `%s`, got %v", tc.expectedError, errors)
+				}
+			} else if tc.expectedError != "" && !strings.Contains(w.Body.String(), tc.expectedError) {
+				t.Errorf("expected error `%s`, got `%s`", tc.expectedError, w.Body.String())
+			}
+			if writes := len(mock.puts[configmodels.UpfDataColl]); writes != tc.expectedWrites {
+				t.Errorf("expected %d UPF writes, got %d", tc.expectedWrites, writes)
+			}
+			if tc.slicePorts != nil {
+				slicePorts := map[string]any{}
+				for _, networkSlice := range writtenSlices(t, mock) {
+					slicePorts[networkSlice.SliceName] = networkSlice.SiteInfo.Upf["upf-port"]
+				}
+				if !reflect.DeepEqual(slicePorts, tc.slicePorts) {
+					t.Errorf("expected network slice UPF ports %v, got %v", tc.slicePorts, slicePorts)
+				}
+				if !slices.Equal(*synced, slices.Sorted(maps.Keys(tc.slicePorts))) {
+					t.Errorf("expected synced network slices %v, got %v", slices.Sorted(maps.Keys(tc.slicePorts)), *synced)
+				}
+			}
+		})
+	}
+}
+
+func TestInventoryExportImportRoundTrip(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+	AddConfigV1Service(router)
+	tac1, tac2 := int32(1), int32(2)
+	gnbs := []configmodels.Gnb{
+		{
+			Name:      "gnb1",
+			Tac:       &tac1,
+			GnbId:     &configmodels.GnbId{Value: 4194303, BitLength: 22},
+			Plmns:     []configmodels.SliceSiteInfoPlmn{{Mcc: "001", Mnc: "01"}, {Mcc: "001", Mnc: "002"}},
+			N2Address: "192.168.1.10",
+			Vendor:    "acme",
+			Model:     "r1",
+			Labels:    map[string]string{"rack": "r1", "zone": "north"},
+		},
+		{Name: "gnb2", Tac: &tac2},
+	}
+	upfs := []configmodels.Upf{
+		{
+			Hostname:     "upf1.example.com",
+			Port:         "8805",
+			N4Address:    "10.1.1.1",
+			N3Interfaces: []configmodels.UpfN3Interface{{Name: "n3", Address: "10.2.2.2"}},
+			Dnns:         []configmodels.UpfDnn{{Dnn: "internet", UeIpPools: []string{"10.0.0.0/16", "10.1.0.0/16"}}},
+			Capacity:     &configmodels.UpfCapacity{MaxSessions: 1000, MaxThroughputMbps: 10000},
+		},
+		{Hostname: "upf2.example.com", Port: "8806"},
+	}
+
+	for _, format := range []string{"csv", "json", "yaml"} {
+		t.Run(format, func(t *testing.T) {
+			originalDBClient := dbadapter.CommonDBClient
+			defer func() { dbadapter.CommonDBClient = originalDBClient }()
+			exportedGnbs := exportInventory(t, router, &BulkInventoryMockDBClient{gnbs: gnbs}, "/config/v1/inventory/export/gnb?format="+format)
+			exportedUpfs := exportInventory(t, router, &BulkInventoryMockDBClient{upfs: upfs}, "/config/v1/inventory/export/upf?format="+format)
+
+			mock := &BulkInventoryMockDBClient{dnns: []string{"internet"}}
+			dbadapter.CommonDBClient = mock
+			for route, body := range map[string]string{
+				"/config/v1/inventory/import/gnb?format=" + format: exportedGnbs,
+				"/config/v1/inventory/import/upf?format=" + format: exportedUpfs,
+			} {
+				req, err := http.NewRequest(http.MethodPost, route, strings.NewReader(body))
+				if err != nil {
+					t.Fatalf("failed to create request: %v", err)
+				}
+				w := httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				if w.Code != http.StatusOK {
+					t.Fatalf("expected import to succeed, got `%v` with body %s", w.Code, w.Body.String())
+				}
+			}
+
+			var importedGnbs []configmodels.Gnb
+			for _, putData := range mock.puts[configmodels.GnbDataColl] {
+				var gnb configmodels.Gnb
+				if err := json.Unmarshal(configmodels.MapToByte(putData), &gnb); err != nil {
+					t.Fatalf("failed to unmarshal gNB: %v", err)
+				}
+				importedGnbs = append(importedGnbs, gnb)
+			}
+			if !reflect.DeepEqual(importedGnbs, gnbs) {
+				t.Errorf("expected gNBs %+v, got %+v", gnbs, importedGnbs)
+			}
+			var importedUpfs []configmodels.Upf
+			for _, putData := range mock.puts[configmodels.UpfDataColl] {
+				var upf configmodels.Upf
+				if err := json.Unmarshal(configmodels.MapToByte(putData), &upf); err != nil {
+					t.Fatalf("failed to unmarshal UPF: %v", err)
+				}
+				importedUpfs = append(importedUpfs, upf)
+			}
+			if !reflect.DeepEqual(importedUpfs, upfs) {
+				t.Errorf("expected UPFs %+v, got %+v", upfs, importedUpfs)
+			}
+		})
+	}
+}
+
+func exportInventory(t *testing.T, router *gin.Engine, mock *BulkInventoryMockDBClient, route string) string {
+	dbadapter.CommonDBClient = mock
+	req, err := http.NewRequest(http.MethodGet, route, nil)
+	if err != nil {
+		t.Fatalf("failed to create request: %v", err)
+	}
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected export to succeed, got `%v` with body %s", w.Code, w.Body.String())
+	}
+	return w.Body.String()
+}
@@ -29,21 +29,29 @@ func gnb(name string, tac int32) configmodels.Gnb {
 	}
 }
 
+func gnbWithPlmns(name string, tac int32, mcc, mnc string) configmodels.Gnb {
+	g := gnb(name, tac)
+	g.Plmns = []configmodels.SliceSiteInfoPlmn{{Mcc: mcc, Mnc: mnc}}
+	return g
+}
+
 type GnbMockDBClient struct {
 	dbadapter.DBInterface
 	gnbs []configmodels.Gnb
-	err  error
+	// otherGnbs are only returned by queries and do not make POST fail
+	otherGnbs []configmodels.Gnb
+	err       error
 }
 
 func (db *GnbMockDBClient) RestfulAPIGetMany(coll string, filter bson.M) ([]map[string]any, error) {
-	if coll == sliceDataColl {
+	if coll == sliceDataColl || coll == configmodels.SiteDataColl {
 		return nil, nil
 	}
 	if db.err != nil {
 		return nil, db.err
 	}
 	var results []map[string]any
-	for _, g := range db.gnbs {
+	for _, g := range append(db.gnbs, db.otherGnbs...) {
 		gnb := configmodels.ToBsonM(g)
 		if gnb == nil {
 			logger.DbLog.Fatalln("failed to convert gnbs to BsonM")
@@ -53,6 +61,22 @@ func (db *GnbMockDBClient) RestfulAPIGetMany(coll string, filter bson.M) ([]map[
 	return results, db.err
 }
 
+func (db *GnbMockDBClient) RestfulAPIGetManyWithContext(context context.Context, coll string, filter bson.M) ([]map[string]any, error) {
+	return db.RestfulAPIGetMany(coll, filter)
+}
+
+func (db *GnbMockDBClient) RestfulAPIGetOne(coll string, filter bson.M) (map[string]any, error) {
+	if db.err != nil {
+		return nil, db.err
+	}
+	for _, g := range db.gnbs {
+		if g.Name == filter["name"] {
+			return configmodels.ToBsonM(g), nil
+		}
+	}
+	return nil, nil
+}
+
 func (db *GnbMockDBClient) RestfulAPIPutOneWithContext(context context.Context, collName string, filter bson.M, putData map[string]any) (bool, error) {
 	if db.err != nil {
 		return false, db.err
@@ -94,11 +118,12 @@ func upf(hostname, port string) configmodels.Upf {
 type UpfMockDBClient struct {
 	dbadapter.DBInterface
 	upfs []configmodels.Upf
+	dnns []configmodels.Dnn
 	err  error
 }
 
 func (db *UpfMockDBClient) RestfulAPIGetMany(coll string, filter bson.M) ([]map[string]any, error) {
-	if coll == sliceDataColl {
+	if coll == sliceDataColl || coll == configmodels.SiteDataColl || coll == configmodels.UpfRegistrationDataColl {
 		return nil, nil
 	}
 	if db.err != nil {
@@ -115,6 +140,30 @@ func (db *UpfMockDBClient) RestfulAPIGetMany(coll string, filter bson.M) ([]map[
 	return results, db.err
 }
 
+func (db *UpfMockDBClient) RestfulAPIGetManyWithContext(context context.Context, coll string, filter bson.M) ([]map[string]any, error) {
+	return db.RestfulAPIGetMany(coll, filter)
+}
+
+func (db *UpfMockDBClient) RestfulAPIGetOne(coll string, filter bson.M) (map[string]any, error) {
+	if db.err != nil {
+		return nil, db.err
+	}
+	if coll == configmodels.DnnDataColl {
+		for _, dnn := range db.dnns {
+			if dnn.Name == filter["name"] {
+				return configmodels.ToBsonM(dnn), nil
+			}
+		}
+		return nil, nil
+	}
+	for _, u := range db.upfs {
+		if u.Hostname == filter["hostname"] {
+			return configmodels.ToBsonM(u), nil
+		}
+	}
+	return nil, nil
+}
+
 func (db *UpfMockDBClient) RestfulAPIPutOneWithContext(context context.Context, collName string, filter bson.M, putData map[string]any) (bool, error) {
 	if db.err != nil {
 		return false, db.err
@@ -284,6 +333,87 @@ func TestInventoryGetUPFHandler(t *testing.T) {
 	}
 }
 
+func TestInventoryGetSingleItemHandlers(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+	AddConfigV1Service(router)
+	detailedGnb := gnbWithPlmns("gnb1", 123, "001", "01")
+	detailedGnb.GnbId = &configmodels.GnbId{Value: 1, BitLength: 22}
+	detailedGnb.Labels = map[string]string{"rack": "r1"}
+
+	testCases := []struct {
+		name         string
+		route        string
+		dbAdapter    dbadapter.DBInterface
+		expectedCode int
+		expectedBody string
+	}{
+		{
+			name:         "Existing gNB",
+			route:        "/config/v1/inventory/gnb/gnb1",
+			dbAdapter:    &GnbMockDBClient{gnbs: []configmodels.Gnb{gnb("gnb0", 12), detailedGnb}},
+			expectedCode: http.StatusOK,
+			expectedBody: `{"name":"gnb1","tac":123,"gnb-id":{"value":1,"bit-length":22},"plmns":[{"mcc":"001","mnc":"01"}],"labels":{"rack":"r1"}}`,
+		},
+		{
+			name:         "Missing gNB",
+			route:        "/config/v1/inventory/gnb/gnb2",
+			dbAdapter:    &GnbMockDBClient{gnbs: []configmodels.Gnb{gnb("gnb0", 12)}},
+			expectedCode: http.StatusNotFound,
+			expectedBody: `{"error":"gNB gnb2 not found"}`,
+		},
+		{
+			name:         "gNB DB error",
+			route:        "/config/v1/inventory/gnb/gnb1",
+			dbAdapter:    &GnbMockDBClient{err: errors.New("mock error")},
+			expectedCode: http.StatusInternalServerError,
+			expectedBody: `{"error":"failed to retrieve gNB"}`,
+		},
+		{
+			name:         "Existing UPF",
+			route:        "/config/v1/inventory/upf/upf1.example.com",
+			dbAdapter:    &UpfMockDBClient{upfs: []configmodels.Upf{upf("upf0.example.com", "8805"), upf("upf1.example.com", "8806")}},
+			expectedCode: http.StatusOK,
+			expectedBody: `{"hostname":"upf1.example.com","port":"8806"}`,
+		},
+		{
+			name:         "Missing UPF",
+			route:        "/config/v1/inventory/upf/upf2.example.com",
+			dbAdapter:    &UpfMockDBClient{upfs: []configmodels.Upf{upf("upf0.example.com", "8805")}},
+			expectedCode: http.StatusNotFound,
+			expectedBody: `{"error":"UPF upf2.example.com not found"}`,
+		},
+		{
+			name:         "UPF DB error",
+			route:        "/config/v1/inventory/upf/upf1.example.com",
+			dbAdapter:    &UpfMockDBClient{err: errors.New("mock error")},
+			expectedCode: http.StatusInternalServerError,
+			expectedBody: `{"error":"failed to retrieve UPF"}`,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			originalDBClient := dbadapter.CommonDBClient
+			defer func() { dbadapter.CommonDBClient = originalDBClient }()
+			dbadapter.CommonDBClient = tc.dbAdapter
+			req, err := http.NewRequest(http.MethodGet, tc.route, nil)
+			if err != nil {
+				t.Fatalf("failed to create request: %v", err)
+			}
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			if tc.expectedCode != w.Code {
+				t.Errorf("expected `%v`, got `%v`", tc.expectedCode, w.Code)
+			}
+			if w.Body.String() != tc.expectedBody {
+				t.Errorf("expected `%v`, got `%v`", tc.expectedBody, w.Body.String())
+			}
+		})
+	}
+}
+
 func TestGetInventory_DBError(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	router := gin.Default()
@@ -397,6 +527,62 @@ func TestGnbPostHandler(t *testing.T) {
 			expectedCode: http.StatusBadRequest,
 			expectedBody: map[string]string{"error": "invalid gNB TAC '0'. TAC must be an integer within the range [1, 16777215]"},
 		},
+		{
+			name:         "Create a new gNB with details expects created status",
+			route:        "/config/v1/inventory/gnb",
+			dbAdapter:    &GnbMockDBClient{gnbs: []configmodels.Gnb{}},
+			inputData:    `{"name": "gnb1", "tac": 123, "gnb-id": {"value": 4194303, "bit-length": 22}, "plmns": [{"mcc": "001", "mnc": "01"}], "n2-address": "10.0.0.1", "vendor": "acme", "model": "g5", "labels": {"rack": "r1", "example.com/zone": "north"}}`,
+			expectedCode: http.StatusCreated,
+			expectedBody: make(map[string]string),
+		},
+		{
+			name:         "gNB ID does not fit in bit length expects failure",
+			route:        "/config/v1/inventory/gnb",
+			dbAdapter:    &GnbMockDBClient{gnbs: []configmodels.Gnb{}},
+			inputData:    `{"name": "gnb1", "tac": 123, "gnb-id": {"value": 4194304, "bit-length": 22}}`,
+			expectedCode: http.StatusBadRequest,
+			expectedBody: map[string]string{"error": "invalid gNB ID 4194304 with bit length 22 for gNB gnb1. Bit length must be within the range [22, 32] and the ID must fit in it"},
+		},
+		{
+			name:         "Invalid served PLMN expects failure",
+			route:        "/config/v1/inventory/gnb",
+			dbAdapter:    &GnbMockDBClient{gnbs: []configmodels.Gnb{}},
+			inputData:    `{"name": "gnb1", "tac": 123, "plmns": [{"mcc": "1", "mnc": "01"}]}`,
+			expectedCode: http.StatusBadRequest,
+			expectedBody: map[string]string{"error": "invalid PLMN 101 for gNB gnb1. MCC must be 3 digits and MNC 2 or 3 digits"},
+		},
+		{
+			name:         "Invalid N2 address expects failure",
+			route:        "/config/v1/inventory/gnb",
+			dbAdapter:    &GnbMockDBClient{gnbs: []configmodels.Gnb{}},
+			inputData:    `{"name": "gnb1", "tac": 123, "n2-address": "gnb1.example.com"}`,
+			expectedCode: http.StatusBadRequest,
+			expectedBody: map[string]string{"error": "invalid N2 address 'gnb1.example.com' for gNB gnb1. N2 address must be an IP address"},
+		},
+		{
+			name:         "Invalid label expects failure",
+			route:        "/config/v1/inventory/gnb",
+			dbAdapter:    &GnbMockDBClient{gnbs: []configmodels.Gnb{}},
+			inputData:    `{"name": "gnb1", "tac": 123, "labels": {"-rack": "r1"}}`,
+			expectedCode: http.StatusBadRequest,
+			expectedBody: map[string]string{"error": "invalid label '-rack' for gNB gnb1. Label needs to match the following regular expression: " + LABEL_KEY_PATTERN},
+		},
+		{
+			name:         "TAC already used in the same PLMN expects failure",
+			route:        "/config/v1/inventory/gnb",
+			dbAdapter:    &GnbMockDBClient{gnbs: []configmodels.Gnb{gnbWithPlmns("gnb0", 123, "001", "01")}},
+			inputData:    `{"name": "gnb1", "tac": 123, "plmns": [{"mcc": "001", "mnc": "01"}]}`,
+			expectedCode: http.StatusBadRequest,
+			expectedBody: map[string]string{"error": "TAC 123 is already used by gNB gnb0 in PLMN 00101"},
+		},
+		{
+			name:         "TAC used in another PLMN expects created status",
+			route:        "/config/v1/inventory/gnb",
+			dbAdapter:    &GnbMockDBClient{gnbs: []configmodels.Gnb{}, otherGnbs: []configmodels.Gnb{gnbWithPlmns("gnb0", 123, "001", "02")}},
+			inputData:    `{"name": "gnb1", "tac": 123, "plmns": [{"mcc": "001", "mnc": "01"}]}`,
+			expectedCode: http.StatusCreated,
+			expectedBody: make(map[string]string),
+		},
 		{
 			name:         "DB POST operation fails expects failure",
 			route:        "/config/v1/inventory/gnb",
@@ -507,6 +693,22 @@ func TestGnbPutHandler(t *testing.T) {
 			expectedCode: http.StatusBadRequest,
 			expectedBody: map[string]string{"error": "invalid gNB TAC '0'. TAC must be an integer within the range [1, 16777215]"},
 		},
+		{
+			name:         "Put a gNB keeping its own TAC expects OK status",
+			route:        "/config/v1/inventory/gnb/gnb1",
+			dbAdapter:    &GnbMockDBClient{gnbs: []configmodels.Gnb{gnbWithPlmns("gnb1", 123, "001", "01")}},
+			inputData:    `{"tac": 123, "plmns": [{"mcc": "001", "mnc": "01"}], "vendor": "acme"}`,
+			expectedCode: http.StatusOK,
+			expectedBody: make(map[string]string),
+		},
+		{
+			name:         "Put a gNB with a TAC used in the same PLMN expects failure",
+			route:        "/config/v1/inventory/gnb/gnb1",
+			dbAdapter:    &GnbMockDBClient{gnbs: []configmodels.Gnb{gnbWithPlmns("gnb0", 123, "001", "01")}},
+			inputData:    `{"tac": 123, "plmns": [{"mcc": "208", "mnc": "93"}, {"mcc": "001", "mnc": "01"}]}`,
+			expectedCode: http.StatusBadRequest,
+			expectedBody: map[string]string{"error": "TAC 123 is already used by gNB gnb0 in PLMN 00101"},
+		},
 		{
 			name:         "DB PUT operation fails expects failure",
 			route:        "/config/v1/inventory/gnb/gnb1",
@@ -601,6 +803,78 @@ func TestUpfPostHandler(t *testing.T) {
 			expectedCode: http.StatusBadRequest,
 			expectedBody: map[string]string{"error": "invalid UPF port ''. Port must be a numeric string within the range [0, 65535]"},
 		},
+		{
+			name:         "Create a new UPF with details expects created status",
+			route:        "/config/v1/inventory/upf",
+			dbAdapter:    &UpfMockDBClient{upfs: []configmodels.Upf{}, dnns: []configmodels.Dnn{{Name: "internet"}, {Name: "ims"}}},
+			inputData:    `{"hostname": "upf1.my-domain.com", "port": "8805", "n4-address": "10.0.0.1", "n3-interfaces": [{"name": "access", "address": "192.168.252.3"}], "dnns": [{"dnn": "internet", "ue-ip-pools": ["172.250.0.0/16"]}, {"dnn": "ims", "ue-ip-pools": ["172.251.0.0/16", "2001:db8::/64"]}], "capacity": {"max-sessions": 10000, "max-throughput-mbps": 10000}}`,
+			expectedCode: http.StatusCreated,
+			expectedBody: make(map[string]string),
+		},
+		{
+			name:         "Invalid N4 address expects failure",
+			route:        "/config/v1/inventory/upf",
+			dbAdapter:    &UpfMockDBClient{upfs: []configmodels.Upf{}},
+			inputData:    `{"hostname": "upf1.my-domain.com", "port": "8805", "n4-address": "upf1"}`,
+			expectedCode: http.StatusBadRequest,
+			expectedBody: map[string]string{"error": "invalid N4 address 'upf1' for UPF upf1.my-domain.com. N4 address must be an IP address"},
+		},
+		{
+			name:         "Duplicate N3 interface expects failure",
+			route:        "/config/v1/inventory/upf",
+			dbAdapter:    &UpfMockDBClient{upfs: []configmodels.Upf{}},
+			inputData:    `{"hostname": "upf1.my-domain.com", "port": "8805", "n3-interfaces": [{"name": "access", "address": "192.168.252.3"}, {"name": "access", "address": "192.168.252.4"}]}`,
+			expectedCode: http.StatusBadRequest,
+			expectedBody: map[string]string{"error": "duplicate N3 interface access for UPF upf1.my-domain.com"},
+		},
+		{
+			name:         "Invalid N3 address expects failure",
+			route:        "/config/v1/inventory/upf",
+			dbAdapter:    &UpfMockDBClient{upfs: []configmodels.Upf{}},
+			inputData:    `{"hostname": "upf1.my-domain.com", "port": "8805", "n3-interfaces": [{"name": "access", "address": "192.168.252.300"}]}`,
+			expectedCode: http.StatusBadRequest,
+			expectedBody: map[string]string{"error": "invalid address '192.168.252.300' for N3 interface access of UPF upf1.my-domain.com. Address must be an IP address"},
+		},
+		{
+			name:         "Unknown DNN expects failure",
+			route:        "/config/v1/inventory/upf",
+			dbAdapter:    &UpfMockDBClient{upfs: []configmodels.Upf{}},
+			inputData:    `{"hostname": "upf1.my-domain.com", "port": "8805", "dnns": [{"dnn": "internet", "ue-ip-pools": ["172.250.0.0/16"]}]}`,
+			expectedCode: http.StatusBadRequest,
+			expectedBody: map[string]string{"error": "DNN internet not found"},
+		},
+		{
+			name:         "DNN without UE IP pool expects failure",
+			route:        "/config/v1/inventory/upf",
+			dbAdapter:    &UpfMockDBClient{upfs: []configmodels.Upf{}, dnns: []configmodels.Dnn{{Name: "internet"}}},
+			inputData:    `{"hostname": "upf1.my-domain.com", "port": "8805", "dnns": [{"dnn": "internet"}]}`,
+			expectedCode: http.StatusBadRequest,
+			expectedBody: map[string]string{"error": "DNN internet of UPF upf1.my-domain.com has no UE IP pool"},
+		},
+		{
+			name:         "Invalid UE IP pool expects failure",
+			route:        "/config/v1/inventory/upf",
+			dbAdapter:    &UpfMockDBClient{upfs: []configmodels.Upf{}, dnns: []configmodels.Dnn{{Name: "internet"}}},
+			inputData:    `{"hostname": "upf1.my-domain.com", "port": "8805", "dnns": [{"dnn": "internet", "ue-ip-pools": ["172.250.0.1"]}]}`,
+			expectedCode: http.StatusBadRequest,
+			expectedBody: map[string]string{"error": "invalid UE IP pool '172.250.0.1' for DNN internet of UPF upf1.my-domain.com. Pool must be an IP network"},
+		},
+		{
+			name:         "Overlapping UE IP pools expects failure",
+			route:        "/config/v1/inventory/upf",
+			dbAdapter:    &UpfMockDBClient{upfs: []configmodels.Upf{}, dnns: []configmodels.Dnn{{Name: "internet"}, {Name: "ims"}}},
+			inputData:    `{"hostname": "upf1.my-domain.com", "port": "8805", "dnns": [{"dnn": "internet", "ue-ip-pools": ["172.250.0.0/16"]}, {"dnn": "ims", "ue-ip-pools": ["172.250.1.0/24"]}]}`,
+			expectedCode: http.StatusBadRequest,
+			expectedBody: map[string]string{"error": "UE IP pool 172.250.1.0/24 of DNN ims overlaps 172.250.0.0/16 on UPF upf1.my-domain.com"},
+		},
+		{
+			name:         "Negative capacity expects failure",
+			route:        "/config/v1/inventory/upf",
+			dbAdapter:    &UpfMockDBClient{upfs: []configmodels.Upf{}},
+			inputData:    `{"hostname": "upf1.my-domain.com", "port": "8805", "capacity": {"max-sessions": -1}}`,
+			expectedCode: http.StatusBadRequest,
+			expectedBody: map[string]string{"error": "invalid capacity for UPF upf1.my-domain.com. Capacity must not be negative"},
+		},
 		{
 			name:         "DB POST operation fails expects failure",
 			route:        "/config/v1/inventory/upf",
@@ -711,6 +985,22 @@ func TestUpfPutHandler(t *testing.T) {
 			expectedCode: http.StatusBadRequest,
 			expectedBody: map[string]string{"error": "invalid UPF port ''. Port must be a numeric string within the range [0, 65535]"},
 		},
+		{
+			name:         "Put a UPF with details expects OK status",
+			route:        "/config/v1/inventory/upf/upf1.my-domain.com",
+			dbAdapter:    &UpfMockDBClient{upfs: []configmodels.Upf{upf("upf1.my-domain.com", "8805")}, dnns: []configmodels.Dnn{{Name: "internet"}}},
+			inputData:    `{"port": "8805", "n4-address": "10.0.0.1", "dnns": [{"dnn": "internet", "ue-ip-pools": ["172.250.0.0/16"]}]}`,
+			expectedCode: http.StatusOK,
+			expectedBody: make(map[string]string),
+		},
+		{
+			name:         "Put a UPF with unknown DNN expects failure",
+			route:        "/config/v1/inventory/upf/upf1.my-domain.com",
+			dbAdapter:    &UpfMockDBClient{upfs: []configmodels.Upf{upf("upf1.my-domain.com", "8805")}},
+			inputData:    `{"port": "8805", "dnns": [{"dnn": "internet", "ue-ip-pools": ["172.250.0.0/16"]}]}`,
+			expectedCode: http.StatusBadRequest,
+			expectedBody: map[string]string{"error": "DNN internet not found"},
+		},
 		{
 			name:         "DB PUT operation fails expects failure",
 			route:        "/config/v1/inventory/upf/upf1.my-domain.com",
@@ -840,3 +1130,112 @@ func TestInventoryDeleteHandlers(t *testing.T) {
 		})
 	}
 }
+
+func TestInventoryDeleteHandlers_InUse(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+	AddConfigV1Service(router)
+	gnbs, upfs := siteInventory()
+	usingSlice := configmodels.Slice{
+		SliceName: "slice1",
+		SliceId:   configmodels.SliceSliceId{Sst: "1", Sd: "010203"},
+		SiteInfo: configmodels.SliceSiteInfo{
+			SiteName: "demo",
+			Plmn:     configmodels.SliceSiteInfoPlmn{Mcc: "001", Mnc: "01"},
+			GNodeBs:  []configmodels.SliceSiteInfoGNodeBs{{Name: "gnb1", Tac: 1}, {Name: "gnb2", Tac: 2}},
+			Upf:      map[string]any{"upf-name": "upf1.example.com", "upf-port": "8805"},
+		},
+	}
+
+	testCases := []struct {
+		name            string
+		route           string
+		expectedCode    int
+		expectedError   string
+		expectedSlices  []any
+		expectedUpdates int
+	}{
+		{
+			name:           "Delete gNB used by a slice expects conflict",
+			route:          "/config/v1/inventory/gnb/gnb1",
+			expectedCode:   http.StatusConflict,
+			expectedError:  "gNB gnb1 is in use",
+			expectedSlices: []any{"slice1"},
+		},
+		{
+			name:           "Delete gNB used by a slice with cascade false expects conflict",
+			route:          "/config/v1/inventory/gnb/gnb1?cascade=false",
+			expectedCode:   http.StatusConflict,
+			expectedError:  "gNB gnb1 is in use",
+			expectedSlices: []any{"slice1"},
+		},
+		{
+			name:            "Delete gNB used by a slice with cascade removes it from the slice",
+			route:           "/config/v1/inventory/gnb/gnb1?cascade=true",
+			expectedCode:    http.StatusOK,
+			expectedUpdates: 1,
+		},
+		{
+			name:          "Delete gNB with invalid cascade expects failure",
+			route:         "/config/v1/inventory/gnb/gnb1?cascade=yes",
+			expectedCode:  http.StatusBadRequest,
+			expectedError: "invalid cascade value 'yes'. Value must be true or false",
+		},
+		{
+			name:         "Delete unused gNB expects OK status",
+			route:        "/config/v1/inventory/gnb/gnb3",
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:           "Delete UPF used by a slice expects conflict",
+			route:          "/config/v1/inventory/upf/upf1.example.com",
+			expectedCode:   http.StatusConflict,
+			expectedError:  "UPF upf1.example.com is in use",
+			expectedSlices: []any{"slice1"},
+		},
+		{
+			name:            "Delete UPF used by a slice with cascade removes it from the slice",
+			route:           "/config/v1/inventory/upf/upf1.example.com?cascade=true",
+			expectedCode:    http.StatusOK,
+			expectedUpdates: 1,
+		},
+		{
+			name:          "Delete UPF with invalid cascade expects failure",
+			route:         "/config/v1/inventory/upf/upf1.example.com?cascade=1x",
+			expectedCode:  http.StatusBadRequest,
+			expectedError: "invalid cascade value '1x'. Value must be true or false",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			originalDBClient := dbadapter.CommonDBClient
+			defer func() { dbadapter.CommonDBClient = originalDBClient }()
+			mock := &SiteMockDBClient{gnbs: gnbs, upfs: upfs, slices: []configmodels.Slice{usingSlice}}
+			dbadapter.CommonDBClient = mock
+			req, err := http.NewRequest(http.MethodDelete, tc.route, nil)
+			if err != nil {
+				t.Fatalf("failed to create request: %v", err)
+			}
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			if tc.expectedCode != w.Code {
+				t.Errorf("expected `%v`, got `%v`", tc.expectedCode, w.Code)
+			}
+			var body map[string]any
+			if err = json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+				t.Fatalf("failed to unmarshal JSON: %v", err)
+			}
+			if tc.expectedError != "" && body["error"] != tc.expectedError {
+				t.Errorf("expected error `%s`, got `%v`", tc.expectedError, body["error"])
+			}
+			if tc.expectedSlices != nil && !reflect.DeepEqual(body["network-slices"], tc.expectedSlices) {
+				t.Errorf("expected network slices %v, got %v", tc.expectedSlices, body["network-slices"])
+			}
+			if len(mock.postedData) != tc.expectedUpdates {
+				t.Errorf("expected %d network slice updates, got %d", tc.expectedUpdates, len(mock.postedData))
+			}
+		})
+	}
+}
@@ -0,0 +1,213 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2026 Canonical Ltd
+
+package configapi
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/omec-project/webconsole/backend/logger"
+	"github.com/omec-project/webconsole/configmodels"
+	"github.com/omec-project/webconsole/dbadapter"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// GetQosProfiles godoc
+//
+// @Description Return the list of QoS profiles
+// @Tags        QoS Profiles
+// @Produce     json
+// @Security    BearerAuth
+// @Success     200  {array}   configmodels.QosProfile  "List of QoS profiles"
+// @Failure     401  {object}  nil                      "Authorization failed"
+// @Failure     403  {object}  nil                      "Forbidden"
+// @Failure     500  {object}  nil                      "Error retrieving QoS profiles"
+// @Router      /config/v1/qos-profile  [get]
+func GetQosProfiles(c *gin.Context) {
+	setInventoryCorsHeader(c)
+	logger.WebUILog.Infoln("received a GET QoS profiles request")
+	qosProfiles := make([]*configmodels.QosProfile, 0)
+	rawQosProfiles, err := dbadapter.CommonDBClient.RestfulAPIGetMany(configmodels.QosProfileDataColl, bson.M{})
+	if err != nil {
+		logger.DbLog.Errorf("failed to retrieve QoS profiles with error: %+v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to retrieve QoS profiles"})
+		return
+	}
+
+	for _, rawQosProfile := range rawQosProfiles {
+		var qosProfile configmodels.QosProfile
+		if err = json.Unmarshal(configmodels.MapToByte(rawQosProfile), &qosProfile); err != nil {
+			logger.DbLog.Errorf("could not unmarshal QoS profile %s", rawQosProfile)
+			continue
+		}
+		qosProfiles = append(qosProfiles, &qosProfile)
+	}
+	logger.WebUILog.Infoln("successfully executed GET QoS profiles request")
+	c.JSON(http.StatusOK, qosProfiles)
+}
+
+// GetQosProfileByName godoc
+//
+// @Description Return the QoS profile
+// @Tags        QoS Profiles
+// @Produce     json
+// @Param       qos-profile-name  path  string  true  "Name of the QoS profile"
+// @Security    BearerAuth
+// @Success     200  {object}  configmodels.QosProfile  "QoS profile"
+// @Failure     401  {object}  nil                      "Authorization failed"
+// @Failure     403  {object}  nil                      "Forbidden"
+// @Failure     404  {object}  nil                      "QoS profile not found"
+// @Failure     500  {object}  nil                      "Error retrieving QoS profile"
+// @Router      /config/v1/qos-profile/{qos-profile-name}  [get]
+func GetQosProfileByName(c *gin.Context) {
+	setInventoryCorsHeader(c)
+	logger.WebUILog.Infoln("received a GET QoS profile request")
+	name, _ := c.Params.Get("qos-profile-name")
+	qosProfile, err := getQosProfileByName(name)
+	if err != nil {
+		logger.DbLog.Errorf("failed to retrieve QoS profile %s with error: %+v", name, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to retrieve QoS profile"})
+		return
+	}
+	if qosProfile == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": fmt.Sprintf("QoS profile %s not found", name)})
+		return
+	}
+	c.JSON(http.StatusOK, qosProfile)
+}
+
+// PostQosProfile godoc
+//
+// @Description Create a new QoS profile
+// @Tags        QoS Profiles
+// @Produce     json
+// @Param       qos-profile  body  configmodels.QosProfile  true  "QoS profile to create"
+// @Security    BearerAuth
+// @Success     201  {object}  nil  "QoS profile successfully created"
+// @Failure     400  {object}  nil  "Bad request"
+// @Failure     401  {object}  nil  "Authorization failed"
+// @Failure     403  {object}  nil  "Forbidden"
+// @Failure     500  {object}  nil  "Error creating QoS profile"
+// @Router      /config/v1/qos-profile  [post]
+func PostQosProfile(c *gin.Context) {
+	setInventoryCorsHeader(c)
+	logger.WebUILog.Infoln("received a POST QoS profile request")
+	var qosProfile configmodels.QosProfile
+	if err := c.ShouldBindJSON(&qosProfile); err != nil {
+		logger.WebUILog.Errorf("invalid QoS profile POST input parameters with error: %+v", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid JSON format"})
+		return
+	}
+	if err := validateQosProfile(qosProfile); err != nil {
+		logger.WebUILog.Errorln(err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	filter := bson.M{"name": qosProfile.Name}
+	qosProfileBson := configmodels.ToBsonM(qosProfile)
+	err := dbadapter.CommonDBClient.RestfulAPIPostManyWithContext(c.Request.Context(), configmodels.QosProfileDataColl, filter, []any{qosProfileBson})
+	if err != nil {
+		if strings.Contains(err.Error(), "E11000") {
+			logger.WebUILog.Errorf("duplicate QoS profile name found error: %+v", err)
+			c.JSON(http.StatusBadRequest, gin.H{"error": "QoS profile already exists"})
+			return
+		}
+		logger.WebUILog.Errorf("failed to create QoS profile %s with error: %+v", qosProfile.Name, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to create QoS profile"})
+		return
+	}
+	logger.WebUILog.Infof("successfully executed POST QoS profile %s request", qosProfile.Name)
+	c.JSON(http.StatusCreated, gin.H{})
+}
+
+// PutQosProfile godoc
+//
+// @Description Create or update a QoS profile. The subscribers of the device groups using the profile are provisioned again.
+// @Tags        QoS Profiles
+// @Produce     json
+// @Param       qos-profile-name  path  string                             true  "Name of the QoS profile"
+// @Param       qos-profile       body  configmodels.PutQosProfileRequest  true  "QoS profile parameters"
+// @Security    BearerAuth
+// @Success     200  {object}  nil  "QoS profile successfully updated"
+// @Failure     400  {object}  nil  "Bad request"
+// @Failure     401  {object}  nil  "Authorization failed"
+// @Failure     403  {object}  nil  "Forbidden"
+// @Failure     500  {object}  nil  "Error updating QoS profile, or QoS profile stored without provisioning all its device groups"
+// @Router      /config/v1/qos-profile/{qos-profile-name}  [put]
+func PutQosProfile(c *gin.Context) {
+	setInventoryCorsHeader(c)
+	logger.WebUILog.Infoln("received a PUT QoS profile request")
+	name, _ := c.Params.Get("qos-profile-name")
+	var putQosProfileParams configmodels.PutQosProfileRequest
+	if err := c.ShouldBindJSON(&putQosProfileParams); err != nil {
+		logger.WebUILog.Errorf("invalid QoS profile PUT input parameters for %s with error: %+v", name, err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid JSON format"})
+		return
+	}
+	qosProfile := configmodels.QosProfile{
+		Name:             name,
+		DnnMbrUplink:     putQosProfileParams.DnnMbrUplink,
+		DnnMbrDownlink:   putQosProfileParams.DnnMbrDownlink,
+		BitrateUnit:      putQosProfileParams.BitrateUnit,
+		TrafficClass:     putQosProfileParams.TrafficClass,
+		TrafficClassName: putQosProfileParams.TrafficClassName,
+	}
+	if err := validateQosProfile(qosProfile); err != nil {
+		logger.WebUILog.Errorln(err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	filter := bson.M{"name": name}
+	qosProfileBson := configmodels.ToBsonM(qosProfile)
+	if _, err := dbadapter.CommonDBClient.RestfulAPIPutOne(configmodels.QosProfileDataColl, filter, qosProfileBson); err != nil {
+		logger.WebUILog.Errorf("failed to PUT QoS profile %s with error: %+v", name, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to PUT QoS profile"})
+		return
+	}
+	if err := provisionDeviceGroupsUsingQosProfile(name); err != nil {
+		// the QoS profile is stored and some subscribers may use it already
+		logger.WebUILog.Errorf("failed to provision device groups using QoS profile %s with error: %+v", name, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("QoS profile %s is stored, but the device groups using it are not all provisioned. PUT it again to retry", name)})
+		return
+	}
+	logger.WebUILog.Infof("successfully executed PUT QoS profile %s request", name)
+	c.JSON(http.StatusOK, gin.H{})
+}
+
+// DeleteQosProfile godoc
+//
+// @Description Delete an existing QoS profile. The QoS profile cannot be deleted while device groups reference it.
+// @Tags        QoS Profiles
+// @Produce     json
+// @Param       qos-profile-name  path  string  true  "Name of the QoS profile"
+// @Security    BearerAuth
+// @Success     200  {object}  nil  "QoS profile deleted"
+// @Failure     401  {object}  nil  "Authorization failed"
+// @Failure     403  {object}  nil  "Forbidden"
+// @Failure     409  {object}  nil  "QoS profile is in use"
+// @Failure     500  {object}  nil  "Failed to delete QoS profile"
+// @Router      /config/v1/qos-profile/{qos-profile-name}  [delete]
+func DeleteQosProfile(c *gin.Context) {
+	setInventoryCorsHeader(c)
+	logger.WebUILog.Infoln("received a DELETE QoS profile request")
+	name, _ := c.Params.Get("qos-profile-name")
+	err := deleteUnusedItem(c.Request.Context(), "QoS profile "+name, configmodels.QosProfileDataColl, bson.M{"name": name}, deviceGroupReference(bson.M{"ip-domains.qos-profile-name": name}))
+	if err != nil {
+		var inUse *inUseError
+		if errors.As(err, &inUse) {
+			logger.WebUILog.Errorf("%s by %v", inUse.Error(), inUse.references)
+			c.JSON(http.StatusConflict, inUse.body())
+			return
+		}
+		logger.WebUILog.Errorf("failed to delete QoS profile %s with error: %+v", name, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to delete QoS profile"})
+		return
+	}
+	logger.WebUILog.Infof("successfully executed DELETE QoS profile %s request", name)
+	c.JSON(http.StatusOK, gin.H{})
+}
@@ -0,0 +1,557 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2026 Canonical Ltd
+
+package configapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/omec-project/webconsole/configmodels"
+	"github.com/omec-project/webconsole/dbadapter"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+type QosProfileMockDBClient struct {
+	dbadapter.DBInterface
+	qosProfiles    []configmodels.QosProfile
+	trafficClasses []configmodels.TrafficClassInfo
+	dnns           []configmodels.Dnn
+	slices         []configmodels.Slice
+	deviceGroups   []configmodels.DeviceGroups
+	putData        []map[string]any
+	deleted        []bson.M
+	err            error
+	// deviceGroupsErr fails the reads of the device groups only
+	deviceGroupsErr error
+}
+
+func (db *QosProfileMockDBClient) RestfulAPIGetOne(coll string, filter bson.M) (map[string]any, error) {
+	if db.err != nil {
+		return nil, db.err
+	}
+	switch coll {
+	case configmodels.QosProfileDataColl:
+		for _, qosProfile := range db.qosProfiles {
+			if qosProfile.Name == filter["name"] {
+				return configmodels.ToBsonM(qosProfile), nil
+			}
+		}
+	case configmodels.TrafficClassDataColl:
+		for _, trafficClass := range db.trafficClasses {
+			if trafficClass.Name == filter["name"] {
+				return configmodels.ToBsonM(trafficClass), nil
+			}
+		}
+	case configmodels.DnnDataColl:
+		for _, dnn := range db.dnns {
+			if dnn.Name == filter["name"] {
+				return configmodels.ToBsonM(dnn), nil
+			}
+		}
+	}
+	return nil, nil
+}
+
+func (db *QosProfileMockDBClient) RestfulAPIGetMany(coll string, filter bson.M) ([]map[string]any, error) {
+	if db.err != nil {
+		return nil, db.err
+	}
+	var results []map[string]any
+	switch coll {
+	case configmodels.QosProfileDataColl:
+		for _, qosProfile := range db.qosProfiles {
+			results = append(results, configmodels.ToBsonM(qosProfile))
+		}
+	case configmodels.TrafficClassDataColl:
+		for _, trafficClass := range db.trafficClasses {
+			results = append(results, configmodels.ToBsonM(trafficClass))
+		}
+	case sliceDataColl:
+		for _, slice := range db.slices {
+			results = append(results, configmodels.ToBsonM(slice))
+		}
+	case devGroupDataColl:
+		if db.deviceGroupsErr != nil {
+			return nil, db.deviceGroupsErr
+		}
+		for _, deviceGroup := range db.deviceGroups {
+			results = append(results, configmodels.ToBsonM(deviceGroup))
+		}
+	}
+	return results, nil
+}
+
+func (db *QosProfileMockDBClient) RestfulAPIGetManyWithContext(context context.Context, coll string, filter bson.M) ([]map[string]any, error) {
+	return db.RestfulAPIGetMany(coll, filter)
+}
+
+func (db *QosProfileMockDBClient) StartSession() (dbadapter.DBSession, error) {
+	return nil, nil
+}
+
+func (db *QosProfileMockDBClient) RestfulAPIPostManyWithContext(context context.Context, collName string, filter bson.M, postDataArray []any) error {
+	if db.err != nil {
+		return db.err
+	}
+	for _, qosProfile := range db.qosProfiles {
+		if qosProfile.Name == filter["name"] {
+			return errors.New("E11000")
+		}
+	}
+	return nil
+}
+
+func (db *QosProfileMockDBClient) RestfulAPIPutOne(collName string, filter bson.M, putData map[string]any) (bool, error) {
+	if db.err != nil {
+		return false, db.err
+	}
+	db.putData = append(db.putData, putData)
+	return true, nil
+}
+
+func (db *QosProfileMockDBClient) RestfulAPIDeleteOne(collName string, filter bson.M) error {
+	if db.err != nil {
+		return db.err
+	}
+	db.deleted = append(db.deleted, filter)
+	return nil
+}
+
+func (db *QosProfileMockDBClient) RestfulAPIDeleteOneWithContext(context context.Context, collName string, filter bson.M) error {
+	return db.RestfulAPIDeleteOne(collName, filter)
+}
+
+var silverQosProfile = configmodels.QosProfile{
+	Name:             "silver",
+	DnnMbrUplink:     20,
+	DnnMbrDownlink:   200,
+	BitrateUnit:      "Mbps",
+	TrafficClassName: "platinum",
+}
+
+func TestQosProfileGetHandlers(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+	AddConfigV1Service(router)
+
+	testCases := []struct {
+		name         string
+		route        string
+		dbAdapter    dbadapter.DBInterface
+		expectedCode int
+		expectedBody string
+	}{
+		{
+			name:         "List QoS profiles",
+			route:        "/config/v1/qos-profile",
+			dbAdapter:    &QosProfileMockDBClient{qosProfiles: []configmodels.QosProfile{silverQosProfile}},
+			expectedCode: http.StatusOK,
+			expectedBody: `[{"name":"silver","dnn-mbr-uplink":20,"dnn-mbr-downlink":200,"bitrate-unit":"Mbps","traffic-class-name":"platinum"}]`,
+		},
+		{
+			name:         "List QoS profiles when there are none",
+			route:        "/config/v1/qos-profile",
+			dbAdapter:    &QosProfileMockDBClient{},
+			expectedCode: http.StatusOK,
+			expectedBody: `[]`,
+		},
+		{
+			name:         "Get existing QoS profile",
+			route:        "/config/v1/qos-profile/silver",
+			dbAdapter:    &QosProfileMockDBClient{qosProfiles: []configmodels.QosProfile{silverQosProfile}},
+			expectedCode: http.StatusOK,
+			expectedBody: `{"name":"silver","dnn-mbr-uplink":20,"dnn-mbr-downlink":200,"bitrate-unit":"Mbps","traffic-class-name":"platinum"}`,
+		},
+		{
+			name:         "Get missing QoS profile",
+			route:        "/config/v1/qos-profile/gold",
+			dbAdapter:    &QosProfileMockDBClient{},
+			expectedCode: http.StatusNotFound,
+			expectedBody: `{"error":"QoS profile gold not found"}`,
+		},
+		{
+			name:         "List QoS profiles DB error",
+			route:        "/config/v1/qos-profile",
+			dbAdapter:    &QosProfileMockDBClient{err: errors.New("mock error")},
+			expectedCode: http.StatusInternalServerError,
+			expectedBody: `{"error":"failed to retrieve QoS profiles"}`,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			originalDBClient := dbadapter.CommonDBClient
+			defer func() { dbadapter.CommonDBClient = originalDBClient }()
+			dbadapter.CommonDBClient = tc.dbAdapter
+			req, err := http.NewRequest(http.MethodGet, tc.route, nil)
+			if err != nil {
+				t.Fatalf("failed to create request: %v", err)
+			}
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			if tc.expectedCode != w.Code {
+				t.Errorf("expected `%v`, got `%v`", tc.expectedCode, w.Code)
+			}
+			if w.Body.String() != tc.expectedBody {
+				t.Errorf("expected `%v`, got `%v`", tc.expectedBody, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestQosProfileWriteHandlers(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+	AddConfigV1Service(router)
+
+	testCases := []struct {
+		name          string
+		method        string
+		route         string
+		dbAdapter     dbadapter.DBInterface
+		inputData     string
+		expectedCode  int
+		expectedError string
+	}{
+		{
+			name:         "Create a QoS profile with an embedded traffic class",
+			method:       http.MethodPost,
+			route:        "/config/v1/qos-profile",
+			dbAdapter:    &QosProfileMockDBClient{},
+			inputData:    `{"name": "silver", "dnn-mbr-uplink": 20, "dnn-mbr-downlink": 200, "bitrate-unit": "Mbps", "traffic-class": {"name": "tc", "qci": 9, "arp": 1}}`,
+			expectedCode: http.StatusCreated,
+		},
+		{
+			name:         "Create a QoS profile referencing a traffic class",
+			method:       http.MethodPost,
+			route:        "/config/v1/qos-profile",
+			dbAdapter:    &QosProfileMockDBClient{trafficClasses: []configmodels.TrafficClassInfo{platinumTrafficClass}},
+			inputData:    `{"name": "silver", "dnn-mbr-uplink": 20, "bitrate-unit": "Mbps", "traffic-class-name": "platinum"}`,
+			expectedCode: http.StatusCreated,
+		},
+		{
+			name:   "Create an existing QoS profile",
+			method: http.MethodPost,
+			route:  "/config/v1/qos-profile",
+			dbAdapter: &QosProfileMockDBClient{
+				qosProfiles:    []configmodels.QosProfile{silverQosProfile},
+				trafficClasses: []configmodels.TrafficClassInfo{platinumTrafficClass},
+			},
+			inputData:     `{"name": "silver", "traffic-class-name": "platinum"}`,
+			expectedCode:  http.StatusBadRequest,
+			expectedError: "QoS profile already exists",
+		},
+		{
+			name:          "Create a QoS profile with invalid name",
+			method:        http.MethodPost,
+			route:         "/config/v1/qos-profile",
+			dbAdapter:     &QosProfileMockDBClient{},
+			inputData:     `{"name": "silv&r", "traffic-class-name": "platinum"}`,
+			expectedCode:  http.StatusBadRequest,
+			expectedError: "invalid QoS profile name",
+		},
+		{
+			name:          "Create a QoS profile with negative MBR",
+			method:        http.MethodPost,
+			route:         "/config/v1/qos-profile",
+			dbAdapter:     &QosProfileMockDBClient{},
+			inputData:     `{"name": "silver", "dnn-mbr-uplink": -1, "bitrate-unit": "Mbps", "traffic-class-name": "platinum"}`,
+			expectedCode:  http.StatusBadRequest,
+			expectedError: "MBR must not be negative",
+		},
+		{
+			name:          "Create a QoS profile with invalid bitrate unit",
+			method:        http.MethodPost,
+			route:         "/config/v1/qos-profile",
+			dbAdapter:     &QosProfileMockDBClient{},
+			inputData:     `{"name": "silver", "dnn-mbr-uplink": 20, "bitrate-unit": "Tbps", "traffic-class-name": "platinum"}`,
+			expectedCode:  http.StatusBadRequest,
+			expectedError: "Invalid bitrate unit",
+		},
+		{
+			name:          "Create a QoS profile without traffic class",
+			method:        http.MethodPost,
+			route:         "/config/v1/qos-profile",
+			dbAdapter:     &QosProfileMockDBClient{},
+			inputData:     `{"name": "silver"}`,
+			expectedCode:  http.StatusBadRequest,
+			expectedError: "Traffic class or traffic class name must be provided",
+		},
+		{
+			name:          "Create a QoS profile with unknown traffic class",
+			method:        http.MethodPost,
+			route:         "/config/v1/qos-profile",
+			dbAdapter:     &QosProfileMockDBClient{},
+			inputData:     `{"name": "silver", "traffic-class-name": "gold"}`,
+			expectedCode:  http.StatusBadRequest,
+			expectedError: "traffic class gold not found",
+		},
+		{
+			name:          "Create a QoS profile with invalid embedded traffic class",
+			method:        http.MethodPost,
+			route:         "/config/v1/qos-profile",
+			dbAdapter:     &QosProfileMockDBClient{},
+			inputData:     `{"name": "silver", "traffic-class": {"qci": 0, "arp": 1}}`,
+			expectedCode:  http.StatusBadRequest,
+			expectedError: "invalid 5QI",
+		},
+		{
+			name:   "Update a QoS profile",
+			method: http.MethodPut,
+			route:  "/config/v1/qos-profile/silver",
+			dbAdapter: &QosProfileMockDBClient{
+				qosProfiles:    []configmodels.QosProfile{silverQosProfile},
+				trafficClasses: []configmodels.TrafficClassInfo{platinumTrafficClass},
+			},
+			inputData:    `{"dnn-mbr-uplink": 50, "bitrate-unit": "Mbps", "traffic-class-name": "platinum"}`,
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:   "Update a QoS profile whose device groups fail to provision",
+			method: http.MethodPut,
+			route:  "/config/v1/qos-profile/silver",
+			dbAdapter: &QosProfileMockDBClient{
+				qosProfiles:     []configmodels.QosProfile{silverQosProfile},
+				trafficClasses:  []configmodels.TrafficClassInfo{platinumTrafficClass},
+				deviceGroupsErr: errors.New("connection lost"),
+			},
+			inputData:     `{"dnn-mbr-uplink": 50, "bitrate-unit": "Mbps", "traffic-class-name": "platinum"}`,
+			expectedCode:  http.StatusInternalServerError,
+			expectedError: "QoS profile silver is stored, but the device groups using it are not all provisioned",
+		},
+		{
+			name:          "Update a QoS profile DB error",
+			method:        http.MethodPut,
+			route:         "/config/v1/qos-profile/silver",
+			dbAdapter:     &QosProfileMockDBClient{err: errors.New("mock error")},
+			inputData:     `{"traffic-class": {"qci": 9, "arp": 1}}`,
+			expectedCode:  http.StatusInternalServerError,
+			expectedError: "failed to PUT QoS profile",
+		},
+		{
+			name:         "Delete an unused QoS profile",
+			method:       http.MethodDelete,
+			route:        "/config/v1/qos-profile/silver",
+			dbAdapter:    &QosProfileMockDBClient{qosProfiles: []configmodels.QosProfile{silverQosProfile}},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:   "Delete a QoS profile used by a device group",
+			method: http.MethodDelete,
+			route:  "/config/v1/qos-profile/silver",
+			dbAdapter: &QosProfileMockDBClient{
+				qosProfiles:  []configmodels.QosProfile{silverQosProfile},
+				deviceGroups: []configmodels.DeviceGroups{{DeviceGroupName: "group1"}},
+			},
+			expectedCode:  http.StatusConflict,
+			expectedError: "QoS profile silver is in use",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			originalDBClient := dbadapter.CommonDBClient
+			defer func() { dbadapter.CommonDBClient = originalDBClient }()
+			dbadapter.CommonDBClient = tc.dbAdapter
+			req, err := http.NewRequest(tc.method, tc.route, strings.NewReader(tc.inputData))
+			if err != nil {
+				t.Fatalf("failed to create request: %v", err)
+			}
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			if tc.expectedCode != w.Code {
+				t.Errorf("expected `%v`, got `%v`", tc.expectedCode, w.Code)
+			}
+			var body map[string]any
+			if err = json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+				t.Fatalf("failed to unmarshal JSON: %v", err)
+			}
+			if tc.expectedError == "" {
+				if len(body) != 0 {
+					t.Errorf("expected empty body, got %v", body)
+				}
+				return
+			}
+			errorMessage, _ := body["error"].(string)
+			if !strings.Contains(errorMessage, tc.expectedError) {
+				t.Errorf("expected error containing `%s`, got `%v`", tc.expectedError, body)
+			}
+		})
+	}
+}
+
+func TestDeleteTrafficClass_ReportsQosProfiles(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+	AddConfigV1Service(router)
+	mockDB := &QosProfileMockDBClient{
+		qosProfiles:    []configmodels.QosProfile{silverQosProfile},
+		trafficClasses: []configmodels.TrafficClassInfo{platinumTrafficClass},
+	}
+	originalDBClient := dbadapter.CommonDBClient
+	defer func() { dbadapter.CommonDBClient = originalDBClient }()
+	dbadapter.CommonDBClient = mockDB
+
+	req, err := http.NewRequest(http.MethodDelete, "/config/v1/traffic-class/platinum", nil)
+	if err != nil {
+		t.Fatalf("failed to create request: %v", err)
+	}
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusConflict {
+		t.Fatalf("expected `%v`, got `%v`", http.StatusConflict, w.Code)
+	}
+	var body struct {
+		QosProfiles []string `json:"qos-profiles"`
+	}
+	if err = json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+		t.Fatalf("failed to unmarshal JSON: %v", err)
+	}
+	if !reflect.DeepEqual(body.QosProfiles, []string{"silver"}) {
+		t.Errorf("expected QoS profiles [silver], got %v", body.QosProfiles)
+	}
+	if len(mockDB.deleted) != 0 {
+		t.Errorf("expected traffic class not to be deleted")
+	}
+}
+
+func TestResolveDeviceGroupQosProfiles(t *testing.T) {
+	unlimited := configmodels.QosProfile{Name: "unlimited", DnnMbrUplink: math.MaxInt64, BitrateUnit: "Gbps", TrafficClass: &platinumTrafficClass}
+	catalog := map[string]configmodels.QosProfile{"silver": silverQosProfile, "unlimited": unlimited}
+	embedded := &configmodels.DeviceGroupsIpDomainExpandedUeDnnQos{DnnMbrUplink: 10, BitrateUnit: "bps"}
+	deviceGroup := configmodels.DeviceGroups{
+		DeviceGroupName: "group1",
+		IpDomainsExpanded: []configmodels.DeviceGroupsIpDomainExpanded{
+			{Dnn: "internet", QosProfileName: "silver"},
+			{Dnn: "ims", UeDnnQos: embedded},
+			{Dnn: "iot", QosProfileName: "unlimited"},
+			{Dnn: "unknown", QosProfileName: "gold"},
+		},
+	}
+	original := deviceGroup.IpDomainsExpanded
+
+	err := ResolveDeviceGroupQosProfiles(&deviceGroup, catalog)
+	if err == nil || !strings.Contains(err.Error(), "DNN unknown: QoS profile gold not found") {
+		t.Errorf("expected error for unknown QoS profile, got %v", err)
+	}
+	expected := &configmodels.DeviceGroupsIpDomainExpandedUeDnnQos{
+		DnnMbrUplink:     20 * MBPS,
+		DnnMbrDownlink:   200 * MBPS,
+		BitrateUnit:      "bps",
+		TrafficClassName: "platinum",
+	}
+	if !reflect.DeepEqual(deviceGroup.IpDomainsExpanded[0].UeDnnQos, expected) {
+		t.Errorf("expected %+v, got %+v", expected, deviceGroup.IpDomainsExpanded[0].UeDnnQos)
+	}
+	if deviceGroup.IpDomainsExpanded[1].UeDnnQos != embedded {
+		t.Errorf("expected IP domain to keep its embedded QoS")
+	}
+	if deviceGroup.IpDomainsExpanded[2].UeDnnQos.DnnMbrUplink != math.MaxInt64 {
+		t.Errorf("expected overflowing MBR to be capped, got %d", deviceGroup.IpDomainsExpanded[2].UeDnnQos.DnnMbrUplink)
+	}
+	if deviceGroup.IpDomainsExpanded[2].UeDnnQos.TrafficClass == &platinumTrafficClass {
+		t.Errorf("expected traffic class of the QoS profile to be copied")
+	}
+	if deviceGroup.IpDomainsExpanded[3].UeDnnQos != nil {
+		t.Errorf("expected IP domain with unknown QoS profile to have no QoS")
+	}
+	if original[0].UeDnnQos != nil {
+		t.Errorf("expected original IP domains not to be modified")
+	}
+}
+
+func TestResolveDeviceGroupForProvisioning_QosProfileAndTrafficClass(t *testing.T) {
+	originalDBClient := dbadapter.CommonDBClient
+	defer func() { dbadapter.CommonDBClient = originalDBClient }()
+	dbadapter.CommonDBClient = &QosProfileMockDBClient{
+		qosProfiles:    []configmodels.QosProfile{silverQosProfile},
+		trafficClasses: []configmodels.TrafficClassInfo{platinumTrafficClass},
+	}
+	deviceGroup := configmodels.DeviceGroups{
+		DeviceGroupName: "group1",
+		IpDomainsExpanded: []configmodels.DeviceGroupsIpDomainExpanded{
+			{Dnn: "internet", QosProfileName: "silver"},
+		},
+	}
+
+	resolved, err := resolveDeviceGroupForProvisioning(&deviceGroup)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	qos := resolved.IpDomainsExpanded[0].UeDnnQos
+	if qos == nil || qos.TrafficClass == nil || *qos.TrafficClass != platinumTrafficClass {
+		t.Errorf("expected DNN QoS to use the platinum traffic class, got %+v", qos)
+	}
+	if deviceGroup.IpDomainsExpanded[0].UeDnnQos != nil {
+		t.Errorf("expected original device group not to be modified")
+	}
+}
+
+func TestDeviceGroupPost_QosProfileValidation(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+	AddConfigV1Service(router)
+
+	testCases := []struct {
+		name          string
+		ipDomain      configmodels.DeviceGroupsIpDomainExpanded
+		expectedError string
+	}{
+		{
+			name:          "Unknown QoS profile",
+			ipDomain:      configmodels.DeviceGroupsIpDomainExpanded{Dnn: "internet", QosProfileName: "gold"},
+			expectedError: "QoS profile gold not found",
+		},
+		{
+			name: "QoS profile and embedded QoS",
+			ipDomain: configmodels.DeviceGroupsIpDomainExpanded{
+				Dnn:            "internet",
+				QosProfileName: "silver",
+				UeDnnQos:       &configmodels.DeviceGroupsIpDomainExpandedUeDnnQos{DnnMbrUplink: 10, BitrateUnit: "bps"},
+			},
+			expectedError: "QoS profile name silver and ue-dnn-qos cannot be set together",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			originalDBClient := dbadapter.CommonDBClient
+			defer func() { dbadapter.CommonDBClient = originalDBClient }()
+			dbadapter.CommonDBClient = &QosProfileMockDBClient{
+				qosProfiles: []configmodels.QosProfile{silverQosProfile},
+				dnns:        []configmodels.Dnn{{Name: "internet"}},
+			}
+			group := deviceGroup("group1")
+			group.IpDomainsExpanded = []configmodels.DeviceGroupsIpDomainExpanded{tc.ipDomain}
+			jsonBody, err := json.Marshal(group)
+			if err != nil {
+				t.Fatalf("failed to marshal device group %v", err)
+			}
+			req, err := http.NewRequest(http.MethodPost, "/config/v1/device-group/group1", strings.NewReader(string(jsonBody)))
+			if err != nil {
+				t.Fatalf("failed to create request: %v", err)
+			}
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != http.StatusBadRequest {
+				t.Errorf("expected `%v`, got `%v`", http.StatusBadRequest, w.Code)
+			}
+			if !strings.Contains(w.Body.String(), tc.expectedError) {
+				t.Errorf("expected body to contain `%s`, got `%v`", tc.expectedError, w.Body.String())
+			}
+		})
+	}
+}
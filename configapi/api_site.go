@@ -0,0 +1,227 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2026 Canonical Ltd
+
+package configapi
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/omec-project/webconsole/backend/logger"
+	"github.com/omec-project/webconsole/configmodels"
+	"github.com/omec-project/webconsole/dbadapter"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// GetSites godoc
+//
+// @Description Return the list of sites
+// @Tags        Sites
+// @Produce     json
+// @Security    BearerAuth
+// @Success     200  {array}   configmodels.Site  "List of sites"
+// @Failure     401  {object}  nil                "Authorization failed"
+// @Failure     403  {object}  nil                "Forbidden"
+// @Failure     500  {object}  nil                "Error retrieving sites"
+// @Router      /config/v1/site  [get]
+func GetSites(c *gin.Context) {
+	setInventoryCorsHeader(c)
+	logger.WebUILog.Infoln("received a GET sites request")
+	sites := make([]*configmodels.Site, 0)
+	rawSites, err := dbadapter.CommonDBClient.RestfulAPIGetMany(configmodels.SiteDataColl, bson.M{})
+	if err != nil {
+		logger.DbLog.Errorf("failed to retrieve sites with error: %+v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to retrieve sites"})
+		return
+	}
+
+	for _, rawSite := range rawSites {
+		var site configmodels.Site
+		if err = json.Unmarshal(configmodels.MapToByte(rawSite), &site); err != nil {
+			logger.DbLog.Errorf("could not unmarshal site %s", rawSite)
+			continue
+		}
+		sites = append(sites, &site)
+	}
+	logger.WebUILog.Infoln("successfully executed GET sites request")
+	c.JSON(http.StatusOK, sites)
+}
+
+// GetSiteByName godoc
+//
+// @Description Return the site
+// @Tags        Sites
+// @Produce     json
+// @Param       site-name  path  string  true  "Name of the site"
+// @Security    BearerAuth
+// @Success     200  {object}  configmodels.Site  "Site"
+// @Failure     401  {object}  nil                "Authorization failed"
+// @Failure     403  {object}  nil                "Forbidden"
+// @Failure     404  {object}  nil                "Site not found"
+// @Failure     500  {object}  nil                "Error retrieving site"
+// @Router      /config/v1/site/{site-name}  [get]
+func GetSiteByName(c *gin.Context) {
+	setInventoryCorsHeader(c)
+	logger.WebUILog.Infoln("received a GET site request")
+	name, _ := c.Params.Get("site-name")
+	site, err := getSiteByName(name)
+	if err != nil {
+		logger.DbLog.Errorf("failed to retrieve site %s with error: %+v", name, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to retrieve site"})
+		return
+	}
+	if site == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": fmt.Sprintf("site %s not found", name)})
+		return
+	}
+	c.JSON(http.StatusOK, site)
+}
+
+// PostSite godoc
+//
+// @Description Create a new site. Network slices are not updated: they get the site data when attached to the site or when the site is updated.
+// @Tags        Sites
+// @Produce     json
+// @Param       site  body  configmodels.Site  true  "Site to create"
+// @Security    BearerAuth
+// @Success     201  {object}  nil  "Site successfully created"
+// @Failure     400  {object}  nil  "Bad request"
+// @Failure     401  {object}  nil  "Authorization failed"
+// @Failure     403  {object}  nil  "Forbidden"
+// @Failure     409  {object}  nil  "Network slices with the site name have different site data"
+// @Failure     500  {object}  nil  "Error creating site"
+// @Router      /config/v1/site  [post]
+func PostSite(c *gin.Context) {
+	setInventoryCorsHeader(c)
+	logger.WebUILog.Infoln("received a POST site request")
+	var site configmodels.Site
+	if err := c.ShouldBindJSON(&site); err != nil {
+		logger.WebUILog.Errorf("invalid site POST input parameters with error: %+v", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid JSON format"})
+		return
+	}
+	normalizeSite(&site)
+	if err := validateSite(site); err != nil {
+		logger.WebUILog.Errorln(err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	sliceNames, err := getSliceSiteConflicts(site)
+	if err != nil {
+		logger.WebUILog.Errorf("failed to check network slices of site %s with error: %+v", site.SiteName, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to create site"})
+		return
+	}
+	if len(sliceNames) > 0 {
+		errorMessage := fmt.Sprintf("network slices with site name %s have a different PLMN, gNBs or UPF", site.SiteName)
+		logger.WebUILog.Errorf("%s: %v", errorMessage, sliceNames)
+		c.JSON(http.StatusConflict, gin.H{
+			"error":          errorMessage,
+			"network-slices": sliceNames,
+		})
+		return
+	}
+	if err = postSiteOperation(c.Request.Context(), site); err != nil {
+		if strings.Contains(err.Error(), "E11000") {
+			logger.WebUILog.Errorf("duplicate site name found error: %+v", err)
+			c.JSON(http.StatusBadRequest, gin.H{"error": "site already exists"})
+			return
+		}
+		logger.WebUILog.Errorf("failed to create site %s with error: %+v", site.SiteName, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to create site"})
+		return
+	}
+	logger.WebUILog.Infof("successfully executed POST site %s request", site.SiteName)
+	c.JSON(http.StatusCreated, gin.H{})
+}
+
+// PutSite godoc
+//
+// @Description Create or update a site. Every network slice attached to the site is updated.
+// @Tags        Sites
+// @Produce     json
+// @Param       site-name  path  string                       true  "Name of the site"
+// @Param       site       body  configmodels.PutSiteRequest  true  "PLMN, gNBs and UPF of the site"
+// @Security    BearerAuth
+// @Success     200  {object}  nil  "Site successfully updated"
+// @Failure     400  {object}  nil  "Bad request"
+// @Failure     401  {object}  nil  "Authorization failed"
+// @Failure     403  {object}  nil  "Forbidden"
+// @Failure     500  {object}  nil  "Error updating site"
+// @Router      /config/v1/site/{site-name}  [put]
+func PutSite(c *gin.Context) {
+	setInventoryCorsHeader(c)
+	logger.WebUILog.Infoln("received a PUT site request")
+	name, _ := c.Params.Get("site-name")
+	var putSiteParams configmodels.PutSiteRequest
+	if err := c.ShouldBindJSON(&putSiteParams); err != nil {
+		logger.WebUILog.Errorf("invalid site PUT input parameters for %s with error: %+v", name, err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid JSON format"})
+		return
+	}
+	site := configmodels.Site{
+		SiteName: name,
+		Plmn:     putSiteParams.Plmn,
+		GNodeBs:  putSiteParams.GNodeBs,
+		Upf:      putSiteParams.Upf,
+	}
+	normalizeSite(&site)
+	if err := validateSite(site); err != nil {
+		logger.WebUILog.Errorln(err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err := executeSiteTransaction(c.Request.Context(), site, updateSiteInNetworkSlices, putSiteOperation); err != nil {
+		logger.WebUILog.Errorf("failed to PUT site %s with error: %+v", name, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to PUT site"})
+		return
+	}
+	logger.WebUILog.Infof("successfully executed PUT site %s request", name)
+	c.JSON(http.StatusOK, gin.H{})
+}
+
+// DeleteSite godoc
+//
+// @Description Delete an existing site. The site cannot be deleted while network slices are attached to it.
+// @Tags        Sites
+// @Produce     json
+// @Param       site-name  path  string  true  "Name of the site"
+// @Security    BearerAuth
+// @Success     200  {object}  nil  "Site deleted"
+// @Failure     401  {object}  nil  "Authorization failed"
+// @Failure     403  {object}  nil  "Forbidden"
+// @Failure     409  {object}  nil  "Site is in use"
+// @Failure     500  {object}  nil  "Failed to delete site"
+// @Router      /config/v1/site/{site-name}  [delete]
+func DeleteSite(c *gin.Context) {
+	setInventoryCorsHeader(c)
+	logger.WebUILog.Infoln("received a DELETE site request")
+	name, _ := c.Params.Get("site-name")
+	err := deleteUnusedItem(c.Request.Context(), "site "+name, configmodels.SiteDataColl, bson.M{"site-name": name}, sliceReference(bson.M{"site-info.site-name": name}))
+	if err != nil {
+		var inUse *inUseError
+		if errors.As(err, &inUse) {
+			logger.WebUILog.Errorf("%s by %v", inUse.Error(), inUse.references)
+			c.JSON(http.StatusConflict, inUse.body())
+			return
+		}
+		logger.WebUILog.Errorf("failed to delete site %s with error: %+v", name, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to delete site"})
+		return
+	}
+	logger.WebUILog.Infof("successfully executed DELETE site %s request", name)
+	c.JSON(http.StatusOK, gin.H{})
+}
+
+func normalizeSite(site *configmodels.Site) {
+	if site.GNodeBs == nil {
+		site.GNodeBs = []string{}
+	}
+	slices.Sort(site.GNodeBs)
+	site.GNodeBs = slices.Compact(site.GNodeBs)
+}
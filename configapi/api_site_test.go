@@ -0,0 +1,643 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2026 Canonical Ltd
+
+package configapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/omec-project/webconsole/configmodels"
+	"github.com/omec-project/webconsole/dbadapter"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+type SiteMockDBClient struct {
+	dbadapter.DBInterface
+	sites      []configmodels.Site
+	gnbs       []configmodels.Gnb
+	upfs       []configmodels.Upf
+	slices     []configmodels.Slice
+	postedData []map[string]any
+	sitePuts   []map[string]any
+	err        error
+}
+
+func (db *SiteMockDBClient) RestfulAPIGetOne(coll string, filter bson.M) (map[string]any, error) {
+	if db.err != nil {
+		return nil, db.err
+	}
+	switch coll {
+	case configmodels.SiteDataColl:
+		for _, site := range db.sites {
+			if site.SiteName == filter["site-name"] {
+				return configmodels.ToBsonM(site), nil
+			}
+		}
+	case configmodels.GnbDataColl:
+		for _, gnb := range db.gnbs {
+			if gnb.Name == filter["name"] {
+				return configmodels.ToBsonM(gnb), nil
+			}
+		}
+	case configmodels.UpfDataColl:
+		for _, upf := range db.upfs {
+			if upf.Hostname == filter["hostname"] {
+				return configmodels.ToBsonM(upf), nil
+			}
+		}
+	case sliceDataColl:
+		for _, slice := range db.slices {
+			if slice.SliceName == filter["slice-name"] {
+				return configmodels.ToBsonM(slice), nil
+			}
+		}
+	}
+	return nil, nil
+}
+
+func (db *SiteMockDBClient) RestfulAPIGetMany(coll string, filter bson.M) ([]map[string]any, error) {
+	if db.err != nil {
+		return nil, db.err
+	}
+	var results []map[string]any
+	switch coll {
+	case configmodels.SiteDataColl:
+		for _, site := range db.sites {
+			if gnbName, ok := filter["gNodeBs"]; ok && !strings.Contains(strings.Join(site.GNodeBs, ","), gnbName.(string)) {
+				continue
+			}
+			if hostname, ok := filter["upf"]; ok && site.Upf != hostname {
+				continue
+			}
+			results = append(results, configmodels.ToBsonM(site))
+		}
+	case configmodels.GnbDataColl:
+		for _, gnb := range db.gnbs {
+			if tac, ok := filter["tac"]; ok && (gnb.Tac == nil || *gnb.Tac != tac) {
+				continue
+			}
+			results = append(results, configmodels.ToBsonM(gnb))
+		}
+	case sliceDataColl:
+		for _, slice := range db.slices {
+			if sliceMatchesFilter(slice, filter) {
+				results = append(results, configmodels.ToBsonM(slice))
+			}
+		}
+	}
+	return results, nil
+}
+
+func (db *SiteMockDBClient) RestfulAPIGetManyWithContext(context context.Context, coll string, filter bson.M) ([]map[string]any, error) {
+	return db.RestfulAPIGetMany(coll, filter)
+}
+
+// sliceMatchesFilter supports the network slice filters used to find the
+// slices referencing a site, an inventory item or a device group
+func sliceMatchesFilter(slice configmodels.Slice, filter bson.M) bool {
+	for key, value := range filter {
+		switch key {
+		case "site-info.site-name":
+			if slice.SiteInfo.SiteName != value {
+				return false
+			}
+		case "site-info.gNodeBs.name":
+			if !slices.ContainsFunc(slice.SiteInfo.GNodeBs, func(gnb configmodels.SliceSiteInfoGNodeBs) bool { return gnb.Name == value }) {
+				return false
+			}
+		case "site-info.upf.upf-name":
+			if slice.SiteInfo.Upf["upf-name"] != value {
+				return false
+			}
+		case "site-device-group":
+			if !slices.Contains(slice.SiteDeviceGroup, value.(string)) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func (db *SiteMockDBClient) StartSession() (dbadapter.DBSession, error) {
+	return nil, nil
+}
+
+func (db *SiteMockDBClient) RestfulAPIPostManyWithContext(context context.Context, collName string, filter bson.M, postDataArray []any) error {
+	for _, site := range db.sites {
+		if site.SiteName == filter["site-name"] {
+			return errors.New("E11000")
+		}
+	}
+	return nil
+}
+
+func (db *SiteMockDBClient) RestfulAPIPutOneWithContext(context context.Context, collName string, filter bson.M, putData map[string]any) (bool, error) {
+	return true, nil
+}
+
+func (db *SiteMockDBClient) RestfulAPIPutOne(collName string, filter bson.M, putData map[string]any) (bool, error) {
+	if collName == configmodels.SiteDataColl {
+		db.sitePuts = append(db.sitePuts, putData)
+	}
+	return true, nil
+}
+
+func (db *SiteMockDBClient) RestfulAPIPost(collName string, filter bson.M, postData map[string]any) (bool, error) {
+	db.postedData = append(db.postedData, postData)
+	return true, nil
+}
+
+func (db *SiteMockDBClient) RestfulAPIDeleteOne(collName string, filter bson.M) error {
+	return nil
+}
+
+func (db *SiteMockDBClient) RestfulAPIDeleteOneWithContext(context context.Context, collName string, filter bson.M) error {
+	return nil
+}
+
+func siteInventory() ([]configmodels.Gnb, []configmodels.Upf) {
+	tac1, tac2 := int32(1), int32(2)
+	gnbs := []configmodels.Gnb{{Name: "gnb1", Tac: &tac1}, {Name: "gnb2", Tac: &tac2}, {Name: "gnb3"}}
+	upfs := []configmodels.Upf{{Hostname: "upf1.example.com", Port: "8805"}}
+	return gnbs, upfs
+}
+
+var berlinSite = configmodels.Site{
+	SiteName: "berlin",
+	Plmn:     configmodels.SliceSiteInfoPlmn{Mcc: "001", Mnc: "01"},
+	GNodeBs:  []string{"gnb1"},
+	Upf:      "upf1.example.com",
+}
+
+func TestSiteGetHandlers(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+	AddConfigV1Service(router)
+
+	testCases := []struct {
+		name         string
+		route        string
+		dbAdapter    dbadapter.DBInterface
+		expectedCode int
+		expectedBody string
+	}{
+		{
+			name:         "List sites",
+			route:        "/config/v1/site",
+			dbAdapter:    &SiteMockDBClient{sites: []configmodels.Site{berlinSite}},
+			expectedCode: http.StatusOK,
+			expectedBody: `[{"site-name":"berlin","plmn":{"mcc":"001","mnc":"01"},"gNodeBs":["gnb1"],"upf":"upf1.example.com"}]`,
+		},
+		{
+			name:         "List sites when there are none",
+			route:        "/config/v1/site",
+			dbAdapter:    &SiteMockDBClient{},
+			expectedCode: http.StatusOK,
+			expectedBody: `[]`,
+		},
+		{
+			name:         "Get existing site",
+			route:        "/config/v1/site/berlin",
+			dbAdapter:    &SiteMockDBClient{sites: []configmodels.Site{berlinSite}},
+			expectedCode: http.StatusOK,
+			expectedBody: `{"site-name":"berlin","plmn":{"mcc":"001","mnc":"01"},"gNodeBs":["gnb1"],"upf":"upf1.example.com"}`,
+		},
+		{
+			name:         "Get missing site",
+			route:        "/config/v1/site/paris",
+			dbAdapter:    &SiteMockDBClient{},
+			expectedCode: http.StatusNotFound,
+			expectedBody: `{"error":"site paris not found"}`,
+		},
+		{
+			name:         "List sites DB error",
+			route:        "/config/v1/site",
+			dbAdapter:    &SiteMockDBClient{err: errors.New("mock error")},
+			expectedCode: http.StatusInternalServerError,
+			expectedBody: `{"error":"failed to retrieve sites"}`,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			originalDBClient := dbadapter.CommonDBClient
+			defer func() { dbadapter.CommonDBClient = originalDBClient }()
+			dbadapter.CommonDBClient = tc.dbAdapter
+			req, err := http.NewRequest(http.MethodGet, tc.route, nil)
+			if err != nil {
+				t.Fatalf("failed to create request: %v", err)
+			}
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			if tc.expectedCode != w.Code {
+				t.Errorf("expected `%v`, got `%v`", tc.expectedCode, w.Code)
+			}
+			if w.Body.String() != tc.expectedBody {
+				t.Errorf("expected `%v`, got `%v`", tc.expectedBody, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestSiteWriteHandlers(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+	AddConfigV1Service(router)
+	gnbs, upfs := siteInventory()
+
+	testCases := []struct {
+		name          string
+		method        string
+		route         string
+		dbAdapter     dbadapter.DBInterface
+		inputData     string
+		expectedCode  int
+		expectedError string
+	}{
+		{
+			name:         "Create a new site",
+			method:       http.MethodPost,
+			route:        "/config/v1/site",
+			dbAdapter:    &SiteMockDBClient{gnbs: gnbs, upfs: upfs},
+			inputData:    `{"site-name": "berlin", "plmn": {"mcc": "001", "mnc": "01"}, "gNodeBs": ["gnb1", "gnb2"], "upf": "upf1.example.com"}`,
+			expectedCode: http.StatusCreated,
+		},
+		{
+			name:          "Create an existing site",
+			method:        http.MethodPost,
+			route:         "/config/v1/site",
+			dbAdapter:     &SiteMockDBClient{sites: []configmodels.Site{berlinSite}, gnbs: gnbs, upfs: upfs},
+			inputData:     `{"site-name": "berlin", "plmn": {"mcc": "001", "mnc": "01"}}`,
+			expectedCode:  http.StatusBadRequest,
+			expectedError: "site already exists",
+		},
+		{
+			name:   "Create a site with slices of the same site data",
+			method: http.MethodPost,
+			route:  "/config/v1/site",
+			dbAdapter: &SiteMockDBClient{
+				gnbs:   gnbs,
+				upfs:   upfs,
+				slices: []configmodels.Slice{{SliceName: "slice1", SiteInfo: configmodels.SliceSiteInfo{SiteName: "berlin", Plmn: berlinSite.Plmn}}},
+			},
+			inputData:    `{"site-name": "berlin", "plmn": {"mcc": "001", "mnc": "01"}, "gNodeBs": ["gnb1"]}`,
+			expectedCode: http.StatusCreated,
+		},
+		{
+			name:   "Create a site with slices of different site data",
+			method: http.MethodPost,
+			route:  "/config/v1/site",
+			dbAdapter: &SiteMockDBClient{
+				gnbs:   gnbs,
+				upfs:   upfs,
+				slices: []configmodels.Slice{{SliceName: "slice1", SiteInfo: configmodels.SliceSiteInfo{SiteName: "berlin", Plmn: configmodels.SliceSiteInfoPlmn{Mcc: "001", Mnc: "02"}}}},
+			},
+			inputData:     `{"site-name": "berlin", "plmn": {"mcc": "001", "mnc": "01"}}`,
+			expectedCode:  http.StatusConflict,
+			expectedError: "network slices with site name berlin have a different PLMN, gNBs or UPF",
+		},
+		{
+			name:          "Create a site with invalid MCC",
+			method:        http.MethodPost,
+			route:         "/config/v1/site",
+			dbAdapter:     &SiteMockDBClient{},
+			inputData:     `{"site-name": "berlin", "plmn": {"mcc": "01", "mnc": "01"}}`,
+			expectedCode:  http.StatusBadRequest,
+			expectedError: "invalid MCC",
+		},
+		{
+			name:          "Create a site with invalid MNC",
+			method:        http.MethodPost,
+			route:         "/config/v1/site",
+			dbAdapter:     &SiteMockDBClient{},
+			inputData:     `{"site-name": "berlin", "plmn": {"mcc": "001", "mnc": "1"}}`,
+			expectedCode:  http.StatusBadRequest,
+			expectedError: "invalid MNC",
+		},
+		{
+			name:          "Create a site with unknown gNB",
+			method:        http.MethodPost,
+			route:         "/config/v1/site",
+			dbAdapter:     &SiteMockDBClient{gnbs: gnbs, upfs: upfs},
+			inputData:     `{"site-name": "berlin", "plmn": {"mcc": "001", "mnc": "01"}, "gNodeBs": ["gnb9"]}`,
+			expectedCode:  http.StatusBadRequest,
+			expectedError: "gNB gnb9 not found",
+		},
+		{
+			name:          "Create a site with a gNB without TAC",
+			method:        http.MethodPost,
+			route:         "/config/v1/site",
+			dbAdapter:     &SiteMockDBClient{gnbs: gnbs, upfs: upfs},
+			inputData:     `{"site-name": "berlin", "plmn": {"mcc": "001", "mnc": "01"}, "gNodeBs": ["gnb3"]}`,
+			expectedCode:  http.StatusBadRequest,
+			expectedError: "gNB gnb3 has no TAC",
+		},
+		{
+			name:          "Create a site with unknown UPF",
+			method:        http.MethodPost,
+			route:         "/config/v1/site",
+			dbAdapter:     &SiteMockDBClient{gnbs: gnbs, upfs: upfs},
+			inputData:     `{"site-name": "berlin", "plmn": {"mcc": "001", "mnc": "01"}, "upf": "upf9.example.com"}`,
+			expectedCode:  http.StatusBadRequest,
+			expectedError: "UPF upf9.example.com not found",
+		},
+		{
+			name:         "Update a site",
+			method:       http.MethodPut,
+			route:        "/config/v1/site/berlin",
+			dbAdapter:    &SiteMockDBClient{sites: []configmodels.Site{berlinSite}, gnbs: gnbs, upfs: upfs},
+			inputData:    `{"plmn": {"mcc": "001", "mnc": "02"}, "gNodeBs": ["gnb2"]}`,
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:         "Delete a site without slices",
+			method:       http.MethodDelete,
+			route:        "/config/v1/site/berlin",
+			dbAdapter:    &SiteMockDBClient{sites: []configmodels.Site{berlinSite}},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:   "Delete a site with slices",
+			method: http.MethodDelete,
+			route:  "/config/v1/site/berlin",
+			dbAdapter: &SiteMockDBClient{
+				sites:  []configmodels.Site{berlinSite},
+				slices: []configmodels.Slice{{SliceName: "slice1", SiteInfo: configmodels.SliceSiteInfo{SiteName: "berlin"}}},
+			},
+			expectedCode:  http.StatusConflict,
+			expectedError: "site berlin is in use",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			originalDBClient := dbadapter.CommonDBClient
+			defer func() { dbadapter.CommonDBClient = originalDBClient }()
+			dbadapter.CommonDBClient = tc.dbAdapter
+			req, err := http.NewRequest(tc.method, tc.route, strings.NewReader(tc.inputData))
+			if err != nil {
+				t.Fatalf("failed to create request: %v", err)
+			}
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			if tc.expectedCode != w.Code {
+				t.Errorf("expected `%v`, got `%v`", tc.expectedCode, w.Code)
+			}
+			var body map[string]any
+			if err = json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+				t.Fatalf("failed to unmarshal JSON: %v", err)
+			}
+			if tc.expectedError == "" {
+				if len(body) != 0 {
+					t.Errorf("expected empty body, got %v", body)
+				}
+				return
+			}
+			errorMessage, _ := body["error"].(string)
+			if !strings.Contains(errorMessage, tc.expectedError) {
+				t.Errorf("expected error containing `%s`, got `%v`", tc.expectedError, body)
+			}
+		})
+	}
+}
+
+func TestPutSite_UpdatesAttachedSlices(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+	AddConfigV1Service(router)
+	gnbs, upfs := siteInventory()
+	mock := &SiteMockDBClient{
+		sites: []configmodels.Site{berlinSite},
+		gnbs:  gnbs,
+		upfs:  upfs,
+		slices: []configmodels.Slice{
+			{SliceName: "slice1", SliceId: configmodels.SliceSliceId{Sst: "1", Sd: "010203"}, SiteInfo: configmodels.SliceSiteInfo{SiteName: "berlin", Plmn: berlinSite.Plmn}},
+			{SliceName: "slice2", SiteInfo: configmodels.SliceSiteInfo{SiteName: "paris"}},
+		},
+	}
+	originalDBClient := dbadapter.CommonDBClient
+	defer func() { dbadapter.CommonDBClient = originalDBClient }()
+	dbadapter.CommonDBClient = mock
+
+	req, err := http.NewRequest(http.MethodPut, "/config/v1/site/berlin", strings.NewReader(`{"plmn": {"mcc": "001", "mnc": "02"}, "gNodeBs": ["gnb2", "gnb1"], "upf": "upf1.example.com"}`))
+	if err != nil {
+		t.Fatalf("failed to create request: %v", err)
+	}
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected `%v`, got `%v` with body %s", http.StatusOK, w.Code, w.Body.String())
+	}
+	if len(mock.postedData) != 1 {
+		t.Fatalf("expected 1 network slice update, got %d", len(mock.postedData))
+	}
+	var slice configmodels.Slice
+	if err = json.Unmarshal(configmodels.MapToByte(mock.postedData[0]), &slice); err != nil {
+		t.Fatalf("failed to unmarshal network slice: %v", err)
+	}
+	expected := configmodels.SliceSiteInfo{
+		SiteName: "berlin",
+		Plmn:     configmodels.SliceSiteInfoPlmn{Mcc: "001", Mnc: "02"},
+		GNodeBs:  []configmodels.SliceSiteInfoGNodeBs{{Name: "gnb1", Tac: 1}, {Name: "gnb2", Tac: 2}},
+		Upf:      map[string]any{"upf-name": "upf1.example.com", "upf-port": "8805"},
+	}
+	if slice.SliceName != "slice1" {
+		t.Errorf("expected slice1 to be updated, got %s", slice.SliceName)
+	}
+	if slice.SiteInfo.Plmn != expected.Plmn || len(slice.SiteInfo.GNodeBs) != 2 || slice.SiteInfo.GNodeBs[1] != expected.GNodeBs[1] {
+		t.Errorf("expected site info %+v, got %+v", expected, slice.SiteInfo)
+	}
+	if slice.SiteInfo.Upf["upf-port"] != "8805" {
+		t.Errorf("expected UPF %v, got %v", expected.Upf, slice.SiteInfo.Upf)
+	}
+}
+
+func TestResolveSliceSite(t *testing.T) {
+	gnbs, upfs := siteInventory()
+	testCases := []struct {
+		name          string
+		siteInfo      configmodels.SliceSiteInfo
+		expectedGnbs  int
+		expectedError string
+	}{
+		{
+			name:         "site name only is resolved",
+			siteInfo:     configmodels.SliceSiteInfo{SiteName: "berlin"},
+			expectedGnbs: 1,
+		},
+		{
+			name: "matching site information is accepted",
+			siteInfo: configmodels.SliceSiteInfo{
+				SiteName: "berlin",
+				Plmn:     configmodels.SliceSiteInfoPlmn{Mcc: "001", Mnc: "01"},
+				GNodeBs:  []configmodels.SliceSiteInfoGNodeBs{{Name: "gnb1", Tac: 1}},
+				Upf:      map[string]any{"upf-name": "upf1.example.com", "upf-port": "8805"},
+			},
+			expectedGnbs: 1,
+		},
+		{
+			name: "unknown site name is kept as a label",
+			siteInfo: configmodels.SliceSiteInfo{
+				SiteName: "demo",
+				GNodeBs:  []configmodels.SliceSiteInfoGNodeBs{{Name: "gnb5", Tac: 5}, {Name: "gnb6", Tac: 6}},
+			},
+			expectedGnbs: 2,
+		},
+		{
+			name: "different PLMN is rejected",
+			siteInfo: configmodels.SliceSiteInfo{
+				SiteName: "berlin",
+				Plmn:     configmodels.SliceSiteInfoPlmn{Mcc: "208", Mnc: "93"},
+			},
+			expectedError: "do not match site berlin",
+		},
+		{
+			name: "different gNBs are rejected",
+			siteInfo: configmodels.SliceSiteInfo{
+				SiteName: "berlin",
+				GNodeBs:  []configmodels.SliceSiteInfoGNodeBs{{Name: "gnb2", Tac: 2}},
+			},
+			expectedError: "do not match site berlin",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			originalDBClient := dbadapter.CommonDBClient
+			defer func() { dbadapter.CommonDBClient = originalDBClient }()
+			dbadapter.CommonDBClient = &SiteMockDBClient{sites: []configmodels.Site{berlinSite}, gnbs: gnbs, upfs: upfs}
+			slice := configmodels.Slice{SliceName: "slice1", SiteInfo: tc.siteInfo}
+
+			err := resolveSliceSite(&slice)
+
+			if tc.expectedError != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.expectedError) {
+					t.Fatalf("expected error containing `%s`, got `%v`", tc.expectedError, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(slice.SiteInfo.GNodeBs) != tc.expectedGnbs {
+				t.Errorf("expected %d gNBs, got %+v", tc.expectedGnbs, slice.SiteInfo.GNodeBs)
+			}
+		})
+	}
+}
+
+func TestDeleteGnb_RemovesGnbFromSites(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+	AddConfigV1Service(router)
+	gnbs, upfs := siteInventory()
+	site := configmodels.Site{SiteName: "berlin", Plmn: berlinSite.Plmn, GNodeBs: []string{"gnb1", "gnb2"}}
+	mock := &SiteMockDBClient{sites: []configmodels.Site{site}, gnbs: gnbs, upfs: upfs}
+	originalDBClient := dbadapter.CommonDBClient
+	defer func() { dbadapter.CommonDBClient = originalDBClient }()
+	dbadapter.CommonDBClient = mock
+
+	req, err := http.NewRequest(http.MethodDelete, "/config/v1/inventory/gnb/gnb1", nil)
+	if err != nil {
+		t.Fatalf("failed to create request: %v", err)
+	}
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusConflict || !strings.Contains(w.Body.String(), `"sites":["berlin"]`) {
+		t.Fatalf("expected `%v` with the sites using the gNB, got `%v` with body %s", http.StatusConflict, w.Code, w.Body.String())
+	}
+	if len(mock.sitePuts) != 0 {
+		t.Fatalf("expected no site update, got %d", len(mock.sitePuts))
+	}
+
+	req, err = http.NewRequest(http.MethodDelete, "/config/v1/inventory/gnb/gnb1?cascade=true", nil)
+	if err != nil {
+		t.Fatalf("failed to create request: %v", err)
+	}
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected `%v`, got `%v`", http.StatusOK, w.Code)
+	}
+	if len(mock.sitePuts) != 1 {
+		t.Fatalf("expected 1 site update, got %d", len(mock.sitePuts))
+	}
+	var updatedSite configmodels.Site
+	if err = json.Unmarshal(configmodels.MapToByte(mock.sitePuts[0]), &updatedSite); err != nil {
+		t.Fatalf("failed to unmarshal site: %v", err)
+	}
+	if len(updatedSite.GNodeBs) != 1 || updatedSite.GNodeBs[0] != "gnb2" {
+		t.Errorf("expected site gNBs [gnb2], got %v", updatedSite.GNodeBs)
+	}
+}
+
+func TestFindGnbTacConflict_PlmnOfSitesAndSlices(t *testing.T) {
+	gnbs, upfs := siteInventory()
+	tac := int32(1)
+	gnbs = append(gnbs, configmodels.Gnb{Name: "gnb4", Tac: &tac}, configmodels.Gnb{Name: "gnb5", Tac: &tac})
+	mock := &SiteMockDBClient{
+		sites: []configmodels.Site{berlinSite},
+		gnbs:  gnbs,
+		upfs:  upfs,
+		slices: []configmodels.Slice{
+			{SliceName: "slice1", SiteInfo: configmodels.SliceSiteInfo{
+				Plmn:    configmodels.SliceSiteInfoPlmn{Mcc: "001", Mnc: "02"},
+				GNodeBs: []configmodels.SliceSiteInfoGNodeBs{{Name: "gnb5", Tac: 1}},
+			}},
+		},
+	}
+	originalDBClient := dbadapter.CommonDBClient
+	defer func() { dbadapter.CommonDBClient = originalDBClient }()
+	dbadapter.CommonDBClient = mock
+
+	testCases := []struct {
+		name            string
+		gnb             configmodels.Gnb
+		expectedGnb     string
+		expectedPlmnMnc string
+	}{
+		{
+			name:            "gNB with the PLMN of the site of the other gNB",
+			gnb:             gnbWithPlmns("gnb4", 1, "001", "01"),
+			expectedGnb:     "gnb1",
+			expectedPlmnMnc: "01",
+		},
+		{
+			name:            "gNB with the PLMN of the slice of the other gNB",
+			gnb:             gnbWithPlmns("gnb4", 1, "001", "02"),
+			expectedGnb:     "gnb5",
+			expectedPlmnMnc: "02",
+		},
+		{
+			name: "gNB without PLMN, site or slice",
+			gnb:  gnb("gnb4", 1),
+		},
+		{
+			name: "gNB with another PLMN",
+			gnb:  gnbWithPlmns("gnb4", 1, "001", "03"),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			conflictingGnb, plmn, err := findGnbTacConflict(context.Background(), tc.gnb)
+			if err != nil {
+				t.Fatalf("failed to check the TAC: %v", err)
+			}
+			if conflictingGnb != tc.expectedGnb || plmn.Mnc != tc.expectedPlmnMnc {
+				t.Errorf("expected conflict with %q in MNC %q, got %q in %+v", tc.expectedGnb, tc.expectedPlmnMnc, conflictingGnb, plmn)
+			}
+		})
+	}
+}
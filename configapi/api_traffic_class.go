@@ -0,0 +1,214 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2026 Canonical Ltd
+
+package configapi
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/omec-project/webconsole/backend/logger"
+	"github.com/omec-project/webconsole/configmodels"
+	"github.com/omec-project/webconsole/dbadapter"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// GetTrafficClasses godoc
+//
+// @Description Return the list of traffic classes
+// @Tags        Traffic Classes
+// @Produce     json
+// @Security    BearerAuth
+// @Success     200  {array}   configmodels.TrafficClassInfo  "List of traffic classes"
+// @Failure     401  {object}  nil                            "Authorization failed"
+// @Failure     403  {object}  nil                            "Forbidden"
+// @Failure     500  {object}  nil                            "Error retrieving traffic classes"
+// @Router      /config/v1/traffic-class  [get]
+func GetTrafficClasses(c *gin.Context) {
+	setInventoryCorsHeader(c)
+	logger.WebUILog.Infoln("received a GET traffic classes request")
+	trafficClasses := make([]*configmodels.TrafficClassInfo, 0)
+	rawTrafficClasses, err := dbadapter.CommonDBClient.RestfulAPIGetMany(configmodels.TrafficClassDataColl, bson.M{})
+	if err != nil {
+		logger.DbLog.Errorf("failed to retrieve traffic classes with error: %+v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to retrieve traffic classes"})
+		return
+	}
+
+	for _, rawTrafficClass := range rawTrafficClasses {
+		var trafficClass configmodels.TrafficClassInfo
+		if err = json.Unmarshal(configmodels.MapToByte(rawTrafficClass), &trafficClass); err != nil {
+			logger.DbLog.Errorf("could not unmarshal traffic class %s", rawTrafficClass)
+			continue
+		}
+		trafficClasses = append(trafficClasses, &trafficClass)
+	}
+	logger.WebUILog.Infoln("successfully executed GET traffic classes request")
+	c.JSON(http.StatusOK, trafficClasses)
+}
+
+// GetTrafficClassByName godoc
+//
+// @Description Return the traffic class
+// @Tags        Traffic Classes
+// @Produce     json
+// @Param       traffic-class-name  path  string  true  "Name of the traffic class"
+// @Security    BearerAuth
+// @Success     200  {object}  configmodels.TrafficClassInfo  "Traffic class"
+// @Failure     401  {object}  nil                            "Authorization failed"
+// @Failure     403  {object}  nil                            "Forbidden"
+// @Failure     404  {object}  nil                            "Traffic class not found"
+// @Failure     500  {object}  nil                            "Error retrieving traffic class"
+// @Router      /config/v1/traffic-class/{traffic-class-name}  [get]
+func GetTrafficClassByName(c *gin.Context) {
+	setInventoryCorsHeader(c)
+	logger.WebUILog.Infoln("received a GET traffic class request")
+	name, _ := c.Params.Get("traffic-class-name")
+	trafficClass, err := getTrafficClassByName(name)
+	if err != nil {
+		logger.DbLog.Errorf("failed to retrieve traffic class %s with error: %+v", name, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to retrieve traffic class"})
+		return
+	}
+	if trafficClass == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": fmt.Sprintf("traffic class %s not found", name)})
+		return
+	}
+	c.JSON(http.StatusOK, trafficClass)
+}
+
+// PostTrafficClass godoc
+//
+// @Description Create a new traffic class
+// @Tags        Traffic Classes
+// @Produce     json
+// @Param       traffic-class  body  configmodels.TrafficClassInfo  true  "Traffic class to create"
+// @Security    BearerAuth
+// @Success     201  {object}  nil  "Traffic class successfully created"
+// @Failure     400  {object}  nil  "Bad request"
+// @Failure     401  {object}  nil  "Authorization failed"
+// @Failure     403  {object}  nil  "Forbidden"
+// @Failure     500  {object}  nil  "Error creating traffic class"
+// @Router      /config/v1/traffic-class  [post]
+func PostTrafficClass(c *gin.Context) {
+	setInventoryCorsHeader(c)
+	logger.WebUILog.Infoln("received a POST traffic class request")
+	var trafficClass configmodels.TrafficClassInfo
+	if err := c.ShouldBindJSON(&trafficClass); err != nil {
+		logger.WebUILog.Errorf("invalid traffic class POST input parameters with error: %+v", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid JSON format"})
+		return
+	}
+	if err := validateCatalogTrafficClass(trafficClass); err != nil {
+		logger.WebUILog.Errorln(err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	filter := bson.M{"name": trafficClass.Name}
+	trafficClassBson := configmodels.ToBsonM(trafficClass)
+	err := dbadapter.CommonDBClient.RestfulAPIPostManyWithContext(c.Request.Context(), configmodels.TrafficClassDataColl, filter, []any{trafficClassBson})
+	if err != nil {
+		if strings.Contains(err.Error(), "E11000") {
+			logger.WebUILog.Errorf("duplicate traffic class name found error: %+v", err)
+			c.JSON(http.StatusBadRequest, gin.H{"error": "traffic class already exists"})
+			return
+		}
+		logger.WebUILog.Errorf("failed to create traffic class %s with error: %+v", trafficClass.Name, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to create traffic class"})
+		return
+	}
+	logger.WebUILog.Infof("successfully executed POST traffic class %s request", trafficClass.Name)
+	c.JSON(http.StatusCreated, gin.H{})
+}
+
+// PutTrafficClass godoc
+//
+// @Description Create or update a traffic class. Device groups referencing the traffic class are provisioned again.
+// @Tags        Traffic Classes
+// @Produce     json
+// @Param       traffic-class-name  path  string                               true  "Name of the traffic class"
+// @Param       traffic-class       body  configmodels.PutTrafficClassRequest  true  "Traffic class parameters"
+// @Security    BearerAuth
+// @Success     200  {object}  nil  "Traffic class successfully updated"
+// @Failure     400  {object}  nil  "Bad request"
+// @Failure     401  {object}  nil  "Authorization failed"
+// @Failure     403  {object}  nil  "Forbidden"
+// @Failure     500  {object}  nil  "Error updating traffic class, or traffic class stored without provisioning all its device groups"
+// @Router      /config/v1/traffic-class/{traffic-class-name}  [put]
+func PutTrafficClass(c *gin.Context) {
+	setInventoryCorsHeader(c)
+	logger.WebUILog.Infoln("received a PUT traffic class request")
+	name, _ := c.Params.Get("traffic-class-name")
+	var putTrafficClassParams configmodels.PutTrafficClassRequest
+	if err := c.ShouldBindJSON(&putTrafficClassParams); err != nil {
+		logger.WebUILog.Errorf("invalid traffic class PUT input parameters for %s with error: %+v", name, err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid JSON format"})
+		return
+	}
+	trafficClass := configmodels.TrafficClassInfo{
+		Name:        name,
+		Qci:         putTrafficClassParams.Qci,
+		Arp:         putTrafficClassParams.Arp,
+		Pdb:         putTrafficClassParams.Pdb,
+		Pelr:        putTrafficClassParams.Pelr,
+		PreemptCap:  putTrafficClassParams.PreemptCap,
+		PreemptVuln: putTrafficClassParams.PreemptVuln,
+	}
+	if err := validateCatalogTrafficClass(trafficClass); err != nil {
+		logger.WebUILog.Errorln(err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	filter := bson.M{"name": name}
+	trafficClassBson := configmodels.ToBsonM(trafficClass)
+	if _, err := dbadapter.CommonDBClient.RestfulAPIPutOne(configmodels.TrafficClassDataColl, filter, trafficClassBson); err != nil {
+		logger.WebUILog.Errorf("failed to PUT traffic class %s with error: %+v", name, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to PUT traffic class"})
+		return
+	}
+	if err := syncDeviceGroupsUsingTrafficClass(name); err != nil {
+		// the traffic class is stored and some subscribers may use it already
+		logger.WebUILog.Errorf("failed to provision device groups using traffic class %s with error: %+v", name, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("traffic class %s is stored, but the device groups using it are not all provisioned. PUT it again to retry", name)})
+		return
+	}
+	logger.WebUILog.Infof("successfully executed PUT traffic class %s request", name)
+	c.JSON(http.StatusOK, gin.H{})
+}
+
+// DeleteTrafficClass godoc
+//
+// @Description Delete an existing traffic class. The traffic class cannot be deleted while network slices, device groups or QoS profiles reference it.
+// @Tags        Traffic Classes
+// @Produce     json
+// @Param       traffic-class-name  path  string  true  "Name of the traffic class"
+// @Security    BearerAuth
+// @Success     200  {object}  nil  "Traffic class deleted"
+// @Failure     401  {object}  nil  "Authorization failed"
+// @Failure     403  {object}  nil  "Forbidden"
+// @Failure     409  {object}  nil  "Traffic class is in use"
+// @Failure     500  {object}  nil  "Failed to delete traffic class"
+// @Router      /config/v1/traffic-class/{traffic-class-name}  [delete]
+func DeleteTrafficClass(c *gin.Context) {
+	setInventoryCorsHeader(c)
+	logger.WebUILog.Infoln("received a DELETE traffic class request")
+	name, _ := c.Params.Get("traffic-class-name")
+	err := deleteUnusedItem(c.Request.Context(), "traffic class "+name, configmodels.TrafficClassDataColl, bson.M{"name": name}, trafficClassReferences(name)...)
+	if err != nil {
+		var inUse *inUseError
+		if errors.As(err, &inUse) {
+			logger.WebUILog.Errorf("%s by %v", inUse.Error(), inUse.references)
+			c.JSON(http.StatusConflict, inUse.body())
+			return
+		}
+		logger.WebUILog.Errorf("failed to delete traffic class %s with error: %+v", name, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to delete traffic class"})
+		return
+	}
+	logger.WebUILog.Infof("successfully executed DELETE traffic class %s request", name)
+	c.JSON(http.StatusOK, gin.H{})
+}
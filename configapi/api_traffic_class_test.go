@@ -0,0 +1,437 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2026 Canonical Ltd
+
+package configapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/omec-project/webconsole/configmodels"
+	"github.com/omec-project/webconsole/dbadapter"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+type TrafficClassMockDBClient struct {
+	dbadapter.DBInterface
+	trafficClasses []configmodels.TrafficClassInfo
+	slices         []configmodels.Slice
+	deviceGroups   []configmodels.DeviceGroups
+	putData        []map[string]any
+	deleted        []bson.M
+	err            error
+	// deviceGroupsErr fails the reads of the device groups only
+	deviceGroupsErr error
+}
+
+func (db *TrafficClassMockDBClient) RestfulAPIGetOne(coll string, filter bson.M) (map[string]any, error) {
+	if db.err != nil {
+		return nil, db.err
+	}
+	if coll == configmodels.TrafficClassDataColl {
+		for _, trafficClass := range db.trafficClasses {
+			if trafficClass.Name == filter["name"] {
+				return configmodels.ToBsonM(trafficClass), nil
+			}
+		}
+	}
+	return nil, nil
+}
+
+func (db *TrafficClassMockDBClient) RestfulAPIGetMany(coll string, filter bson.M) ([]map[string]any, error) {
+	if db.err != nil {
+		return nil, db.err
+	}
+	var results []map[string]any
+	switch coll {
+	case configmodels.TrafficClassDataColl:
+		for _, trafficClass := range db.trafficClasses {
+			results = append(results, configmodels.ToBsonM(trafficClass))
+		}
+	case sliceDataColl:
+		for _, slice := range db.slices {
+			results = append(results, configmodels.ToBsonM(slice))
+		}
+	case devGroupDataColl:
+		if db.deviceGroupsErr != nil {
+			return nil, db.deviceGroupsErr
+		}
+		for _, deviceGroup := range db.deviceGroups {
+			results = append(results, configmodels.ToBsonM(deviceGroup))
+		}
+	}
+	return results, nil
+}
+
+func (db *TrafficClassMockDBClient) RestfulAPIGetManyWithContext(context context.Context, coll string, filter bson.M) ([]map[string]any, error) {
+	return db.RestfulAPIGetMany(coll, filter)
+}
+
+func (db *TrafficClassMockDBClient) StartSession() (dbadapter.DBSession, error) {
+	return nil, nil
+}
+
+func (db *TrafficClassMockDBClient) RestfulAPIPostManyWithContext(context context.Context, collName string, filter bson.M, postDataArray []any) error {
+	if db.err != nil {
+		return db.err
+	}
+	for _, trafficClass := range db.trafficClasses {
+		if trafficClass.Name == filter["name"] {
+			return errors.New("E11000")
+		}
+	}
+	return nil
+}
+
+func (db *TrafficClassMockDBClient) RestfulAPIPutOne(collName string, filter bson.M, putData map[string]any) (bool, error) {
+	if db.err != nil {
+		return false, db.err
+	}
+	db.putData = append(db.putData, putData)
+	return true, nil
+}
+
+func (db *TrafficClassMockDBClient) RestfulAPIDeleteOne(collName string, filter bson.M) error {
+	if db.err != nil {
+		return db.err
+	}
+	db.deleted = append(db.deleted, filter)
+	return nil
+}
+
+func (db *TrafficClassMockDBClient) RestfulAPIDeleteOneWithContext(context context.Context, collName string, filter bson.M) error {
+	return db.RestfulAPIDeleteOne(collName, filter)
+}
+
+var platinumTrafficClass = configmodels.TrafficClassInfo{Name: "platinum", Qci: 8, Arp: 6, Pdb: 300, Pelr: 6}
+
+func TestTrafficClassGetHandlers(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+	AddConfigV1Service(router)
+
+	testCases := []struct {
+		name         string
+		route        string
+		dbAdapter    dbadapter.DBInterface
+		expectedCode int
+		expectedBody string
+	}{
+		{
+			name:         "List traffic classes",
+			route:        "/config/v1/traffic-class",
+			dbAdapter:    &TrafficClassMockDBClient{trafficClasses: []configmodels.TrafficClassInfo{platinumTrafficClass}},
+			expectedCode: http.StatusOK,
+			expectedBody: `[{"name":"platinum","qci":8,"arp":6,"pdb":300,"pelr":6}]`,
+		},
+		{
+			name:         "List traffic classes when there are none",
+			route:        "/config/v1/traffic-class",
+			dbAdapter:    &TrafficClassMockDBClient{},
+			expectedCode: http.StatusOK,
+			expectedBody: `[]`,
+		},
+		{
+			name:         "Get existing traffic class",
+			route:        "/config/v1/traffic-class/platinum",
+			dbAdapter:    &TrafficClassMockDBClient{trafficClasses: []configmodels.TrafficClassInfo{platinumTrafficClass}},
+			expectedCode: http.StatusOK,
+			expectedBody: `{"name":"platinum","qci":8,"arp":6,"pdb":300,"pelr":6}`,
+		},
+		{
+			name:         "Get missing traffic class",
+			route:        "/config/v1/traffic-class/gold",
+			dbAdapter:    &TrafficClassMockDBClient{},
+			expectedCode: http.StatusNotFound,
+			expectedBody: `{"error":"traffic class gold not found"}`,
+		},
+		{
+			name:         "List traffic classes DB error",
+			route:        "/config/v1/traffic-class",
+			dbAdapter:    &TrafficClassMockDBClient{err: errors.New("mock error")},
+			expectedCode: http.StatusInternalServerError,
+			expectedBody: `{"error":"failed to retrieve traffic classes"}`,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			originalDBClient := dbadapter.CommonDBClient
+			defer func() { dbadapter.CommonDBClient = originalDBClient }()
+			dbadapter.CommonDBClient = tc.dbAdapter
+			req, err := http.NewRequest(http.MethodGet, tc.route, nil)
+			if err != nil {
+				t.Fatalf("failed to create request: %v", err)
+			}
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			if tc.expectedCode != w.Code {
+				t.Errorf("expected `%v`, got `%v`", tc.expectedCode, w.Code)
+			}
+			if w.Body.String() != tc.expectedBody {
+				t.Errorf("expected `%v`, got `%v`", tc.expectedBody, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestTrafficClassWriteHandlers(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+	AddConfigV1Service(router)
+
+	testCases := []struct {
+		name          string
+		method        string
+		route         string
+		dbAdapter     dbadapter.DBInterface
+		inputData     string
+		expectedCode  int
+		expectedError string
+	}{
+		{
+			name:         "Create a new traffic class",
+			method:       http.MethodPost,
+			route:        "/config/v1/traffic-class",
+			dbAdapter:    &TrafficClassMockDBClient{},
+			inputData:    `{"name": "platinum", "qci": 8, "arp": 6, "preempt-cap": "NOT_PREEMPT"}`,
+			expectedCode: http.StatusCreated,
+		},
+		{
+			name:          "Create an existing traffic class",
+			method:        http.MethodPost,
+			route:         "/config/v1/traffic-class",
+			dbAdapter:     &TrafficClassMockDBClient{trafficClasses: []configmodels.TrafficClassInfo{platinumTrafficClass}},
+			inputData:     `{"name": "platinum", "qci": 8, "arp": 6}`,
+			expectedCode:  http.StatusBadRequest,
+			expectedError: "traffic class already exists",
+		},
+		{
+			name:          "Create a traffic class with invalid name",
+			method:        http.MethodPost,
+			route:         "/config/v1/traffic-class",
+			dbAdapter:     &TrafficClassMockDBClient{},
+			inputData:     `{"name": "plat!num", "qci": 8, "arp": 6}`,
+			expectedCode:  http.StatusBadRequest,
+			expectedError: "invalid traffic class name",
+		},
+		{
+			name:          "Create a traffic class with invalid 5QI",
+			method:        http.MethodPost,
+			route:         "/config/v1/traffic-class",
+			dbAdapter:     &TrafficClassMockDBClient{},
+			inputData:     `{"name": "platinum", "qci": 0, "arp": 6}`,
+			expectedCode:  http.StatusBadRequest,
+			expectedError: "invalid 5QI",
+		},
+		{
+			name:         "Update a traffic class",
+			method:       http.MethodPut,
+			route:        "/config/v1/traffic-class/platinum",
+			dbAdapter:    &TrafficClassMockDBClient{trafficClasses: []configmodels.TrafficClassInfo{platinumTrafficClass}},
+			inputData:    `{"qci": 7, "arp": 2}`,
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:          "Update a traffic class whose device groups fail to provision",
+			method:        http.MethodPut,
+			route:         "/config/v1/traffic-class/platinum",
+			dbAdapter:     &TrafficClassMockDBClient{trafficClasses: []configmodels.TrafficClassInfo{platinumTrafficClass}, deviceGroupsErr: errors.New("connection lost")},
+			inputData:     `{"qci": 7, "arp": 2}`,
+			expectedCode:  http.StatusInternalServerError,
+			expectedError: "traffic class platinum is stored, but the device groups using it are not all provisioned",
+		},
+		{
+			name:          "Update a traffic class with invalid ARP",
+			method:        http.MethodPut,
+			route:         "/config/v1/traffic-class/platinum",
+			dbAdapter:     &TrafficClassMockDBClient{},
+			inputData:     `{"qci": 7, "arp": 20}`,
+			expectedCode:  http.StatusBadRequest,
+			expectedError: "invalid ARP",
+		},
+		{
+			name:         "Delete an unused traffic class",
+			method:       http.MethodDelete,
+			route:        "/config/v1/traffic-class/platinum",
+			dbAdapter:    &TrafficClassMockDBClient{trafficClasses: []configmodels.TrafficClassInfo{platinumTrafficClass}},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:   "Delete a traffic class used by a network slice and a device group",
+			method: http.MethodDelete,
+			route:  "/config/v1/traffic-class/platinum",
+			dbAdapter: &TrafficClassMockDBClient{
+				trafficClasses: []configmodels.TrafficClassInfo{platinumTrafficClass},
+				slices:         []configmodels.Slice{{SliceName: "slice1"}},
+				deviceGroups:   []configmodels.DeviceGroups{{DeviceGroupName: "group1"}},
+			},
+			expectedCode:  http.StatusConflict,
+			expectedError: "traffic class platinum is in use",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			originalDBClient := dbadapter.CommonDBClient
+			defer func() { dbadapter.CommonDBClient = originalDBClient }()
+			dbadapter.CommonDBClient = tc.dbAdapter
+			req, err := http.NewRequest(tc.method, tc.route, strings.NewReader(tc.inputData))
+			if err != nil {
+				t.Fatalf("failed to create request: %v", err)
+			}
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			if tc.expectedCode != w.Code {
+				t.Errorf("expected `%v`, got `%v`", tc.expectedCode, w.Code)
+			}
+			var body map[string]any
+			if err = json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+				t.Fatalf("failed to unmarshal JSON: %v", err)
+			}
+			if tc.expectedError == "" {
+				if len(body) != 0 {
+					t.Errorf("expected empty body, got %v", body)
+				}
+				return
+			}
+			errorMessage, _ := body["error"].(string)
+			if !strings.Contains(errorMessage, tc.expectedError) {
+				t.Errorf("expected error containing `%s`, got `%v`", tc.expectedError, body)
+			}
+		})
+	}
+}
+
+func TestDeleteTrafficClass_ReportsReferences(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+	AddConfigV1Service(router)
+	mockDB := &TrafficClassMockDBClient{
+		trafficClasses: []configmodels.TrafficClassInfo{platinumTrafficClass},
+		slices:         []configmodels.Slice{{SliceName: "slice2"}, {SliceName: "slice1"}},
+		deviceGroups:   []configmodels.DeviceGroups{{DeviceGroupName: "group1"}},
+	}
+	originalDBClient := dbadapter.CommonDBClient
+	defer func() { dbadapter.CommonDBClient = originalDBClient }()
+	dbadapter.CommonDBClient = mockDB
+
+	req, err := http.NewRequest(http.MethodDelete, "/config/v1/traffic-class/platinum", nil)
+	if err != nil {
+		t.Fatalf("failed to create request: %v", err)
+	}
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusConflict {
+		t.Fatalf("expected `%v`, got `%v`", http.StatusConflict, w.Code)
+	}
+	var body struct {
+		NetworkSlices []string `json:"network-slices"`
+		DeviceGroups  []string `json:"device-groups"`
+	}
+	if err = json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+		t.Fatalf("failed to unmarshal JSON: %v", err)
+	}
+	if !reflect.DeepEqual(body.NetworkSlices, []string{"slice1", "slice2"}) {
+		t.Errorf("expected network slices [slice1 slice2], got %v", body.NetworkSlices)
+	}
+	if !reflect.DeepEqual(body.DeviceGroups, []string{"group1"}) {
+		t.Errorf("expected device groups [group1], got %v", body.DeviceGroups)
+	}
+	if len(mockDB.deleted) != 0 {
+		t.Errorf("expected traffic class not to be deleted")
+	}
+}
+
+func TestResolveTrafficClasses(t *testing.T) {
+	catalog := map[string]configmodels.TrafficClassInfo{"platinum": platinumTrafficClass}
+	embedded := &configmodels.TrafficClassInfo{Qci: 9, Arp: 1}
+
+	slice := configmodels.Slice{
+		ApplicationFilteringRules: []configmodels.SliceApplicationFilteringRules{
+			{RuleName: "by-name", TrafficClassName: "platinum"},
+			{RuleName: "embedded", TrafficClass: embedded},
+			{RuleName: "unknown", TrafficClassName: "gold"},
+		},
+	}
+	original := slice.ApplicationFilteringRules
+	err := ResolveSliceTrafficClasses(&slice, catalog)
+	if err == nil || !strings.Contains(err.Error(), "rule unknown: traffic class gold not found") {
+		t.Errorf("expected error for unknown traffic class, got %v", err)
+	}
+	if slice.ApplicationFilteringRules[0].TrafficClass == nil || *slice.ApplicationFilteringRules[0].TrafficClass != platinumTrafficClass {
+		t.Errorf("expected rule to use the platinum traffic class, got %+v", slice.ApplicationFilteringRules[0].TrafficClass)
+	}
+	if slice.ApplicationFilteringRules[1].TrafficClass != embedded {
+		t.Errorf("expected rule to keep its embedded traffic class")
+	}
+	if slice.ApplicationFilteringRules[2].TrafficClass != nil {
+		t.Errorf("expected rule with unknown traffic class to have no traffic class")
+	}
+	if original[0].TrafficClass != nil {
+		t.Errorf("expected original rules not to be modified")
+	}
+
+	deviceGroup := configmodels.DeviceGroups{
+		IpDomainsExpanded: []configmodels.DeviceGroupsIpDomainExpanded{
+			{Dnn: "internet", UeDnnQos: &configmodels.DeviceGroupsIpDomainExpandedUeDnnQos{DnnMbrUplink: 10, TrafficClassName: "platinum"}},
+			{Dnn: "ims"},
+		},
+	}
+	originalQos := deviceGroup.IpDomainsExpanded[0].UeDnnQos
+	if err = ResolveDeviceGroupTrafficClasses(&deviceGroup, catalog); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	qos := deviceGroup.IpDomainsExpanded[0].UeDnnQos
+	if qos.TrafficClass == nil || *qos.TrafficClass != platinumTrafficClass || qos.DnnMbrUplink != 10 {
+		t.Errorf("expected DNN QoS to use the platinum traffic class, got %+v", qos)
+	}
+	if originalQos.TrafficClass != nil {
+		t.Errorf("expected original DNN QoS not to be modified")
+	}
+	if deviceGroup.IpDomainsExpanded[1].UeDnnQos != nil {
+		t.Errorf("expected DNN without QoS to be left untouched")
+	}
+}
+
+func TestNetworkSlicePost_UnknownTrafficClassName(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+	AddConfigV1Service(router)
+	originalDBClient := dbadapter.CommonDBClient
+	defer func() { dbadapter.CommonDBClient = originalDBClient }()
+	dbadapter.CommonDBClient = &TrafficClassMockDBClient{}
+
+	slice := networkSliceWithGnbParams("slice-1", "valid-gnb", 3)
+	slice.ApplicationFilteringRules = []configmodels.SliceApplicationFilteringRules{
+		{RuleName: "rule-1", TrafficClassName: "gold"},
+	}
+	jsonBody, err := json.Marshal(slice)
+	if err != nil {
+		t.Fatalf("failed to marshal network slice %v", err)
+	}
+	req, err := http.NewRequest(http.MethodPost, "/config/v1/network-slice/slice-1", strings.NewReader(string(jsonBody)))
+	if err != nil {
+		t.Fatalf("failed to create request: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected `%v`, got `%v`", http.StatusBadRequest, w.Code)
+	}
+	if !strings.Contains(w.Body.String(), "traffic class gold not found") {
+		t.Errorf("expected body to mention the missing traffic class, got `%v`", w.Body.String())
+	}
+}
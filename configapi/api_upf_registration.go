@@ -0,0 +1,271 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2026 Canonical Ltd
+
+package configapi
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/omec-project/webconsole/backend/logger"
+	"github.com/omec-project/webconsole/configmodels"
+	"github.com/omec-project/webconsole/dbadapter"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// RegisterUpf godoc
+//
+// @Description  Create or refresh a UPF of the inventory on behalf of the UPF itself. The request is authenticated with a registration token instead of a user token. The hostname must match the one of the token, and a known UPF can only be refreshed with the token it registered with. A request with only the hostname of a known UPF is a heartbeat. The NF configuration is synchronized when the UPF is created or changed.
+// @Tags         UPFs
+// @Accept       json
+// @Produce      json
+// @Param        Authorization    header    string                         true    "Bearer registration token"
+// @Param        upf              body      configmodels.PostUpfRequest    true    "UPF registering itself"
+// @Success      200  {object}  configmodels.RegisterUpfResponse  "UPF updated or refreshed"
+// @Success      201  {object}  configmodels.RegisterUpfResponse  "UPF created"
+// @Failure      400  {object}  nil                               "Bad request"
+// @Failure      401  {object}  nil                               "Invalid registration token"
+// @Failure      403  {object}  nil                               "Hostname not allowed by the registration token"
+// @Failure      409  {object}  nil                               "UPF not registered with this token"
+// @Failure      500  {object}  nil                               "Error registering UPF"
+// @Router       /config/v1/inventory/upf/register  [post]
+func RegisterUpf(syncChan chan<- struct{}) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		setInventoryCorsHeader(c)
+		logger.WebUILog.Infoln("received a UPF registration request")
+		token, err := getBearerToken(c.Request.Header.Get("Authorization"))
+		if err != nil {
+			logger.WebUILog.Errorln(err.Error())
+			c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+			return
+		}
+		now := time.Now().UTC()
+		registrationToken, err := getValidUpfRegistrationToken(token, now)
+		if err != nil {
+			logger.DbLog.Errorf("failed to retrieve registration token with error: %+v", err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to register UPF"})
+			return
+		}
+		if registrationToken == nil {
+			logger.WebUILog.Errorln("UPF registration with an unknown or expired token")
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid registration token"})
+			return
+		}
+		var registerUpfParams configmodels.PostUpfRequest
+		if err = c.ShouldBindJSON(&registerUpfParams); err != nil {
+			logger.WebUILog.Errorf("invalid UPF registration input parameters with error: %+v", err)
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid JSON format"})
+			return
+		}
+		upf := configmodels.Upf(registerUpfParams)
+		if !isValidFQDN(upf.Hostname) {
+			errorMessage := fmt.Sprintf("invalid UPF hostname '%s'. Hostname needs to represent a valid FQDN", upf.Hostname)
+			logger.WebUILog.Errorln(errorMessage)
+			c.JSON(http.StatusBadRequest, gin.H{"error": errorMessage})
+			return
+		}
+		if !upfHostnameMatches(registrationToken.Hostname, upf.Hostname) {
+			errorMessage := fmt.Sprintf("registration token %s does not allow hostname %s", registrationToken.Id, upf.Hostname)
+			logger.WebUILog.Errorln(errorMessage)
+			c.JSON(http.StatusForbidden, gin.H{"error": errorMessage})
+			return
+		}
+		existingUpf, err := getUpfByHostname(upf.Hostname)
+		if err != nil {
+			logger.DbLog.Errorf("failed to retrieve UPF %s with error: %+v", upf.Hostname, err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to register UPF"})
+			return
+		}
+		if existingUpf != nil {
+			var registeredWithToken bool
+			if registeredWithToken, err = isUpfRegisteredWithToken(upf.Hostname, registrationToken.Id); err != nil {
+				logger.DbLog.Errorf("failed to retrieve registration of UPF %s with error: %+v", upf.Hostname, err)
+				c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to register UPF"})
+				return
+			}
+			if !registeredWithToken {
+				errorMessage := fmt.Sprintf("UPF %s was not registered with registration token %s", upf.Hostname, registrationToken.Id)
+				logger.WebUILog.Errorln(errorMessage)
+				c.JSON(http.StatusConflict, gin.H{"error": errorMessage})
+				return
+			}
+		}
+		status := upfRegistrationRefreshed
+		if existingUpf == nil || upf.Port != "" {
+			if !isValidUpfPort(upf.Port) {
+				errorMessage := fmt.Sprintf("invalid UPF port '%s'. Port must be a numeric string within the range [0, 65535]", upf.Port)
+				logger.WebUILog.Errorln(errorMessage)
+				c.JSON(http.StatusBadRequest, gin.H{"error": errorMessage})
+				return
+			}
+			if err = validateUpf(upf); err != nil {
+				logger.WebUILog.Errorln(err.Error())
+				c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+				return
+			}
+			if status, err = registerUpf(c.Request.Context(), upf, existingUpf); err != nil {
+				logger.WebUILog.Errorf("failed to register UPF %s with error: %+v", upf.Hostname, err)
+				c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to register UPF"})
+				return
+			}
+		}
+		registration := configmodels.UpfRegistration{
+			Hostname: upf.Hostname,
+			LastSeen: now,
+			TokenId:  registrationToken.Id,
+		}
+		if err = recordUpfRegistration(registration); err != nil {
+			logger.DbLog.Errorf("failed to record registration of UPF %s with error: %+v", upf.Hostname, err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to register UPF"})
+			return
+		}
+		if status != upfRegistrationRefreshed && syncChan != nil {
+			syncChan <- struct{}{}
+		}
+		logger.WebUILog.Infof("successfully registered UPF %s with status %s", upf.Hostname, status)
+		statusCode := http.StatusOK
+		if status == configmodels.InventoryImportCreated {
+			statusCode = http.StatusCreated
+		}
+		c.JSON(statusCode, configmodels.RegisterUpfResponse{Hostname: upf.Hostname, Status: status, LastSeen: now})
+	}
+}
+
+// GetUpfRegistrationTokens godoc
+//
+// @Description  Return the list of UPF registration tokens without their secret
+// @Tags         UPFs
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {array}   configmodels.GetUpfRegistrationTokenResponse  "List of registration tokens"
+// @Failure      401  {object}  nil                                           "Authorization failed"
+// @Failure      403  {object}  nil                                           "Forbidden"
+// @Failure      500  {object}  nil                                           "Error retrieving registration tokens"
+// @Router       /config/v1/inventory/upf-registration-token  [get]
+func GetUpfRegistrationTokens(c *gin.Context) {
+	setInventoryCorsHeader(c)
+	logger.WebUILog.Infoln("received a GET UPF registration tokens request")
+	tokens, err := getUpfRegistrationTokens()
+	if err != nil {
+		logger.DbLog.Errorf("failed to retrieve registration tokens with error: %+v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to retrieve registration tokens"})
+		return
+	}
+	slices.SortFunc(tokens, func(a, b configmodels.UpfRegistrationToken) int {
+		return a.CreatedAt.Compare(b.CreatedAt)
+	})
+	tokenResponses := make([]configmodels.GetUpfRegistrationTokenResponse, 0, len(tokens))
+	for _, token := range tokens {
+		tokenResponses = append(tokenResponses, configmodels.GetUpfRegistrationTokenResponse{
+			Id:          token.Id,
+			Description: token.Description,
+			Hostname:    token.Hostname,
+			CreatedAt:   token.CreatedAt,
+			ExpiresAt:   token.ExpiresAt,
+		})
+	}
+	c.JSON(http.StatusOK, tokenResponses)
+}
+
+// PostUpfRegistrationToken godoc
+//
+// @Description  Issue a UPF registration token for a hostname, or for the hostnames of a domain with a '*.' pattern. The token is only returned in this response.
+// @Tags         UPFs
+// @Accept       json
+// @Produce      json
+// @Param        token    body    configmodels.PostUpfRegistrationTokenRequest    true     "Hostname, description and validity of the token"
+// @Security     BearerAuth
+// @Success      201  {object}  configmodels.PostUpfRegistrationTokenResponse  "Registration token issued"
+// @Failure      400  {object}  nil                                            "Bad request"
+// @Failure      401  {object}  nil                                            "Authorization failed"
+// @Failure      403  {object}  nil                                            "Forbidden"
+// @Failure      500  {object}  nil                                            "Error issuing registration token"
+// @Router       /config/v1/inventory/upf-registration-token  [post]
+func PostUpfRegistrationToken(c *gin.Context) {
+	setInventoryCorsHeader(c)
+	logger.WebUILog.Infoln("received a POST UPF registration token request")
+	var tokenParams configmodels.PostUpfRegistrationTokenRequest
+	if err := c.ShouldBindJSON(&tokenParams); err != nil {
+		logger.WebUILog.Errorf("invalid registration token input parameters with error: %+v", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid JSON format"})
+		return
+	}
+	if !isValidUpfHostnamePattern(tokenParams.Hostname) {
+		errorMessage := fmt.Sprintf("invalid hostname '%s'. Hostname needs to represent a valid FQDN, or a domain prefixed with '*.'", tokenParams.Hostname)
+		logger.WebUILog.Errorln(errorMessage)
+		c.JSON(http.StatusBadRequest, gin.H{"error": errorMessage})
+		return
+	}
+	if tokenParams.TtlSeconds < 0 {
+		errorMessage := fmt.Sprintf("invalid TTL %d. TTL must not be negative", tokenParams.TtlSeconds)
+		logger.WebUILog.Errorln(errorMessage)
+		c.JSON(http.StatusBadRequest, gin.H{"error": errorMessage})
+		return
+	}
+	id, token, err := generateUpfRegistrationToken()
+	if err != nil {
+		logger.WebUILog.Errorf("failed to generate registration token with error: %+v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to issue registration token"})
+		return
+	}
+	registrationToken := configmodels.UpfRegistrationToken{
+		Id:          id,
+		Description: strings.TrimSpace(tokenParams.Description),
+		Hostname:    tokenParams.Hostname,
+		TokenHash:   hashUpfRegistrationToken(token),
+		CreatedAt:   time.Now().UTC(),
+	}
+	if tokenParams.TtlSeconds > 0 {
+		expiresAt := registrationToken.CreatedAt.Add(time.Duration(tokenParams.TtlSeconds) * time.Second)
+		registrationToken.ExpiresAt = &expiresAt
+	}
+	filter := bson.M{"id": id}
+	if err = dbadapter.CommonDBClient.RestfulAPIPostMany(configmodels.UpfRegistrationTokenDataColl, filter, []any{configmodels.ToBsonM(registrationToken)}); err != nil {
+		logger.DbLog.Errorf("failed to store registration token with error: %+v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to issue registration token"})
+		return
+	}
+	logger.WebUILog.Infof("successfully issued UPF registration token %s", id)
+	c.JSON(http.StatusCreated, configmodels.PostUpfRegistrationTokenResponse{Id: id, Token: token, ExpiresAt: registrationToken.ExpiresAt})
+}
+
+// DeleteUpfRegistrationToken godoc
+//
+// @Description  Revoke a UPF registration token. The UPFs registered with it stay in the inventory.
+// @Tags         UPFs
+// @Produce      json
+// @Param        token-id    path    string    true    "ID of the registration token"
+// @Security     BearerAuth
+// @Success      200  {object}  nil  "Registration token revoked"
+// @Failure      401  {object}  nil  "Authorization failed"
+// @Failure      403  {object}  nil  "Forbidden"
+// @Failure      404  {object}  nil  "Registration token not found"
+// @Failure      500  {object}  nil  "Error revoking registration token"
+// @Router       /config/v1/inventory/upf-registration-token/{token-id}  [delete]
+func DeleteUpfRegistrationToken(c *gin.Context) {
+	setInventoryCorsHeader(c)
+	logger.WebUILog.Infoln("received a DELETE UPF registration token request")
+	tokenId, _ := c.Params.Get("token-id")
+	filter := bson.M{"id": tokenId}
+	rawToken, err := dbadapter.CommonDBClient.RestfulAPIGetOne(configmodels.UpfRegistrationTokenDataColl, filter)
+	if err != nil {
+		logger.DbLog.Errorf("failed to retrieve registration token %s with error: %+v", tokenId, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to revoke registration token"})
+		return
+	}
+	if len(rawToken) == 0 {
+		c.JSON(http.StatusNotFound, gin.H{"error": fmt.Sprintf("registration token %s not found", tokenId)})
+		return
+	}
+	if err = dbadapter.CommonDBClient.RestfulAPIDeleteOne(configmodels.UpfRegistrationTokenDataColl, filter); err != nil {
+		logger.DbLog.Errorf("failed to delete registration token %s with error: %+v", tokenId, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to revoke registration token"})
+		return
+	}
+	logger.WebUILog.Infof("successfully revoked UPF registration token %s", tokenId)
+	c.JSON(http.StatusOK, gin.H{})
+}
@@ -0,0 +1,444 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2026 Canonical Ltd
+
+package configapi
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/omec-project/webconsole/configmodels"
+	"github.com/omec-project/webconsole/dbadapter"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// UpfRegistrationMockDBClient stores the documents of each collection in
+// memory. Filters only match top-level fields.
+type UpfRegistrationMockDBClient struct {
+	dbadapter.DBInterface
+	colls map[string][]map[string]any
+}
+
+func newUpfRegistrationMockDBClient() *UpfRegistrationMockDBClient {
+	return &UpfRegistrationMockDBClient{colls: map[string][]map[string]any{}}
+}
+
+func documentMatches(document map[string]any, filter bson.M) bool {
+	for key, value := range filter {
+		if document[key] != value {
+			return false
+		}
+	}
+	return true
+}
+
+func (db *UpfRegistrationMockDBClient) find(coll string, filter bson.M) int {
+	for i, document := range db.colls[coll] {
+		if documentMatches(document, filter) {
+			return i
+		}
+	}
+	return -1
+}
+
+func (db *UpfRegistrationMockDBClient) RestfulAPIGetOne(coll string, filter bson.M) (map[string]any, error) {
+	if i := db.find(coll, filter); i >= 0 {
+		return db.colls[coll][i], nil
+	}
+	return nil, nil
+}
+
+func (db *UpfRegistrationMockDBClient) RestfulAPIGetMany(coll string, filter bson.M) ([]map[string]any, error) {
+	var documents []map[string]any
+	for _, document := range db.colls[coll] {
+		if documentMatches(document, filter) {
+			documents = append(documents, document)
+		}
+	}
+	return documents, nil
+}
+
+func (db *UpfRegistrationMockDBClient) RestfulAPIPutOne(coll string, filter bson.M, putData map[string]any) (bool, error) {
+	if i := db.find(coll, filter); i >= 0 {
+		db.colls[coll][i] = putData
+		return true, nil
+	}
+	db.colls[coll] = append(db.colls[coll], putData)
+	return false, nil
+}
+
+func (db *UpfRegistrationMockDBClient) RestfulAPIPutOneWithContext(_ context.Context, coll string, filter bson.M, putData map[string]any) (bool, error) {
+	return db.RestfulAPIPutOne(coll, filter, putData)
+}
+
+func (db *UpfRegistrationMockDBClient) RestfulAPIPostMany(coll string, _ bson.M, postDataArray []any) error {
+	for _, postData := range postDataArray {
+		db.colls[coll] = append(db.colls[coll], postData.(bson.M))
+	}
+	return nil
+}
+
+func (db *UpfRegistrationMockDBClient) RestfulAPIPostManyWithContext(_ context.Context, coll string, filter bson.M, postDataArray []any) error {
+	return db.RestfulAPIPostMany(coll, filter, postDataArray)
+}
+
+func (db *UpfRegistrationMockDBClient) RestfulAPIDeleteOne(coll string, filter bson.M) error {
+	if i := db.find(coll, filter); i >= 0 {
+		db.colls[coll] = append(db.colls[coll][:i], db.colls[coll][i+1:]...)
+	}
+	return nil
+}
+
+func (db *UpfRegistrationMockDBClient) StartSession() (dbadapter.DBSession, error) {
+	return nil, nil
+}
+
+func (db *UpfRegistrationMockDBClient) addToken(t *testing.T, token string, hostname string, expiresAt *time.Time) {
+	t.Helper()
+	registrationToken := configmodels.UpfRegistrationToken{
+		Id:        "token" + token,
+		Hostname:  hostname,
+		TokenHash: hashUpfRegistrationToken(token),
+		CreatedAt: time.Now().UTC(),
+		ExpiresAt: expiresAt,
+	}
+	db.colls[configmodels.UpfRegistrationTokenDataColl] = append(db.colls[configmodels.UpfRegistrationTokenDataColl], configmodels.ToBsonM(registrationToken))
+}
+
+func registerTestUpf(router *gin.Engine, token string, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/config/v1/inventory/upf/register", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestRegisterUpf(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	expired := time.Now().Add(-time.Minute)
+	testCases := []struct {
+		name           string
+		existingUpfs   []configmodels.Upf
+		registeredWith string
+		token          string
+		body           string
+		expectedCode   int
+		expectedStatus string
+		expectedSync   bool
+		expectedUpf    *configmodels.Upf
+	}{
+		{
+			name:           "NewUpf",
+			token:          "valid",
+			body:           `{"hostname": "upf1.example.com", "port": "8805", "n4-address": "10.0.0.1"}`,
+			expectedCode:   http.StatusCreated,
+			expectedStatus: configmodels.InventoryImportCreated,
+			expectedSync:   true,
+			expectedUpf:    &configmodels.Upf{Hostname: "upf1.example.com", Port: "8805", N4Address: "10.0.0.1"},
+		},
+		{
+			name:           "Heartbeat",
+			existingUpfs:   []configmodels.Upf{{Hostname: "upf1.example.com", Port: "8805"}},
+			registeredWith: "tokenvalid",
+			token:          "valid",
+			body:           `{"hostname": "upf1.example.com"}`,
+			expectedCode:   http.StatusOK,
+			expectedStatus: upfRegistrationRefreshed,
+			expectedUpf:    &configmodels.Upf{Hostname: "upf1.example.com", Port: "8805"},
+		},
+		{
+			name:           "SameDetails",
+			existingUpfs:   []configmodels.Upf{{Hostname: "upf1.example.com", Port: "8805", N4Address: "10.0.0.1"}},
+			registeredWith: "tokenvalid",
+			token:          "valid",
+			body:           `{"hostname": "upf1.example.com", "port": "8805", "n4-address": "10.0.0.1"}`,
+			expectedCode:   http.StatusOK,
+			expectedStatus: upfRegistrationRefreshed,
+			expectedUpf:    &configmodels.Upf{Hostname: "upf1.example.com", Port: "8805", N4Address: "10.0.0.1"},
+		},
+		{
+			name:           "ChangedDetails",
+			existingUpfs:   []configmodels.Upf{{Hostname: "upf1.example.com", Port: "8805"}},
+			registeredWith: "tokenvalid",
+			token:          "valid",
+			body:           `{"hostname": "upf1.example.com", "port": "8806", "n4-address": "10.0.0.2"}`,
+			expectedCode:   http.StatusOK,
+			expectedStatus: configmodels.InventoryImportUpdated,
+			expectedSync:   true,
+			expectedUpf:    &configmodels.Upf{Hostname: "upf1.example.com", Port: "8806", N4Address: "10.0.0.2"},
+		},
+		{
+			name:         "UpfCreatedByUser",
+			existingUpfs: []configmodels.Upf{{Hostname: "upf1.example.com", Port: "8805"}},
+			token:        "valid",
+			body:         `{"hostname": "upf1.example.com", "port": "8806"}`,
+			expectedCode: http.StatusConflict,
+		},
+		{
+			name:           "UpfRegisteredWithAnotherToken",
+			existingUpfs:   []configmodels.Upf{{Hostname: "upf1.example.com", Port: "8805"}},
+			registeredWith: "tokenother",
+			token:          "valid",
+			body:           `{"hostname": "upf1.example.com"}`,
+			expectedCode:   http.StatusConflict,
+		},
+		{
+			name:         "HostnameNotAllowedByToken",
+			token:        "valid",
+			body:         `{"hostname": "upf1.other.com", "port": "8805"}`,
+			expectedCode: http.StatusForbidden,
+		},
+		{
+			name:         "SubdomainNotAllowedByToken",
+			token:        "valid",
+			body:         `{"hostname": "upf1.edge.example.com", "port": "8805"}`,
+			expectedCode: http.StatusForbidden,
+		},
+		{
+			name:         "MissingToken",
+			body:         `{"hostname": "upf1.example.com", "port": "8805"}`,
+			expectedCode: http.StatusUnauthorized,
+		},
+		{
+			name:         "UnknownToken",
+			token:        "unknown",
+			body:         `{"hostname": "upf1.example.com", "port": "8805"}`,
+			expectedCode: http.StatusUnauthorized,
+		},
+		{
+			name:         "ExpiredToken",
+			token:        "expired",
+			body:         `{"hostname": "upf1.example.com", "port": "8805"}`,
+			expectedCode: http.StatusUnauthorized,
+		},
+		{
+			name:         "HeartbeatOfUnknownUpf",
+			token:        "valid",
+			body:         `{"hostname": "upf1.example.com"}`,
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "InvalidHostname",
+			token:        "valid",
+			body:         `{"hostname": "upf_1", "port": "8805"}`,
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "InvalidN4Address",
+			token:        "valid",
+			body:         `{"hostname": "upf1.example.com", "port": "8805", "n4-address": "not-an-ip"}`,
+			expectedCode: http.StatusBadRequest,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			originalDBClient := dbadapter.CommonDBClient
+			defer func() { dbadapter.CommonDBClient = originalDBClient }()
+			mockDB := newUpfRegistrationMockDBClient()
+			mockDB.addToken(t, "valid", "*.example.com", nil)
+			mockDB.addToken(t, "expired", "*.example.com", &expired)
+			for _, upf := range tc.existingUpfs {
+				mockDB.colls[configmodels.UpfDataColl] = append(mockDB.colls[configmodels.UpfDataColl], configmodels.ToBsonM(upf))
+				if tc.registeredWith != "" {
+					registration := configmodels.UpfRegistration{Hostname: upf.Hostname, LastSeen: time.Now().UTC(), TokenId: tc.registeredWith}
+					mockDB.colls[configmodels.UpfRegistrationDataColl] = append(mockDB.colls[configmodels.UpfRegistrationDataColl], configmodels.ToBsonM(registration))
+				}
+			}
+			dbadapter.CommonDBClient = mockDB
+			syncChan := make(chan struct{}, 1)
+			router := gin.Default()
+			AddUpfRegistrationService(router, nil, syncChan)
+
+			w := registerTestUpf(router, tc.token, tc.body)
+
+			if w.Code != tc.expectedCode {
+				t.Fatalf("expected status %d, got %d with body %s", tc.expectedCode, w.Code, w.Body.String())
+			}
+			if synced := len(syncChan) == 1; synced != tc.expectedSync {
+				t.Errorf("expected sync %t, got %t", tc.expectedSync, synced)
+			}
+			registrations, err := getUpfRegistrations()
+			if err != nil {
+				t.Fatalf("failed to get registrations: %v", err)
+			}
+			if tc.expectedStatus == "" {
+				for _, registration := range registrations {
+					if registration.TokenId != tc.registeredWith {
+						t.Errorf("expected no new registration, got %+v", registrations)
+					}
+				}
+				return
+			}
+			var response configmodels.RegisterUpfResponse
+			if err = json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+				t.Fatalf("failed to unmarshal response: %v", err)
+			}
+			if response.Status != tc.expectedStatus {
+				t.Errorf("expected registration status %s, got %s", tc.expectedStatus, response.Status)
+			}
+			registration, ok := registrations[tc.expectedUpf.Hostname]
+			if !ok || registration.TokenId != "tokenvalid" {
+				t.Errorf("expected registration of %s with token tokenvalid, got %+v", tc.expectedUpf.Hostname, registrations)
+			}
+			upf, err := getUpfByHostname(tc.expectedUpf.Hostname)
+			if err != nil || upf == nil {
+				t.Fatalf("expected UPF %s in the inventory, got %v", tc.expectedUpf.Hostname, err)
+			}
+			if upf.Port != tc.expectedUpf.Port || upf.N4Address != tc.expectedUpf.N4Address {
+				t.Errorf("expected UPF %+v, got %+v", *tc.expectedUpf, *upf)
+			}
+		})
+	}
+}
+
+func TestUpfRegistrationTokenHandlers(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	originalDBClient := dbadapter.CommonDBClient
+	defer func() { dbadapter.CommonDBClient = originalDBClient }()
+	dbadapter.CommonDBClient = newUpfRegistrationMockDBClient()
+	router := gin.Default()
+	AddUpfRegistrationService(router, nil, nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/config/v1/inventory/upf-registration-token", strings.NewReader(`{"description": "edge site", "hostname": "upf1.example.com", "ttl-seconds": 3600}`))
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected status %d, got %d", http.StatusCreated, w.Code)
+	}
+	var issued configmodels.PostUpfRegistrationTokenResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &issued); err != nil {
+		t.Fatalf("failed to unmarshal response: %v", err)
+	}
+	if issued.Token == "" || issued.ExpiresAt == nil {
+		t.Fatalf("expected a token with an expiry, got %+v", issued)
+	}
+
+	for _, body := range []string{`{"hostname": "upf1.example.com", "ttl-seconds": -1}`, `{}`, `{"hostname": "*.com"}`, `{"hostname": "upf*.example.com"}`} {
+		req = httptest.NewRequest(http.MethodPost, "/config/v1/inventory/upf-registration-token", strings.NewReader(body))
+		w = httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("expected status %d for %s, got %d", http.StatusBadRequest, body, w.Code)
+		}
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/config/v1/inventory/upf-registration-token", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d", http.StatusOK, w.Code)
+	}
+	if strings.Contains(w.Body.String(), issued.Token) || strings.Contains(w.Body.String(), "token-hash") {
+		t.Errorf("expected the token list to hide the token, got %s", w.Body.String())
+	}
+	var tokens []configmodels.GetUpfRegistrationTokenResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &tokens); err != nil {
+		t.Fatalf("failed to unmarshal response: %v", err)
+	}
+	if len(tokens) != 1 || tokens[0].Id != issued.Id || tokens[0].Description != "edge site" || tokens[0].Hostname != "upf1.example.com" {
+		t.Errorf("expected token %s, got %+v", issued.Id, tokens)
+	}
+
+	if w = registerTestUpf(router, issued.Token, `{"hostname": "upf1.example.com", "port": "8805"}`); w.Code != http.StatusCreated {
+		t.Fatalf("expected status %d when registering with the issued token, got %d", http.StatusCreated, w.Code)
+	}
+
+	req = httptest.NewRequest(http.MethodDelete, "/config/v1/inventory/upf-registration-token/"+issued.Id, nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d", http.StatusOK, w.Code)
+	}
+	if w = registerTestUpf(router, issued.Token, `{"hostname": "upf1.example.com"}`); w.Code != http.StatusUnauthorized {
+		t.Errorf("expected status %d when registering with a revoked token, got %d", http.StatusUnauthorized, w.Code)
+	}
+
+	req = httptest.NewRequest(http.MethodDelete, "/config/v1/inventory/upf-registration-token/"+issued.Id, nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	if w.Code != http.StatusNotFound {
+		t.Errorf("expected status %d, got %d", http.StatusNotFound, w.Code)
+	}
+}
+
+func TestGetUpfs_RegistrationStatus(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	originalDBClient := dbadapter.CommonDBClient
+	defer func() { dbadapter.CommonDBClient = originalDBClient }()
+	mockDB := newUpfRegistrationMockDBClient()
+	for _, hostname := range []string{"upf-fresh", "upf-stale", "upf-static"} {
+		mockDB.colls[configmodels.UpfDataColl] = append(mockDB.colls[configmodels.UpfDataColl], configmodels.ToBsonM(configmodels.Upf{Hostname: hostname, Port: "8805"}))
+	}
+	now := time.Now().UTC()
+	for _, registration := range []configmodels.UpfRegistration{
+		{Hostname: "upf-fresh", LastSeen: now.Add(-time.Minute)},
+		{Hostname: "upf-stale", LastSeen: now.Add(-upfStaleAfter - time.Minute)},
+	} {
+		mockDB.colls[configmodels.UpfRegistrationDataColl] = append(mockDB.colls[configmodels.UpfRegistrationDataColl], configmodels.ToBsonM(registration))
+	}
+	dbadapter.CommonDBClient = mockDB
+	router := gin.Default()
+	AddConfigV1Service(router)
+
+	testCases := []struct {
+		name          string
+		route         string
+		expectedCode  int
+		expectedStale map[string]bool
+	}{
+		{
+			name:          "AllUpfs",
+			route:         "/config/v1/inventory/upf",
+			expectedCode:  http.StatusOK,
+			expectedStale: map[string]bool{"upf-fresh": false, "upf-stale": true, "upf-static": false},
+		},
+		{
+			name:          "StaleUpfs",
+			route:         "/config/v1/inventory/upf?stale=true",
+			expectedCode:  http.StatusOK,
+			expectedStale: map[string]bool{"upf-stale": true},
+		},
+		{
+			name:         "InvalidStaleFilter",
+			route:        "/config/v1/inventory/upf?stale=maybe",
+			expectedCode: http.StatusBadRequest,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tc.route, nil)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+			if w.Code != tc.expectedCode {
+				t.Fatalf("expected status %d, got %d", tc.expectedCode, w.Code)
+			}
+			if tc.expectedStale == nil {
+				return
+			}
+			var upfs []configmodels.UpfStatus
+			if err := json.Unmarshal(w.Body.Bytes(), &upfs); err != nil {
+				t.Fatalf("failed to unmarshal response: %v", err)
+			}
+			if len(upfs) != len(tc.expectedStale) {
+				t.Fatalf("expected %d UPFs, got %+v", len(tc.expectedStale), upfs)
+			}
+			for _, upf := range upfs {
+				if upf.Stale != tc.expectedStale[upf.Hostname] {
+					t.Errorf("expected stale %t for %s, got %t", tc.expectedStale[upf.Hostname], upf.Hostname, upf.Stale)
+				}
+				if (upf.LastSeen == nil) != (upf.Hostname == "upf-static") {
+					t.Errorf("unexpected last seen %v for %s", upf.LastSeen, upf.Hostname)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,110 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2026 Canonical Ltd
+
+package configapi
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/omec-project/webconsole/backend/logger"
+	"github.com/omec-project/webconsole/configmodels"
+	"github.com/omec-project/webconsole/dbadapter"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+func validateApplication(application configmodels.SliceApplicationsInformation) error {
+	if !isValidName(application.AppName) {
+		return fmt.Errorf("invalid application name '%s'. Name needs to match the following regular expression: %s", application.AppName, NAME_PATTERN)
+	}
+	if !isValidEndpoint(application.Endpoint) {
+		return fmt.Errorf("invalid endpoint '%s' for application %s. Endpoint must be an IP address or network", application.Endpoint, application.AppName)
+	}
+	if !isValidPortRange(application.StartPort, application.EndPort) {
+		return fmt.Errorf("invalid port range %d-%d for application %s", application.StartPort, application.EndPort, application.AppName)
+	}
+	if !isValidIpProtocol(application.Protocol) {
+		return fmt.Errorf("invalid protocol %d for application %s. Protocol must be between 0 and 255", application.Protocol, application.AppName)
+	}
+	return nil
+}
+
+// validateApplicationReference checks that an application referenced by name
+// exists in the catalog and that the rule does not define its own flow
+func validateApplicationReference(rule configmodels.SliceApplicationFilteringRules) error {
+	if rule.AppName == "" {
+		return nil
+	}
+	if rule.Endpoint != "" || rule.Protocol != 0 || rule.StartPort != 0 || rule.EndPort != 0 {
+		return fmt.Errorf("application %s and endpoint, protocol or ports cannot be set together", rule.AppName)
+	}
+	application, err := getApplicationByName(rule.AppName)
+	if err != nil {
+		return fmt.Errorf("failed to retrieve application %s: %w", rule.AppName, err)
+	}
+	if application == nil {
+		return fmt.Errorf("application %s not found", rule.AppName)
+	}
+	return nil
+}
+
+func getApplicationByName(name string) (*configmodels.SliceApplicationsInformation, error) {
+	rawApplication, err := dbadapter.CommonDBClient.RestfulAPIGetOne(configmodels.ApplicationDataColl, bson.M{"app-name": name})
+	if err != nil {
+		return nil, err
+	}
+	if len(rawApplication) == 0 {
+		return nil, nil
+	}
+	var application configmodels.SliceApplicationsInformation
+	if err = json.Unmarshal(configmodels.MapToByte(rawApplication), &application); err != nil {
+		return nil, fmt.Errorf("could not unmarshal application %s: %w", name, err)
+	}
+	return &application, nil
+}
+
+// GetApplicationCatalog returns the applications of the catalog indexed by name
+func GetApplicationCatalog() (map[string]configmodels.SliceApplicationsInformation, error) {
+	rawApplications, err := dbadapter.CommonDBClient.RestfulAPIGetMany(configmodels.ApplicationDataColl, bson.M{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch applications: %w", err)
+	}
+	catalog := make(map[string]configmodels.SliceApplicationsInformation, len(rawApplications))
+	for _, rawApplication := range rawApplications {
+		var application configmodels.SliceApplicationsInformation
+		if err = json.Unmarshal(configmodels.MapToByte(rawApplication), &application); err != nil {
+			logger.DbLog.Warnf("could not unmarshal application %s: %+v", rawApplication, err)
+			continue
+		}
+		if application.AppName == "" {
+			continue
+		}
+		catalog[application.AppName] = application
+	}
+	return catalog, nil
+}
+
+// ResolveSliceApplications sets the endpoint, protocol and ports of the application
+// filtering rules referencing the catalog by application name. Rules referencing an
+// unknown application are left without endpoint and reported in the returned error.
+func ResolveSliceApplications(slice *configmodels.Slice, catalog map[string]configmodels.SliceApplicationsInformation) error {
+	var errs []error
+	rules := slices.Clone(slice.ApplicationFilteringRules)
+	for i := range rules {
+		if rules[i].AppName == "" {
+			continue
+		}
+		application, ok := catalog[rules[i].AppName]
+		if !ok {
+			errs = append(errs, fmt.Errorf("rule %s: application %s not found", rules[i].RuleName, rules[i].AppName))
+		}
+		rules[i].Endpoint = application.Endpoint
+		rules[i].Protocol = application.Protocol
+		rules[i].StartPort = application.StartPort
+		rules[i].EndPort = application.EndPort
+	}
+	slice.ApplicationFilteringRules = rules
+	return errors.Join(errs...)
+}
@@ -51,6 +51,10 @@ func (db *MockMongoClientDBError) RestfulAPIGetMany(coll string, filter bson.M)
 	return nil, errors.New("DB error")
 }
 
+func (db *MockMongoClientDBError) RestfulAPIGetManyWithContext(context context.Context, coll string, filter bson.M) ([]map[string]any, error) {
+	return nil, errors.New("DB error")
+}
+
 func (db *MockMongoClientDBError) RestfulAPIPutOneWithContext(context context.Context, collName string, filter bson.M, putData map[string]any) (bool, error) {
 	return false, errors.New("DB error")
 }
@@ -112,6 +116,11 @@ func (db *MockMongoClientEmptyDB) RestfulAPIGetMany(coll string, filter bson.M)
 	return results, nil
 }
 
+func (db *MockMongoClientEmptyDB) RestfulAPIGetManyWithContext(context context.Context, coll string, filter bson.M) ([]map[string]any, error) {
+	var results []map[string]any
+	return results, nil
+}
+
 func (db *MockMongoClientEmptyDB) RestfulAPIPutOneWithContext(context context.Context, collName string, filter bson.M, putData map[string]any) (bool, error) {
 	return false, nil
 }
@@ -5,7 +5,9 @@
 package configapi
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"math"
 	"net/http"
@@ -75,6 +77,22 @@ func updateDeviceGroupInNetworkSlices(groupName string) error {
 func deviceGroupPostHelper(requestDeviceGroup configmodels.DeviceGroups, groupName string) (int, error) {
 	logger.ConfigLog.Infof("received device group: %s", groupName)
 
+	if err := validateDeviceGroupDnns(requestDeviceGroup); err != nil {
+		return http.StatusBadRequest, fmt.Errorf("invalid DNN in device group %s: %w", groupName, err)
+	}
+
+	for _, ipdomain := range requestDeviceGroup.IpDomainsExpanded {
+		if err := validateQosProfileReference(ipdomain.QosProfileName, ipdomain.UeDnnQos); err != nil {
+			return http.StatusBadRequest, fmt.Errorf("invalid QoS profile for DNN %s: %w", ipdomain.Dnn, err)
+		}
+		if ipdomain.UeDnnQos == nil {
+			continue
+		}
+		if err := validateTrafficClassReference(ipdomain.UeDnnQos.TrafficClassName, ipdomain.UeDnnQos.TrafficClass); err != nil {
+			return http.StatusBadRequest, fmt.Errorf("invalid traffic class for DNN %s: %w", ipdomain.Dnn, err)
+		}
+	}
+
 	for i := range requestDeviceGroup.IpDomainsExpanded {
 		ipdomain := &requestDeviceGroup.IpDomainsExpanded[i]
 		logger.ConfigLog.Infof("IP Domain details [%d]: %+v", i, ipdomain)
@@ -170,6 +188,35 @@ func handleDeviceGroupPost(devGroup *configmodels.DeviceGroups, prevDevGroup *co
 func syncDeviceGroupSubscriber(devGroup *configmodels.DeviceGroups, prevDevGroup *configmodels.DeviceGroups) (int, error) {
 	rwLock.Lock()
 	defer rwLock.Unlock()
+	return provisionDeviceGroupSubscribers(devGroup, prevDevGroup)
+}
+
+// provisionDeviceGroupsMatching provisions again the subscribers of the device
+// groups matching the filter, e.g. the groups referencing a catalog entry
+func provisionDeviceGroupsMatching(filter bson.M) error {
+	rwLock.Lock()
+	defer rwLock.Unlock()
+	rawDeviceGroups, err := dbadapter.CommonDBClient.RestfulAPIGetMany(devGroupDataColl, filter)
+	if err != nil {
+		return fmt.Errorf("failed to fetch device groups: %w", err)
+	}
+	var errs []error
+	for _, rawDeviceGroup := range rawDeviceGroups {
+		var deviceGroup configmodels.DeviceGroups
+		if err = json.Unmarshal(configmodels.MapToByte(rawDeviceGroup), &deviceGroup); err != nil {
+			errs = append(errs, fmt.Errorf("could not unmarshal device group %s: %w", rawDeviceGroup, err))
+			continue
+		}
+		logger.ConfigLog.Infof("provisioning device group %s", deviceGroup.DeviceGroupName)
+		if _, err = provisionDeviceGroupSubscribers(&deviceGroup, &deviceGroup); err != nil {
+			errs = append(errs, fmt.Errorf("device group %s: %w", deviceGroup.DeviceGroupName, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// provisionDeviceGroupSubscribers must be called with rwLock held
+func provisionDeviceGroupSubscribers(devGroup *configmodels.DeviceGroups, prevDevGroup *configmodels.DeviceGroups) (int, error) {
 	slice := findSliceByDeviceGroup(devGroup.DeviceGroupName)
 	if slice == nil {
 		logger.WebUILog.Infof("Device group %s not associated with any slice — skipping sync", devGroup.DeviceGroupName)
@@ -190,9 +237,14 @@ func syncDeviceGroupSubscriber(devGroup *configmodels.DeviceGroups, prevDevGroup
 		Sd:  openapi.PtrString(slice.SliceId.Sd),
 		Sst: int32(sVal),
 	}
+	resolvedDevGroup, err := resolveDeviceGroupForProvisioning(devGroup)
+	if err != nil {
+		logger.ConfigLog.Errorln(err)
+		return http.StatusInternalServerError, err
+	}
 	var errorOccured bool
 	dnnMap := make(map[string][]configmodels.DeviceGroupsIpDomainExpandedUeDnnQos)
-	for _, ipDomain := range devGroup.IpDomainsExpanded {
+	for _, ipDomain := range resolvedDevGroup.IpDomainsExpanded {
 		if ipDomain.UeDnnQos != nil {
 			dnnMap[ipDomain.Dnn] = append(dnnMap[ipDomain.Dnn], *ipDomain.UeDnnQos)
 		}
@@ -204,7 +256,7 @@ func syncDeviceGroupSubscriber(devGroup *configmodels.DeviceGroups, prevDevGroup
 		allQosProfiles = append(allQosProfiles, qosList...)
 	}
 
-	aggregatedQoS := aggregateQoS(allQosProfiles)
+	aggregatedQoS := aggregateQoS(allQosProfiles, slice.SliceQos)
 	for i, imsi := range devGroup.Imsis {
 		/* update all current IMSIs */
 		if subscriberAuthenticationDataGet("imsi-"+imsi) != nil {
@@ -257,6 +309,25 @@ func handleDeviceGroupDelete(groupName string) error {
 	return nil
 }
 
+// deleteUnusedDeviceGroup deletes the device group unless network slices use
+// it, checking the network slices in the delete transaction
+func deleteUnusedDeviceGroup(ctx context.Context, groupName string) error {
+	rwLock.Lock()
+	defer rwLock.Unlock()
+	sessionRunner := dbadapter.GetSessionRunner(dbadapter.CommonDBClient)
+	err := sessionRunner(ctx, func(sc context.Context) error {
+		if err := checkNotInUse(sc, "device group "+groupName, sliceReference(bson.M{"site-device-group": groupName})); err != nil {
+			return err
+		}
+		return dbadapter.CommonDBClient.RestfulAPIDeleteOneWithContext(sc, devGroupDataColl, bson.M{"group-name": groupName})
+	})
+	if err != nil {
+		return err
+	}
+	logger.DbLog.Debugf("succeeded to delete device group data for %s", groupName)
+	return nil
+}
+
 func getDeviceGroupByName(name string) *configmodels.DeviceGroups {
 	filter := bson.M{"group-name": name}
 	devGroupDataInterface, err := dbadapter.CommonDBClient.RestfulAPIGetOne(devGroupDataColl, filter)
@@ -23,6 +23,7 @@ import (
 type DeviceGroupMockDBClient struct {
 	dbadapter.DBInterface
 	configuredDeviceGroups []configmodels.DeviceGroups
+	configuredDnns         []configmodels.Dnn
 	postData               []map[string]any
 	deleteData             []map[string]any
 	err                    error
@@ -32,6 +33,14 @@ func (db *DeviceGroupMockDBClient) RestfulAPIGetOne(coll string, filter bson.M)
 	if db.err != nil {
 		return nil, db.err
 	}
+	if coll == configmodels.DnnDataColl {
+		for _, dnn := range db.configuredDnns {
+			if dnn.Name == filter["name"] {
+				return configmodels.ToBsonM(dnn), nil
+			}
+		}
+		return nil, nil
+	}
 	if len(db.configuredDeviceGroups) == 0 {
 		return nil, nil
 	}
@@ -442,7 +451,9 @@ func TestDeviceGroupPostHandler_DeviceGroupNameValidation(t *testing.T) {
 			originalDBClient := dbadapter.CommonDBClient
 			defer func() { dbadapter.CommonDBClient = originalDBClient }()
 			if tc.expectedCode == http.StatusOK {
-				dbadapter.CommonDBClient = &DeviceGroupMockDBClient{}
+				dbadapter.CommonDBClient = &DeviceGroupMockDBClient{
+					configuredDnns: []configmodels.Dnn{{Name: "internet"}},
+				}
 			}
 			newDeviceGroup := deviceGroup("name")
 			jsonBody, err := json.Marshal(newDeviceGroup)
@@ -463,3 +474,58 @@ func TestDeviceGroupPostHandler_DeviceGroupNameValidation(t *testing.T) {
 		})
 	}
 }
+
+func TestDeviceGroupDeleteHandler_InUse(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+	AddConfigV1Service(router)
+	usingSlice := configmodels.Slice{SliceName: "slice1", SiteDeviceGroup: []string{"group1", "group2"}}
+
+	testCases := []struct {
+		name          string
+		route         string
+		expectedCode  int
+		expectedError string
+	}{
+		{
+			name:          "Delete device group used by a slice expects conflict",
+			route:         "/config/v1/device-group/group1",
+			expectedCode:  http.StatusConflict,
+			expectedError: "device group group1 is in use",
+		},
+		{
+			name:          "Delete device group with invalid cascade expects failure",
+			route:         "/config/v1/device-group/group1?cascade=maybe",
+			expectedCode:  http.StatusBadRequest,
+			expectedError: "invalid cascade value 'maybe'. Value must be true or false",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			originalDBClient := dbadapter.CommonDBClient
+			defer func() { dbadapter.CommonDBClient = originalDBClient }()
+			dbadapter.CommonDBClient = &SiteMockDBClient{slices: []configmodels.Slice{usingSlice}}
+			req, err := http.NewRequest(http.MethodDelete, tc.route, nil)
+			if err != nil {
+				t.Fatalf("failed to create request: %v", err)
+			}
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			if tc.expectedCode != w.Code {
+				t.Errorf("expected `%v`, got `%v`", tc.expectedCode, w.Code)
+			}
+			var body map[string]any
+			if err = json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+				t.Fatalf("failed to unmarshal JSON: %v", err)
+			}
+			if body["error"] != tc.expectedError {
+				t.Errorf("expected error `%s`, got `%v`", tc.expectedError, body["error"])
+			}
+			if tc.expectedCode == http.StatusConflict && !reflect.DeepEqual(body["network-slices"], []any{"slice1"}) {
+				t.Errorf("expected network slices [slice1], got %v", body["network-slices"])
+			}
+		})
+	}
+}
@@ -0,0 +1,132 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2026 Canonical Ltd
+
+package configapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/omec-project/webconsole/backend/logger"
+	"github.com/omec-project/webconsole/configmodels"
+	"github.com/omec-project/webconsole/dbadapter"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+func validateDnn(dnn configmodels.Dnn) error {
+	if !isValidDnn(dnn.Name) {
+		return fmt.Errorf("invalid DNN name '%s'. Name needs to match the following regular expression: %s", dnn.Name, DNN_PATTERN)
+	}
+	if dnn.Mtu != 0 && !isValidMtu(dnn.Mtu) {
+		return fmt.Errorf("invalid MTU %d for DNN %s. MTU must be between 1280 and 65535", dnn.Mtu, dnn.Name)
+	}
+	for _, dnsServer := range []string{dnn.DnsPrimary, dnn.DnsSecondary} {
+		if dnsServer != "" && net.ParseIP(dnsServer) == nil {
+			return fmt.Errorf("invalid DNS server '%s' for DNN %s", dnsServer, dnn.Name)
+		}
+	}
+	for _, pduSessionType := range dnn.PduSessionTypes {
+		if !isValidPduSessionType(pduSessionType) {
+			return fmt.Errorf("invalid PDU session type '%s' for DNN %s", pduSessionType, dnn.Name)
+		}
+	}
+	return nil
+}
+
+// validateDeviceGroupDnns checks that the DNNs of the device group exist in the catalog
+func validateDeviceGroupDnns(deviceGroup configmodels.DeviceGroups) error {
+	for _, ipDomain := range deviceGroup.IpDomainsExpanded {
+		dnn, err := getDnnByName(ipDomain.Dnn)
+		if err != nil {
+			return fmt.Errorf("failed to retrieve DNN %s: %w", ipDomain.Dnn, err)
+		}
+		if dnn == nil {
+			return fmt.Errorf("DNN %s not found", ipDomain.Dnn)
+		}
+	}
+	return nil
+}
+
+// BackfillDnnCatalog adds to the catalog the DNNs used by device groups that
+// predate it, so that these device groups can still be updated. The added
+// DNNs have no defaults.
+func BackfillDnnCatalog(ctx context.Context) error {
+	catalog, err := GetDnnCatalog()
+	if err != nil {
+		return err
+	}
+	rawDeviceGroups, err := dbadapter.CommonDBClient.RestfulAPIGetMany(devGroupDataColl, bson.M{})
+	if err != nil {
+		return fmt.Errorf("failed to fetch device groups: %w", err)
+	}
+	var errs []error
+	for _, rawDeviceGroup := range rawDeviceGroups {
+		var deviceGroup configmodels.DeviceGroups
+		if err = json.Unmarshal(configmodels.MapToByte(rawDeviceGroup), &deviceGroup); err != nil {
+			logger.DbLog.Warnf("could not unmarshal device group %s: %+v", rawDeviceGroup, err)
+			continue
+		}
+		for _, ipDomain := range deviceGroup.IpDomainsExpanded {
+			if _, found := catalog[ipDomain.Dnn]; found || ipDomain.Dnn == "" {
+				continue
+			}
+			dnn := configmodels.Dnn{Name: ipDomain.Dnn}
+			err = dbadapter.CommonDBClient.RestfulAPIPostManyWithContext(ctx, configmodels.DnnDataColl, bson.M{"name": dnn.Name}, []any{configmodels.ToBsonM(dnn)})
+			if err != nil && !strings.Contains(err.Error(), "E11000") {
+				errs = append(errs, fmt.Errorf("failed to add DNN %s: %w", dnn.Name, err))
+				continue
+			}
+			logger.ConfigLog.Infof("added DNN %s of device group %s to the DNN catalog", dnn.Name, deviceGroup.DeviceGroupName)
+			catalog[dnn.Name] = dnn
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func getDnnByName(name string) (*configmodels.Dnn, error) {
+	rawDnn, err := dbadapter.CommonDBClient.RestfulAPIGetOne(configmodels.DnnDataColl, bson.M{"name": name})
+	if err != nil {
+		return nil, err
+	}
+	if len(rawDnn) == 0 {
+		return nil, nil
+	}
+	var dnn configmodels.Dnn
+	if err = json.Unmarshal(configmodels.MapToByte(rawDnn), &dnn); err != nil {
+		return nil, fmt.Errorf("could not unmarshal DNN %s: %w", name, err)
+	}
+	return &dnn, nil
+}
+
+// GetDnnCatalog returns the DNNs of the catalog indexed by name
+func GetDnnCatalog() (map[string]configmodels.Dnn, error) {
+	rawDnns, err := dbadapter.CommonDBClient.RestfulAPIGetMany(configmodels.DnnDataColl, bson.M{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch DNNs: %w", err)
+	}
+	catalog := make(map[string]configmodels.Dnn, len(rawDnns))
+	for _, rawDnn := range rawDnns {
+		var dnn configmodels.Dnn
+		if err = json.Unmarshal(configmodels.MapToByte(rawDnn), &dnn); err != nil {
+			logger.DbLog.Warnf("could not unmarshal DNN %s: %+v", rawDnn, err)
+			continue
+		}
+		if dnn.Name == "" {
+			continue
+		}
+		catalog[dnn.Name] = dnn
+	}
+	return catalog, nil
+}
+
+// dnnReferences selects the device groups and UPFs using the DNN
+func dnnReferences(name string) []inUseReference {
+	return []inUseReference{
+		deviceGroupReference(bson.M{"ip-domains.dnn": name}),
+		{key: "upfs", collection: configmodels.UpfDataColl, filter: bson.M{"dnns.dnn": name}, nameField: "hostname"},
+	}
+}
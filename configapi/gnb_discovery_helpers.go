@@ -0,0 +1,311 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2026 Canonical Ltd
+
+package configapi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/omec-project/openapi/v2/models"
+	"github.com/omec-project/webconsole/backend/factory"
+	"github.com/omec-project/webconsole/backend/logger"
+	"github.com/omec-project/webconsole/backend/webui_context"
+	"github.com/omec-project/webconsole/configmodels"
+	"github.com/omec-project/webconsole/dbadapter"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+const (
+	defaultGnbDiscoveryInterval    = 60 * time.Second
+	amfConnectedGnbsRequestTimeout = 10 * time.Second
+)
+
+// amfConnectedGnb is a gNB connected to the AMF as reported on the configured
+// path of its OAM API. The gNB ID and TACs are hexadecimal strings as in the
+// SBI models.
+type amfConnectedGnb struct {
+	Name  string          `json:"gnbName"`
+	GnbId *models.GNbId   `json:"gnbId,omitempty"`
+	Plmns []models.PlmnId `json:"plmns,omitempty"`
+	Tacs  []string        `json:"tacs,omitempty"`
+}
+
+var (
+	gnbDiscoveryMutex      sync.Mutex
+	lastGnbDiscoveryReport *configmodels.GnbDiscoveryReport
+)
+
+// gnbDiscoveryConfig returns the gNB discovery configuration, or nil when the
+// discovery is disabled
+func gnbDiscoveryConfig() *factory.GnbDiscovery {
+	if factory.WebUIConfig == nil || factory.WebUIConfig.Configuration == nil {
+		return nil
+	}
+	discoveryConfig := factory.WebUIConfig.Configuration.GnbDiscovery
+	if discoveryConfig == nil || !discoveryConfig.Enabled || discoveryConfig.AmfPath == "" {
+		return nil
+	}
+	return discoveryConfig
+}
+
+// StartGnbDiscovery periodically reconciles the gNBs connected to the AMFs
+// with the inventory until the context is done. A sync of the NF
+// configuration is requested when gNBs are imported.
+func StartGnbDiscovery(ctx context.Context, syncChan chan<- struct{}) {
+	discoveryConfig := gnbDiscoveryConfig()
+	if discoveryConfig == nil {
+		logger.WebUILog.Infoln("gNB discovery is disabled")
+		return
+	}
+	interval := defaultGnbDiscoveryInterval
+	if discoveryConfig.IntervalSeconds > 0 {
+		interval = time.Duration(discoveryConfig.IntervalSeconds) * time.Second
+	}
+	logger.WebUILog.Infof("starting gNB discovery every %s", interval)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			logger.WebUILog.Infoln("stopping gNB discovery")
+			return
+		case <-ticker.C:
+			report := RunGnbDiscovery(ctx, discoveryConfig.AmfPath, discoveryConfig.AutoImport)
+			if len(report.ImportedGnbs) > 0 {
+				syncChan <- struct{}{}
+			}
+		}
+	}
+}
+
+// RunGnbDiscovery reconciles the gNBs listed on the OAM path of the
+// registered AMFs with the inventory and keeps the report as the latest one
+func RunGnbDiscovery(ctx context.Context, amfPath string, autoImport bool) configmodels.GnbDiscoveryReport {
+	webuiSelf := webui_context.WEBUI_Self()
+	webuiSelf.UpdateNfProfiles()
+	report := reconcileGnbs(ctx, webuiSelf.GetOamUris(models.NFTYPE_AMF), amfPath, autoImport)
+	gnbDiscoveryMutex.Lock()
+	lastGnbDiscoveryReport = &report
+	gnbDiscoveryMutex.Unlock()
+	return report
+}
+
+func getLastGnbDiscoveryReport() *configmodels.GnbDiscoveryReport {
+	gnbDiscoveryMutex.Lock()
+	defer gnbDiscoveryMutex.Unlock()
+	return lastGnbDiscoveryReport
+}
+
+func reconcileGnbs(ctx context.Context, amfUris []string, amfPath string, autoImport bool) configmodels.GnbDiscoveryReport {
+	report := configmodels.GnbDiscoveryReport{
+		Time:             time.Now().UTC(),
+		Amfs:             amfUris,
+		UnknownGnbs:      []configmodels.DiscoveredGnb{},
+		NotConnectedGnbs: []string{},
+	}
+	if len(amfUris) == 0 {
+		report.Amfs = []string{}
+		report.Errors = append(report.Errors, "no AMF found")
+		return report
+	}
+	discoveredGnbs := []configmodels.DiscoveredGnb{}
+	allAmfsAnswered := true
+	for _, amfUri := range amfUris {
+		connectedGnbs, err := fetchConnectedGnbs(ctx, amfUri+amfPath)
+		if err != nil {
+			logger.WebUILog.Warnf("failed to retrieve the gNBs connected to AMF %s: %+v", amfUri, err)
+			report.Errors = append(report.Errors, fmt.Sprintf("failed to retrieve the gNBs connected to AMF %s: %v", amfUri, err))
+			allAmfsAnswered = false
+			continue
+		}
+		for _, connectedGnb := range connectedGnbs {
+			discoveredGnb, err := toDiscoveredGnb(connectedGnb, amfUri)
+			if err != nil {
+				logger.WebUILog.Warnf("ignoring gNB reported by AMF %s: %+v", amfUri, err)
+				report.Errors = append(report.Errors, fmt.Sprintf("ignoring gNB reported by AMF %s: %v", amfUri, err))
+				continue
+			}
+			discoveredGnbs = mergeDiscoveredGnb(discoveredGnbs, discoveredGnb)
+		}
+	}
+
+	inventory, err := getGnbInventory()
+	if err != nil {
+		logger.WebUILog.Errorf("failed to retrieve the gNB inventory: %+v", err)
+		report.Errors = append(report.Errors, "failed to retrieve the gNB inventory")
+		return report
+	}
+	connectedNames := map[string]struct{}{}
+	for _, discoveredGnb := range discoveredGnbs {
+		inventoryGnb := findInventoryGnb(inventory, discoveredGnb)
+		if inventoryGnb == nil {
+			report.UnknownGnbs = append(report.UnknownGnbs, discoveredGnb)
+			continue
+		}
+		connectedNames[inventoryGnb.Name] = struct{}{}
+	}
+	if allAmfsAnswered {
+		for _, gnb := range inventory {
+			if _, connected := connectedNames[gnb.Name]; !connected {
+				report.NotConnectedGnbs = append(report.NotConnectedGnbs, gnb.Name)
+			}
+		}
+	} else {
+		report.Errors = append(report.Errors, "not connected gNBs are not reported because some AMFs could not be queried")
+	}
+	if autoImport {
+		for _, unknownGnb := range report.UnknownGnbs {
+			if err = importDiscoveredGnb(ctx, unknownGnb); err != nil {
+				logger.WebUILog.Warnf("failed to import discovered gNB %s: %+v", unknownGnb.Name, err)
+				report.Errors = append(report.Errors, fmt.Sprintf("failed to import gNB %s: %v", unknownGnb.Name, err))
+				continue
+			}
+			logger.WebUILog.Infof("imported discovered gNB %s", unknownGnb.Name)
+			report.ImportedGnbs = append(report.ImportedGnbs, unknownGnb.Name)
+		}
+	}
+	logger.WebUILog.Infof("gNB discovery found %d unknown and %d not connected gNBs", len(report.UnknownGnbs), len(report.NotConnectedGnbs))
+	return report
+}
+
+func fetchConnectedGnbs(ctx context.Context, requestUri string) ([]amfConnectedGnb, error) {
+	requestCtx, cancel := context.WithTimeout(ctx, amfConnectedGnbsRequestTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(requestCtx, http.MethodGet, requestUri, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := httpsClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if closeErr := resp.Body.Close(); closeErr != nil {
+			logger.WebUILog.Warnf("failed to close AMF response body: %+v", closeErr)
+		}
+	}()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+	var connectedGnbs []amfConnectedGnb
+	if err = json.NewDecoder(resp.Body).Decode(&connectedGnbs); err != nil {
+		return nil, fmt.Errorf("invalid response: %w", err)
+	}
+	return connectedGnbs, nil
+}
+
+func toDiscoveredGnb(connectedGnb amfConnectedGnb, amfUri string) (configmodels.DiscoveredGnb, error) {
+	discoveredGnb := configmodels.DiscoveredGnb{
+		Name: connectedGnb.Name,
+		Amfs: []string{amfUri},
+	}
+	if connectedGnb.GnbId != nil {
+		value, err := strconv.ParseUint(connectedGnb.GnbId.GNBValue, 16, 32)
+		if err != nil {
+			return discoveredGnb, fmt.Errorf("invalid gNB ID '%s' of gNB %s", connectedGnb.GnbId.GNBValue, connectedGnb.Name)
+		}
+		discoveredGnb.GnbId = &configmodels.GnbId{Value: uint32(value), BitLength: connectedGnb.GnbId.BitLength}
+	}
+	if discoveredGnb.Name == "" && discoveredGnb.GnbId == nil {
+		return discoveredGnb, fmt.Errorf("gNB without name nor ID")
+	}
+	for _, plmn := range connectedGnb.Plmns {
+		discoveredGnb.Plmns = append(discoveredGnb.Plmns, configmodels.SliceSiteInfoPlmn{Mcc: plmn.Mcc, Mnc: plmn.Mnc})
+	}
+	for _, tac := range connectedGnb.Tacs {
+		value, err := strconv.ParseInt(tac, 16, 32)
+		if err != nil {
+			return discoveredGnb, fmt.Errorf("invalid TAC '%s' of gNB %s", tac, connectedGnb.Name)
+		}
+		discoveredGnb.Tacs = append(discoveredGnb.Tacs, int32(value))
+	}
+	return discoveredGnb, nil
+}
+
+// mergeDiscoveredGnb adds the gNB to the discovered ones, or adds its AMF when
+// the gNB is connected to several AMFs
+func mergeDiscoveredGnb(discoveredGnbs []configmodels.DiscoveredGnb, discoveredGnb configmodels.DiscoveredGnb) []configmodels.DiscoveredGnb {
+	for i := range discoveredGnbs {
+		if isSameDiscoveredGnb(discoveredGnbs[i], discoveredGnb) {
+			discoveredGnbs[i].Amfs = append(discoveredGnbs[i].Amfs, discoveredGnb.Amfs...)
+			return discoveredGnbs
+		}
+	}
+	return append(discoveredGnbs, discoveredGnb)
+}
+
+func isSameDiscoveredGnb(a, b configmodels.DiscoveredGnb) bool {
+	if a.GnbId != nil && b.GnbId != nil {
+		return *a.GnbId == *b.GnbId
+	}
+	return a.Name != "" && a.Name == b.Name
+}
+
+// findInventoryGnb returns the inventory gNB with the ID of the discovered gNB
+// or, when either has no ID, with its name
+func findInventoryGnb(inventory []configmodels.Gnb, discoveredGnb configmodels.DiscoveredGnb) *configmodels.Gnb {
+	for i, gnb := range inventory {
+		if gnb.GnbId != nil && discoveredGnb.GnbId != nil {
+			if *gnb.GnbId == *discoveredGnb.GnbId {
+				return &inventory[i]
+			}
+			continue
+		}
+		if discoveredGnb.Name != "" && gnb.Name == discoveredGnb.Name {
+			return &inventory[i]
+		}
+	}
+	return nil
+}
+
+func getGnbInventory() ([]configmodels.Gnb, error) {
+	rawGnbs, err := dbadapter.CommonDBClient.RestfulAPIGetMany(configmodels.GnbDataColl, bson.M{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch gNBs: %w", err)
+	}
+	inventory := make([]configmodels.Gnb, 0, len(rawGnbs))
+	for _, rawGnb := range rawGnbs {
+		var gnb configmodels.Gnb
+		if err = json.Unmarshal(configmodels.MapToByte(rawGnb), &gnb); err != nil {
+			logger.DbLog.Warnf("could not unmarshal gNB %s: %+v", rawGnb, err)
+			continue
+		}
+		inventory = append(inventory, gnb)
+	}
+	slices.SortFunc(inventory, func(a, b configmodels.Gnb) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+	return inventory, nil
+}
+
+// importDiscoveredGnb creates the inventory entry of an unknown gNB. The TAC
+// is only set when the gNB serves a single one.
+func importDiscoveredGnb(ctx context.Context, discoveredGnb configmodels.DiscoveredGnb) error {
+	if !isValidName(discoveredGnb.Name) {
+		return fmt.Errorf("invalid gNB name '%s'. Name needs to match the following regular expression: %s", discoveredGnb.Name, NAME_PATTERN)
+	}
+	gnb := configmodels.Gnb{
+		Name:  discoveredGnb.Name,
+		GnbId: discoveredGnb.GnbId,
+		Plmns: discoveredGnb.Plmns,
+	}
+	if len(discoveredGnb.Tacs) == 1 && isValidGnbTac(discoveredGnb.Tacs[0]) {
+		gnb.Tac = &discoveredGnb.Tacs[0]
+	}
+	if err := validateGnb(gnb); err != nil {
+		return err
+	}
+	nsOperation := func(configmodels.Gnb) error { return nil }
+	if gnb.Tac != nil {
+		nsOperation = updateGnbInNetworkSlices
+	}
+	return executeGnbTransaction(ctx, gnb, nsOperation, withGnbTacCheck(postGnbOperation))
+}
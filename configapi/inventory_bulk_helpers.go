@@ -0,0 +1,618 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2026 Canonical Ltd
+
+package configapi
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/omec-project/webconsole/backend/logger"
+	"github.com/omec-project/webconsole/configmodels"
+	"github.com/omec-project/webconsole/dbadapter"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.yaml.in/yaml/v4"
+)
+
+const (
+	inventoryFormatCsv  = "csv"
+	inventoryFormatJson = "json"
+	inventoryFormatYaml = "yaml"
+)
+
+// maxInventoryImportSize is the largest import file accepted, in bytes
+const maxInventoryImportSize = 10 << 20
+
+var inventoryContentTypes = map[string]string{
+	inventoryFormatCsv:  "text/csv",
+	inventoryFormatJson: "application/json",
+	inventoryFormatYaml: "application/yaml",
+}
+
+// CSV columns of the inventory files. Lists are separated by ';', key/value
+// pairs by '=' and the UE IP pools of a DNN by '|'.
+var (
+	gnbCsvHeader = []string{"name", "tac", "gnb-id", "gnb-id-bit-length", "plmns", "n2-address", "vendor", "model", "labels"}
+	upfCsvHeader = []string{"hostname", "port", "n4-address", "n3-interfaces", "dnns", "max-sessions", "max-throughput-mbps"}
+)
+
+type gnbImportRow struct {
+	gnb configmodels.Gnb
+	err error
+}
+
+type upfImportRow struct {
+	upf configmodels.Upf
+	err error
+}
+
+// getInventoryFormat returns the format of an import or export request. The
+// format query parameter takes precedence over the content type of an import.
+func getInventoryFormat(c *gin.Context) (string, error) {
+	format := strings.ToLower(c.Query("format"))
+	if format == "" {
+		switch c.ContentType() {
+		case "text/csv":
+			format = inventoryFormatCsv
+		case "application/yaml", "application/x-yaml", "text/yaml":
+			format = inventoryFormatYaml
+		default:
+			format = inventoryFormatJson
+		}
+	}
+	if _, ok := inventoryContentTypes[format]; !ok {
+		return "", fmt.Errorf("invalid format '%s'. Format must be csv, json or yaml", format)
+	}
+	return format, nil
+}
+
+// decodeInventoryDocument splits a JSON or YAML list into its items, encoded
+// as JSON so that both formats share the field names of the API
+func decodeInventoryDocument(format string, body []byte) ([][]byte, error) {
+	var items []any
+	var err error
+	if format == inventoryFormatYaml {
+		err = yaml.Unmarshal(body, &items)
+	} else {
+		err = json.Unmarshal(body, &items)
+	}
+	if err != nil {
+		return nil, err
+	}
+	encodedItems := make([][]byte, 0, len(items))
+	for _, item := range items {
+		encodedItem, err := json.Marshal(item)
+		if err != nil {
+			// kept as an invalid row so that the row numbers match the file
+			encodedItem = nil
+		}
+		encodedItems = append(encodedItems, encodedItem)
+	}
+	return encodedItems, nil
+}
+
+func decodeInventoryItem(encodedItem []byte, item any) error {
+	if encodedItem == nil {
+		return fmt.Errorf("invalid item")
+	}
+	decoder := json.NewDecoder(bytes.NewReader(encodedItem))
+	decoder.DisallowUnknownFields()
+	return decoder.Decode(item)
+}
+
+// readInventoryCsv returns the records of a CSV file indexed by column. The
+// first line is the header and may list any subset of the columns in any order.
+func readInventoryCsv(body []byte, header []string, keyColumn string) ([]map[string]string, error) {
+	reader := csv.NewReader(bytes.NewReader(body))
+	reader.TrimLeadingSpace = true
+	lines, err := reader.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(lines) == 0 {
+		return nil, fmt.Errorf("missing CSV header")
+	}
+	columns := lines[0]
+	for i, column := range columns {
+		columns[i] = strings.TrimSpace(column)
+		if !slices.Contains(header, columns[i]) {
+			return nil, fmt.Errorf("unknown CSV column '%s'", columns[i])
+		}
+		if slices.Contains(columns[:i], columns[i]) {
+			return nil, fmt.Errorf("duplicate CSV column '%s'", columns[i])
+		}
+	}
+	if !slices.Contains(columns, keyColumn) {
+		return nil, fmt.Errorf("missing CSV column '%s'", keyColumn)
+	}
+	records := make([]map[string]string, 0, len(lines)-1)
+	for _, line := range lines[1:] {
+		record := make(map[string]string, len(columns))
+		for i, column := range columns {
+			record[column] = strings.TrimSpace(line[i])
+		}
+		records = append(records, record)
+	}
+	return records, nil
+}
+
+func splitCsvList(value string, separator string) []string {
+	if value == "" {
+		return nil
+	}
+	items := strings.Split(value, separator)
+	for i := range items {
+		items[i] = strings.TrimSpace(items[i])
+	}
+	return items
+}
+
+func splitCsvPair(value string) (string, string, error) {
+	key, pairValue, found := strings.Cut(value, "=")
+	if !found {
+		return "", "", fmt.Errorf("invalid pair '%s'. Pair must be formatted as key=value", value)
+	}
+	return strings.TrimSpace(key), strings.TrimSpace(pairValue), nil
+}
+
+func decodeGnbRows(format string, body []byte) ([]gnbImportRow, error) {
+	if format == inventoryFormatCsv {
+		records, err := readInventoryCsv(body, gnbCsvHeader, "name")
+		if err != nil {
+			return nil, err
+		}
+		rows := make([]gnbImportRow, 0, len(records))
+		for _, record := range records {
+			gnb, err := gnbFromCsvRecord(record)
+			rows = append(rows, gnbImportRow{gnb: gnb, err: err})
+		}
+		return rows, nil
+	}
+	items, err := decodeInventoryDocument(format, body)
+	if err != nil {
+		return nil, err
+	}
+	rows := make([]gnbImportRow, 0, len(items))
+	for _, item := range items {
+		var postGnbParams configmodels.PostGnbRequest
+		err = decodeInventoryItem(item, &postGnbParams)
+		rows = append(rows, gnbImportRow{gnb: configmodels.Gnb(postGnbParams), err: err})
+	}
+	return rows, nil
+}
+
+func gnbFromCsvRecord(record map[string]string) (configmodels.Gnb, error) {
+	gnb := configmodels.Gnb{
+		Name:      record["name"],
+		N2Address: record["n2-address"],
+		Vendor:    record["vendor"],
+		Model:     record["model"],
+	}
+	if record["tac"] != "" {
+		tac, err := strconv.ParseInt(record["tac"], 10, 32)
+		if err != nil {
+			return gnb, fmt.Errorf("invalid TAC '%s'. TAC must be an integer", record["tac"])
+		}
+		gnbTac := int32(tac)
+		gnb.Tac = &gnbTac
+	}
+	if record["gnb-id"] != "" || record["gnb-id-bit-length"] != "" {
+		value, err := strconv.ParseUint(record["gnb-id"], 10, 32)
+		if err != nil {
+			return gnb, fmt.Errorf("invalid gNB ID '%s'. gNB ID must be an unsigned integer", record["gnb-id"])
+		}
+		bitLength, err := strconv.ParseInt(record["gnb-id-bit-length"], 10, 32)
+		if err != nil {
+			return gnb, fmt.Errorf("invalid gNB ID bit length '%s'. Bit length must be an integer", record["gnb-id-bit-length"])
+		}
+		gnb.GnbId = &configmodels.GnbId{Value: uint32(value), BitLength: int32(bitLength)}
+	}
+	for _, plmn := range splitCsvList(record["plmns"], ";") {
+		mcc, mnc, found := strings.Cut(plmn, "-")
+		if !found {
+			return gnb, fmt.Errorf("invalid PLMN '%s'. PLMN must be formatted as MCC-MNC", plmn)
+		}
+		gnb.Plmns = append(gnb.Plmns, configmodels.SliceSiteInfoPlmn{Mcc: mcc, Mnc: mnc})
+	}
+	for _, label := range splitCsvList(record["labels"], ";") {
+		key, value, err := splitCsvPair(label)
+		if err != nil {
+			return gnb, fmt.Errorf("invalid label: %w", err)
+		}
+		if gnb.Labels == nil {
+			gnb.Labels = map[string]string{}
+		}
+		gnb.Labels[key] = value
+	}
+	return gnb, nil
+}
+
+func gnbToCsvRecord(gnb configmodels.Gnb) []string {
+	var tac, gnbId, bitLength string
+	if gnb.Tac != nil {
+		tac = strconv.FormatInt(int64(*gnb.Tac), 10)
+	}
+	if gnb.GnbId != nil {
+		gnbId = strconv.FormatUint(uint64(gnb.GnbId.Value), 10)
+		bitLength = strconv.FormatInt(int64(gnb.GnbId.BitLength), 10)
+	}
+	plmns := make([]string, 0, len(gnb.Plmns))
+	for _, plmn := range gnb.Plmns {
+		plmns = append(plmns, plmn.Mcc+"-"+plmn.Mnc)
+	}
+	labels := make([]string, 0, len(gnb.Labels))
+	for _, key := range slices.Sorted(maps.Keys(gnb.Labels)) {
+		labels = append(labels, key+"="+gnb.Labels[key])
+	}
+	return []string{gnb.Name, tac, gnbId, bitLength, strings.Join(plmns, ";"), gnb.N2Address, gnb.Vendor, gnb.Model, strings.Join(labels, ";")}
+}
+
+func decodeUpfRows(format string, body []byte) ([]upfImportRow, error) {
+	if format == inventoryFormatCsv {
+		records, err := readInventoryCsv(body, upfCsvHeader, "hostname")
+		if err != nil {
+			return nil, err
+		}
+		rows := make([]upfImportRow, 0, len(records))
+		for _, record := range records {
+			upf, err := upfFromCsvRecord(record)
+			rows = append(rows, upfImportRow{upf: upf, err: err})
+		}
+		return rows, nil
+	}
+	items, err := decodeInventoryDocument(format, body)
+	if err != nil {
+		return nil, err
+	}
+	rows := make([]upfImportRow, 0, len(items))
+	for _, item := range items {
+		var postUpfParams configmodels.PostUpfRequest
+		err = decodeInventoryItem(item, &postUpfParams)
+		rows = append(rows, upfImportRow{upf: configmodels.Upf(postUpfParams), err: err})
+	}
+	return rows, nil
+}
+
+func upfFromCsvRecord(record map[string]string) (configmodels.Upf, error) {
+	upf := configmodels.Upf{
+		Hostname:  record["hostname"],
+		Port:      record["port"],
+		N4Address: record["n4-address"],
+	}
+	for _, n3Interface := range splitCsvList(record["n3-interfaces"], ";") {
+		name, address, err := splitCsvPair(n3Interface)
+		if err != nil {
+			return upf, fmt.Errorf("invalid N3 interface: %w", err)
+		}
+		upf.N3Interfaces = append(upf.N3Interfaces, configmodels.UpfN3Interface{Name: name, Address: address})
+	}
+	for _, upfDnn := range splitCsvList(record["dnns"], ";") {
+		dnn, pools, err := splitCsvPair(upfDnn)
+		if err != nil {
+			return upf, fmt.Errorf("invalid DNN: %w", err)
+		}
+		upf.Dnns = append(upf.Dnns, configmodels.UpfDnn{Dnn: dnn, UeIpPools: splitCsvList(pools, "|")})
+	}
+	if record["max-sessions"] != "" || record["max-throughput-mbps"] != "" {
+		upf.Capacity = &configmodels.UpfCapacity{}
+		if record["max-sessions"] != "" {
+			maxSessions, err := strconv.ParseInt(record["max-sessions"], 10, 32)
+			if err != nil {
+				return upf, fmt.Errorf("invalid max sessions '%s'. Max sessions must be an integer", record["max-sessions"])
+			}
+			upf.Capacity.MaxSessions = int32(maxSessions)
+		}
+		if record["max-throughput-mbps"] != "" {
+			maxThroughput, err := strconv.ParseInt(record["max-throughput-mbps"], 10, 64)
+			if err != nil {
+				return upf, fmt.Errorf("invalid max throughput '%s'. Max throughput must be an integer", record["max-throughput-mbps"])
+			}
+			upf.Capacity.MaxThroughputMbps = maxThroughput
+		}
+	}
+	return upf, nil
+}
+
+func upfToCsvRecord(upf configmodels.Upf) []string {
+	n3Interfaces := make([]string, 0, len(upf.N3Interfaces))
+	for _, n3Interface := range upf.N3Interfaces {
+		n3Interfaces = append(n3Interfaces, n3Interface.Name+"="+n3Interface.Address)
+	}
+	dnns := make([]string, 0, len(upf.Dnns))
+	for _, upfDnn := range upf.Dnns {
+		dnns = append(dnns, upfDnn.Dnn+"="+strings.Join(upfDnn.UeIpPools, "|"))
+	}
+	var maxSessions, maxThroughput string
+	if upf.Capacity != nil {
+		if upf.Capacity.MaxSessions != 0 {
+			maxSessions = strconv.FormatInt(int64(upf.Capacity.MaxSessions), 10)
+		}
+		if upf.Capacity.MaxThroughputMbps != 0 {
+			maxThroughput = strconv.FormatInt(upf.Capacity.MaxThroughputMbps, 10)
+		}
+	}
+	return []string{upf.Hostname, upf.Port, upf.N4Address, strings.Join(n3Interfaces, ";"), strings.Join(dnns, ";"), maxSessions, maxThroughput}
+}
+
+// encodeInventory encodes the inventory items in the format of an export
+func encodeInventory(format string, items any, header []string, records [][]string) ([]byte, error) {
+	switch format {
+	case inventoryFormatCsv:
+		var buffer bytes.Buffer
+		writer := csv.NewWriter(&buffer)
+		if err := writer.Write(header); err != nil {
+			return nil, err
+		}
+		if err := writer.WriteAll(records); err != nil {
+			return nil, err
+		}
+		return buffer.Bytes(), nil
+	case inventoryFormatYaml:
+		// going through JSON keeps the field names of the API
+		encodedItems, err := json.Marshal(items)
+		if err != nil {
+			return nil, err
+		}
+		var genericItems []any
+		if err = json.Unmarshal(encodedItems, &genericItems); err != nil {
+			return nil, err
+		}
+		return yaml.Marshal(genericItems)
+	default:
+		return json.Marshal(items)
+	}
+}
+
+// validateGnbImport validates the imported gNBs against each other and against
+// the inventory. It returns the result of each row and the gNBs to write when
+// all rows are valid.
+func validateGnbImport(rows []gnbImportRow) ([]configmodels.InventoryImportResult, []configmodels.Gnb, error) {
+	rawGnbs, err := dbadapter.CommonDBClient.RestfulAPIGetMany(configmodels.GnbDataColl, bson.M{})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to fetch gNBs: %w", err)
+	}
+	inventory := make(map[string]configmodels.Gnb, len(rawGnbs))
+	for _, rawGnb := range rawGnbs {
+		var gnb configmodels.Gnb
+		if err = json.Unmarshal(configmodels.MapToByte(rawGnb), &gnb); err != nil {
+			logger.DbLog.Warnf("could not unmarshal gNB %s: %+v", rawGnb, err)
+			continue
+		}
+		inventory[gnb.Name] = gnb
+	}
+	results := make([]configmodels.InventoryImportResult, len(rows))
+	imported := make(map[string]configmodels.Gnb, len(rows))
+	for i, row := range rows {
+		results[i] = configmodels.InventoryImportResult{Row: i + 1, Name: row.gnb.Name}
+		if err = validateImportedGnb(row, inventory, imported); err != nil {
+			results[i].Status = configmodels.InventoryImportInvalid
+			results[i].Error = err.Error()
+			continue
+		}
+		results[i].Status = configmodels.InventoryImportCreated
+		if _, exists := inventory[row.gnb.Name]; exists {
+			results[i].Status = configmodels.InventoryImportUpdated
+		}
+		imported[row.gnb.Name] = row.gnb
+	}
+	// TACs are compared once the imported gNBs replaced the existing ones
+	maps.Copy(inventory, imported)
+	others := slices.SortedFunc(maps.Values(inventory), func(a, b configmodels.Gnb) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+	for i, row := range rows {
+		if results[i].Status == configmodels.InventoryImportInvalid {
+			continue
+		}
+		if conflictingGnb, plmn := gnbTacConflict(row.gnb, others); conflictingGnb != "" {
+			results[i].Status = configmodels.InventoryImportInvalid
+			results[i].Error = fmt.Sprintf("TAC %d is already used by gNB %s in PLMN %s%s", *row.gnb.Tac, conflictingGnb, plmn.Mcc, plmn.Mnc)
+		}
+	}
+	if slices.ContainsFunc(results, isInvalidImportResult) {
+		return results, nil, nil
+	}
+	gnbs := make([]configmodels.Gnb, 0, len(rows))
+	for _, row := range rows {
+		gnbs = append(gnbs, row.gnb)
+	}
+	return results, gnbs, nil
+}
+
+func validateImportedGnb(row gnbImportRow, inventory, imported map[string]configmodels.Gnb) error {
+	if row.err != nil {
+		return row.err
+	}
+	gnb := row.gnb
+	if !isValidName(gnb.Name) {
+		return fmt.Errorf("invalid gNB name '%s'. Name needs to match the following regular expression: %s", gnb.Name, NAME_PATTERN)
+	}
+	if _, exists := imported[gnb.Name]; exists {
+		return fmt.Errorf("duplicate gNB %s", gnb.Name)
+	}
+	if gnb.Tac == nil {
+		if _, exists := inventory[gnb.Name]; exists {
+			return fmt.Errorf("TAC is required to update gNB %s", gnb.Name)
+		}
+	} else if !isValidGnbTac(*gnb.Tac) {
+		return fmt.Errorf("invalid gNB TAC '%+v'. TAC must be an integer within the range [1, 16777215]", *gnb.Tac)
+	}
+	return validateGnb(gnb)
+}
+
+// validateUpfImport validates the imported UPFs. It returns the result of each
+// row and the UPFs to write when all rows are valid.
+func validateUpfImport(rows []upfImportRow) ([]configmodels.InventoryImportResult, []configmodels.Upf, error) {
+	inventory, err := GetUpfInventory()
+	if err != nil {
+		return nil, nil, err
+	}
+	results := make([]configmodels.InventoryImportResult, len(rows))
+	imported := make(map[string]struct{}, len(rows))
+	for i, row := range rows {
+		results[i] = configmodels.InventoryImportResult{Row: i + 1, Name: row.upf.Hostname}
+		if err = validateImportedUpf(row, imported); err != nil {
+			results[i].Status = configmodels.InventoryImportInvalid
+			results[i].Error = err.Error()
+			continue
+		}
+		results[i].Status = configmodels.InventoryImportCreated
+		if _, exists := inventory[row.upf.Hostname]; exists {
+			results[i].Status = configmodels.InventoryImportUpdated
+		}
+		imported[row.upf.Hostname] = struct{}{}
+	}
+	if slices.ContainsFunc(results, isInvalidImportResult) {
+		return results, nil, nil
+	}
+	upfs := make([]configmodels.Upf, 0, len(rows))
+	for _, row := range rows {
+		upfs = append(upfs, row.upf)
+	}
+	return results, upfs, nil
+}
+
+func validateImportedUpf(row upfImportRow, imported map[string]struct{}) error {
+	if row.err != nil {
+		return row.err
+	}
+	upf := row.upf
+	if !isValidFQDN(upf.Hostname) {
+		return fmt.Errorf("invalid UPF hostname '%s'. Hostname needs to represent a valid FQDN", upf.Hostname)
+	}
+	if _, exists := imported[upf.Hostname]; exists {
+		return fmt.Errorf("duplicate UPF %s", upf.Hostname)
+	}
+	if !isValidUpfPort(upf.Port) {
+		return fmt.Errorf("invalid UPF port '%s'. Port must be a numeric string within the range [0, 65535]", upf.Port)
+	}
+	return validateUpf(upf)
+}
+
+func isInvalidImportResult(result configmodels.InventoryImportResult) bool {
+	return result.Status == configmodels.InventoryImportInvalid
+}
+
+// importedSlice is a network slice using imported items, as stored before the
+// import and as updated by it
+type importedSlice struct {
+	slice     configmodels.Slice
+	prevSlice configmodels.Slice
+}
+
+// updateImportedSlices applies updateFunc to the network slices matching the
+// filter. A slice used by several imported items is updated with all of them.
+func updateImportedSlices(sc context.Context, importedSlices map[string]*importedSlice, filter bson.M, updateFunc func(*configmodels.Slice)) error {
+	rawNetworkSlices, err := dbadapter.CommonDBClient.RestfulAPIGetManyWithContext(sc, sliceDataColl, filter)
+	if err != nil {
+		return fmt.Errorf("failed to fetch network slices: %w", err)
+	}
+	for _, rawNetworkSlice := range rawNetworkSlices {
+		sliceName, _ := rawNetworkSlice["slice-name"].(string)
+		imported, ok := importedSlices[sliceName]
+		if !ok {
+			imported = &importedSlice{}
+			// unmarshaled twice so that the update does not share memory with the stored slice
+			for _, networkSlice := range []*configmodels.Slice{&imported.slice, &imported.prevSlice} {
+				if err = json.Unmarshal(configmodels.MapToByte(rawNetworkSlice), networkSlice); err != nil {
+					return fmt.Errorf("error unmarshaling network slice: %w", err)
+				}
+			}
+			importedSlices[sliceName] = imported
+		}
+		updateFunc(&imported.slice)
+	}
+	return nil
+}
+
+// writeImportedSlices writes the updated network slices in the import session
+func writeImportedSlices(sc context.Context, importedSlices map[string]*importedSlice) error {
+	for _, sliceName := range slices.Sorted(maps.Keys(importedSlices)) {
+		filter := bson.M{"slice-name": sliceName}
+		if _, err := dbadapter.CommonDBClient.RestfulAPIPutOneWithContext(sc, sliceDataColl, filter, configmodels.ToBsonM(importedSlices[sliceName].slice)); err != nil {
+			return fmt.Errorf("failed to write network slice %s: %w", sliceName, err)
+		}
+	}
+	return nil
+}
+
+// syncImportedSlices provisions the subscribers of the network slices once the
+// import is committed
+func syncImportedSlices(importedSlices map[string]*importedSlice) error {
+	var errs []error
+	for _, sliceName := range slices.Sorted(maps.Keys(importedSlices)) {
+		imported := importedSlices[sliceName]
+		if _, err := syncNetworkSlice(imported.slice, imported.prevSlice); err != nil {
+			logger.ConfigLog.Errorf("error syncing slice %s: %+v", sliceName, err)
+			errs = append(errs, fmt.Errorf("failed to sync network slice %s: %w", sliceName, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func importGnbs(ctx context.Context, gnbs []configmodels.Gnb) error {
+	importedSlices := map[string]*importedSlice{}
+	err := executeInventoryTransaction(ctx,
+		func() error { return nil },
+		func(sc context.Context) error {
+			for _, gnb := range gnbs {
+				if err := putGnbOperation(sc, gnb); err != nil {
+					return fmt.Errorf("failed to write gNB %s: %w", gnb.Name, err)
+				}
+			}
+			// checked once all the gNBs are written, as the import may move a
+			// TAC from one gNB to another
+			for _, gnb := range gnbs {
+				if err := checkGnbTac(sc, gnb); err != nil {
+					return err
+				}
+			}
+			for _, gnb := range gnbs {
+				if gnb.Tac == nil {
+					continue
+				}
+				filter := bson.M{"site-info.gNodeBs.name": gnb.Name}
+				if err := updateImportedSlices(sc, importedSlices, filter, setGnbInNetworkSlice(gnb)); err != nil {
+					return err
+				}
+			}
+			return writeImportedSlices(sc, importedSlices)
+		})
+	if err != nil {
+		return err
+	}
+	return syncImportedSlices(importedSlices)
+}
+
+func importUpfs(ctx context.Context, upfs []configmodels.Upf) error {
+	importedSlices := map[string]*importedSlice{}
+	err := executeInventoryTransaction(ctx,
+		func() error { return nil },
+		func(sc context.Context) error {
+			for _, upf := range upfs {
+				if err := putUpfOperation(sc, upf); err != nil {
+					return fmt.Errorf("failed to write UPF %s: %w", upf.Hostname, err)
+				}
+			}
+			for _, upf := range upfs {
+				filter := bson.M{"site-info.upf.upf-name": upf.Hostname}
+				if err := updateImportedSlices(sc, importedSlices, filter, setUpfInNetworkSlice(upf)); err != nil {
+					return err
+				}
+			}
+			return writeImportedSlices(sc, importedSlices)
+		})
+	if err != nil {
+		return err
+	}
+	return syncImportedSlices(importedSlices)
+}
@@ -0,0 +1,367 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2026 Canonical Ltd
+
+package configapi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"slices"
+
+	"github.com/gin-gonic/gin"
+	"github.com/omec-project/webconsole/backend/logger"
+	"github.com/omec-project/webconsole/configmodels"
+	"github.com/omec-project/webconsole/dbadapter"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+const maxLabelValueLength = 255
+
+// validateGnb checks the optional identity and location details of a gNB.
+// The name and TAC are validated by the handlers.
+func validateGnb(gnb configmodels.Gnb) error {
+	if gnb.GnbId != nil && !isValidGnbId(*gnb.GnbId) {
+		return fmt.Errorf("invalid gNB ID %d with bit length %d for gNB %s. Bit length must be within the range [22, 32] and the ID must fit in it", gnb.GnbId.Value, gnb.GnbId.BitLength, gnb.Name)
+	}
+	for _, plmn := range gnb.Plmns {
+		if !isValidMcc(plmn.Mcc) || !isValidMnc(plmn.Mnc) {
+			return fmt.Errorf("invalid PLMN %s%s for gNB %s. MCC must be 3 digits and MNC 2 or 3 digits", plmn.Mcc, plmn.Mnc, gnb.Name)
+		}
+	}
+	if gnb.N2Address != "" && net.ParseIP(gnb.N2Address) == nil {
+		return fmt.Errorf("invalid N2 address '%s' for gNB %s. N2 address must be an IP address", gnb.N2Address, gnb.Name)
+	}
+	for key, value := range gnb.Labels {
+		if !isValidLabelKey(key) {
+			return fmt.Errorf("invalid label '%s' for gNB %s. Label needs to match the following regular expression: %s", key, gnb.Name, LABEL_KEY_PATTERN)
+		}
+		if len(value) > maxLabelValueLength {
+			return fmt.Errorf("invalid value for label '%s' of gNB %s. Value must be at most %d characters", key, gnb.Name, maxLabelValueLength)
+		}
+	}
+	return nil
+}
+
+// validateUpf checks the optional N4, N3, DNN and capacity details of a UPF.
+// The hostname and port are validated by the handlers.
+func validateUpf(upf configmodels.Upf) error {
+	if upf.N4Address != "" && net.ParseIP(upf.N4Address) == nil {
+		return fmt.Errorf("invalid N4 address '%s' for UPF %s. N4 address must be an IP address", upf.N4Address, upf.Hostname)
+	}
+	interfaceNames := make(map[string]struct{}, len(upf.N3Interfaces))
+	for _, n3Interface := range upf.N3Interfaces {
+		if !isValidName(n3Interface.Name) {
+			return fmt.Errorf("invalid N3 interface name '%s' for UPF %s. Name needs to match the following regular expression: %s", n3Interface.Name, upf.Hostname, NAME_PATTERN)
+		}
+		if _, exists := interfaceNames[n3Interface.Name]; exists {
+			return fmt.Errorf("duplicate N3 interface %s for UPF %s", n3Interface.Name, upf.Hostname)
+		}
+		interfaceNames[n3Interface.Name] = struct{}{}
+		if net.ParseIP(n3Interface.Address) == nil {
+			return fmt.Errorf("invalid address '%s' for N3 interface %s of UPF %s. Address must be an IP address", n3Interface.Address, n3Interface.Name, upf.Hostname)
+		}
+	}
+	dnnNames := make(map[string]struct{}, len(upf.Dnns))
+	var pools []*net.IPNet
+	for _, upfDnn := range upf.Dnns {
+		if _, exists := dnnNames[upfDnn.Dnn]; exists {
+			return fmt.Errorf("duplicate DNN %s for UPF %s", upfDnn.Dnn, upf.Hostname)
+		}
+		dnnNames[upfDnn.Dnn] = struct{}{}
+		dnn, err := getDnnByName(upfDnn.Dnn)
+		if err != nil {
+			return fmt.Errorf("failed to retrieve DNN %s: %w", upfDnn.Dnn, err)
+		}
+		if dnn == nil {
+			return fmt.Errorf("DNN %s not found", upfDnn.Dnn)
+		}
+		if len(upfDnn.UeIpPools) == 0 {
+			return fmt.Errorf("DNN %s of UPF %s has no UE IP pool", upfDnn.Dnn, upf.Hostname)
+		}
+		for _, pool := range upfDnn.UeIpPools {
+			if !isValidIpPool(pool) {
+				return fmt.Errorf("invalid UE IP pool '%s' for DNN %s of UPF %s. Pool must be an IP network", pool, upfDnn.Dnn, upf.Hostname)
+			}
+			_, network, _ := net.ParseCIDR(pool)
+			for _, other := range pools {
+				if other.Contains(network.IP) || network.Contains(other.IP) {
+					return fmt.Errorf("UE IP pool %s of DNN %s overlaps %s on UPF %s", pool, upfDnn.Dnn, other, upf.Hostname)
+				}
+			}
+			pools = append(pools, network)
+		}
+	}
+	if upf.Capacity != nil && (upf.Capacity.MaxSessions < 0 || upf.Capacity.MaxThroughputMbps < 0) {
+		return fmt.Errorf("invalid capacity for UPF %s. Capacity must not be negative", upf.Hostname)
+	}
+	return nil
+}
+
+// gnbTacConflictError reports a TAC already used by another gNB in a PLMN
+type gnbTacConflictError struct {
+	gnb            string
+	tac            int32
+	conflictingGnb string
+	plmn           configmodels.SliceSiteInfoPlmn
+}
+
+func (e *gnbTacConflictError) Error() string {
+	return fmt.Sprintf("TAC %d is already used by gNB %s in PLMN %s%s", e.tac, e.conflictingGnb, e.plmn.Mcc, e.plmn.Mnc)
+}
+
+// withGnbTacCheck runs the gNB operation after checking, in the same
+// transaction, that the TAC of the gNB is unique in its PLMNs
+func withGnbTacCheck(gnbOperation func(context.Context, configmodels.Gnb) error) func(context.Context, configmodels.Gnb) error {
+	return func(sc context.Context, gnb configmodels.Gnb) error {
+		if err := checkGnbTac(sc, gnb); err != nil {
+			return err
+		}
+		return gnbOperation(sc, gnb)
+	}
+}
+
+// checkGnbTac returns a gnbTacConflictError when another gNB uses the TAC of
+// the gNB in one of its PLMNs
+func checkGnbTac(ctx context.Context, gnb configmodels.Gnb) error {
+	conflictingGnb, plmn, err := findGnbTacConflict(ctx, gnb)
+	if err != nil {
+		return err
+	}
+	if conflictingGnb != "" {
+		return &gnbTacConflictError{gnb: gnb.Name, tac: *gnb.Tac, conflictingGnb: conflictingGnb, plmn: plmn}
+	}
+	return nil
+}
+
+// findGnbTacConflict returns the name of another gNB using the TAC of the gNB
+// in one of its PLMNs, and that PLMN. The PLMNs of a gNB without served PLMNs
+// are the ones of its sites and network slices.
+func findGnbTacConflict(ctx context.Context, gnb configmodels.Gnb) (string, configmodels.SliceSiteInfoPlmn, error) {
+	if gnb.Tac == nil {
+		return "", configmodels.SliceSiteInfoPlmn{}, nil
+	}
+	plmns, err := getGnbPlmns(ctx, gnb)
+	if err != nil || len(plmns) == 0 {
+		return "", configmodels.SliceSiteInfoPlmn{}, err
+	}
+	gnb.Plmns = plmns
+	rawGnbs, err := dbadapter.CommonDBClient.RestfulAPIGetManyWithContext(ctx, configmodels.GnbDataColl, bson.M{"tac": *gnb.Tac})
+	if err != nil {
+		return "", configmodels.SliceSiteInfoPlmn{}, fmt.Errorf("failed to fetch gNBs: %w", err)
+	}
+	existingGnbs := make([]configmodels.Gnb, 0, len(rawGnbs))
+	for _, rawGnb := range rawGnbs {
+		var existingGnb configmodels.Gnb
+		if err = json.Unmarshal(configmodels.MapToByte(rawGnb), &existingGnb); err != nil {
+			logger.DbLog.Warnf("could not unmarshal gNB %s: %+v", rawGnb, err)
+			continue
+		}
+		if existingGnb.Name == gnb.Name {
+			continue
+		}
+		if existingGnb.Plmns, err = getGnbPlmns(ctx, existingGnb); err != nil {
+			return "", configmodels.SliceSiteInfoPlmn{}, err
+		}
+		existingGnbs = append(existingGnbs, existingGnb)
+	}
+	conflictingGnb, plmn := gnbTacConflict(gnb, existingGnbs)
+	return conflictingGnb, plmn, nil
+}
+
+// getGnbPlmns returns the served PLMNs of the gNB or, when it has none, the
+// PLMNs of the sites and network slices using it
+func getGnbPlmns(ctx context.Context, gnb configmodels.Gnb) ([]configmodels.SliceSiteInfoPlmn, error) {
+	if len(gnb.Plmns) > 0 {
+		return gnb.Plmns, nil
+	}
+	rawSites, err := dbadapter.CommonDBClient.RestfulAPIGetManyWithContext(ctx, configmodels.SiteDataColl, bson.M{"gNodeBs": gnb.Name})
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch sites: %w", err)
+	}
+	rawSlices, err := dbadapter.CommonDBClient.RestfulAPIGetManyWithContext(ctx, sliceDataColl, bson.M{"site-info.gNodeBs.name": gnb.Name})
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch network slices: %w", err)
+	}
+	var plmns []configmodels.SliceSiteInfoPlmn
+	addPlmn := func(plmn configmodels.SliceSiteInfoPlmn) {
+		if plmn != (configmodels.SliceSiteInfoPlmn{}) && !slices.Contains(plmns, plmn) {
+			plmns = append(plmns, plmn)
+		}
+	}
+	for _, rawSite := range rawSites {
+		var site configmodels.Site
+		if err = json.Unmarshal(configmodels.MapToByte(rawSite), &site); err != nil {
+			return nil, fmt.Errorf("error unmarshaling site: %w", err)
+		}
+		addPlmn(site.Plmn)
+	}
+	for _, rawSlice := range rawSlices {
+		var slice configmodels.Slice
+		if err = json.Unmarshal(configmodels.MapToByte(rawSlice), &slice); err != nil {
+			return nil, fmt.Errorf("error unmarshaling network slice: %w", err)
+		}
+		addPlmn(slice.SiteInfo.Plmn)
+	}
+	return plmns, nil
+}
+
+// gnbTacConflict returns the first of the other gNBs using the TAC of the gNB
+// in one of its served PLMNs, and that PLMN
+func gnbTacConflict(gnb configmodels.Gnb, others []configmodels.Gnb) (string, configmodels.SliceSiteInfoPlmn) {
+	if gnb.Tac == nil {
+		return "", configmodels.SliceSiteInfoPlmn{}
+	}
+	for _, other := range others {
+		if other.Name == gnb.Name || other.Tac == nil || *other.Tac != *gnb.Tac {
+			continue
+		}
+		for _, plmn := range gnb.Plmns {
+			if slices.Contains(other.Plmns, plmn) {
+				return other.Name, plmn
+			}
+		}
+	}
+	return "", configmodels.SliceSiteInfoPlmn{}
+}
+
+// inUseReference selects the documents of a collection using an item. Their
+// names are listed under key in the 409 Conflict body.
+type inUseReference struct {
+	key        string
+	collection string
+	filter     bson.M
+	nameField  string
+}
+
+func sliceReference(filter bson.M) inUseReference {
+	return inUseReference{key: "network-slices", collection: sliceDataColl, filter: filter, nameField: "slice-name"}
+}
+
+func siteReference(filter bson.M) inUseReference {
+	return inUseReference{key: "sites", collection: configmodels.SiteDataColl, filter: filter, nameField: "site-name"}
+}
+
+func deviceGroupReference(filter bson.M) inUseReference {
+	return inUseReference{key: "device-groups", collection: devGroupDataColl, filter: filter, nameField: "group-name"}
+}
+
+// inUseError reports the documents using an item to delete, by the key of
+// their reference
+type inUseError struct {
+	item       string
+	references map[string][]string
+}
+
+func (e *inUseError) Error() string {
+	return fmt.Sprintf("%s is in use", e.item)
+}
+
+// body returns the response body of the 409 Conflict reporting the error
+func (e *inUseError) body() gin.H {
+	body := gin.H{"error": e.Error()}
+	for key, names := range e.references {
+		body[key] = names
+	}
+	return body
+}
+
+// checkNotInUse returns an inUseError when documents match one of the
+// references. Called with the context of the delete transaction, the check
+// sees the writes of the transaction.
+func checkNotInUse(ctx context.Context, item string, references ...inUseReference) error {
+	inUse := &inUseError{item: item, references: map[string][]string{}}
+	found := false
+	for _, reference := range references {
+		names, err := getNamesWithContext(ctx, reference.collection, reference.filter, reference.nameField)
+		if err != nil {
+			return fmt.Errorf("failed to check the %s using %s: %w", reference.key, item, err)
+		}
+		inUse.references[reference.key] = names
+		found = found || len(names) > 0
+	}
+	if found {
+		return inUse
+	}
+	return nil
+}
+
+// deleteUnusedItem deletes the document matching the filter unless documents
+// match one of the references, checking them in the delete transaction
+func deleteUnusedItem(ctx context.Context, item string, collection string, filter bson.M, references ...inUseReference) error {
+	return executeInventoryTransaction(ctx,
+		func() error { return nil },
+		func(sc context.Context) error {
+			if err := checkNotInUse(sc, item, references...); err != nil {
+				return err
+			}
+			return dbadapter.CommonDBClient.RestfulAPIDeleteOneWithContext(sc, collection, filter)
+		})
+}
+
+func getNamesWithContext(ctx context.Context, collName string, filter bson.M, nameField string) ([]string, error) {
+	rawDocuments, err := dbadapter.CommonDBClient.RestfulAPIGetManyWithContext(ctx, collName, filter)
+	if err != nil {
+		return nil, err
+	}
+	names := []string{}
+	for _, rawDocument := range rawDocuments {
+		if name, ok := rawDocument[nameField].(string); ok {
+			names = append(names, name)
+		}
+	}
+	slices.Sort(names)
+	return names, nil
+}
+
+func getGnbByName(name string) (*configmodels.Gnb, error) {
+	rawGnb, err := dbadapter.CommonDBClient.RestfulAPIGetOne(configmodels.GnbDataColl, bson.M{"name": name})
+	if err != nil {
+		return nil, err
+	}
+	if len(rawGnb) == 0 {
+		return nil, nil
+	}
+	var gnb configmodels.Gnb
+	if err = json.Unmarshal(configmodels.MapToByte(rawGnb), &gnb); err != nil {
+		return nil, fmt.Errorf("could not unmarshal gNB %s: %w", name, err)
+	}
+	return &gnb, nil
+}
+
+func getUpfByHostname(hostname string) (*configmodels.Upf, error) {
+	rawUpf, err := dbadapter.CommonDBClient.RestfulAPIGetOne(configmodels.UpfDataColl, bson.M{"hostname": hostname})
+	if err != nil {
+		return nil, err
+	}
+	if len(rawUpf) == 0 {
+		return nil, nil
+	}
+	var upf configmodels.Upf
+	if err = json.Unmarshal(configmodels.MapToByte(rawUpf), &upf); err != nil {
+		return nil, fmt.Errorf("could not unmarshal UPF %s: %w", hostname, err)
+	}
+	return &upf, nil
+}
+
+// GetUpfInventory returns the UPFs of the inventory indexed by hostname
+func GetUpfInventory() (map[string]configmodels.Upf, error) {
+	rawUpfs, err := dbadapter.CommonDBClient.RestfulAPIGetMany(configmodels.UpfDataColl, bson.M{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch UPFs: %w", err)
+	}
+	inventory := make(map[string]configmodels.Upf, len(rawUpfs))
+	for _, rawUpf := range rawUpfs {
+		var upf configmodels.Upf
+		if err = json.Unmarshal(configmodels.MapToByte(rawUpf), &upf); err != nil {
+			logger.DbLog.Warnf("could not unmarshal UPF %s: %+v", rawUpf, err)
+			continue
+		}
+		if upf.Hostname == "" {
+			continue
+		}
+		inventory[upf.Hostname] = upf
+	}
+	return inventory, nil
+}
@@ -0,0 +1,174 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2026 Canonical Ltd
+
+package configapi
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+
+	"github.com/omec-project/webconsole/backend/logger"
+	"github.com/omec-project/webconsole/configmodels"
+	"github.com/omec-project/webconsole/dbadapter"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+func validateQosProfile(qosProfile configmodels.QosProfile) error {
+	if !isValidName(qosProfile.Name) {
+		return fmt.Errorf("invalid QoS profile name '%s'. Name needs to match the following regular expression: %s", qosProfile.Name, NAME_PATTERN)
+	}
+	if qosProfile.DnnMbrUplink < 0 || qosProfile.DnnMbrDownlink < 0 {
+		return fmt.Errorf("invalid QoS profile %s. MBR must not be negative", qosProfile.Name)
+	}
+	if (qosProfile.DnnMbrUplink != 0 || qosProfile.DnnMbrDownlink != 0) && !isValidBitrateUnit(qosProfile.BitrateUnit) {
+		return fmt.Errorf("invalid QoS profile %s. Invalid bitrate unit '%s'", qosProfile.Name, qosProfile.BitrateUnit)
+	}
+	if qosProfile.TrafficClass == nil && qosProfile.TrafficClassName == "" {
+		return fmt.Errorf("invalid QoS profile %s. Traffic class or traffic class name must be provided", qosProfile.Name)
+	}
+	if qosProfile.TrafficClass != nil && qosProfile.TrafficClassName == "" {
+		if err := validateTrafficClass(*qosProfile.TrafficClass); err != nil {
+			return fmt.Errorf("invalid QoS profile %s: %w", qosProfile.Name, err)
+		}
+	}
+	if err := validateTrafficClassReference(qosProfile.TrafficClassName, qosProfile.TrafficClass); err != nil {
+		return fmt.Errorf("invalid QoS profile %s: %w", qosProfile.Name, err)
+	}
+	return nil
+}
+
+// validateQosProfileReference checks that a QoS profile referenced by name
+// exists in the catalog and is not combined with an embedded DNN QoS
+func validateQosProfileReference(name string, embedded *configmodels.DeviceGroupsIpDomainExpandedUeDnnQos) error {
+	if name == "" {
+		return nil
+	}
+	if embedded != nil {
+		return fmt.Errorf("QoS profile name %s and ue-dnn-qos cannot be set together", name)
+	}
+	qosProfile, err := getQosProfileByName(name)
+	if err != nil {
+		return fmt.Errorf("failed to retrieve QoS profile %s: %w", name, err)
+	}
+	if qosProfile == nil {
+		return fmt.Errorf("QoS profile %s not found", name)
+	}
+	return nil
+}
+
+func getQosProfileByName(name string) (*configmodels.QosProfile, error) {
+	rawQosProfile, err := dbadapter.CommonDBClient.RestfulAPIGetOne(configmodels.QosProfileDataColl, bson.M{"name": name})
+	if err != nil {
+		return nil, err
+	}
+	if len(rawQosProfile) == 0 {
+		return nil, nil
+	}
+	var qosProfile configmodels.QosProfile
+	if err = json.Unmarshal(configmodels.MapToByte(rawQosProfile), &qosProfile); err != nil {
+		return nil, fmt.Errorf("could not unmarshal QoS profile %s: %w", name, err)
+	}
+	return &qosProfile, nil
+}
+
+// GetQosProfileCatalog returns the QoS profiles of the catalog indexed by name
+func GetQosProfileCatalog() (map[string]configmodels.QosProfile, error) {
+	rawQosProfiles, err := dbadapter.CommonDBClient.RestfulAPIGetMany(configmodels.QosProfileDataColl, bson.M{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch QoS profiles: %w", err)
+	}
+	catalog := make(map[string]configmodels.QosProfile, len(rawQosProfiles))
+	for _, rawQosProfile := range rawQosProfiles {
+		var qosProfile configmodels.QosProfile
+		if err = json.Unmarshal(configmodels.MapToByte(rawQosProfile), &qosProfile); err != nil {
+			logger.DbLog.Warnf("could not unmarshal QoS profile %s: %+v", rawQosProfile, err)
+			continue
+		}
+		if qosProfile.Name == "" {
+			continue
+		}
+		catalog[qosProfile.Name] = qosProfile
+	}
+	return catalog, nil
+}
+
+// getQosProfilesUsingTrafficClass returns the QoS profiles referencing the traffic class
+func getQosProfilesUsingTrafficClass(name string) ([]string, error) {
+	rawQosProfiles, err := dbadapter.CommonDBClient.RestfulAPIGetMany(configmodels.QosProfileDataColl, bson.M{"traffic-class-name": name})
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch QoS profiles: %w", err)
+	}
+	profileNames := []string{}
+	for _, rawQosProfile := range rawQosProfiles {
+		if profileName, ok := rawQosProfile["name"].(string); ok {
+			profileNames = append(profileNames, profileName)
+		}
+	}
+	slices.Sort(profileNames)
+	return profileNames, nil
+}
+
+// provisionDeviceGroupsUsingQosProfile provisions again the subscribers of the
+// device groups referencing the QoS profile, with the QoS of their network slice
+func provisionDeviceGroupsUsingQosProfile(name string) error {
+	logger.ConfigLog.Infof("provisioning the device groups using QoS profile %s", name)
+	return provisionDeviceGroupsMatching(bson.M{"ip-domains.qos-profile-name": name})
+}
+
+// ResolveDeviceGroupQosProfiles sets the DNN QoS of the IP domains referencing a
+// QoS profile of the catalog, with the bitrates converted to bps. IP domains
+// referencing an unknown QoS profile are left without QoS and reported in the
+// returned error.
+func ResolveDeviceGroupQosProfiles(deviceGroup *configmodels.DeviceGroups, catalog map[string]configmodels.QosProfile) error {
+	var errs []error
+	ipDomains := slices.Clone(deviceGroup.IpDomainsExpanded)
+	for i := range ipDomains {
+		if ipDomains[i].QosProfileName == "" {
+			continue
+		}
+		qosProfile, ok := catalog[ipDomains[i].QosProfileName]
+		if !ok {
+			errs = append(errs, fmt.Errorf("DNN %s: QoS profile %s not found", ipDomains[i].Dnn, ipDomains[i].QosProfileName))
+			ipDomains[i].UeDnnQos = nil
+			continue
+		}
+		ipDomains[i].UeDnnQos = qosProfileToUeDnnQos(qosProfile)
+	}
+	deviceGroup.IpDomainsExpanded = ipDomains
+	return errors.Join(errs...)
+}
+
+func qosProfileToUeDnnQos(qosProfile configmodels.QosProfile) *configmodels.DeviceGroupsIpDomainExpandedUeDnnQos {
+	uplink := convertToBps(qosProfile.DnnMbrUplink, qosProfile.BitrateUnit)
+	if uplink < 0 {
+		uplink = math.MaxInt64
+	}
+	downlink := convertToBps(qosProfile.DnnMbrDownlink, qosProfile.BitrateUnit)
+	if downlink < 0 {
+		downlink = math.MaxInt64
+	}
+	var trafficClass *configmodels.TrafficClassInfo
+	if qosProfile.TrafficClass != nil {
+		tc := *qosProfile.TrafficClass
+		trafficClass = &tc
+	}
+	return &configmodels.DeviceGroupsIpDomainExpandedUeDnnQos{
+		DnnMbrUplink:     uplink,
+		DnnMbrDownlink:   downlink,
+		BitrateUnit:      "bps",
+		TrafficClass:     trafficClass,
+		TrafficClassName: qosProfile.TrafficClassName,
+	}
+}
+
+func deviceGroupReferencesQosProfile(deviceGroup *configmodels.DeviceGroups) bool {
+	for _, ipDomain := range deviceGroup.IpDomainsExpanded {
+		if ipDomain.QosProfileName != "" {
+			return true
+		}
+	}
+	return false
+}
@@ -149,6 +149,12 @@ var routes = Routes{
 		"/inventory/gnb",
 		GetGnbs,
 	},
+	{
+		"GetGnbByName",
+		http.MethodGet,
+		"/inventory/gnb/:gnb-name",
+		GetGnbByName,
+	},
 	{
 		"PostGnb",
 		http.MethodPost,
@@ -167,12 +173,42 @@ var routes = Routes{
 		"/inventory/gnb/:gnb-name",
 		DeleteGnb,
 	},
+	{
+		"ImportGnbs",
+		http.MethodPost,
+		"/inventory/import/gnb",
+		ImportGnbs,
+	},
+	{
+		"ExportGnbs",
+		http.MethodGet,
+		"/inventory/export/gnb",
+		ExportGnbs,
+	},
+	{
+		"GetGnbDiscovery",
+		http.MethodGet,
+		"/inventory/gnb-discovery",
+		GetGnbDiscovery,
+	},
+	{
+		"PostGnbDiscovery",
+		http.MethodPost,
+		"/inventory/gnb-discovery",
+		PostGnbDiscovery,
+	},
 	{
 		"GetUpfs",
 		http.MethodGet,
 		"/inventory/upf",
 		GetUpfs,
 	},
+	{
+		"GetUpfByHostname",
+		http.MethodGet,
+		"/inventory/upf/:upf-hostname",
+		GetUpfByHostname,
+	},
 	{
 		"PostUpf",
 		http.MethodPost,
@@ -191,4 +227,166 @@ var routes = Routes{
 		"/inventory/upf/:upf-hostname",
 		DeleteUpf,
 	},
+	{
+		"ImportUpfs",
+		http.MethodPost,
+		"/inventory/import/upf",
+		ImportUpfs,
+	},
+	{
+		"ExportUpfs",
+		http.MethodGet,
+		"/inventory/export/upf",
+		ExportUpfs,
+	},
+	{
+		"GetTrafficClasses",
+		http.MethodGet,
+		"/traffic-class",
+		GetTrafficClasses,
+	},
+	{
+		"GetTrafficClassByName",
+		http.MethodGet,
+		"/traffic-class/:traffic-class-name",
+		GetTrafficClassByName,
+	},
+	{
+		"PostTrafficClass",
+		http.MethodPost,
+		"/traffic-class",
+		PostTrafficClass,
+	},
+	{
+		"PutTrafficClass",
+		http.MethodPut,
+		"/traffic-class/:traffic-class-name",
+		PutTrafficClass,
+	},
+	{
+		"DeleteTrafficClass",
+		http.MethodDelete,
+		"/traffic-class/:traffic-class-name",
+		DeleteTrafficClass,
+	},
+	{
+		"GetQosProfiles",
+		http.MethodGet,
+		"/qos-profile",
+		GetQosProfiles,
+	},
+	{
+		"GetQosProfileByName",
+		http.MethodGet,
+		"/qos-profile/:qos-profile-name",
+		GetQosProfileByName,
+	},
+	{
+		"PostQosProfile",
+		http.MethodPost,
+		"/qos-profile",
+		PostQosProfile,
+	},
+	{
+		"PutQosProfile",
+		http.MethodPut,
+		"/qos-profile/:qos-profile-name",
+		PutQosProfile,
+	},
+	{
+		"DeleteQosProfile",
+		http.MethodDelete,
+		"/qos-profile/:qos-profile-name",
+		DeleteQosProfile,
+	},
+	{
+		"GetApplications",
+		http.MethodGet,
+		"/application",
+		GetApplications,
+	},
+	{
+		"GetApplicationByName",
+		http.MethodGet,
+		"/application/:app-name",
+		GetApplicationByName,
+	},
+	{
+		"PostApplication",
+		http.MethodPost,
+		"/application",
+		PostApplication,
+	},
+	{
+		"PutApplication",
+		http.MethodPut,
+		"/application/:app-name",
+		PutApplication,
+	},
+	{
+		"DeleteApplication",
+		http.MethodDelete,
+		"/application/:app-name",
+		DeleteApplication,
+	},
+	{
+		"GetDnns",
+		http.MethodGet,
+		"/dnn",
+		GetDnns,
+	},
+	{
+		"GetDnnByName",
+		http.MethodGet,
+		"/dnn/:dnn-name",
+		GetDnnByName,
+	},
+	{
+		"PostDnn",
+		http.MethodPost,
+		"/dnn",
+		PostDnn,
+	},
+	{
+		"PutDnn",
+		http.MethodPut,
+		"/dnn/:dnn-name",
+		PutDnn,
+	},
+	{
+		"DeleteDnn",
+		http.MethodDelete,
+		"/dnn/:dnn-name",
+		DeleteDnn,
+	},
+	{
+		"GetSites",
+		http.MethodGet,
+		"/site",
+		GetSites,
+	},
+	{
+		"GetSiteByName",
+		http.MethodGet,
+		"/site/:site-name",
+		GetSiteByName,
+	},
+	{
+		"PostSite",
+		http.MethodPost,
+		"/site",
+		PostSite,
+	},
+	{
+		"PutSite",
+		http.MethodPut,
+		"/site/:site-name",
+		PutSite,
+	},
+	{
+		"DeleteSite",
+		http.MethodDelete,
+		"/site/:site-name",
+		DeleteSite,
+	},
 }
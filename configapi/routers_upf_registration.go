@@ -0,0 +1,54 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2026 Canonical Ltd
+
+package configapi
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/omec-project/webconsole/backend/auth"
+)
+
+// AddUpfRegistrationService adds the UPF self-registration endpoint, which is
+// authenticated with registration tokens, and the endpoints managing these
+// tokens. The tokens are managed by admins when a JWT secret is given.
+func AddUpfRegistrationService(engine *gin.Engine, jwtSecret []byte, syncChan chan<- struct{}) {
+	group := engine.Group("/config/v1")
+	addRoutes(group, getUpfRegistrationRoutes(jwtSecret, syncChan))
+}
+
+func getUpfRegistrationRoutes(jwtSecret []byte, syncChan chan<- struct{}) Routes {
+	adminOnly := func(handler gin.HandlerFunc) gin.HandlerFunc {
+		if jwtSecret == nil {
+			return handler
+		}
+		return auth.AdminOnly(jwtSecret, handler)
+	}
+	return Routes{
+		{
+			"RegisterUpf",
+			http.MethodPost,
+			"/inventory/upf/register",
+			RegisterUpf(syncChan),
+		},
+		{
+			"GetUpfRegistrationTokens",
+			http.MethodGet,
+			"/inventory/upf-registration-token",
+			adminOnly(GetUpfRegistrationTokens),
+		},
+		{
+			"PostUpfRegistrationToken",
+			http.MethodPost,
+			"/inventory/upf-registration-token",
+			adminOnly(PostUpfRegistrationToken),
+		},
+		{
+			"DeleteUpfRegistrationToken",
+			http.MethodDelete,
+			"/inventory/upf-registration-token/:token-id",
+			adminOnly(DeleteUpfRegistrationToken),
+		},
+	}
+}
@@ -0,0 +1,207 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2026 Canonical Ltd
+
+package configapi
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	_ "time/tzdata" // the runtime image does not ship a zoneinfo database
+
+	"github.com/omec-project/webconsole/configmodels"
+	"github.com/robfig/cron/v3"
+)
+
+var cronParser = cron.NewParser(cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow)
+
+var weekdays = map[string]time.Weekday{
+	"sun": time.Sunday,
+	"mon": time.Monday,
+	"tue": time.Tuesday,
+	"wed": time.Wednesday,
+	"thu": time.Thursday,
+	"fri": time.Friday,
+	"sat": time.Saturday,
+}
+
+type ruleSchedule struct {
+	location *time.Location
+	cron     cron.Schedule
+	duration time.Duration
+	windows  []ruleTimeWindow
+}
+
+type ruleTimeWindow struct {
+	days  map[time.Weekday]struct{}
+	start int
+	end   int
+}
+
+func validateRuleTrigger(rule configmodels.SliceApplicationFilteringRules) error {
+	switch rule.RuleTrigger {
+	case "", configmodels.RuleTriggerAlways:
+		if rule.Schedule != nil {
+			return fmt.Errorf("rule %s has a schedule but its trigger is not `%s`", rule.RuleName, configmodels.RuleTriggerSchedule)
+		}
+		return nil
+	case configmodels.RuleTriggerSchedule:
+		if rule.Schedule == nil {
+			return fmt.Errorf("rule %s is triggered by schedule but has no schedule", rule.RuleName)
+		}
+		if _, err := parseRuleSchedule(*rule.Schedule); err != nil {
+			return fmt.Errorf("invalid schedule for rule %s: %w", rule.RuleName, err)
+		}
+		return nil
+	default:
+		return fmt.Errorf("invalid trigger `%s` for rule %s. Trigger must be `%s` or `%s`", rule.RuleTrigger, rule.RuleName, configmodels.RuleTriggerAlways, configmodels.RuleTriggerSchedule)
+	}
+}
+
+func parseRuleSchedule(schedule configmodels.RuleSchedule) (*ruleSchedule, error) {
+	parsed := &ruleSchedule{location: time.UTC}
+	if schedule.TimeZone != "" {
+		location, err := time.LoadLocation(schedule.TimeZone)
+		if err != nil {
+			return nil, fmt.Errorf("invalid time zone %s: %w", schedule.TimeZone, err)
+		}
+		parsed.location = location
+	}
+	if schedule.Cron == "" && len(schedule.Windows) == 0 {
+		return nil, fmt.Errorf("schedule needs a cron expression or at least one time window")
+	}
+	if schedule.Cron != "" {
+		cronSchedule, err := cronParser.Parse(schedule.Cron)
+		if err != nil {
+			return nil, fmt.Errorf("invalid cron expression %s: %w", schedule.Cron, err)
+		}
+		cronSchedule.(*cron.SpecSchedule).Location = parsed.location
+		parsed.cron = cronSchedule
+		duration, err := time.ParseDuration(schedule.Duration)
+		if err != nil || duration <= 0 {
+			return nil, fmt.Errorf("cron activation needs a positive duration, got `%s`", schedule.Duration)
+		}
+		parsed.duration = duration
+	} else if schedule.Duration != "" {
+		return nil, fmt.Errorf("duration is only allowed together with a cron expression")
+	}
+	for _, window := range schedule.Windows {
+		parsedWindow, err := parseRuleTimeWindow(window)
+		if err != nil {
+			return nil, err
+		}
+		parsed.windows = append(parsed.windows, parsedWindow)
+	}
+	return parsed, nil
+}
+
+func parseRuleTimeWindow(window configmodels.RuleTimeWindow) (ruleTimeWindow, error) {
+	start, err := parseTimeOfDay(window.Start)
+	if err != nil {
+		return ruleTimeWindow{}, fmt.Errorf("invalid window start: %w", err)
+	}
+	end, err := parseTimeOfDay(window.End)
+	if err != nil {
+		return ruleTimeWindow{}, fmt.Errorf("invalid window end: %w", err)
+	}
+	if start == end {
+		return ruleTimeWindow{}, fmt.Errorf("window start and end must differ, got %s", window.Start)
+	}
+	parsed := ruleTimeWindow{start: start, end: end}
+	if len(window.Days) > 0 {
+		parsed.days = make(map[time.Weekday]struct{}, len(window.Days))
+		for _, day := range window.Days {
+			weekday, ok := weekdays[strings.ToLower(day)]
+			if !ok {
+				return ruleTimeWindow{}, fmt.Errorf("invalid day `%s`. Day must be one of sun, mon, tue, wed, thu, fri, sat", day)
+			}
+			parsed.days[weekday] = struct{}{}
+		}
+	}
+	return parsed, nil
+}
+
+// parseTimeOfDay returns the number of minutes since midnight for a HH:MM time
+func parseTimeOfDay(value string) (int, error) {
+	t, err := time.Parse("15:04", value)
+	if err != nil {
+		return 0, fmt.Errorf("time of day `%s` must use the HH:MM format", value)
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
+
+func (w ruleTimeWindow) appliesOn(day time.Weekday) bool {
+	if w.days == nil {
+		return true
+	}
+	_, ok := w.days[day]
+	return ok
+}
+
+func (w ruleTimeWindow) isActive(local time.Time) bool {
+	minute := local.Hour()*60 + local.Minute()
+	if w.start < w.end {
+		return w.appliesOn(local.Weekday()) && minute >= w.start && minute < w.end
+	}
+	// window spans midnight
+	yesterday := local.AddDate(0, 0, -1).Weekday()
+	return (w.appliesOn(local.Weekday()) && minute >= w.start) || (w.appliesOn(yesterday) && minute < w.end)
+}
+
+// nextOccurrence returns the first time after now at which the local clock shows the given minute of the day
+func nextOccurrence(now time.Time, location *time.Location, minuteOfDay int) time.Time {
+	local := now.In(location)
+	candidate := time.Date(local.Year(), local.Month(), local.Day(), minuteOfDay/60, minuteOfDay%60, 0, 0, location)
+	if !candidate.After(now) {
+		candidate = candidate.AddDate(0, 0, 1)
+	}
+	return candidate
+}
+
+func (s *ruleSchedule) evaluate(now time.Time) (bool, time.Time) {
+	active := false
+	var nextBoundary time.Time
+	if s.cron != nil {
+		firstActivation := s.cron.Next(now.Add(-s.duration))
+		if !firstActivation.IsZero() && !firstActivation.After(now) {
+			active = true
+			nextBoundary = EarliestBoundary(nextBoundary, firstActivation.Add(s.duration))
+		}
+		nextBoundary = EarliestBoundary(nextBoundary, s.cron.Next(now))
+	}
+	local := now.In(s.location)
+	for _, window := range s.windows {
+		if window.isActive(local) {
+			active = true
+		}
+		nextBoundary = EarliestBoundary(nextBoundary, nextOccurrence(now, s.location, window.start))
+		nextBoundary = EarliestBoundary(nextBoundary, nextOccurrence(now, s.location, window.end))
+	}
+	return active, nextBoundary
+}
+
+// EarliestBoundary returns the earliest of two boundaries, ignoring unset (zero) ones
+func EarliestBoundary(a, b time.Time) time.Time {
+	if a.IsZero() || (!b.IsZero() && b.Before(a)) {
+		return b
+	}
+	return a
+}
+
+// IsRuleActive reports whether an application filtering rule is active at the given time,
+// together with the next time its activation state may change. Rules that are not triggered
+// by a schedule are always active and have no boundary (zero time).
+func IsRuleActive(rule configmodels.SliceApplicationFilteringRules, now time.Time) (bool, time.Time, error) {
+	if rule.RuleTrigger != configmodels.RuleTriggerSchedule {
+		return true, time.Time{}, nil
+	}
+	if rule.Schedule == nil {
+		return false, time.Time{}, fmt.Errorf("rule %s is triggered by schedule but has no schedule", rule.RuleName)
+	}
+	schedule, err := parseRuleSchedule(*rule.Schedule)
+	if err != nil {
+		return false, time.Time{}, err
+	}
+	active, nextBoundary := schedule.evaluate(now)
+	return active, nextBoundary, nil
+}
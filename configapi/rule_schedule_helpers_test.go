@@ -0,0 +1,216 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2026 Canonical Ltd
+
+package configapi
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/omec-project/webconsole/configmodels"
+)
+
+func scheduledRule(schedule configmodels.RuleSchedule) configmodels.SliceApplicationFilteringRules {
+	return configmodels.SliceApplicationFilteringRules{
+		RuleName:    "scheduled-rule",
+		RuleTrigger: configmodels.RuleTriggerSchedule,
+		Schedule:    &schedule,
+	}
+}
+
+func TestValidateRuleTrigger(t *testing.T) {
+	testCases := []struct {
+		name          string
+		rule          configmodels.SliceApplicationFilteringRules
+		expectedError string
+	}{
+		{
+			name: "rule without trigger is valid",
+			rule: configmodels.SliceApplicationFilteringRules{RuleName: "rule"},
+		},
+		{
+			name: "rule with always trigger is valid",
+			rule: configmodels.SliceApplicationFilteringRules{RuleName: "rule", RuleTrigger: configmodels.RuleTriggerAlways},
+		},
+		{
+			name: "cron schedule with duration is valid",
+			rule: scheduledRule(configmodels.RuleSchedule{Cron: "0 8 * * 1-5", Duration: "2h", TimeZone: "Europe/Paris"}),
+		},
+		{
+			name: "time window schedule is valid",
+			rule: scheduledRule(configmodels.RuleSchedule{Windows: []configmodels.RuleTimeWindow{{Days: []string{"Sat", "sun"}, Start: "22:00", End: "06:00"}}}),
+		},
+		{
+			name:          "unknown trigger is rejected",
+			rule:          configmodels.SliceApplicationFilteringRules{RuleName: "rule", RuleTrigger: "sometimes"},
+			expectedError: "invalid trigger",
+		},
+		{
+			name:          "schedule without schedule trigger is rejected",
+			rule:          configmodels.SliceApplicationFilteringRules{RuleName: "rule", Schedule: &configmodels.RuleSchedule{Cron: "0 8 * * *", Duration: "1h"}},
+			expectedError: "has a schedule but its trigger",
+		},
+		{
+			name:          "schedule trigger without schedule is rejected",
+			rule:          configmodels.SliceApplicationFilteringRules{RuleName: "rule", RuleTrigger: configmodels.RuleTriggerSchedule},
+			expectedError: "has no schedule",
+		},
+		{
+			name:          "empty schedule is rejected",
+			rule:          scheduledRule(configmodels.RuleSchedule{}),
+			expectedError: "needs a cron expression or at least one time window",
+		},
+		{
+			name:          "invalid cron expression is rejected",
+			rule:          scheduledRule(configmodels.RuleSchedule{Cron: "0 25 * * *", Duration: "1h"}),
+			expectedError: "invalid cron expression",
+		},
+		{
+			name:          "cron descriptor is rejected",
+			rule:          scheduledRule(configmodels.RuleSchedule{Cron: "@every 1h", Duration: "1h"}),
+			expectedError: "invalid cron expression",
+		},
+		{
+			name:          "cron without duration is rejected",
+			rule:          scheduledRule(configmodels.RuleSchedule{Cron: "0 8 * * *"}),
+			expectedError: "positive duration",
+		},
+		{
+			name:          "duration without cron is rejected",
+			rule:          scheduledRule(configmodels.RuleSchedule{Duration: "1h", Windows: []configmodels.RuleTimeWindow{{Start: "08:00", End: "09:00"}}}),
+			expectedError: "only allowed together with a cron expression",
+		},
+		{
+			name:          "invalid time zone is rejected",
+			rule:          scheduledRule(configmodels.RuleSchedule{TimeZone: "Mars/Olympus_Mons", Cron: "0 8 * * *", Duration: "1h"}),
+			expectedError: "invalid time zone",
+		},
+		{
+			name:          "invalid window time is rejected",
+			rule:          scheduledRule(configmodels.RuleSchedule{Windows: []configmodels.RuleTimeWindow{{Start: "8am", End: "09:00"}}}),
+			expectedError: "HH:MM",
+		},
+		{
+			name:          "empty window is rejected",
+			rule:          scheduledRule(configmodels.RuleSchedule{Windows: []configmodels.RuleTimeWindow{{Start: "09:00", End: "09:00"}}}),
+			expectedError: "must differ",
+		},
+		{
+			name:          "invalid window day is rejected",
+			rule:          scheduledRule(configmodels.RuleSchedule{Windows: []configmodels.RuleTimeWindow{{Days: []string{"monday"}, Start: "08:00", End: "09:00"}}}),
+			expectedError: "invalid day",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := validateRuleTrigger(tc.rule)
+			if tc.expectedError == "" {
+				if err != nil {
+					t.Errorf("expected no error, got %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tc.expectedError) {
+				t.Errorf("expected error containing `%s`, got %v", tc.expectedError, err)
+			}
+		})
+	}
+}
+
+func TestIsRuleActive(t *testing.T) {
+	paris, err := time.LoadLocation("Europe/Paris")
+	if err != nil {
+		t.Fatalf("failed to load location: %v", err)
+	}
+	// Wednesday
+	wednesday := func(hour, minute int) time.Time {
+		return time.Date(2026, time.January, 7, hour, minute, 0, 0, time.UTC)
+	}
+	testCases := []struct {
+		name             string
+		rule             configmodels.SliceApplicationFilteringRules
+		now              time.Time
+		expectedActive   bool
+		expectedBoundary time.Time
+	}{
+		{
+			name:           "rule without schedule is always active",
+			rule:           configmodels.SliceApplicationFilteringRules{RuleName: "rule"},
+			now:            wednesday(12, 0),
+			expectedActive: true,
+		},
+		{
+			name:             "cron rule before activation",
+			rule:             scheduledRule(configmodels.RuleSchedule{Cron: "0 8 * * *", Duration: "2h"}),
+			now:              wednesday(7, 30),
+			expectedActive:   false,
+			expectedBoundary: wednesday(8, 0),
+		},
+		{
+			name:             "cron rule during activation",
+			rule:             scheduledRule(configmodels.RuleSchedule{Cron: "0 8 * * *", Duration: "2h"}),
+			now:              wednesday(8, 0),
+			expectedActive:   true,
+			expectedBoundary: wednesday(10, 0),
+		},
+		{
+			name:             "cron rule at the end of the activation",
+			rule:             scheduledRule(configmodels.RuleSchedule{Cron: "0 8 * * *", Duration: "2h"}),
+			now:              wednesday(10, 0),
+			expectedActive:   false,
+			expectedBoundary: wednesday(8, 0).AddDate(0, 0, 1),
+		},
+		{
+			name:             "cron rule is evaluated in its time zone",
+			rule:             scheduledRule(configmodels.RuleSchedule{Cron: "0 8 * * *", Duration: "2h", TimeZone: "Europe/Paris"}),
+			now:              wednesday(8, 30),
+			expectedActive:   true,
+			expectedBoundary: time.Date(2026, time.January, 7, 10, 0, 0, 0, paris),
+		},
+		{
+			name:             "window rule inside the window",
+			rule:             scheduledRule(configmodels.RuleSchedule{Windows: []configmodels.RuleTimeWindow{{Days: []string{"wed"}, Start: "09:00", End: "17:00"}}}),
+			now:              wednesday(12, 0),
+			expectedActive:   true,
+			expectedBoundary: wednesday(17, 0),
+		},
+		{
+			name:             "window rule on another day",
+			rule:             scheduledRule(configmodels.RuleSchedule{Windows: []configmodels.RuleTimeWindow{{Days: []string{"tue"}, Start: "09:00", End: "17:00"}}}),
+			now:              wednesday(12, 0),
+			expectedActive:   false,
+			expectedBoundary: wednesday(17, 0),
+		},
+		{
+			name:             "overnight window started the previous day",
+			rule:             scheduledRule(configmodels.RuleSchedule{Windows: []configmodels.RuleTimeWindow{{Days: []string{"tue"}, Start: "22:00", End: "06:00"}}}),
+			now:              wednesday(5, 0),
+			expectedActive:   true,
+			expectedBoundary: wednesday(6, 0),
+		},
+		{
+			name:             "overnight window not started on a day that is not listed",
+			rule:             scheduledRule(configmodels.RuleSchedule{Windows: []configmodels.RuleTimeWindow{{Days: []string{"tue"}, Start: "22:00", End: "06:00"}}}),
+			now:              wednesday(23, 0),
+			expectedActive:   false,
+			expectedBoundary: wednesday(6, 0).AddDate(0, 0, 1),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			active, boundary, err := IsRuleActive(tc.rule, tc.now)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if active != tc.expectedActive {
+				t.Errorf("expected active %v, got %v", tc.expectedActive, active)
+			}
+			if !boundary.Equal(tc.expectedBoundary) {
+				t.Errorf("expected boundary %s, got %s", tc.expectedBoundary, boundary)
+			}
+		})
+	}
+}
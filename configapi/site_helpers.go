@@ -0,0 +1,250 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2026 Canonical Ltd
+
+package configapi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+
+	"github.com/omec-project/webconsole/backend/logger"
+	"github.com/omec-project/webconsole/configmodels"
+	"github.com/omec-project/webconsole/dbadapter"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+func validateSite(site configmodels.Site) error {
+	if !isValidName(site.SiteName) {
+		return fmt.Errorf("invalid site name '%s'. Name needs to match the following regular expression: %s", site.SiteName, NAME_PATTERN)
+	}
+	if !isValidMcc(site.Plmn.Mcc) {
+		return fmt.Errorf("invalid MCC '%s' for site %s. MCC must be 3 digits", site.Plmn.Mcc, site.SiteName)
+	}
+	if !isValidMnc(site.Plmn.Mnc) {
+		return fmt.Errorf("invalid MNC '%s' for site %s. MNC must be 2 or 3 digits", site.Plmn.Mnc, site.SiteName)
+	}
+	for _, gnbName := range site.GNodeBs {
+		gnb, err := getGnbByName(gnbName)
+		if err != nil {
+			return fmt.Errorf("failed to retrieve gNB %s: %w", gnbName, err)
+		}
+		if gnb == nil {
+			return fmt.Errorf("gNB %s not found", gnbName)
+		}
+		if gnb.Tac == nil {
+			return fmt.Errorf("gNB %s has no TAC", gnbName)
+		}
+	}
+	if site.Upf != "" {
+		upf, err := getUpfByHostname(site.Upf)
+		if err != nil {
+			return fmt.Errorf("failed to retrieve UPF %s: %w", site.Upf, err)
+		}
+		if upf == nil {
+			return fmt.Errorf("UPF %s not found", site.Upf)
+		}
+	}
+	return nil
+}
+
+func getSiteByName(name string) (*configmodels.Site, error) {
+	rawSite, err := dbadapter.CommonDBClient.RestfulAPIGetOne(configmodels.SiteDataColl, bson.M{"site-name": name})
+	if err != nil {
+		return nil, err
+	}
+	if len(rawSite) == 0 {
+		return nil, nil
+	}
+	var site configmodels.Site
+	if err = json.Unmarshal(configmodels.MapToByte(rawSite), &site); err != nil {
+		return nil, fmt.Errorf("could not unmarshal site %s: %w", name, err)
+	}
+	return &site, nil
+}
+
+// buildSliceSiteInfo expands the inventory references of the site into the
+// site information stored in the network slices
+func buildSliceSiteInfo(site configmodels.Site) (configmodels.SliceSiteInfo, error) {
+	siteInfo := configmodels.SliceSiteInfo{
+		SiteName: site.SiteName,
+		Plmn:     site.Plmn,
+		GNodeBs:  []configmodels.SliceSiteInfoGNodeBs{},
+	}
+	for _, gnbName := range site.GNodeBs {
+		gnb, err := getGnbByName(gnbName)
+		if err != nil {
+			return siteInfo, fmt.Errorf("failed to retrieve gNB %s: %w", gnbName, err)
+		}
+		if gnb == nil || gnb.Tac == nil {
+			return siteInfo, fmt.Errorf("gNB %s of site %s not found", gnbName, site.SiteName)
+		}
+		siteInfo.GNodeBs = append(siteInfo.GNodeBs, configmodels.SliceSiteInfoGNodeBs{Name: gnb.Name, Tac: *gnb.Tac})
+	}
+	if site.Upf != "" {
+		upf, err := getUpfByHostname(site.Upf)
+		if err != nil {
+			return siteInfo, fmt.Errorf("failed to retrieve UPF %s: %w", site.Upf, err)
+		}
+		if upf == nil {
+			return siteInfo, fmt.Errorf("UPF %s of site %s not found", site.Upf, site.SiteName)
+		}
+		siteInfo.Upf = map[string]any{
+			"upf-name": upf.Hostname,
+			"upf-port": upf.Port,
+		}
+	}
+	return siteInfo, nil
+}
+
+// resolveSliceSite fills the site information of a network slice attached to a
+// site. A site name without a matching site is kept as a plain label. The slice
+// may repeat the PLMN, gNBs or UPF of the site but cannot set different ones.
+func resolveSliceSite(slice *configmodels.Slice) error {
+	if slice.SiteInfo.SiteName == "" {
+		return nil
+	}
+	site, err := getSiteByName(slice.SiteInfo.SiteName)
+	if err != nil {
+		return fmt.Errorf("failed to retrieve site %s: %w", slice.SiteInfo.SiteName, err)
+	}
+	if site == nil {
+		return nil
+	}
+	siteInfo, err := buildSliceSiteInfo(*site)
+	if err != nil {
+		return err
+	}
+	if !sliceSiteInfoMatches(slice.SiteInfo, siteInfo) {
+		return fmt.Errorf("PLMN, gNBs or UPF do not match site %s", site.SiteName)
+	}
+	slice.SiteInfo = siteInfo
+	return nil
+}
+
+func sliceSiteInfoMatches(requested, site configmodels.SliceSiteInfo) bool {
+	if requested.Plmn != (configmodels.SliceSiteInfoPlmn{}) && requested.Plmn != site.Plmn {
+		return false
+	}
+	if len(requested.GNodeBs) > 0 {
+		requestedNames := gnbNames(requested.GNodeBs)
+		siteNames := gnbNames(site.GNodeBs)
+		if !slices.Equal(requestedNames, siteNames) {
+			return false
+		}
+	}
+	if len(requested.Upf) > 0 && requested.Upf["upf-name"] != site.Upf["upf-name"] {
+		return false
+	}
+	return true
+}
+
+// getSliceSiteConflicts returns the network slices with the site name whose
+// PLMN, gNBs or UPF differ from the ones of the site
+func getSliceSiteConflicts(site configmodels.Site) ([]string, error) {
+	siteInfo, err := buildSliceSiteInfo(site)
+	if err != nil {
+		return nil, err
+	}
+	rawSlices, err := dbadapter.CommonDBClient.RestfulAPIGetMany(sliceDataColl, bson.M{"site-info.site-name": site.SiteName})
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch network slices: %w", err)
+	}
+	sliceNames := []string{}
+	for _, rawSlice := range rawSlices {
+		var slice configmodels.Slice
+		if err = json.Unmarshal(configmodels.MapToByte(rawSlice), &slice); err != nil {
+			return nil, fmt.Errorf("error unmarshaling network slice: %w", err)
+		}
+		if !sliceSiteInfoMatches(slice.SiteInfo, siteInfo) {
+			sliceNames = append(sliceNames, slice.SliceName)
+		}
+	}
+	slices.Sort(sliceNames)
+	return sliceNames, nil
+}
+
+func gnbNames(gnbs []configmodels.SliceSiteInfoGNodeBs) []string {
+	names := make([]string, 0, len(gnbs))
+	for _, gnb := range gnbs {
+		names = append(names, gnb.Name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+func updateSiteInNetworkSlices(site configmodels.Site) error {
+	siteInfo, err := buildSliceSiteInfo(site)
+	if err != nil {
+		return err
+	}
+	filterBySite := bson.M{"site-info.site-name": site.SiteName}
+	statusCode, err := updateInventoryInNetworkSlices(filterBySite, func(networkSlice *configmodels.Slice) {
+		networkSlice.SiteInfo = siteInfo
+	})
+	if err != nil {
+		logger.ConfigLog.Errorf("failed to update site in network slices: %+v", err)
+	}
+	logger.ConfigLog.Infof("update site result statusCode: %d", statusCode)
+	return err
+}
+
+// removeGnbFromSites removes the gNB from the sites referencing it
+func removeGnbFromSites(gnbName string) error {
+	return updateSites(bson.M{"gNodeBs": gnbName}, func(site *configmodels.Site) {
+		site.GNodeBs = slices.DeleteFunc(site.GNodeBs, func(name string) bool {
+			return name == gnbName
+		})
+	})
+}
+
+// removeUpfFromSites removes the UPF from the sites referencing it
+func removeUpfFromSites(hostname string) error {
+	return updateSites(bson.M{"upf": hostname}, func(site *configmodels.Site) {
+		site.Upf = ""
+	})
+}
+
+func updateSites(filter bson.M, updateFunc func(*configmodels.Site)) error {
+	rawSites, err := dbadapter.CommonDBClient.RestfulAPIGetMany(configmodels.SiteDataColl, filter)
+	if err != nil {
+		return fmt.Errorf("failed to fetch sites: %w", err)
+	}
+	for _, rawSite := range rawSites {
+		var site configmodels.Site
+		if err = json.Unmarshal(configmodels.MapToByte(rawSite), &site); err != nil {
+			return fmt.Errorf("error unmarshaling site: %w", err)
+		}
+		updateFunc(&site)
+		siteFilter := bson.M{"site-name": site.SiteName}
+		if _, err = dbadapter.CommonDBClient.RestfulAPIPutOne(configmodels.SiteDataColl, siteFilter, configmodels.ToBsonM(site)); err != nil {
+			return fmt.Errorf("failed to update site %s: %w", site.SiteName, err)
+		}
+	}
+	return nil
+}
+
+func postSiteOperation(sc context.Context, site configmodels.Site) error {
+	filter := bson.M{"site-name": site.SiteName}
+	siteDataBson := configmodels.ToBsonM(site)
+	return dbadapter.CommonDBClient.RestfulAPIPostManyWithContext(sc, configmodels.SiteDataColl, filter, []any{siteDataBson})
+}
+
+func putSiteOperation(sc context.Context, site configmodels.Site) error {
+	filter := bson.M{"site-name": site.SiteName}
+	siteDataBson := configmodels.ToBsonM(site)
+	_, err := dbadapter.CommonDBClient.RestfulAPIPutOneWithContext(sc, configmodels.SiteDataColl, filter, siteDataBson)
+	return err
+}
+
+func deleteSiteOperation(sc context.Context, site configmodels.Site) error {
+	filter := bson.M{"site-name": site.SiteName}
+	return dbadapter.CommonDBClient.RestfulAPIDeleteOneWithContext(sc, configmodels.SiteDataColl, filter)
+}
+
+func executeSiteTransaction(ctx context.Context, site configmodels.Site, nsOperation func(configmodels.Site) error, siteOperation func(context.Context, configmodels.Site) error) error {
+	return executeInventoryTransaction(ctx,
+		func() error { return nsOperation(site) },
+		func(sc context.Context) error { return siteOperation(sc, site) })
+}
@@ -44,6 +44,7 @@ func networkSlicePostHelper(c *gin.Context, sliceName string) (int, error) {
 
 	logSliceMetadata(requestSlice)
 	normalizeApplicationFilteringRules(&requestSlice)
+	normalizeSliceQos(&requestSlice)
 	requestSlice.SliceName = sliceName
 	prevSlice := getSliceByName(sliceName)
 
@@ -84,10 +85,36 @@ func parseAndValidateSliceRequest(c *gin.Context, sliceName string) (configmodel
 	}
 
 	for _, ruleConfig := range request.ApplicationFilteringRules {
-		if ruleConfig.TrafficClass == nil {
+		if ruleConfig.TrafficClass == nil && ruleConfig.TrafficClassName == "" {
 			logger.ConfigLog.Errorln("TrafficClass (QCI, ARP) required but not provided, network slice NOT configured in the network")
 			return request, fmt.Errorf("TrafficClass (QCI, ARP) required but not provided, network slice NOT configured in the network")
 		}
+		if err := validateTrafficClassReference(ruleConfig.TrafficClassName, ruleConfig.TrafficClass); err != nil {
+			return request, fmt.Errorf("invalid traffic class for rule %s: %w", ruleConfig.RuleName, err)
+		}
+		if ruleConfig.TrafficClass != nil {
+			if err := validateTrafficClassPreemption(*ruleConfig.TrafficClass); err != nil {
+				return request, fmt.Errorf("invalid traffic class for rule %s: %w", ruleConfig.RuleName, err)
+			}
+		}
+		if err := validateApplicationReference(ruleConfig); err != nil {
+			return request, fmt.Errorf("invalid application for rule %s: %w", ruleConfig.RuleName, err)
+		}
+		if err := validateRuleTrigger(ruleConfig); err != nil {
+			return request, err
+		}
+	}
+
+	if err := validateSliceQos(request.SliceQos); err != nil {
+		return request, fmt.Errorf("invalid slice QoS in Network Slice %s: %w", sliceName, err)
+	}
+
+	if err := validateSliceDefaultPccRule(request.DefaultPccRule); err != nil {
+		return request, fmt.Errorf("invalid default PCC rule in Network Slice %s: %w", sliceName, err)
+	}
+
+	if err := resolveSliceSite(&request); err != nil {
+		return request, fmt.Errorf("invalid site in Network Slice %s: %w", sliceName, err)
 	}
 
 	slices.Sort(request.SiteDeviceGroup)
@@ -96,6 +123,57 @@ func parseAndValidateSliceRequest(c *gin.Context, sliceName string) (configmodel
 	return request, nil
 }
 
+func validateSliceQos(sliceQos *configmodels.SliceQos) error {
+	if sliceQos == nil {
+		return nil
+	}
+	if sliceQos.Uplink < 0 || sliceQos.Downlink < 0 {
+		return fmt.Errorf("slice MBR must not be negative (uplink %d, downlink %d)", sliceQos.Uplink, sliceQos.Downlink)
+	}
+	if (sliceQos.Uplink > 0 || sliceQos.Downlink > 0) && !isValidBitrateUnit(sliceQos.BitrateUnit) {
+		return fmt.Errorf("invalid bitrate unit `%s`. Unit must be one of bps, Kbps, Mbps, Gbps", sliceQos.BitrateUnit)
+	}
+	if sliceQos.TrafficClass != nil {
+		return validateTrafficClass(*sliceQos.TrafficClass)
+	}
+	return nil
+}
+
+func validateSliceDefaultPccRule(defaultPccRule *configmodels.SliceDefaultPccRule) error {
+	if defaultPccRule == nil {
+		return nil
+	}
+	if defaultPccRule.Precedence < 0 || defaultPccRule.Precedence > 255 {
+		return fmt.Errorf("invalid precedence %d. Precedence must be between 0 and 255", defaultPccRule.Precedence)
+	}
+	if defaultPccRule.TrafficClass != nil {
+		return validateTrafficClass(*defaultPccRule.TrafficClass)
+	}
+	return nil
+}
+
+func validateTrafficClass(trafficClass configmodels.TrafficClassInfo) error {
+	if !isValid5Qi(trafficClass.Qci) {
+		return fmt.Errorf("invalid 5QI %d in traffic class. 5QI must be between 1 and 255", trafficClass.Qci)
+	}
+	if !isValidArpPriorityLevel(trafficClass.Arp) {
+		return fmt.Errorf("invalid ARP %d in traffic class. ARP must be between 1 and 15", trafficClass.Arp)
+	}
+	return validateTrafficClassPreemption(trafficClass)
+}
+
+func validateTrafficClassPreemption(trafficClass configmodels.TrafficClassInfo) error {
+	if !isValidPreemptCap(trafficClass.PreemptCap) {
+		return fmt.Errorf("invalid preemption capability `%s`. Preemption capability must be `%s` or `%s`",
+			trafficClass.PreemptCap, configmodels.PreemptCapMayPreempt, configmodels.PreemptCapNotPreempt)
+	}
+	if !isValidPreemptVuln(trafficClass.PreemptVuln) {
+		return fmt.Errorf("invalid preemption vulnerability `%s`. Preemption vulnerability must be `%s` or `%s`",
+			trafficClass.PreemptVuln, configmodels.PreemptVulnPreemptable, configmodels.PreemptVulnNotPreemptable)
+	}
+	return nil
+}
+
 func logSliceMetadata(slice configmodels.Slice) {
 	logger.ConfigLog.Infof("network slice: sst: %s, sd: %s", slice.SliceId.Sst, slice.SliceId.Sd)
 	logger.ConfigLog.Infof("number of device groups %v", len(slice.SiteDeviceGroup))
@@ -130,6 +208,25 @@ func normalizeApplicationFilteringRules(slice *configmodels.Slice) {
 	}
 }
 
+func normalizeSliceQos(slice *configmodels.Slice) {
+	sliceQos := slice.SliceQos
+	if sliceQos == nil {
+		return
+	}
+	sliceQos.Uplink = convertToBps(sliceQos.Uplink, sliceQos.BitrateUnit)
+	if sliceQos.Uplink < 0 {
+		sliceQos.Uplink = math.MaxInt64
+	}
+	sliceQos.Downlink = convertToBps(sliceQos.Downlink, sliceQos.BitrateUnit)
+	if sliceQos.Downlink < 0 {
+		sliceQos.Downlink = math.MaxInt64
+	}
+	logger.ConfigLog.Infof("Normalized slice MBR Uplink: %v, Downlink: %v", sliceQos.Uplink, sliceQos.Downlink)
+	if sliceQos.TrafficClass != nil {
+		logger.ConfigLog.Infof("Slice default traffic class: %v", sliceQos.TrafficClass)
+	}
+}
+
 func convertBitrateToInt32(bitrate int64) int32 {
 	if bitrate < 0 || bitrate > math.MaxInt32 {
 		return math.MaxInt32
@@ -162,7 +259,12 @@ func handleNetworkSlicePost(slice configmodels.Slice, prevSlice configmodels.Sli
 		return http.StatusInternalServerError, err
 	}
 	logger.DbLog.Debugf("succeeded to post slice data for %s", slice.SliceName)
+	return syncNetworkSlice(slice, prevSlice)
+}
 
+// syncNetworkSlice provisions the subscribers of a stored network slice and
+// notifies Pebble of the change
+func syncNetworkSlice(slice configmodels.Slice, prevSlice configmodels.Slice) (int, error) {
 	statusCode, err := syncSubscribersOnSliceCreateOrUpdate(slice, prevSlice)
 	if err != nil {
 		return statusCode, err
@@ -225,7 +327,7 @@ var syncSubscribersOnSliceCreateOrUpdate = func(slice configmodels.Slice, prevSl
 			logger.ConfigLog.Warnln("IPDomainExpanded is nil or empty for dgName:", dgName)
 			continue
 		}
-		_, err := processDeviceGroup(devGroupConfig, snssai, mcc, mnc)
+		_, err := processDeviceGroup(devGroupConfig, snssai, mcc, mnc, slice.SliceQos)
 		if err != nil {
 			return http.StatusInternalServerError, err
 		}
@@ -236,7 +338,12 @@ var syncSubscribersOnSliceCreateOrUpdate = func(slice configmodels.Slice, prevSl
 	return http.StatusOK, nil
 }
 
-func processDeviceGroup(devGroupConfig *configmodels.DeviceGroups, snssai *models.Snssai, mcc, mnc string) (int, error) {
+func processDeviceGroup(devGroupConfig *configmodels.DeviceGroups, snssai *models.Snssai, mcc, mnc string, sliceQos *configmodels.SliceQos) (int, error) {
+	devGroupConfig, err := resolveDeviceGroupForProvisioning(devGroupConfig)
+	if err != nil {
+		logger.ConfigLog.Errorln(err)
+		return http.StatusInternalServerError, err
+	}
 	dnnMap := make(map[string][]configmodels.DeviceGroupsIpDomainExpandedUeDnnQos) // Stores multiple DNNs & their QoS per IMSI
 	for _, ipDomain := range devGroupConfig.IpDomainsExpanded {
 		dnn := ipDomain.Dnn
@@ -258,7 +365,7 @@ func processDeviceGroup(devGroupConfig *configmodels.DeviceGroups, snssai *model
 		allQosProfiles = append(allQosProfiles, qosList...)
 	}
 	// Calculate aggregate QoS once for the entire group
-	aggregatedQoS := aggregateQoS(allQosProfiles)
+	aggregatedQoS := aggregateQoS(allQosProfiles, sliceQos)
 	for i, imsi := range devGroupConfig.Imsis {
 		if subscriberAuthenticationDataGet("imsi-"+imsi) != nil {
 			// Process each IP domain for this IMSI
@@ -430,7 +537,7 @@ func buildSmProvisionedDataDocument(snssai *models.Snssai, dnnMap map[string][]c
 	dnnConfigurations := make(map[string]interface{}, len(dnnMap))
 
 	for dnn, ueDnnQosList := range dnnMap {
-		aggregatedQoS := aggregateQoS(ueDnnQosList)
+		aggregatedQoS := aggregateQoS(ueDnnQosList, nil)
 		if aggregatedQoS.TrafficClass == nil {
 			logger.DbLog.Errorf("TrafficClass is nil for DNN %s, IMSI %s", dnn, imsi)
 			return nil, fmt.Errorf("traffic class missing for DNN %s", dnn)
@@ -622,7 +729,9 @@ func getDeletedDeviceGroupsList(slice, prevSlice configmodels.Slice) []string {
 	return deleted
 }
 
-func aggregateQoS(qosList []configmodels.DeviceGroupsIpDomainExpandedUeDnnQos) configmodels.DeviceGroupsIpDomainExpandedUeDnnQos {
+// aggregateQoS sums the MBRs of the given QoS profiles. When a slice QoS is provided,
+// its MBRs cap the aggregated values and its traffic class is used as default.
+func aggregateQoS(qosList []configmodels.DeviceGroupsIpDomainExpandedUeDnnQos, sliceQos *configmodels.SliceQos) configmodels.DeviceGroupsIpDomainExpandedUeDnnQos {
 	var aggregated configmodels.DeviceGroupsIpDomainExpandedUeDnnQos
 
 	if len(qosList) == 0 {
@@ -671,5 +780,23 @@ func aggregateQoS(qosList []configmodels.DeviceGroupsIpDomainExpandedUeDnnQos) c
 		logger.ConfigLog.Warnf("inconsistent bitrate units detected when aggregating QoS (using %s)", aggregated.BitrateUnit)
 	}
 
+	if sliceQos != nil {
+		capQoSToSliceQos(&aggregated, *sliceQos)
+	}
+
 	return aggregated
 }
+
+func capQoSToSliceQos(aggregated *configmodels.DeviceGroupsIpDomainExpandedUeDnnQos, sliceQos configmodels.SliceQos) {
+	if sliceQos.Uplink > 0 && aggregated.DnnMbrUplink > sliceQos.Uplink {
+		logger.ConfigLog.Infof("capping aggregated MBR uplink %d to slice MBR uplink %d", aggregated.DnnMbrUplink, sliceQos.Uplink)
+		aggregated.DnnMbrUplink = sliceQos.Uplink
+	}
+	if sliceQos.Downlink > 0 && aggregated.DnnMbrDownlink > sliceQos.Downlink {
+		logger.ConfigLog.Infof("capping aggregated MBR downlink %d to slice MBR downlink %d", aggregated.DnnMbrDownlink, sliceQos.Downlink)
+		aggregated.DnnMbrDownlink = sliceQos.Downlink
+	}
+	if aggregated.TrafficClass == nil && sliceQos.TrafficClass != nil {
+		aggregated.TrafficClass = sliceQos.TrafficClass
+	}
+}
@@ -64,6 +64,20 @@ func networkSliceWithGnbParams(name string, gnbName string, gnbTac int32) config
 	return slice
 }
 
+func networkSliceWithSliceQos(name string, sliceQos configmodels.SliceQos) configmodels.Slice {
+	slice := networkSliceWithGnbParams(name, "valid-gnb", 3)
+	slice.SliceQos = &sliceQos
+	return slice
+}
+
+func networkSliceWithRuleTrafficClass(name string, trafficClass configmodels.TrafficClassInfo) configmodels.Slice {
+	slice := networkSliceWithGnbParams(name, "valid-gnb", 3)
+	slice.ApplicationFilteringRules = []configmodels.SliceApplicationFilteringRules{
+		{RuleName: "rule-1", TrafficClass: &trafficClass},
+	}
+	return slice
+}
+
 type NetworkSliceMockDBClient struct {
 	dbadapter.DBInterface
 	slices   []configmodels.Slice
@@ -479,6 +493,67 @@ func TestNetworkSlicePostHandler_NetworkSliceGnbTacValidation(t *testing.T) {
 			expectedCode:  http.StatusBadRequest,
 			expectedError: "invalid TAC",
 		},
+		{
+			name:          "Network Slice invalid slice QoS bitrate unit",
+			route:         "/config/v1/network-slice/slice-1",
+			inputData:     networkSliceWithSliceQos("slice-1", configmodels.SliceQos{Uplink: 10, Downlink: 20, BitrateUnit: "Tbps"}),
+			expectedCode:  http.StatusBadRequest,
+			expectedError: "invalid bitrate unit",
+		},
+		{
+			name:          "Network Slice negative slice QoS MBR",
+			route:         "/config/v1/network-slice/slice-1",
+			inputData:     networkSliceWithSliceQos("slice-1", configmodels.SliceQos{Uplink: -1, BitrateUnit: "Mbps"}),
+			expectedCode:  http.StatusBadRequest,
+			expectedError: "must not be negative",
+		},
+		{
+			name:  "Network Slice invalid slice QoS 5QI",
+			route: "/config/v1/network-slice/slice-1",
+			inputData: networkSliceWithSliceQos("slice-1", configmodels.SliceQos{
+				TrafficClass: &configmodels.TrafficClassInfo{Qci: 0, Arp: 1},
+			}),
+			expectedCode:  http.StatusBadRequest,
+			expectedError: "invalid 5QI",
+		},
+		{
+			name:          "Network Slice invalid rule preemption capability",
+			route:         "/config/v1/network-slice/slice-1",
+			inputData:     networkSliceWithRuleTrafficClass("slice-1", configmodels.TrafficClassInfo{Qci: 9, Arp: 1, PreemptCap: "ALWAYS"}),
+			expectedCode:  http.StatusBadRequest,
+			expectedError: "invalid preemption capability",
+		},
+		{
+			name:          "Network Slice invalid rule preemption vulnerability",
+			route:         "/config/v1/network-slice/slice-1",
+			inputData:     networkSliceWithRuleTrafficClass("slice-1", configmodels.TrafficClassInfo{Qci: 9, Arp: 1, PreemptVuln: "NEVER"}),
+			expectedCode:  http.StatusBadRequest,
+			expectedError: "invalid preemption vulnerability",
+		},
+		{
+			name:  "Network Slice invalid default PCC rule precedence",
+			route: "/config/v1/network-slice/slice-1",
+			inputData: func() configmodels.Slice {
+				slice := networkSliceWithGnbParams("slice-1", "valid-gnb", 3)
+				slice.DefaultPccRule = &configmodels.SliceDefaultPccRule{Precedence: 256}
+				return slice
+			}(),
+			expectedCode:  http.StatusBadRequest,
+			expectedError: "invalid precedence",
+		},
+		{
+			name:  "Network Slice invalid default PCC rule ARP",
+			route: "/config/v1/network-slice/slice-1",
+			inputData: func() configmodels.Slice {
+				slice := networkSliceWithGnbParams("slice-1", "valid-gnb", 3)
+				slice.DefaultPccRule = &configmodels.SliceDefaultPccRule{
+					TrafficClass: &configmodels.TrafficClassInfo{Qci: 9, Arp: 16},
+				}
+				return slice
+			}(),
+			expectedCode:  http.StatusBadRequest,
+			expectedError: "invalid ARP",
+		},
 	}
 
 	for _, tc := range testCases {
@@ -521,7 +596,7 @@ func TestAggregateQoS_SumsCorrectly(t *testing.T) {
 		},
 	}
 
-	result := aggregateQoS(qosList)
+	result := aggregateQoS(qosList, nil)
 
 	if result.DnnMbrUplink != 150 {
 		t.Fatalf("expected UL 150, got %d", result.DnnMbrUplink)
@@ -546,7 +621,7 @@ func TestAggregateQoS_MixedUnits(t *testing.T) {
 		{DnnMbrUplink: 30, BitrateUnit: ""},
 	}
 
-	result := aggregateQoS(qosList)
+	result := aggregateQoS(qosList, nil)
 	if result.BitrateUnit != "Kbps" {
 		t.Fatalf("expected first non-empty unit 'Kbps', got %s", result.BitrateUnit)
 	}
@@ -555,8 +630,32 @@ func TestAggregateQoS_MixedUnits(t *testing.T) {
 	}
 }
 
+func TestAggregateQoS_CappedBySliceQos(t *testing.T) {
+	qosList := []configmodels.DeviceGroupsIpDomainExpandedUeDnnQos{
+		{DnnMbrUplink: 100000000, DnnMbrDownlink: 200000000, BitrateUnit: "Mbps"},
+		{DnnMbrUplink: 50000000, DnnMbrDownlink: 75000000, BitrateUnit: "Mbps"},
+	}
+	sliceQos := &configmodels.SliceQos{
+		Uplink:       120000000,
+		Downlink:     500000000,
+		BitrateUnit:  "Mbps",
+		TrafficClass: &configmodels.TrafficClassInfo{Qci: 7, Arp: 3},
+	}
+
+	result := aggregateQoS(qosList, sliceQos)
+	if result.DnnMbrUplink != 120000000 {
+		t.Fatalf("expected UL capped to 120000000, got %d", result.DnnMbrUplink)
+	}
+	if result.DnnMbrDownlink != 275000000 {
+		t.Fatalf("expected DL 275000000, got %d", result.DnnMbrDownlink)
+	}
+	if result.TrafficClass == nil || result.TrafficClass.Qci != 7 {
+		t.Fatalf("expected slice default traffic class (QCI 7), got %v", result.TrafficClass)
+	}
+}
+
 func TestAggregateQoS_EmptyList(t *testing.T) {
-	result := aggregateQoS(nil)
+	result := aggregateQoS(nil, nil)
 	if result.DnnMbrUplink != 0 || result.DnnMbrDownlink != 0 || result.BitrateUnit != "" || result.TrafficClass != nil {
 		t.Fatalf("expected zero value for empty list, got %+v", result)
 	}
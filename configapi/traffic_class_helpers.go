@@ -0,0 +1,195 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2026 Canonical Ltd
+
+package configapi
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/omec-project/webconsole/backend/logger"
+	"github.com/omec-project/webconsole/configmodels"
+	"github.com/omec-project/webconsole/dbadapter"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+func validateCatalogTrafficClass(trafficClass configmodels.TrafficClassInfo) error {
+	if !isValidName(trafficClass.Name) {
+		return fmt.Errorf("invalid traffic class name '%s'. Name needs to match the following regular expression: %s", trafficClass.Name, NAME_PATTERN)
+	}
+	if trafficClass.Pdb < 0 || trafficClass.Pelr < 0 {
+		return fmt.Errorf("invalid traffic class %s. PDB and PELR must not be negative", trafficClass.Name)
+	}
+	return validateTrafficClass(trafficClass)
+}
+
+// validateTrafficClassReference checks that a traffic class referenced by name
+// exists in the catalog and is not combined with an embedded traffic class
+func validateTrafficClassReference(name string, embedded *configmodels.TrafficClassInfo) error {
+	if name == "" {
+		return nil
+	}
+	if embedded != nil {
+		return fmt.Errorf("traffic class name %s and traffic class cannot be set together", name)
+	}
+	trafficClass, err := getTrafficClassByName(name)
+	if err != nil {
+		return fmt.Errorf("failed to retrieve traffic class %s: %w", name, err)
+	}
+	if trafficClass == nil {
+		return fmt.Errorf("traffic class %s not found", name)
+	}
+	return nil
+}
+
+func getTrafficClassByName(name string) (*configmodels.TrafficClassInfo, error) {
+	rawTrafficClass, err := dbadapter.CommonDBClient.RestfulAPIGetOne(configmodels.TrafficClassDataColl, bson.M{"name": name})
+	if err != nil {
+		return nil, err
+	}
+	if len(rawTrafficClass) == 0 {
+		return nil, nil
+	}
+	var trafficClass configmodels.TrafficClassInfo
+	if err = json.Unmarshal(configmodels.MapToByte(rawTrafficClass), &trafficClass); err != nil {
+		return nil, fmt.Errorf("could not unmarshal traffic class %s: %w", name, err)
+	}
+	return &trafficClass, nil
+}
+
+// GetTrafficClassCatalog returns the traffic classes of the catalog indexed by name
+func GetTrafficClassCatalog() (map[string]configmodels.TrafficClassInfo, error) {
+	rawTrafficClasses, err := dbadapter.CommonDBClient.RestfulAPIGetMany(configmodels.TrafficClassDataColl, bson.M{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch traffic classes: %w", err)
+	}
+	catalog := make(map[string]configmodels.TrafficClassInfo, len(rawTrafficClasses))
+	for _, rawTrafficClass := range rawTrafficClasses {
+		var trafficClass configmodels.TrafficClassInfo
+		if err = json.Unmarshal(configmodels.MapToByte(rawTrafficClass), &trafficClass); err != nil {
+			logger.DbLog.Warnf("could not unmarshal traffic class %s: %+v", rawTrafficClass, err)
+			continue
+		}
+		if trafficClass.Name == "" {
+			continue
+		}
+		catalog[trafficClass.Name] = trafficClass
+	}
+	return catalog, nil
+}
+
+// trafficClassReferences selects the network slices, device groups and QoS
+// profiles referencing the traffic class
+func trafficClassReferences(name string) []inUseReference {
+	return []inUseReference{
+		sliceReference(bson.M{"application-filtering-rules.traffic-class-name": name}),
+		deviceGroupReference(bson.M{"ip-domains.ue-dnn-qos.traffic-class-name": name}),
+		{key: "qos-profiles", collection: configmodels.QosProfileDataColl, filter: bson.M{"traffic-class-name": name}, nameField: "name"},
+	}
+}
+
+// syncDeviceGroupsUsingTrafficClass provisions again the subscribers of the
+// device groups referencing the traffic class, directly or through a QoS
+// profile. It provisions all of them before returning the errors.
+func syncDeviceGroupsUsingTrafficClass(name string) error {
+	logger.ConfigLog.Infof("provisioning the device groups using traffic class %s", name)
+	errs := []error{provisionDeviceGroupsMatching(bson.M{"ip-domains.ue-dnn-qos.traffic-class-name": name})}
+	profileNames, err := getQosProfilesUsingTrafficClass(name)
+	if err != nil {
+		return errors.Join(append(errs, err)...)
+	}
+	for _, profileName := range profileNames {
+		if err = provisionDeviceGroupsUsingQosProfile(profileName); err != nil {
+			errs = append(errs, fmt.Errorf("QoS profile %s: %w", profileName, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func resolveTrafficClass(name string, embedded *configmodels.TrafficClassInfo, catalog map[string]configmodels.TrafficClassInfo) (*configmodels.TrafficClassInfo, error) {
+	if name == "" {
+		return embedded, nil
+	}
+	trafficClass, ok := catalog[name]
+	if !ok {
+		return nil, fmt.Errorf("traffic class %s not found", name)
+	}
+	return &trafficClass, nil
+}
+
+// ResolveSliceTrafficClasses sets the traffic class of the application filtering rules
+// referencing the catalog by name. Rules referencing an unknown traffic class are left
+// without traffic class and reported in the returned error.
+func ResolveSliceTrafficClasses(slice *configmodels.Slice, catalog map[string]configmodels.TrafficClassInfo) error {
+	var errs []error
+	rules := slices.Clone(slice.ApplicationFilteringRules)
+	for i := range rules {
+		trafficClass, err := resolveTrafficClass(rules[i].TrafficClassName, rules[i].TrafficClass, catalog)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("rule %s: %w", rules[i].RuleName, err))
+		}
+		rules[i].TrafficClass = trafficClass
+	}
+	slice.ApplicationFilteringRules = rules
+	return errors.Join(errs...)
+}
+
+// ResolveDeviceGroupTrafficClasses sets the traffic class of the DNN QoS blocks
+// referencing the catalog by name. QoS blocks referencing an unknown traffic class
+// are left without traffic class and reported in the returned error.
+func ResolveDeviceGroupTrafficClasses(deviceGroup *configmodels.DeviceGroups, catalog map[string]configmodels.TrafficClassInfo) error {
+	var errs []error
+	ipDomains := slices.Clone(deviceGroup.IpDomainsExpanded)
+	for i := range ipDomains {
+		if ipDomains[i].UeDnnQos == nil {
+			continue
+		}
+		qos := *ipDomains[i].UeDnnQos
+		trafficClass, err := resolveTrafficClass(qos.TrafficClassName, qos.TrafficClass, catalog)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("DNN %s: %w", ipDomains[i].Dnn, err))
+		}
+		qos.TrafficClass = trafficClass
+		ipDomains[i].UeDnnQos = &qos
+	}
+	deviceGroup.IpDomainsExpanded = ipDomains
+	return errors.Join(errs...)
+}
+
+func deviceGroupReferencesTrafficClass(deviceGroup *configmodels.DeviceGroups) bool {
+	for _, ipDomain := range deviceGroup.IpDomainsExpanded {
+		if ipDomain.UeDnnQos != nil && ipDomain.UeDnnQos.TrafficClassName != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// resolveDeviceGroupForProvisioning returns a copy of the device group with the
+// QoS profiles and traffic classes of the catalogs resolved. The catalogs are
+// only read when needed.
+func resolveDeviceGroupForProvisioning(deviceGroup *configmodels.DeviceGroups) (*configmodels.DeviceGroups, error) {
+	resolved := *deviceGroup
+	if deviceGroupReferencesQosProfile(&resolved) {
+		qosProfileCatalog, err := GetQosProfileCatalog()
+		if err != nil {
+			return nil, err
+		}
+		if err = ResolveDeviceGroupQosProfiles(&resolved, qosProfileCatalog); err != nil {
+			return nil, fmt.Errorf("failed to resolve QoS profiles of device group %s: %w", deviceGroup.DeviceGroupName, err)
+		}
+	}
+	if !deviceGroupReferencesTrafficClass(&resolved) {
+		return &resolved, nil
+	}
+	catalog, err := GetTrafficClassCatalog()
+	if err != nil {
+		return nil, err
+	}
+	if err = ResolveDeviceGroupTrafficClasses(&resolved, catalog); err != nil {
+		return nil, fmt.Errorf("failed to resolve traffic classes of device group %s: %w", deviceGroup.DeviceGroupName, err)
+	}
+	return &resolved, nil
+}
@@ -0,0 +1,199 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2026 Canonical Ltd
+
+package configapi
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/omec-project/webconsole/backend/logger"
+	"github.com/omec-project/webconsole/configmodels"
+	"github.com/omec-project/webconsole/dbadapter"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+const (
+	// a registered UPF is stale when it missed the heartbeats for this long
+	upfStaleAfter            = 3 * time.Minute
+	upfRegistrationRefreshed = "refreshed"
+)
+
+// generateUpfRegistrationToken returns a token ID and a random token
+func generateUpfRegistrationToken() (string, string, error) {
+	id := make([]byte, 8)
+	if _, err := rand.Read(id); err != nil {
+		return "", "", fmt.Errorf("failed to generate token ID: %w", err)
+	}
+	token := make([]byte, 32)
+	if _, err := rand.Read(token); err != nil {
+		return "", "", fmt.Errorf("failed to generate token: %w", err)
+	}
+	return hex.EncodeToString(id), base64.RawURLEncoding.EncodeToString(token), nil
+}
+
+// isValidUpfHostnamePattern reports whether the pattern is a hostname, or a
+// domain prefixed with '*.'
+func isValidUpfHostnamePattern(pattern string) bool {
+	domain, found := strings.CutPrefix(pattern, "*.")
+	if found {
+		return strings.Contains(domain, ".") && isValidFQDN(domain)
+	}
+	return isValidFQDN(pattern)
+}
+
+// upfHostnameMatches reports whether the hostname is the one of the token
+// pattern or, for a '*.' pattern, a single label followed by its domain
+func upfHostnameMatches(pattern string, hostname string) bool {
+	domain, found := strings.CutPrefix(pattern, "*.")
+	if !found {
+		return hostname == pattern
+	}
+	label, found := strings.CutSuffix(hostname, "."+domain)
+	return found && label != "" && !strings.Contains(label, ".")
+}
+
+func hashUpfRegistrationToken(token string) string {
+	hash := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(hash[:])
+}
+
+// getBearerToken returns the token of an Authorization header with the
+// format 'Bearer token'
+func getBearerToken(header string) (string, error) {
+	if header == "" {
+		return "", fmt.Errorf("authorization header not found")
+	}
+	token, found := strings.CutPrefix(header, "Bearer ")
+	if !found || token == "" || strings.Contains(token, " ") {
+		return "", fmt.Errorf("authorization header couldn't be processed. The expected format is 'Bearer token'")
+	}
+	return token, nil
+}
+
+// getValidUpfRegistrationToken returns the registration token matching the
+// token, or nil when it does not exist or expired
+func getValidUpfRegistrationToken(token string, now time.Time) (*configmodels.UpfRegistrationToken, error) {
+	rawToken, err := dbadapter.CommonDBClient.RestfulAPIGetOne(configmodels.UpfRegistrationTokenDataColl, bson.M{"token-hash": hashUpfRegistrationToken(token)})
+	if err != nil {
+		return nil, err
+	}
+	if len(rawToken) == 0 {
+		return nil, nil
+	}
+	var registrationToken configmodels.UpfRegistrationToken
+	if err = json.Unmarshal(configmodels.MapToByte(rawToken), &registrationToken); err != nil {
+		return nil, fmt.Errorf("could not unmarshal registration token: %w", err)
+	}
+	if registrationToken.ExpiresAt != nil && !now.Before(*registrationToken.ExpiresAt) {
+		return nil, nil
+	}
+	return &registrationToken, nil
+}
+
+func getUpfRegistrationTokens() ([]configmodels.UpfRegistrationToken, error) {
+	rawTokens, err := dbadapter.CommonDBClient.RestfulAPIGetMany(configmodels.UpfRegistrationTokenDataColl, bson.M{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch registration tokens: %w", err)
+	}
+	tokens := make([]configmodels.UpfRegistrationToken, 0, len(rawTokens))
+	for _, rawToken := range rawTokens {
+		var token configmodels.UpfRegistrationToken
+		if err = json.Unmarshal(configmodels.MapToByte(rawToken), &token); err != nil {
+			logger.DbLog.Warnf("could not unmarshal registration token: %+v", err)
+			continue
+		}
+		tokens = append(tokens, token)
+	}
+	return tokens, nil
+}
+
+// getUpfRegistrations returns the last registration of the UPFs indexed by
+// hostname
+func getUpfRegistrations() (map[string]configmodels.UpfRegistration, error) {
+	rawRegistrations, err := dbadapter.CommonDBClient.RestfulAPIGetMany(configmodels.UpfRegistrationDataColl, bson.M{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch UPF registrations: %w", err)
+	}
+	registrations := make(map[string]configmodels.UpfRegistration, len(rawRegistrations))
+	for _, rawRegistration := range rawRegistrations {
+		var registration configmodels.UpfRegistration
+		if err = json.Unmarshal(configmodels.MapToByte(rawRegistration), &registration); err != nil {
+			logger.DbLog.Warnf("could not unmarshal UPF registration %s: %+v", rawRegistration, err)
+			continue
+		}
+		registrations[registration.Hostname] = registration
+	}
+	return registrations, nil
+}
+
+func getUpfRegistration(hostname string) (*configmodels.UpfRegistration, error) {
+	rawRegistration, err := dbadapter.CommonDBClient.RestfulAPIGetOne(configmodels.UpfRegistrationDataColl, bson.M{"hostname": hostname})
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch UPF registration: %w", err)
+	}
+	if len(rawRegistration) == 0 {
+		return nil, nil
+	}
+	var registration configmodels.UpfRegistration
+	if err = json.Unmarshal(configmodels.MapToByte(rawRegistration), &registration); err != nil {
+		return nil, fmt.Errorf("could not unmarshal UPF registration: %w", err)
+	}
+	return &registration, nil
+}
+
+// isUpfRegisteredWithToken reports whether the UPF of the inventory registered
+// itself with the token, rather than being created by a user or registered
+// with another token
+func isUpfRegisteredWithToken(hostname string, tokenId string) (bool, error) {
+	registration, err := getUpfRegistration(hostname)
+	if err != nil {
+		return false, err
+	}
+	return registration != nil && registration.TokenId == tokenId, nil
+}
+
+func recordUpfRegistration(registration configmodels.UpfRegistration) error {
+	filter := bson.M{"hostname": registration.Hostname}
+	_, err := dbadapter.CommonDBClient.RestfulAPIPutOne(configmodels.UpfRegistrationDataColl, filter, configmodels.ToBsonM(registration))
+	return err
+}
+
+func isStaleUpf(registration configmodels.UpfRegistration, now time.Time) bool {
+	return now.Sub(registration.LastSeen) > upfStaleAfter
+}
+
+// registerUpf creates the UPF or updates it when its details changed. It
+// returns the status of the registration.
+func registerUpf(ctx context.Context, upf configmodels.Upf, existingUpf *configmodels.Upf) (string, error) {
+	if existingUpf == nil {
+		if err := executeUpfTransaction(ctx, upf, updateUpfInNetworkSlices, postUpfOperation); err != nil {
+			return "", err
+		}
+		return configmodels.InventoryImportCreated, nil
+	}
+	if reflect.DeepEqual(normalizeUpf(upf), normalizeUpf(*existingUpf)) {
+		return upfRegistrationRefreshed, nil
+	}
+	if err := executeUpfTransaction(ctx, upf, updateUpfInNetworkSlices, putUpfOperation); err != nil {
+		return "", err
+	}
+	return configmodels.InventoryImportUpdated, nil
+}
+
+// normalizeUpf makes UPFs read from the database and from requests comparable
+func normalizeUpf(upf configmodels.Upf) configmodels.Upf {
+	var normalizedUpf configmodels.Upf
+	if err := json.Unmarshal(configmodels.MapToByte(configmodels.ToBsonM(upf)), &normalizedUpf); err != nil {
+		return upf
+	}
+	return normalizedUpf
+}
@@ -4,13 +4,23 @@
 package configapi
 
 import (
+	"net"
 	"regexp"
 	"strconv"
+	"strings"
+
+	"github.com/omec-project/openapi/v2/models"
+	"github.com/omec-project/webconsole/configmodels"
 )
 
 const (
 	NAME_PATTERN = "^[a-zA-Z][a-zA-Z0-9-_]{1,255}$"
 	FQDN_PATTERN = "^([a-zA-Z0-9][a-zA-Z0-9-]+\\.){2,}([a-zA-Z]{2,6})$"
+	DNN_PATTERN  = "^[a-zA-Z0-9]([a-zA-Z0-9-]*[a-zA-Z0-9])?(\\.[a-zA-Z0-9]([a-zA-Z0-9-]*[a-zA-Z0-9])?)*$"
+	MCC_PATTERN  = "^[0-9]{3}$"
+	MNC_PATTERN  = "^[0-9]{2,3}$"
+
+	LABEL_KEY_PATTERN = "^[a-zA-Z0-9]([a-zA-Z0-9._/-]{0,61}[a-zA-Z0-9])?$"
 )
 
 func isValidName(name string) bool {
@@ -29,6 +39,17 @@ func isValidFQDN(fqdn string) bool {
 	return fqdnMatch
 }
 
+func isValidDnn(dnn string) bool {
+	if len(dnn) > 100 {
+		return false
+	}
+	dnnMatch, err := regexp.MatchString(DNN_PATTERN, dnn)
+	if err != nil {
+		return false
+	}
+	return dnnMatch
+}
+
 func isValidUpfPort(port string) bool {
 	portNum, err := strconv.Atoi(port)
 	if err != nil {
@@ -40,3 +61,101 @@ func isValidUpfPort(port string) bool {
 func isValidGnbTac(tac int32) bool {
 	return tac >= 1 && tac <= 16777215
 }
+
+func isValidBitrateUnit(unit string) bool {
+	switch strings.ToLower(unit) {
+	case "bps", "kbps", "mbps", "gbps":
+		return true
+	default:
+		return false
+	}
+}
+
+func isValid5Qi(fiveQi int32) bool {
+	return fiveQi >= 1 && fiveQi <= 255
+}
+
+func isValidArpPriorityLevel(arp int32) bool {
+	return arp >= 1 && arp <= 15
+}
+
+func isValidPreemptCap(preemptCap string) bool {
+	switch preemptCap {
+	case "", configmodels.PreemptCapMayPreempt, configmodels.PreemptCapNotPreempt:
+		return true
+	default:
+		return false
+	}
+}
+
+func isValidPreemptVuln(preemptVuln string) bool {
+	switch preemptVuln {
+	case "", configmodels.PreemptVulnPreemptable, configmodels.PreemptVulnNotPreemptable:
+		return true
+	default:
+		return false
+	}
+}
+
+func isValidEndpoint(endpoint string) bool {
+	if net.ParseIP(endpoint) != nil {
+		return true
+	}
+	_, _, err := net.ParseCIDR(endpoint)
+	return err == nil
+}
+
+func isValidPortRange(startPort, endPort int32) bool {
+	if startPort < 0 || endPort > 65535 {
+		return false
+	}
+	return startPort <= endPort
+}
+
+func isValidIpProtocol(protocol int32) bool {
+	return protocol >= 0 && protocol <= 255
+}
+
+func isValidMtu(mtu int32) bool {
+	return mtu >= 1280 && mtu <= 65535
+}
+
+func isValidPduSessionType(pduSessionType string) bool {
+	return models.PduSessionType(pduSessionType).IsValid()
+}
+
+func isValidMcc(mcc string) bool {
+	mccMatch, err := regexp.MatchString(MCC_PATTERN, mcc)
+	if err != nil {
+		return false
+	}
+	return mccMatch
+}
+
+func isValidMnc(mnc string) bool {
+	mncMatch, err := regexp.MatchString(MNC_PATTERN, mnc)
+	if err != nil {
+		return false
+	}
+	return mncMatch
+}
+
+func isValidGnbId(gnbId configmodels.GnbId) bool {
+	if gnbId.BitLength < 22 || gnbId.BitLength > 32 {
+		return false
+	}
+	return uint64(gnbId.Value) < uint64(1)<<gnbId.BitLength
+}
+
+func isValidLabelKey(key string) bool {
+	keyMatch, err := regexp.MatchString(LABEL_KEY_PATTERN, key)
+	if err != nil {
+		return false
+	}
+	return keyMatch
+}
+
+func isValidIpPool(pool string) bool {
+	_, _, err := net.ParseCIDR(pool)
+	return err == nil
+}
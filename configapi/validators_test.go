@@ -6,6 +6,8 @@ package configapi
 import (
 	"strings"
 	"testing"
+
+	"github.com/omec-project/webconsole/configmodels"
 )
 
 func TestValidateName(t *testing.T) {
@@ -61,6 +63,31 @@ func TestValidateFQDN(t *testing.T) {
 	}
 }
 
+func TestValidateDnn(t *testing.T) {
+	testCases := []struct {
+		dnn      string
+		expected bool
+	}{
+		{"internet", true},
+		{"ims", true},
+		{"iot-1", true},
+		{"ims.mnc001.mcc001.gprs", true},
+		{"", false},
+		{"-internet", false},
+		{"internet-", false},
+		{"inter..net", false},
+		{"inter_net", false},
+		{genLongString(101), false},
+	}
+
+	for _, tc := range testCases {
+		r := isValidDnn(tc.dnn)
+		if r != tc.expected {
+			t.Errorf("%s", tc.dnn)
+		}
+	}
+}
+
 func TestValidateUpfPort(t *testing.T) {
 	testCases := []struct {
 		port     string
@@ -106,6 +133,321 @@ func TestValidateGnbTac(t *testing.T) {
 	}
 }
 
+func TestValidateBitrateUnit(t *testing.T) {
+	testCases := []struct {
+		unit     string
+		expected bool
+	}{
+		{"bps", true},
+		{"Kbps", true},
+		{"Mbps", true},
+		{"GBPS", true},
+		{"", false},
+		{"Tbps", false},
+	}
+
+	for _, tc := range testCases {
+		r := isValidBitrateUnit(tc.unit)
+		if r != tc.expected {
+			t.Errorf("%s", tc.unit)
+		}
+	}
+}
+
+func TestValidate5Qi(t *testing.T) {
+	testCases := []struct {
+		fiveQi   int32
+		expected bool
+	}{
+		{1, true},
+		{9, true},
+		{255, true},
+		{0, false},
+		{256, false},
+	}
+
+	for _, tc := range testCases {
+		r := isValid5Qi(tc.fiveQi)
+		if r != tc.expected {
+			t.Errorf("%d", tc.fiveQi)
+		}
+	}
+}
+
+func TestValidateArpPriorityLevel(t *testing.T) {
+	testCases := []struct {
+		arp      int32
+		expected bool
+	}{
+		{1, true},
+		{15, true},
+		{0, false},
+		{16, false},
+	}
+
+	for _, tc := range testCases {
+		r := isValidArpPriorityLevel(tc.arp)
+		if r != tc.expected {
+			t.Errorf("%d", tc.arp)
+		}
+	}
+}
+
+func TestValidatePreemptCap(t *testing.T) {
+	testCases := []struct {
+		preemptCap string
+		expected   bool
+	}{
+		{"", true},
+		{"MAY_PREEMPT", true},
+		{"NOT_PREEMPT", true},
+		{"may_preempt", false},
+		{"PREEMPTABLE", false},
+	}
+
+	for _, tc := range testCases {
+		r := isValidPreemptCap(tc.preemptCap)
+		if r != tc.expected {
+			t.Errorf("%s", tc.preemptCap)
+		}
+	}
+}
+
+func TestValidatePreemptVuln(t *testing.T) {
+	testCases := []struct {
+		preemptVuln string
+		expected    bool
+	}{
+		{"", true},
+		{"PREEMPTABLE", true},
+		{"NOT_PREEMPTABLE", true},
+		{"MAY_PREEMPT", false},
+	}
+
+	for _, tc := range testCases {
+		r := isValidPreemptVuln(tc.preemptVuln)
+		if r != tc.expected {
+			t.Errorf("%s", tc.preemptVuln)
+		}
+	}
+}
+
+func TestValidateEndpoint(t *testing.T) {
+	testCases := []struct {
+		endpoint string
+		expected bool
+	}{
+		{"8.8.8.8", true},
+		{"10.0.0.0/8", true},
+		{"2001:db8::/32", true},
+		{"0.0.0.0/0", true},
+		{"", false},
+		{"10.0.0.0/33", false},
+		{"example.com", false},
+	}
+
+	for _, tc := range testCases {
+		r := isValidEndpoint(tc.endpoint)
+		if r != tc.expected {
+			t.Errorf("%s", tc.endpoint)
+		}
+	}
+}
+
+func TestValidatePortRange(t *testing.T) {
+	testCases := []struct {
+		startPort int32
+		endPort   int32
+		expected  bool
+	}{
+		{0, 0, true},
+		{80, 80, true},
+		{1000, 2000, true},
+		{0, 65535, true},
+		{2000, 1000, false},
+		{-1, 80, false},
+		{80, 65536, false},
+	}
+
+	for _, tc := range testCases {
+		r := isValidPortRange(tc.startPort, tc.endPort)
+		if r != tc.expected {
+			t.Errorf("%d-%d", tc.startPort, tc.endPort)
+		}
+	}
+}
+
+func TestValidateIpProtocol(t *testing.T) {
+	testCases := []struct {
+		protocol int32
+		expected bool
+	}{
+		{0, true},
+		{6, true},
+		{17, true},
+		{255, true},
+		{-1, false},
+		{256, false},
+	}
+
+	for _, tc := range testCases {
+		r := isValidIpProtocol(tc.protocol)
+		if r != tc.expected {
+			t.Errorf("%d", tc.protocol)
+		}
+	}
+}
+
+func TestValidateMtu(t *testing.T) {
+	testCases := []struct {
+		mtu      int32
+		expected bool
+	}{
+		{1280, true},
+		{1460, true},
+		{9000, true},
+		{65535, true},
+		{0, false},
+		{1279, false},
+		{65536, false},
+	}
+
+	for _, tc := range testCases {
+		r := isValidMtu(tc.mtu)
+		if r != tc.expected {
+			t.Errorf("%d", tc.mtu)
+		}
+	}
+}
+
+func TestValidatePduSessionType(t *testing.T) {
+	testCases := []struct {
+		pduSessionType string
+		expected       bool
+	}{
+		{"IPV4", true},
+		{"IPV6", true},
+		{"IPV4V6", true},
+		{"UNSTRUCTURED", true},
+		{"ETHERNET", true},
+		{"ipv4", false},
+		{"", false},
+	}
+
+	for _, tc := range testCases {
+		r := isValidPduSessionType(tc.pduSessionType)
+		if r != tc.expected {
+			t.Errorf("%s", tc.pduSessionType)
+		}
+	}
+}
+
+func TestValidateMcc(t *testing.T) {
+	testCases := []struct {
+		mcc      string
+		expected bool
+	}{
+		{"001", true},
+		{"310", true},
+		{"01", false},
+		{"0011", false},
+		{"00a", false},
+		{"", false},
+	}
+
+	for _, tc := range testCases {
+		r := isValidMcc(tc.mcc)
+		if r != tc.expected {
+			t.Errorf("%s", tc.mcc)
+		}
+	}
+}
+
+func TestValidateMnc(t *testing.T) {
+	testCases := []struct {
+		mnc      string
+		expected bool
+	}{
+		{"01", true},
+		{"001", true},
+		{"1", false},
+		{"0001", false},
+		{"0x", false},
+		{"", false},
+	}
+
+	for _, tc := range testCases {
+		r := isValidMnc(tc.mnc)
+		if r != tc.expected {
+			t.Errorf("%s", tc.mnc)
+		}
+	}
+}
+
+func TestValidateGnbId(t *testing.T) {
+	testCases := []struct {
+		gnbId    configmodels.GnbId
+		expected bool
+	}{
+		{configmodels.GnbId{Value: 0, BitLength: 22}, true},
+		{configmodels.GnbId{Value: 4194303, BitLength: 22}, true},
+		{configmodels.GnbId{Value: 4294967295, BitLength: 32}, true},
+		{configmodels.GnbId{Value: 4194304, BitLength: 22}, false},
+		{configmodels.GnbId{Value: 1, BitLength: 21}, false},
+		{configmodels.GnbId{Value: 1, BitLength: 33}, false},
+	}
+
+	for _, tc := range testCases {
+		r := isValidGnbId(tc.gnbId)
+		if r != tc.expected {
+			t.Errorf("%+v", tc.gnbId)
+		}
+	}
+}
+
+func TestValidateLabelKey(t *testing.T) {
+	testCases := []struct {
+		key      string
+		expected bool
+	}{
+		{"rack", true},
+		{"r", true},
+		{"example.com/zone", true},
+		{"rack_1", true},
+		{"-rack", false},
+		{"rack.", false},
+		{"", false},
+		{genLongString(64), false},
+	}
+
+	for _, tc := range testCases {
+		r := isValidLabelKey(tc.key)
+		if r != tc.expected {
+			t.Errorf("%s", tc.key)
+		}
+	}
+}
+
+func TestValidateIpPool(t *testing.T) {
+	testCases := []struct {
+		pool     string
+		expected bool
+	}{
+		{"10.250.0.0/16", true},
+		{"2001:db8::/64", true},
+		{"10.250.0.1", false},
+		{"10.250.0.0/33", false},
+		{"", false},
+	}
+
+	for _, tc := range testCases {
+		r := isValidIpPool(tc.pool)
+		if r != tc.expected {
+			t.Errorf("%s", tc.pool)
+		}
+	}
+}
+
 func genLongString(length int) string {
 	return strings.Repeat("a", length)
 }
@@ -0,0 +1,13 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2026 Canonical Ltd
+
+package configmodels
+
+const ApplicationDataColl = "webconsoleData.snapshots.applicationData"
+
+type PutApplicationRequest struct {
+	Endpoint  string `json:"endpoint,omitempty"`
+	StartPort int32  `json:"start-port,omitempty"`
+	EndPort   int32  `json:"end-port,omitempty"`
+	Protocol  int32  `json:"protocol,omitempty"`
+}
@@ -36,6 +36,9 @@ type SliceApplicationFilteringRules struct {
 	// port range end
 	EndPort int32 `json:"dest-port-end,omitempty"`
 
+	// name of an application of the catalog, used instead of endpoint, protocol and ports
+	AppName string `json:"app-name,omitempty"`
+
 	AppMbrUplink int32 `json:"app-mbr-uplink,omitempty"`
 
 	AppMbrDownlink int32 `json:"app-mbr-downlink,omitempty"`
@@ -45,5 +48,12 @@ type SliceApplicationFilteringRules struct {
 
 	TrafficClass *TrafficClassInfo `json:"traffic-class,omitempty"`
 
+	// name of a traffic class of the catalog, used instead of traffic-class
+	TrafficClassName string `json:"traffic-class-name,omitempty"`
+
+	// rule activation trigger: "always" (default) or "schedule"
 	RuleTrigger string `json:"rule-trigger,omitempty"`
+
+	// activation schedule, required when the rule trigger is "schedule"
+	Schedule *RuleSchedule `json:"schedule,omitempty"`
 }
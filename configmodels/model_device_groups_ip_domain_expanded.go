@@ -29,4 +29,7 @@ type DeviceGroupsIpDomainExpanded struct {
 	Mtu int32 `json:"mtu,omitempty"`
 
 	UeDnnQos *DeviceGroupsIpDomainExpandedUeDnnQos `json:"ue-dnn-qos,omitempty"`
+
+	// name of a QoS profile of the catalog, used instead of ue-dnn-qos
+	QosProfileName string `json:"qos-profile-name,omitempty"`
 }
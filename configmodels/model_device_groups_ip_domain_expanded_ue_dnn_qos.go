@@ -24,4 +24,6 @@ type DeviceGroupsIpDomainExpandedUeDnnQos struct {
 	BitrateUnit string `json:"bitrate-unit,omitempty"`
 	// QCI/QFI for the traffic
 	TrafficClass *TrafficClassInfo `json:"traffic-class,omitempty"`
+	// name of a traffic class of the catalog, used instead of traffic-class
+	TrafficClassName string `json:"traffic-class-name,omitempty"`
 }
@@ -0,0 +1,26 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2026 Canonical Ltd
+
+package configmodels
+
+const DnnDataColl = "webconsoleData.snapshots.dnnData"
+
+// Dnn holds the defaults applied to the IP domains of the device groups using
+// the DNN when they do not set their own values
+type Dnn struct {
+	Name string `json:"name"`
+	// default MTU
+	Mtu int32 `json:"mtu,omitempty"`
+	// default DNS servers
+	DnsPrimary   string `json:"dns-primary,omitempty"`
+	DnsSecondary string `json:"dns-secondary,omitempty"`
+	// allowed PDU session types: IPV4, IPV6, IPV4V6, UNSTRUCTURED or ETHERNET
+	PduSessionTypes []string `json:"pdu-session-types,omitempty"`
+}
+
+type PutDnnRequest struct {
+	Mtu             int32    `json:"mtu,omitempty"`
+	DnsPrimary      string   `json:"dns-primary,omitempty"`
+	DnsSecondary    string   `json:"dns-secondary,omitempty"`
+	PduSessionTypes []string `json:"pdu-session-types,omitempty"`
+}
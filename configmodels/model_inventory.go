@@ -3,35 +3,132 @@
 
 package configmodels
 
+import "time"
+
 const (
 	GnbDataColl = "webconsoleData.snapshots.gnbData"
 	UpfDataColl = "webconsoleData.snapshots.upfData"
 )
 
+// GnbId is the gNB ID of the NG-RAN node, 22 to 32 bits long
+type GnbId struct {
+	Value     uint32 `json:"value"`
+	BitLength int32  `json:"bit-length"`
+}
+
 type Gnb struct {
-	Name string `json:"name"`
-	Tac  *int32 `json:"tac,omitempty"`
+	Name      string              `json:"name"`
+	Tac       *int32              `json:"tac,omitempty"`
+	GnbId     *GnbId              `json:"gnb-id,omitempty"`
+	Plmns     []SliceSiteInfoPlmn `json:"plmns,omitempty"`
+	N2Address string              `json:"n2-address,omitempty"`
+	Vendor    string              `json:"vendor,omitempty"`
+	Model     string              `json:"model,omitempty"`
+	Labels    map[string]string   `json:"labels,omitempty"`
 }
 
 type PostGnbRequest struct {
-	Name string `json:"name"`
-	Tac  *int32 `json:"tac"`
+	Name      string              `json:"name"`
+	Tac       *int32              `json:"tac"`
+	GnbId     *GnbId              `json:"gnb-id,omitempty"`
+	Plmns     []SliceSiteInfoPlmn `json:"plmns,omitempty"`
+	N2Address string              `json:"n2-address,omitempty"`
+	Vendor    string              `json:"vendor,omitempty"`
+	Model     string              `json:"model,omitempty"`
+	Labels    map[string]string   `json:"labels,omitempty"`
 }
 
 type PutGnbRequest struct {
-	Tac int32 `json:"tac"`
+	Tac       int32               `json:"tac"`
+	GnbId     *GnbId              `json:"gnb-id,omitempty"`
+	Plmns     []SliceSiteInfoPlmn `json:"plmns,omitempty"`
+	N2Address string              `json:"n2-address,omitempty"`
+	Vendor    string              `json:"vendor,omitempty"`
+	Model     string              `json:"model,omitempty"`
+	Labels    map[string]string   `json:"labels,omitempty"`
+}
+
+// UpfN3Interface is an N3 (user plane) interface of the UPF
+type UpfN3Interface struct {
+	Name    string `json:"name"`
+	Address string `json:"address"`
+}
+
+// UpfDnn is a DNN served by the UPF with the UE IP pools it allocates from
+type UpfDnn struct {
+	Dnn       string   `json:"dnn"`
+	UeIpPools []string `json:"ue-ip-pools"`
+}
+
+// UpfCapacity is a capacity hint used by the SMF to select the UPF
+type UpfCapacity struct {
+	MaxSessions       int32 `json:"max-sessions,omitempty"`
+	MaxThroughputMbps int64 `json:"max-throughput-mbps,omitempty"`
 }
 
 type Upf struct {
-	Hostname string `json:"hostname"`
-	Port     string `json:"port"`
+	Hostname     string           `json:"hostname"`
+	Port         string           `json:"port"`
+	N4Address    string           `json:"n4-address,omitempty"`
+	N3Interfaces []UpfN3Interface `json:"n3-interfaces,omitempty"`
+	Dnns         []UpfDnn         `json:"dnns,omitempty"`
+	Capacity     *UpfCapacity     `json:"capacity,omitempty"`
 }
 
 type PostUpfRequest struct {
-	Hostname string `json:"hostname"`
-	Port     string `json:"port"`
+	Hostname     string           `json:"hostname"`
+	Port         string           `json:"port"`
+	N4Address    string           `json:"n4-address,omitempty"`
+	N3Interfaces []UpfN3Interface `json:"n3-interfaces,omitempty"`
+	Dnns         []UpfDnn         `json:"dnns,omitempty"`
+	Capacity     *UpfCapacity     `json:"capacity,omitempty"`
 }
 
 type PutUpfRequest struct {
-	Port string `json:"port"`
+	Port         string           `json:"port"`
+	N4Address    string           `json:"n4-address,omitempty"`
+	N3Interfaces []UpfN3Interface `json:"n3-interfaces,omitempty"`
+	Dnns         []UpfDnn         `json:"dnns,omitempty"`
+	Capacity     *UpfCapacity     `json:"capacity,omitempty"`
+}
+
+const (
+	InventoryImportCreated = "created"
+	InventoryImportUpdated = "updated"
+	InventoryImportInvalid = "invalid"
+)
+
+// InventoryImportResult is the validation result of one row of an inventory
+// import. Rows are numbered from 1 in the order of the imported file.
+type InventoryImportResult struct {
+	Row    int    `json:"row"`
+	Name   string `json:"name"`
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
+
+type InventoryImportResponse struct {
+	Error   string                  `json:"error,omitempty"`
+	Results []InventoryImportResult `json:"results"`
+}
+
+// DiscoveredGnb is a gNB connected to one or more AMFs
+type DiscoveredGnb struct {
+	Name  string              `json:"name"`
+	GnbId *GnbId              `json:"gnb-id,omitempty"`
+	Plmns []SliceSiteInfoPlmn `json:"plmns,omitempty"`
+	Tacs  []int32             `json:"tacs,omitempty"`
+	Amfs  []string            `json:"amfs"`
+}
+
+// GnbDiscoveryReport compares the gNBs connected to the AMFs with the inventory
+type GnbDiscoveryReport struct {
+	Time time.Time `json:"time"`
+	Amfs []string  `json:"amfs"`
+	// gNBs connected to an AMF but missing from the inventory
+	UnknownGnbs []DiscoveredGnb `json:"unknown-gnbs"`
+	// gNBs of the inventory connected to no AMF. Only set when all AMFs answered.
+	NotConnectedGnbs []string `json:"not-connected-gnbs"`
+	ImportedGnbs     []string `json:"imported-gnbs,omitempty"`
+	Errors           []string `json:"errors,omitempty"`
 }
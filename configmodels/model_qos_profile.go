@@ -0,0 +1,30 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2026 Canonical Ltd
+
+package configmodels
+
+const QosProfileDataColl = "webconsoleData.snapshots.qosProfileData"
+
+// QosProfile is a named DNN QoS that device groups reference instead of
+// embedding their own ue-dnn-qos
+type QosProfile struct {
+	Name string `json:"name"`
+	// uplink data rate, expressed in bitrate-unit
+	DnnMbrUplink int64 `json:"dnn-mbr-uplink,omitempty"`
+	// downlink data rate, expressed in bitrate-unit
+	DnnMbrDownlink int64 `json:"dnn-mbr-downlink,omitempty"`
+	// data rate unit for uplink and downlink
+	BitrateUnit string `json:"bitrate-unit,omitempty"`
+	// QCI/QFI for the traffic
+	TrafficClass *TrafficClassInfo `json:"traffic-class,omitempty"`
+	// name of a traffic class of the catalog, used instead of traffic-class
+	TrafficClassName string `json:"traffic-class-name,omitempty"`
+}
+
+type PutQosProfileRequest struct {
+	DnnMbrUplink     int64             `json:"dnn-mbr-uplink,omitempty"`
+	DnnMbrDownlink   int64             `json:"dnn-mbr-downlink,omitempty"`
+	BitrateUnit      string            `json:"bitrate-unit,omitempty"`
+	TrafficClass     *TrafficClassInfo `json:"traffic-class,omitempty"`
+	TrafficClassName string            `json:"traffic-class-name,omitempty"`
+}
@@ -0,0 +1,36 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2026 Canonical Ltd
+
+package configmodels
+
+const (
+	RuleTriggerAlways   = "always"
+	RuleTriggerSchedule = "schedule"
+)
+
+// RuleSchedule - time periods during which an application filtering rule is active.
+// A rule is active whenever any cron activation or time window covers the current time.
+type RuleSchedule struct {
+	// IANA time zone used to evaluate the schedule. Defaults to UTC.
+	TimeZone string `json:"time-zone,omitempty"`
+
+	// standard 5 field cron expression marking the start of each activation
+	Cron string `json:"cron,omitempty"`
+
+	// how long the rule stays active after each cron activation (e.g. "90m")
+	Duration string `json:"duration,omitempty"`
+
+	// recurring daily time windows
+	Windows []RuleTimeWindow `json:"windows,omitempty"`
+}
+
+type RuleTimeWindow struct {
+	// days of the week the window starts on (mon, tue, ...). Every day if empty.
+	Days []string `json:"days,omitempty"`
+
+	// window start time of day (HH:MM)
+	Start string `json:"start"`
+
+	// window end time of day (HH:MM). A window ending before its start spans midnight.
+	End string `json:"end"`
+}
@@ -0,0 +1,29 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2026 Canonical Ltd
+
+package configmodels
+
+const SiteDataColl = "webconsoleData.snapshots.siteData"
+
+// Site groups the PLMN, gNBs and UPF of a deployment location. Network slices
+// attach to a site through SliceSiteInfo.SiteName and get their site information
+// from it.
+type Site struct {
+	SiteName string `json:"site-name"`
+
+	Plmn SliceSiteInfoPlmn `json:"plmn"`
+
+	// Names of gNBs of the inventory
+	GNodeBs []string `json:"gNodeBs"`
+
+	// Hostname of a UPF of the inventory
+	Upf string `json:"upf,omitempty"`
+}
+
+type PutSiteRequest struct {
+	Plmn SliceSiteInfoPlmn `json:"plmn"`
+
+	GNodeBs []string `json:"gNodeBs"`
+
+	Upf string `json:"upf,omitempty"`
+}
@@ -24,4 +24,8 @@ type Slice struct {
 	SiteInfo SliceSiteInfo `json:"site-info,omitempty"`
 
 	ApplicationFilteringRules []SliceApplicationFilteringRules `json:"application-filtering-rules,omitempty"`
+
+	SliceQos *SliceQos `json:"slice-qos,omitempty"`
+
+	DefaultPccRule *SliceDefaultPccRule `json:"default-pcc-rule,omitempty"`
 }
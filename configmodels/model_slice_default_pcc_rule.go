@@ -0,0 +1,14 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2026 Canonical Ltd
+
+package configmodels
+
+// SliceDefaultPccRule configures the PCC rule applied to a slice that has no
+// active application filtering rule
+type SliceDefaultPccRule struct {
+	// 5QI and ARP of the default rule
+	TrafficClass *TrafficClassInfo `json:"traffic-class,omitempty"`
+
+	// precedence of the default rule
+	Precedence int32 `json:"precedence,omitempty"`
+}
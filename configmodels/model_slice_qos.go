@@ -15,15 +15,15 @@
 package configmodels
 
 type SliceQos struct {
-	// uplink data rate in bps
-	Uplink int32 `json:"uplink,omitempty"`
+	// slice MBR uplink data rate
+	Uplink int64 `json:"uplink,omitempty"`
 
-	// downlink data rate in bps
-	Downlink int32 `json:"downlink,omitempty"`
+	// slice MBR downlink data rate
+	Downlink int64 `json:"downlink,omitempty"`
 
 	// data rate unit for uplink and downlink
 	BitrateUnit string `json:"bitrate-unit,omitempty"`
 
-	// QCI/QFI for the traffic
-	TrafficClass string `json:"traffic-class,omitempty"`
+	// default traffic class of the slice
+	TrafficClass *TrafficClassInfo `json:"traffic-class,omitempty"`
 }
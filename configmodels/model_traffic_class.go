@@ -14,6 +14,8 @@
 
 package configmodels
 
+const TrafficClassDataColl = "webconsoleData.snapshots.trafficClassData"
+
 type TrafficClassInfo struct {
 	// Traffic class name
 	Name string `json:"name,omitempty"`
@@ -29,4 +31,26 @@ type TrafficClassInfo struct {
 
 	// Packet Error Loss Rate
 	Pelr int32 `json:"pelr,omitempty"`
+
+	// ARP preemption capability: MAY_PREEMPT (default) or NOT_PREEMPT
+	PreemptCap string `json:"preempt-cap,omitempty"`
+
+	// ARP preemption vulnerability: PREEMPTABLE (default) or NOT_PREEMPTABLE
+	PreemptVuln string `json:"preempt-vuln,omitempty"`
+}
+
+const (
+	PreemptCapMayPreempt      = "MAY_PREEMPT"
+	PreemptCapNotPreempt      = "NOT_PREEMPT"
+	PreemptVulnPreemptable    = "PREEMPTABLE"
+	PreemptVulnNotPreemptable = "NOT_PREEMPTABLE"
+)
+
+type PutTrafficClassRequest struct {
+	Qci         int32  `json:"qci"`
+	Arp         int32  `json:"arp"`
+	Pdb         int32  `json:"pdb,omitempty"`
+	Pelr        int32  `json:"pelr,omitempty"`
+	PreemptCap  string `json:"preempt-cap,omitempty"`
+	PreemptVuln string `json:"preempt-vuln,omitempty"`
 }
@@ -0,0 +1,70 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2026 Canonical Ltd
+
+package configmodels
+
+import "time"
+
+const (
+	UpfRegistrationTokenDataColl = "webconsoleData.snapshots.upfRegistrationTokenData"
+	UpfRegistrationDataColl      = "webconsoleData.snapshots.upfRegistrationData"
+)
+
+// UpfRegistrationToken is a bootstrap token allowing UPFs to register
+// themselves. Only the SHA-256 hash of the token is stored.
+type UpfRegistrationToken struct {
+	Id          string `json:"id"`
+	Description string `json:"description,omitempty"`
+	// hostname of the UPFs allowed to register, or a pattern such as
+	// *.upf.example.com matching any single label before the domain
+	Hostname  string     `json:"hostname"`
+	TokenHash string     `json:"token-hash"`
+	CreatedAt time.Time  `json:"created-at"`
+	ExpiresAt *time.Time `json:"expires-at,omitempty"`
+}
+
+type PostUpfRegistrationTokenRequest struct {
+	Description string `json:"description,omitempty"`
+	Hostname    string `json:"hostname"`
+	// validity of the token. The token does not expire when unset.
+	TtlSeconds int64 `json:"ttl-seconds,omitempty"`
+}
+
+// PostUpfRegistrationTokenResponse holds the token, which cannot be retrieved
+// again
+type PostUpfRegistrationTokenResponse struct {
+	Id        string     `json:"id"`
+	Token     string     `json:"token"`
+	ExpiresAt *time.Time `json:"expires-at,omitempty"`
+}
+
+type GetUpfRegistrationTokenResponse struct {
+	Id          string     `json:"id"`
+	Description string     `json:"description,omitempty"`
+	Hostname    string     `json:"hostname"`
+	CreatedAt   time.Time  `json:"created-at"`
+	ExpiresAt   *time.Time `json:"expires-at,omitempty"`
+}
+
+// UpfRegistration records the last registration or heartbeat of a UPF
+type UpfRegistration struct {
+	Hostname string    `json:"hostname"`
+	LastSeen time.Time `json:"last-seen"`
+	TokenId  string    `json:"token-id"`
+}
+
+// RegisterUpfResponse reports whether the registration created, updated or
+// only refreshed the UPF
+type RegisterUpfResponse struct {
+	Hostname string    `json:"hostname"`
+	Status   string    `json:"status"`
+	LastSeen time.Time `json:"last-seen"`
+}
+
+// UpfStatus is a UPF of the inventory with the state of its self-registration.
+// UPFs that never registered are never stale.
+type UpfStatus struct {
+	Upf
+	LastSeen *time.Time `json:"last-seen,omitempty"`
+	Stale    bool       `json:"stale,omitempty"`
+}
@@ -61,6 +61,7 @@ func (s *MongoDBSession) WithSession(ctx context.Context, fn func(context.Contex
 type DBInterface interface {
 	RestfulAPIGetOne(collName string, filter bson.M) (map[string]interface{}, error)
 	RestfulAPIGetMany(collName string, filter bson.M) ([]map[string]interface{}, error)
+	RestfulAPIGetManyWithContext(context context.Context, collName string, filter bson.M) ([]map[string]interface{}, error)
 	RestfulAPIPutOneTimeout(collName string, filter bson.M, putData map[string]interface{}, timeout int32, timeField string) bool
 	RestfulAPIPutOne(collName string, filter bson.M, putData map[string]interface{}) (bool, error)
 	RestfulAPIPutOneWithContext(context context.Context, collName string, filter bson.M, putData map[string]interface{}) (bool, error)
@@ -217,6 +218,34 @@ func InitMongoDB() error {
 		logger.InitLog.Errorf("error creating gNB index in commonDB %v", err)
 		return err
 	}
+	if err := createIndexWithRetry(CommonDBClient, configmodels.TrafficClassDataColl, "name", 180*time.Second, 2*time.Second); err != nil {
+		logger.InitLog.Errorf("error creating traffic class index in commonDB %v", err)
+		return err
+	}
+	if err := createIndexWithRetry(CommonDBClient, configmodels.QosProfileDataColl, "name", 180*time.Second, 2*time.Second); err != nil {
+		logger.InitLog.Errorf("error creating QoS profile index in commonDB %v", err)
+		return err
+	}
+	if err := createIndexWithRetry(CommonDBClient, configmodels.ApplicationDataColl, "app-name", 180*time.Second, 2*time.Second); err != nil {
+		logger.InitLog.Errorf("error creating application index in commonDB %v", err)
+		return err
+	}
+	if err := createIndexWithRetry(CommonDBClient, configmodels.DnnDataColl, "name", 180*time.Second, 2*time.Second); err != nil {
+		logger.InitLog.Errorf("error creating DNN index in commonDB %v", err)
+		return err
+	}
+	if err := createIndexWithRetry(CommonDBClient, configmodels.SiteDataColl, "site-name", 180*time.Second, 2*time.Second); err != nil {
+		logger.InitLog.Errorf("error creating site index in commonDB %v", err)
+		return err
+	}
+	if err := createIndexWithRetry(CommonDBClient, configmodels.UpfRegistrationTokenDataColl, "token-hash", 180*time.Second, 2*time.Second); err != nil {
+		logger.InitLog.Errorf("error creating UPF registration token index in commonDB %v", err)
+		return err
+	}
+	if err := createIndexWithRetry(CommonDBClient, configmodels.UpfRegistrationDataColl, "hostname", 180*time.Second, 2*time.Second); err != nil {
+		logger.InitLog.Errorf("error creating UPF registration index in commonDB %v", err)
+		return err
+	}
 
 	if factory.WebUIConfig.Configuration.EnableAuthentication {
 		ConnectMongo(mongodb.WebuiDBUrl, mongodb.WebuiDBName, &WebuiDBClient)
@@ -338,6 +367,24 @@ func (db *MongoDBClient) RestfulAPIGetMany(collName string, filter bson.M) ([]ma
 	return db.MongoClient.RestfulAPIGetMany(collName, filter)
 }
 
+// RestfulAPIGetManyWithContext reads the documents in the session of ctx, if
+// any, so that the reads of a transaction see its own writes
+func (db *MongoDBClient) RestfulAPIGetManyWithContext(context context.Context, collName string, filter bson.M) ([]map[string]interface{}, error) {
+	cur, err := db.GetCollection(collName).Find(context, filter)
+	if err != nil {
+		return nil, fmt.Errorf("RestfulAPIGetManyWithContext err: %+v", err)
+	}
+	var results []map[string]interface{}
+	if err = cur.All(context, &results); err != nil {
+		return nil, fmt.Errorf("RestfulAPIGetManyWithContext err: %+v", err)
+	}
+	for _, result := range results {
+		// Delete "_id" entry which is auto-inserted by MongoDB
+		delete(result, "_id")
+	}
+	return results, nil
+}
+
 func (db *MongoDBClient) RestfulAPIPutOneTimeout(collName string, filter bson.M, putData map[string]interface{}, timeout int32, timeField string) bool {
 	return db.MongoClient.RestfulAPIPutOneTimeout(collName, filter, putData, timeout, timeField)
 }
@@ -475,3 +522,98 @@ func (db *MongoDBClient) RestfulAPIDeleteOneOnDB(ctx context.Context, dbName str
 	}
 	return nil
 }
+
+var (
+	// ErrChangeStreamUnsupported is returned when the deployment, e.g. a
+	// standalone server, does not support change streams
+	ErrChangeStreamUnsupported = errors.New("change streams are not supported by the MongoDB deployment")
+	// ErrChangeStreamNotResumable is returned when the change stream was
+	// invalidated or cannot be resumed after the given resume token
+	ErrChangeStreamNotResumable = errors.New("change stream cannot be resumed")
+)
+
+// MongoDB error codes of the change streams
+const (
+	changeStreamsUnsupportedCode = 40573
+	invalidResumeTokenCode       = 260
+	changeStreamFatalErrorCode   = 280
+	changeStreamHistoryLostCode  = 286
+)
+
+// ChangeEvent is a change of a document of a watched collection
+type ChangeEvent struct {
+	Collection    string
+	OperationType string
+	// resume token of the change stream after this event
+	ResumeToken string
+}
+
+// ChangeStreamer is implemented by the clients that can watch collections
+// with a change stream
+type ChangeStreamer interface {
+	WatchCollections(ctx context.Context, collNames []string, resumeToken string, handle func(ChangeEvent)) error
+}
+
+// WatchCollections calls handle with every change of the collections until
+// ctx ends or the change stream fails. The stream starts after the resume
+// token when set, or else now.
+func (db *MongoDBClient) WatchCollections(ctx context.Context, collNames []string, resumeToken string, handle func(ChangeEvent)) error {
+	if len(collNames) == 0 {
+		return fmt.Errorf("no collection to watch")
+	}
+	pipeline := mongo.Pipeline{{{Key: "$match", Value: bson.M{"$or": []bson.M{
+		{"ns.coll": bson.M{"$in": collNames}},
+		{"operationType": "invalidate"},
+	}}}}}
+	opts := options.ChangeStream()
+	if resumeToken != "" {
+		opts.SetResumeAfter(bson.M{"_data": resumeToken})
+	}
+	stream, err := db.GetCollection(collNames[0]).Database().Watch(ctx, pipeline, opts)
+	if err != nil {
+		return changeStreamError(err)
+	}
+	defer func() {
+		if err := stream.Close(context.WithoutCancel(ctx)); err != nil {
+			logger.DbLog.Warnf("failed to close change stream: %v", err)
+		}
+	}()
+	for stream.Next(ctx) {
+		var event struct {
+			OperationType string `bson:"operationType"`
+			Ns            struct {
+				Coll string `bson:"coll"`
+			} `bson:"ns"`
+		}
+		if err := stream.Decode(&event); err != nil {
+			return fmt.Errorf("failed to decode change event: %w", err)
+		}
+		if event.OperationType == "invalidate" {
+			return ErrChangeStreamNotResumable
+		}
+		token, _ := stream.ResumeToken().Lookup("_data").StringValueOK()
+		handle(ChangeEvent{Collection: event.Ns.Coll, OperationType: event.OperationType, ResumeToken: token})
+	}
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	if err := stream.Err(); err != nil {
+		return changeStreamError(err)
+	}
+	return ErrChangeStreamNotResumable
+}
+
+// changeStreamError wraps the server errors meaning that change streams are
+// unavailable or that the stream cannot be resumed
+func changeStreamError(err error) error {
+	var serverErr mongo.ServerError
+	if errors.As(err, &serverErr) {
+		switch {
+		case serverErr.HasErrorCode(changeStreamsUnsupportedCode):
+			return fmt.Errorf("%w: %v", ErrChangeStreamUnsupported, err)
+		case serverErr.HasErrorCode(invalidResumeTokenCode), serverErr.HasErrorCode(changeStreamFatalErrorCode), serverErr.HasErrorCode(changeStreamHistoryLostCode):
+			return fmt.Errorf("%w: %v", ErrChangeStreamNotResumable, err)
+		}
+	}
+	return err
+}
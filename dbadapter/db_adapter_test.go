@@ -3,6 +3,7 @@
 package dbadapter
 
 import (
+	"errors"
 	"fmt"
 	"strings"
 	"testing"
@@ -99,3 +100,30 @@ func TestCreateIndexWithRetry(t *testing.T) {
 		}
 	})
 }
+
+func TestChangeStreamError(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected error
+	}{
+		{name: "standalone server", err: mongo.CommandError{Code: 40573, Message: "The $changeStream stage is only supported on replica sets"}, expected: ErrChangeStreamUnsupported},
+		{name: "history lost", err: mongo.CommandError{Code: 286, Message: "resume point may no longer be in the oplog"}, expected: ErrChangeStreamNotResumable},
+		{name: "invalid resume token", err: mongo.CommandError{Code: 260, Message: "invalid resume token"}, expected: ErrChangeStreamNotResumable},
+		{name: "other error", err: fmt.Errorf("connection refused")},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := changeStreamError(tc.err)
+			if tc.expected == nil {
+				if err != tc.err {
+					t.Errorf("expected the error to be returned as is, got %v", err)
+				}
+				return
+			}
+			if !errors.Is(err, tc.expected) {
+				t.Errorf("expected %v, got %v", tc.expected, err)
+			}
+		})
+	}
+}